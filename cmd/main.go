@@ -15,18 +15,23 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	clustersecuritypolicycontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/clustersecuritypolicy"
+	commonctl "github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/inventory"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/ipaddressallocation"
 	namespacecontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/namespace"
@@ -42,10 +47,13 @@ import (
 	subnetipreservationcontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/subnetipreservation"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/subnetport"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/subnetset"
+	"github.com/vmware-tanzu/nsx-operator/pkg/debug"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/health"
 	inventoryservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/inventory"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/ipblocksinfo"
 	nodeservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/node"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/realizestate"
+	securitypolicyservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/staticroute"
 	subnetservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
 	subnetbindingservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetbinding"
@@ -60,6 +68,7 @@ import (
 	ipaddressallocationservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/ipaddressallocation"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/tracing"
 	pkgutil "github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
@@ -73,6 +82,7 @@ var (
 	roleMaster           = "master"
 	roleStandby          = "standby"
 	restoreMode          = false
+	debugServer          *debug.Server
 )
 
 func init() {
@@ -111,7 +121,8 @@ func init() {
 	}
 }
 
-func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
+// startServiceController initializes the services and the reconcilers, ctx is the context the manager is started with.
+func startServiceController(ctx context.Context, mgr manager.Manager, nsxClient *nsx.Client) {
 	// Generate webhook certificates and start refreshing webhook certificates periodically
 	if cf.CoeConfig.EnableVPCNetwork {
 		if err := pkgutil.GenerateWebhookCerts(); err != nil {
@@ -132,7 +143,9 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 		Client:    mgr.GetClient(),
 		NSXClient: nsxClient,
 		NSXConfig: cf,
+		StopCh:    ctx.Done(),
 	}
+	startRealizeTracker(mgr, commonService)
 
 	checkLicense(nsxClient, cf.LicenseValidationInterval)
 
@@ -212,6 +225,14 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 			os.Exit(1)
 		}
 
+		if debugServer != nil {
+			debugServer.AddStores(vpcService, subnetService, ipAddressAllocationService, subnetPortService, nodeService,
+				staticRouteService, subnetBindingService, subnetIPReservationService)
+			if inventoryService != nil {
+				debugServer.AddStores(inventoryService)
+			}
+		}
+
 		if _, err := os.Stat(config.WebhookCertDir); errors.Is(err, os.ErrNotExist) {
 			log.Error(err, "Server cert not found, disabling webhook server", "cert", config.WebhookCertDir)
 		} else {
@@ -240,6 +261,7 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 			subnetport.NewSubnetPortReconciler(mgr, subnetPortService, subnetService, vpcService, ipAddressAllocationService),
 			pod.NewPodReconciler(mgr, subnetPortService, subnetService, vpcService, nodeService),
 			networkpolicycontroller.NewNetworkPolicyReconciler(mgr, commonService, vpcService),
+			clustersecuritypolicycontroller.NewClusterSecurityPolicyReconciler(mgr, commonService, vpcService),
 			service.NewServiceLbReconciler(mgr, commonService),
 			subnetbindingcontroller.NewReconciler(mgr, subnetService, subnetBindingService),
 			subnetipreservationcontroller.NewReconciler(mgr, subnetIPReservationService, subnetService),
@@ -251,6 +273,9 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 
 	// Add controllers which can run in non-VPC mode
 	reconcilerList = append(reconcilerList, securitypolicycontroller.NewSecurityPolicyReconciler(mgr, commonService, vpcService))
+	if debugServer != nil {
+		debugServer.AddStores(securitypolicyservice.GetSecurityService(commonService, vpcService))
+	}
 
 	// Add the NSXServiceAccount controller.
 	if cf.EnableAntreaNSXInterworking {
@@ -277,6 +302,7 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 			}
 		}
 	}
+	startDriftDetector(mgr, reconcilerList)
 
 	// Update pod labels to determine if this pod is the master
 	err := updatePodLabels(mgr)
@@ -286,7 +312,50 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 	}
 }
 
-func electMaster(mgr manager.Manager, nsxClient *nsx.Client) {
+// startDriftDetector starts checking the out-of-band changes of the NSX resources created by the reconcilers.
+func startDriftDetector(mgr manager.Manager, reconcilerList []pkgutil.ReconcilerProvider) {
+	if cf.DriftDetectionInterval == 0 {
+		return
+	}
+	detector := &commonctl.DriftDetector{
+		Client:      mgr.GetClient(),
+		Recorder:    mgr.GetEventRecorderFor("drift-detector"),
+		NSXConfig:   cf,
+		SelfHealing: cf.DriftSelfHealing,
+	}
+	for _, reconciler := range reconcilerList {
+		if provider, ok := reconciler.(commonctl.DriftSourceProvider); ok {
+			detector.Sources = append(detector.Sources, provider.DriftSources()...)
+		}
+	}
+	// The detector is run by the manager so it's stopped with the manager.
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		detector.Start(ctx, time.Duration(cf.DriftDetectionInterval)*time.Second)
+		return nil
+	})); err != nil {
+		log.Error(err, "Failed to add drift detector")
+		os.Exit(1)
+	}
+}
+
+// startRealizeTracker makes the services check the realization of the NSX resources in batches by the tracker.
+func startRealizeTracker(mgr manager.Manager, service common.Service) {
+	if cf.RealizeTrackerInterval == 0 {
+		return
+	}
+	tracker := realizestate.NewTracker(service, time.Duration(cf.RealizeTrackerInterval)*time.Second)
+	realizestate.SetDefaultTracker(tracker)
+	// The tracker is run by the manager so it's stopped with the manager.
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		tracker.Start(ctx)
+		return nil
+	})); err != nil {
+		log.Error(err, "Failed to add realization tracker")
+		os.Exit(1)
+	}
+}
+
+func electMaster(ctx context.Context, mgr manager.Manager, nsxClient *nsx.Client) {
 	log.Info("I'm trying to be elected as master")
 	<-mgr.Elected()
 	log.Info("I'm the master now")
@@ -299,7 +368,7 @@ func electMaster(mgr manager.Manager, nsxClient *nsx.Client) {
 	// ensuring a smooth transition.
 	log.Info("Waiting a 15-second delay to let the old instance know that it has lost its lease")
 	time.Sleep(15 * time.Second)
-	startServiceController(mgr, nsxClient)
+	startServiceController(ctx, mgr, nsxClient)
 }
 
 func main() {
@@ -322,6 +391,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	var kubeClient kubernetes.Interface
+	if cf.HasSecretRefs() {
+		kubeClient = kubernetes.NewForConfigOrDie(cfg)
+		if err := cf.LoadSecrets(context.TODO(), kubeClient); err != nil {
+			log.Error(err, "Failed to load credentials from Secrets")
+			os.Exit(1)
+		}
+	}
+
 	// nsxClient is used to interact with NSX API.
 	nsxClient := nsx.GetClient(cf)
 	if nsxClient == nil {
@@ -329,10 +407,19 @@ func main() {
 		os.Exit(1)
 	}
 
+	if cf.EnableDebugServer {
+		debugServer = debug.NewServer(nsxClient)
+		if err := addDebugServer(mgr, cfg); err != nil {
+			log.Error(err, "Failed to add debug server")
+			os.Exit(1)
+		}
+	}
+
+	ctx := ctrl.SetupSignalHandler()
 	if cf.HAEnabled() {
-		go electMaster(mgr, nsxClient)
+		go electMaster(ctx, mgr, nsxClient)
 	} else {
-		go startServiceController(mgr, nsxClient)
+		go startServiceController(ctx, mgr, nsxClient)
 	}
 
 	if metrics.AreMetricsExposed(cf) {
@@ -348,13 +435,46 @@ func main() {
 		os.Exit(1)
 	}
 
+	shutdownTracing, err := tracing.InitTracing(ctx, cf)
+	if err != nil {
+		log.Error(err, "Failed to init tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error(err, "Failed to shutdown tracing")
+		}
+	}()
+
+	var secretWatcher *config.SecretWatcher
+	if kubeClient != nil {
+		secretWatcher = watchSecrets(ctx, nsxClient, kubeClient)
+	}
+	go watchConfigFiles(ctx, nsxClient, kubeClient, secretWatcher)
+
 	log.Info("Starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		log.Error(err, "Failed to start manager")
 		os.Exit(1)
 	}
 }
 
+// addDebugServer serves the debug endpoints on config.DebugAddr, the stores are added once the services are
+// initialized. It's a metrics server so the requests are authenticated and authorized like the ones of the metrics.
+func addDebugServer(mgr manager.Manager, restConfig *rest.Config) error {
+	server, err := metricsserver.NewServer(metricsserver.Options{
+		BindAddress:    config.DebugAddr,
+		SecureServing:  true,
+		FilterProvider: filters.WithAuthenticationAndAuthorization,
+		ExtraHandlers:  debugServer.Handlers(),
+	}, restConfig, mgr.GetHTTPClient())
+	if err != nil {
+		return err
+	}
+	log.Info("Serving debug endpoints", "address", config.DebugAddr)
+	return mgr.Add(server)
+}
+
 // Function for fetching nsx health status and feeding it to the prometheus metric.
 func getHealthStatus(nsxClient *nsx.Client) error {
 	status := 1
@@ -407,6 +527,65 @@ func updateLicensePeriodically(nsxClient *nsx.Client, interval time.Duration) {
 	}
 }
 
+// reloadNSXClient loads the Secrets referenced by newConfig and reloads nsxClient with it. newConfig is loaded again
+// from the config file, the running config is not changed in place since it's read by the reconcilers.
+func reloadNSXClient(ctx context.Context, nsxClient *nsx.Client, kubeClient kubernetes.Interface, newConfig *config.NSXOperatorConfig) bool {
+	if newConfig.HasSecretRefs() {
+		if kubeClient == nil {
+			log.Info("Secret references are added to config, restart to read them")
+			return false
+		}
+		if err := newConfig.LoadSecrets(ctx, kubeClient); err != nil {
+			log.Error(err, "Failed to load credentials from Secrets")
+			return false
+		}
+	}
+	if err := nsxClient.Reload(newConfig); err != nil {
+		log.Error(err, "Failed to reload NSX client")
+		return false
+	}
+	return true
+}
+
+// watchConfigFiles reloads the NSX managers, credentials and certificates of nsxClient once the config file or the
+// referenced cert files are changed, the operator doesn't need to restart. The Secrets referenced by the reloaded
+// config are watched by secretWatcher.
+func watchConfigFiles(ctx context.Context, nsxClient *nsx.Client, kubeClient kubernetes.Interface, secretWatcher *config.SecretWatcher) {
+	err := cf.WatchConfigFiles(ctx, func(newConfig *config.NSXOperatorConfig) {
+		if !reloadNSXClient(ctx, nsxClient, kubeClient, newConfig) {
+			return
+		}
+		log.Info("Reloaded NSX client", "managers", newConfig.NsxApiManagers)
+		if secretWatcher != nil {
+			if err := secretWatcher.Watch(newConfig); err != nil {
+				log.Error(err, "Failed to watch Secrets")
+			}
+		}
+	})
+	if err != nil {
+		log.Error(err, "Failed to watch config files")
+	}
+}
+
+// watchSecrets reloads nsxClient with the new credentials and certificates once the referenced Secrets are changed.
+func watchSecrets(ctx context.Context, nsxClient *nsx.Client, kubeClient kubernetes.Interface) *config.SecretWatcher {
+	secretWatcher := config.NewSecretWatcher(ctx, kubeClient, func() {
+		newConfig, err := config.LoadConfigFromFile()
+		if err != nil {
+			log.Error(err, "Failed to load config file, the credentials from Secrets are not reloaded")
+			return
+		}
+		if !reloadNSXClient(ctx, nsxClient, kubeClient, newConfig) {
+			return
+		}
+		log.Info("Reloaded NSX client with credentials from Secrets")
+	})
+	if err := secretWatcher.Watch(cf); err != nil {
+		log.Error(err, "Failed to watch Secrets")
+	}
+	return secretWatcher
+}
+
 func refreshCertPeriodically() {
 	ticker := time.NewTicker(30 * 24 * time.Hour) // 30 days
 	defer ticker.Stop()
@@ -9,6 +9,8 @@ import (
 	"os"
 	"time"
 
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/clean"
@@ -28,6 +30,10 @@ import (
 // envoy thumbprint mode:
 //
 //	./clean -cluster=domain-c9:d75735a3-2847-45d2-a652-ef2d146afd54 -nsx-user=admin -nsx-passwd='xxx'  -mgr-ip=nsxmanager-ob-22386469-1-dev-integ-nsxt-8791 -envoyhost=localhost -envoyport=1080 -log-level=1 -thumbprint=8bc2fa2b5879c27b1180fa44e5f747832f2ded6be483e3c3d2c4816a38870868
+//
+// credentials in Secrets, the references are in namespace/name/key format:
+//
+//	./clean -cluster=domain-c9:d75735a3-2847-45d2-a652-ef2d146afd54 -nsx-user=admin -nsx-passwd-secret=vmware-system-nsx/nsx-secret/password -mgr-ip=nsxmanager-ob-22386469-1-dev-integ-nsxt-8791 -ca-file-secret=vmware-system-nsx/nsx-secret/ca.crt
 var (
 	log         logger.CustomLogger
 	cf          *config.NSXOperatorConfig
@@ -44,6 +50,13 @@ var (
 	cluster     string
 	envoyHost   string
 	envoyPort   int
+
+	nsxPasswdSecret string
+	nsxCertSecret   string
+	nsxKeySecret    string
+	caFileSecret    string
+	vcUserSecret    string
+	vcPasswdSecret  string
 )
 
 func main() {
@@ -60,6 +73,12 @@ func main() {
 	flag.StringVar(&cluster, "cluster", "", "cluster name")
 	flag.StringVar(&envoyHost, "envoyhost", "", "envoy host")
 	flag.IntVar(&envoyPort, "envoyport", 0, "envoy port")
+	flag.StringVar(&nsxPasswdSecret, "nsx-passwd-secret", "", "nsx password Secret reference in namespace/name/key format")
+	flag.StringVar(&nsxCertSecret, "nsx-cert-secret", "", "nsx client cert Secret reference in namespace/name/key format")
+	flag.StringVar(&nsxKeySecret, "nsx-key-secret", "", "nsx client private key Secret reference in namespace/name/key format")
+	flag.StringVar(&caFileSecret, "ca-file-secret", "", "ca Secret reference in namespace/name/key format")
+	flag.StringVar(&vcUserSecret, "vc-user-secret", "", "vc username Secret reference in namespace/name/key format")
+	flag.StringVar(&vcPasswdSecret, "vc-passwd-secret", "", "vc password Secret reference in namespace/name/key format")
 	flag.IntVar(&config.LogLevel, "log-level", 2, "Use zap-core log system.")
 	flag.Parse()
 
@@ -77,12 +96,25 @@ func main() {
 	cf.Cluster = cluster
 	cf.EnvoyHost = envoyHost
 	cf.EnvoyPort = envoyPort
+	cf.NsxApiPasswordSecret = nsxPasswdSecret
+	cf.NsxApiCertSecret = nsxCertSecret
+	cf.NsxApiPrivateKeySecret = nsxKeySecret
+	cf.CaSecret = caFileSecret
+	cf.VCUserSecret = vcUserSecret
+	cf.VCPasswordSecret = vcPasswdSecret
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
 	defer cancel()
 	log = logger.ZapCustomLogger(cf.DefaultConfig.Debug, config.LogLevel)
 	logger.Log = log
 	logf.SetLogger(log.Logger)
+	if cf.HasSecretRefs() {
+		kubeClient := kubernetes.NewForConfigOrDie(ctrl.GetConfigOrDie())
+		if err := cf.LoadSecrets(ctx, kubeClient); err != nil {
+			log.Error(err, "Failed to load credentials from Secrets")
+			os.Exit(1)
+		}
+	}
 	err := clean.Clean(ctx, cf, &log.Logger, cf.DefaultConfig.Debug, config.LogLevel)
 	if err != nil {
 		log.Error(err, "Failed to clean nsx resources")
@@ -0,0 +1,97 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+// +kubebuilder:object:generate=true
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterSecurityPolicySpec defines the desired state of ClusterSecurityPolicy.
+type ClusterSecurityPolicySpec struct {
+	// Priority defines the order of policy enforcement among the ClusterSecurityPolicies of the same category,
+	// the policy with the lower value is enforced first. The ClusterSecurityPolicies are always enforced before
+	// the SecurityPolicies and NetworkPolicies in the Namespaces of the same category.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	Priority int `json:"priority,omitempty"`
+	// Category is the NSX distributed firewall category of the policy, it is Application by default.
+	// +kubebuilder:validation:Enum=Emergency;Infrastructure;Environment;Application
+	Category SecurityPolicyCategory `json:"category,omitempty"`
+	// AppliedTo is a list of policy targets to apply rules.
+	// Policy level 'Applied To' will take precedence over rule level.
+	AppliedTo []ClusterSecurityPolicyTarget `json:"appliedTo,omitempty"`
+	// Rules is a list of policy rules.
+	Rules []ClusterSecurityPolicyRule `json:"rules,omitempty"`
+	// Logging enables the packet logging of the rules which don't set it. It is false by default.
+	Logging *bool `json:"logging,omitempty"`
+}
+
+// ClusterSecurityPolicyRule defines a rule of ClusterSecurityPolicy.
+type ClusterSecurityPolicyRule struct {
+	// Action specifies the action to be applied on the rule, including 'Allow', 'Drop', 'Reject' and 'Pass'.
+	Action *RuleAction `json:"action"`
+	// AppliedTo is a list of rule targets.
+	// Policy level 'Applied To' will take precedence over rule level.
+	AppliedTo []ClusterSecurityPolicyTarget `json:"appliedTo,omitempty"`
+	// Direction is the direction of the rule, including 'In' or 'Ingress', 'Out' or 'Egress'.
+	Direction *RuleDirection `json:"direction"`
+	// Sources defines the endpoints where the traffic is from. For ingress rule only.
+	// The Pods or VMs of the peers without NamespaceSelector are selected in all the Namespaces.
+	Sources []SecurityPolicyPeer `json:"sources,omitempty"`
+	// Destinations defines the endpoints where the traffic is to. For egress rule only.
+	// The Pods or VMs of the peers without NamespaceSelector are selected in all the Namespaces.
+	Destinations []SecurityPolicyPeer `json:"destinations,omitempty"`
+	// Ports is a list of ports to be matched. Named ports are not supported.
+	Ports []SecurityPolicyPort `json:"ports,omitempty"`
+	// Name is the display name of this rule.
+	Name string `json:"name,omitempty"`
+	// Logging enables the packet logging of this rule, it takes precedence over the policy level Logging.
+	Logging *bool `json:"logging,omitempty"`
+	// LogLabel is printed in the packet logs of this rule to identify it.
+	// +kubebuilder:validation:MaxLength=32
+	LogLabel string `json:"logLabel,omitempty"`
+}
+
+// ClusterSecurityPolicyTarget defines the target endpoints across the Namespaces to apply ClusterSecurityPolicy.
+// +kubebuilder:validation:XValidation:rule="!has(self.vmSelector) || !has(self.podSelector)",message="vmSelector and podSelector can not be set in one target"
+type ClusterSecurityPolicyTarget struct {
+	// NamespaceSelector uses label selector to select Namespaces, the Pods or VMs are selected in all the
+	// Namespaces if it is not set.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// VMSelector uses label selector to select VMs.
+	VMSelector *metav1.LabelSelector `json:"vmSelector,omitempty"`
+	// PodSelector uses label selector to select Pods.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:scope="Cluster"
+
+// ClusterSecurityPolicy is the Schema for the clustersecuritypolicies API, it applies the rules to the Pods and VMs
+// across the Namespaces.
+type ClusterSecurityPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterSecurityPolicySpec `json:"spec"`
+	Status SecurityPolicyStatus      `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterSecurityPolicyList contains a list of ClusterSecurityPolicy.
+type ClusterSecurityPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSecurityPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterSecurityPolicy{}, &ClusterSecurityPolicyList{})
+}
@@ -21,6 +21,20 @@ const (
 	// RuleActionReject indicates that the traffic matching the rule must be rejected and the
 	// client will receive a response.
 	RuleActionReject RuleAction = "Reject"
+	// RuleActionPass indicates that the traffic matching the rule skips the rules of the remaining categories
+	// and is evaluated by the rules of the Application category. It is only valid in the Environment category.
+	RuleActionPass RuleAction = "Pass"
+)
+
+// SecurityPolicyCategory is the NSX distributed firewall category of the SecurityPolicy, the categories are
+// enforced in the order of Emergency, Infrastructure, Environment and Application.
+type SecurityPolicyCategory string
+
+const (
+	SecurityPolicyCategoryEmergency      SecurityPolicyCategory = "Emergency"
+	SecurityPolicyCategoryInfrastructure SecurityPolicyCategory = "Infrastructure"
+	SecurityPolicyCategoryEnvironment    SecurityPolicyCategory = "Environment"
+	SecurityPolicyCategoryApplication    SecurityPolicyCategory = "Application"
 )
 
 // RuleDirection specifies the direction of traffic.
@@ -48,11 +62,17 @@ type SecurityPolicySpec struct {
 	AppliedTo []SecurityPolicyTarget `json:"appliedTo,omitempty"`
 	// Rules is a list of policy rules.
 	Rules []SecurityPolicyRule `json:"rules,omitempty"`
+	// Logging enables the packet logging of the rules which don't set it. It is false by default.
+	Logging *bool `json:"logging,omitempty"`
+	// Category is the NSX distributed firewall category of the policy, it is Application by default.
+	// The categories other than Application are only allowed in the Namespaces permitted by the cluster admin.
+	// +kubebuilder:validation:Enum=Emergency;Infrastructure;Environment;Application
+	Category SecurityPolicyCategory `json:"category,omitempty"`
 }
 
 // SecurityPolicyRule defines a rule of SecurityPolicy.
 type SecurityPolicyRule struct {
-	// Action specifies the action to be applied on the rule.
+	// Action specifies the action to be applied on the rule, including 'Allow', 'Drop', 'Reject' and 'Pass'.
 	Action *RuleAction `json:"action"`
 	// AppliedTo is a list of rule targets.
 	// Policy level 'Applied To' will take precedence over rule level.
@@ -67,6 +87,11 @@ type SecurityPolicyRule struct {
 	Ports []SecurityPolicyPort `json:"ports,omitempty"`
 	// Name is the display name of this rule.
 	Name string `json:"name,omitempty"`
+	// Logging enables the packet logging of this rule, it takes precedence over the policy level Logging.
+	Logging *bool `json:"logging,omitempty"`
+	// LogLabel is printed in the packet logs of this rule to identify it.
+	// +kubebuilder:validation:MaxLength=32
+	LogLabel string `json:"logLabel,omitempty"`
 }
 
 // SecurityPolicyTarget defines the target endpoints to apply SecurityPolicy.
@@ -78,6 +103,7 @@ type SecurityPolicyTarget struct {
 }
 
 // SecurityPolicyPeer defines the source or destination of traffic.
+// +kubebuilder:validation:XValidation:rule="!has(self.fqdns) || (!has(self.vmSelector) && !has(self.podSelector) && !has(self.namespaceSelector) && !has(self.ipBlocks))",message="fqdns can not be used with the other fields of the peer"
 type SecurityPolicyPeer struct {
 	// VMSelector uses label selector to select VMs.
 	VMSelector *metav1.LabelSelector `json:"vmSelector,omitempty"`
@@ -87,6 +113,11 @@ type SecurityPolicyPeer struct {
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 	// IPBlocks is a list of IP CIDRs.
 	IPBlocks []IPBlock `json:"ipBlocks,omitempty"`
+	// FQDNs is a list of fully qualified domain names, e.g. "www.example.com", or wildcard domain names,
+	// e.g. "*.example.com". For egress rule only.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Pattern=`^(\*\.)?([a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?\.)+[a-zA-Z]{2,}$`
+	FQDNs []string `json:"fqdns,omitempty"`
 }
 
 // IPBlock describes a particular CIDR that is allowed or denied to/from the workloads matched by an AppliedTo.
@@ -96,9 +127,18 @@ type IPBlock struct {
 	CIDR string `json:"cidr"`
 }
 
+const (
+	// ProtocolICMP and ProtocolICMPv6 are the protocols of SecurityPolicyPort besides the ones of corev1.Protocol.
+	ProtocolICMP   corev1.Protocol = "ICMP"
+	ProtocolICMPv6 corev1.Protocol = "ICMPv6"
+)
+
 // SecurityPolicyPort describes protocol and ports for traffic.
+// +kubebuilder:validation:XValidation:rule="!has(self.icmpType) || (has(self.protocol) && self.protocol in ['ICMP', 'ICMPv6'])",message="icmpType is only valid for ICMP and ICMPv6"
+// +kubebuilder:validation:XValidation:rule="!has(self.icmpCode) || has(self.icmpType)",message="icmpCode requires icmpType"
+// +kubebuilder:validation:XValidation:rule="!has(self.protocol) || !(self.protocol in ['ICMP', 'ICMPv6']) || (!has(self.port) && !has(self.endPort))",message="port and endPort are not valid for ICMP and ICMPv6"
 type SecurityPolicyPort struct {
-	// Protocol(TCP, UDP) is the protocol to match traffic.
+	// Protocol(TCP, UDP, SCTP, ICMP, ICMPv6) is the protocol to match traffic.
 	// It is TCP by default.
 	// +kubebuilder:default=TCP
 	Protocol corev1.Protocol `json:"protocol,omitempty"`
@@ -106,6 +146,14 @@ type SecurityPolicyPort struct {
 	Port intstr.IntOrString `json:"port,omitempty"`
 	// EndPort defines the end of port range.
 	EndPort int `json:"endPort,omitempty"`
+	// ICMPType is the ICMP or ICMPv6 type to match, all types are matched if it is not set.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	ICMPType *int32 `json:"icmpType,omitempty"`
+	// ICMPCode is the ICMP or ICMPv6 code to match, all codes of ICMPType are matched if it is not set.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	ICMPCode *int32 `json:"icmpCode,omitempty"`
 }
 
 // SecurityPolicyStatus defines the observed state of SecurityPolicy.
@@ -100,7 +100,7 @@ func (c *CleanupService) cleanupVPCResourcesByVPCPath(ctx context.Context, vpcPa
 		// Extract VPC ID from path for better logging
 		vpcID := extractIDFromPath(vpcPath)
 		c.log.Info("Attempting to delete VPC", "vpcPath", vpcPath, "vpcID", vpcID)
-		if err := c.vpcService.DeleteVPC(vpcPath); err != nil {
+		if err := c.vpcService.DeleteVPC(ctx, vpcPath); err != nil {
 			c.log.Error(err, "Failed to delete VPC on NSX", "vpcPath", vpcPath, "vpcID", vpcID)
 			return err
 		}
@@ -19,6 +19,10 @@ func (c *FakeCrdV1alpha1) AddressBindings(namespace string) v1alpha1.AddressBind
 	return &FakeAddressBindings{c, namespace}
 }
 
+func (c *FakeCrdV1alpha1) ClusterSecurityPolicies() v1alpha1.ClusterSecurityPolicyInterface {
+	return &FakeClusterSecurityPolicies{c}
+}
+
 func (c *FakeCrdV1alpha1) IPAddressAllocations(namespace string) v1alpha1.IPAddressAllocationInterface {
 	return &FakeIPAddressAllocations{c, namespace}
 }
@@ -16,6 +16,7 @@ import (
 type CrdV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AddressBindingsGetter
+	ClusterSecurityPoliciesGetter
 	IPAddressAllocationsGetter
 	IPBlocksInfosGetter
 	NetworkInfosGetter
@@ -38,6 +39,10 @@ func (c *CrdV1alpha1Client) AddressBindings(namespace string) AddressBindingInte
 	return newAddressBindings(c, namespace)
 }
 
+func (c *CrdV1alpha1Client) ClusterSecurityPolicies() ClusterSecurityPolicyInterface {
+	return newClusterSecurityPolicies(c)
+}
+
 func (c *CrdV1alpha1Client) IPAddressAllocations(namespace string) IPAddressAllocationInterface {
 	return newIPAddressAllocations(c, namespace)
 }
@@ -13,6 +13,8 @@ import (
 type Interface interface {
 	// AddressBindings returns a AddressBindingInformer.
 	AddressBindings() AddressBindingInformer
+	// ClusterSecurityPolicies returns a ClusterSecurityPolicyInformer.
+	ClusterSecurityPolicies() ClusterSecurityPolicyInformer
 	// IPAddressAllocations returns a IPAddressAllocationInformer.
 	IPAddressAllocations() IPAddressAllocationInformer
 	// IPBlocksInfos returns a IPBlocksInfoInformer.
@@ -53,6 +55,11 @@ func (v *version) AddressBindings() AddressBindingInformer {
 	return &addressBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterSecurityPolicies returns a ClusterSecurityPolicyInformer.
+func (v *version) ClusterSecurityPolicies() ClusterSecurityPolicyInformer {
+	return &clusterSecurityPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IPAddressAllocations returns a IPAddressAllocationInformer.
 func (v *version) IPAddressAllocations() IPAddressAllocationInformer {
 	return &iPAddressAllocationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
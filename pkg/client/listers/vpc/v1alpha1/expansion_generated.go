@@ -13,6 +13,10 @@ type AddressBindingListerExpansion interface{}
 // AddressBindingNamespaceLister.
 type AddressBindingNamespaceListerExpansion interface{}
 
+// ClusterSecurityPolicyListerExpansion allows custom methods to be added to
+// ClusterSecurityPolicyLister.
+type ClusterSecurityPolicyListerExpansion interface{}
+
 // IPAddressAllocationListerExpansion allows custom methods to be added to
 // IPAddressAllocationLister.
 type IPAddressAllocationListerExpansion interface{}
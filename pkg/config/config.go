@@ -10,6 +10,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	"go.uber.org/zap"
@@ -32,12 +34,12 @@ const (
 )
 
 var (
-	LogLevel               int
-	ProbeAddr, MetricsAddr string
-	WebhookServerPort      int
-	configFilePath         = ""
-	configLog              *zap.SugaredLogger
-	tokenProvider          auth.TokenProvider
+	LogLevel                          int
+	ProbeAddr, MetricsAddr, DebugAddr string
+	WebhookServerPort                 int
+	configFilePath                    = ""
+	configLog                         *zap.SugaredLogger
+	tokenProvider                     auth.TokenProvider
 )
 
 // TODO delete unnecessary config
@@ -48,8 +50,12 @@ type NSXOperatorConfig struct {
 	*K8sConfig
 	*VCConfig
 	*HAConfig
+	*TracingConfig
 	configCache configCache
-	LibMode     bool
+	// connLock guards the NSX connection fields copied by UpdateNsxConnection and the cached CA, they are
+	// changed at runtime once the config file or the referenced Secrets are reloaded.
+	connLock sync.RWMutex
+	LibMode  bool
 }
 
 func init() {
@@ -65,6 +71,8 @@ func (operatorConfig *NSXOperatorConfig) HAEnabled() bool {
 }
 
 func (operatorConfig *NSXOperatorConfig) GetCACert() []byte {
+	operatorConfig.connLock.Lock()
+	defer operatorConfig.connLock.Unlock()
 	ca := operatorConfig.configCache.nsxCA
 	if ca == nil {
 		ca = []byte{}
@@ -86,6 +94,31 @@ func (operatorConfig *NSXOperatorConfig) GetCACert() []byte {
 	return ca
 }
 
+// UpdateNsxConnection copies the NSX managers, credentials and certificate files of nsxConfig, which are reloaded
+// without restarting the operator, and drops the cached CA.
+func (operatorConfig *NSXOperatorConfig) UpdateNsxConnection(nsxConfig *NsxConfig) {
+	operatorConfig.connLock.Lock()
+	defer operatorConfig.connLock.Unlock()
+	operatorConfig.NsxApiManagers = nsxConfig.NsxApiManagers
+	operatorConfig.NsxApiUser = nsxConfig.NsxApiUser
+	operatorConfig.NsxApiPassword = nsxConfig.NsxApiPassword
+	operatorConfig.NsxApiCertFile = nsxConfig.NsxApiCertFile
+	operatorConfig.NsxApiPrivateKeyFile = nsxConfig.NsxApiPrivateKeyFile
+	operatorConfig.CaFile = nsxConfig.CaFile
+	operatorConfig.LeafCertFile = nsxConfig.LeafCertFile
+	operatorConfig.Thumbprint = nsxConfig.Thumbprint
+	operatorConfig.EndpointWeights = nsxConfig.EndpointWeights
+	operatorConfig.configCache.nsxCA = nil
+}
+
+// GetNsxApiManagers returns the NSX managers, use it instead of reading NsxApiManagers once the config could be
+// reloaded.
+func (operatorConfig *NSXOperatorConfig) GetNsxApiManagers() []string {
+	operatorConfig.connLock.RLock()
+	defer operatorConfig.connLock.RUnlock()
+	return operatorConfig.NsxApiManagers
+}
+
 type configCache struct {
 	// nsxCA stores all file contents of NsxConfig.CaFile in a byte slice
 	nsxCA []byte
@@ -93,6 +126,9 @@ type configCache struct {
 
 type DefaultConfig struct {
 	Debug bool `ini:"debug"`
+	// EnableDebugServer serves the dumps of the stores and the NSX endpoint state on DebugAddr, the requests are
+	// authenticated and authorized like the ones of the metrics
+	EnableDebugServer bool `ini:"enable_debug_server"`
 }
 
 type CoeConfig struct {
@@ -126,6 +162,46 @@ type NsxConfig struct {
 	InventoryBatchPeriod      int      `ini:"inventory_batch_period"`
 	InventoryBatchSize        int      `ini:"inventory_batch_size"`
 	EnableInventory           bool     `ini:"enable_inventory"`
+	// EndpointSelectionStrategy is one of least-connections, weighted-round-robin, lowest-latency and first-healthy
+	EndpointSelectionStrategy string `ini:"endpoint_selection_strategy"`
+	// EndpointWeights are used by weighted-round-robin, in the same order as NsxApiManagers
+	EndpointWeights []int `ini:"endpoint_weights"`
+	// CircuitBreakerFailureThreshold is the number of consecutive failed API calls which stops sending requests
+	// to an NSX manager, 0 disables the circuit breaker
+	CircuitBreakerFailureThreshold int `ini:"circuit_breaker_failure_threshold"`
+	// CircuitBreakerSuccessThreshold is the number of successful trial API calls which resumes an NSX manager
+	CircuitBreakerSuccessThreshold int `ini:"circuit_breaker_success_threshold"`
+	// CircuitBreakerOpenTimeout is the time in seconds before trial API calls are sent to a failed NSX manager
+	CircuitBreakerOpenTimeout int `ini:"circuit_breaker_open_timeout"`
+	// CircuitBreakerHalfOpenRequests is the max number of concurrent trial API calls to a failed NSX manager
+	CircuitBreakerHalfOpenRequests int `ini:"circuit_breaker_half_open_requests"`
+	// The Secret references are in namespace/name/key format, the value in the Secret overrides
+	// nsx_api_password, nsx_api_cert_file, nsx_api_private_key_file and ca_file
+	NsxApiPasswordSecret   string `ini:"nsx_api_password_secret"`
+	NsxApiCertSecret       string `ini:"nsx_api_cert_secret"`
+	NsxApiPrivateKeySecret string `ini:"nsx_api_private_key_secret"`
+	CaSecret               string `ini:"ca_secret"`
+	// TransportRecordMode is record or replay, the NSX API calls are recorded into or replayed from the golden
+	// files in TransportRecordDir, it's used to capture the regression tests in the lab and never in production
+	TransportRecordMode string `ini:"transport_record_mode"`
+	TransportRecordDir  string `ini:"transport_record_dir"`
+	// DriftDetectionInterval is the interval in seconds to compare the NSX resources with the operator stores,
+	// 0 disables the drift detection
+	DriftDetectionInterval int `ini:"drift_detection_interval"`
+	// DriftSelfHealing re-reconciles the CR owning an NSX resource changed out of band to restore it
+	DriftSelfHealing bool `ini:"drift_self_healing"`
+	// StoreRefreshInterval is the interval in seconds to apply the NSX resources modified since the last refresh
+	// to the stores, 0 disables the refresh
+	StoreRefreshInterval int `ini:"store_refresh_interval"`
+	// StoreSnapshotDir is the directory, e.g. on a local volume, to save the stores to when they are refreshed. The
+	// stores are loaded from the snapshots at startup and refreshed instead of loading all resources from NSX.
+	StoreSnapshotDir string `ini:"store_snapshot_dir"`
+	// PlanMode renders the changes of the NSX resources as diffs against the stores in the CR Events and the log
+	// instead of applying them, the CRs can also be planned by the nsx.vmware.com/plan: "true" annotation
+	PlanMode bool `ini:"plan_mode"`
+	// RealizeTrackerInterval is the interval in seconds to check the realization of the pending NSX resources in
+	// batches instead of polling them one by one, 0 disables the realization tracker
+	RealizeTrackerInterval int `ini:"realize_tracker_interval"`
 }
 
 type K8sConfig struct {
@@ -137,6 +213,9 @@ type K8sConfig struct {
 	KubeConfigFile     string `ini:"kubeconfig"`
 	// Controlled by FSS
 	EnableAntreaNSXInterworking bool `ini:"enable_antrea_nsx_interworking"`
+	// SecurityPolicyCategoryAllowList is the list of "<namespace>:<category>" entries allowing the SecurityPolicies in
+	// the namespace to use the category other than Application, "*" matches any namespace or category
+	SecurityPolicyCategoryAllowList []string `ini:"securitypolicy_category_allow_list"`
 }
 
 type VCConfig struct {
@@ -146,12 +225,26 @@ type VCConfig struct {
 	VCUser     string `ini:"user"`
 	VCPassword string `ini:"password"`
 	VCCAFile   string `ini:"ca_file"`
+	// The Secret references are in namespace/name/key format, the value in the Secret overrides user and password
+	VCUserSecret     string `ini:"user_secret"`
+	VCPasswordSecret string `ini:"password_secret"`
 }
 
 type HAConfig struct {
 	EnableHA *bool `ini:"enable"`
 }
 
+type TracingConfig struct {
+	EnableTracing bool `ini:"enable"`
+	// Exporter of the spans, could be otlp-grpc or otlp-http.
+	TracingExporter string `ini:"exporter"`
+	// Endpoint of the OTLP collector in host:port format.
+	TracingEndpoint string `ini:"endpoint"`
+	TracingInsecure bool   `ini:"insecure"`
+	// Fraction of the traces to sample, in range [0, 1].
+	TracingSampleRatio float64 `ini:"sample_ratio"`
+}
+
 type Validate interface {
 	validate() error
 }
@@ -164,6 +257,7 @@ func AddFlags() {
 	flag.StringVar(&configFilePath, "nsxconfig", nsxOperatorDefaultConf, "NSX Operator configuration file path")
 	flag.StringVar(&ProbeAddr, "health-probe-bind-address", ":8384", "The address the probe endpoint binds to.")
 	flag.StringVar(&MetricsAddr, "metrics-bind-address", ":8093", "The address the metrics endpoint binds to.")
+	flag.StringVar(&DebugAddr, "debug-bind-address", ":8094", "The address the debug endpoint binds to if the debug server is enabled.")
 	flag.IntVar(&LogLevel, "log-level", 0, "Use zap-core log system.")
 	flag.IntVar(&WebhookServerPort, "webhook-server-port", defaultWebhookPort, "Port number to expose the controller webhook server")
 	flag.Parse()
@@ -210,6 +304,10 @@ func LoadConfigFromFile() (*NSXOperatorConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = cfg.Section("tracing").MapTo(nsxOperatorConfig.TracingConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := nsxOperatorConfig.validate(); err != nil {
 		return nil, err
@@ -232,13 +330,18 @@ func NewNSXOpertorConfig() *NSXOperatorConfig {
 		&DefaultConfig{},
 		&CoeConfig{EnableSha: true},
 		&NsxConfig{
-			InventoryBatchPeriod: 5,
-			InventoryBatchSize:   50,
+			InventoryBatchPeriod:           5,
+			InventoryBatchSize:             50,
+			CircuitBreakerSuccessThreshold: 1,
+			CircuitBreakerOpenTimeout:      30,
+			CircuitBreakerHalfOpenRequests: 1,
 		},
 		&K8sConfig{},
 		&VCConfig{},
 		&HAConfig{},
+		&TracingConfig{TracingExporter: "otlp-grpc", TracingSampleRatio: 1},
 		configCache{},
+		sync.RWMutex{},
 		false,
 	}
 	return defaultNSXOperatorConfig
@@ -251,6 +354,15 @@ func (operatorConfig *NSXOperatorConfig) validate() error {
 	if err := operatorConfig.NsxConfig.validate(operatorConfig.CoeConfig.EnableVPCNetwork); err != nil {
 		return err
 	}
+	if err := operatorConfig.TracingConfig.validate(); err != nil {
+		return err
+	}
+	if err := operatorConfig.K8sConfig.validate(); err != nil {
+		return err
+	}
+	if err := operatorConfig.validateSecretRefs(); err != nil {
+		return err
+	}
 	// TODO, verify if user&pwd, cert, jwt has any of them provided
 	return nil
 }
@@ -354,12 +466,12 @@ func (nsxConfig *NsxConfig) validateCert() error {
 
 	// ca file has high priority than thumbprint
 	// ca file(thumbprint) == 1 or equal to manager count
-	if caCount == 0 && tpCount == 0 && nsxConfig.NsxApiUser == "" && nsxConfig.NsxApiPassword == "" {
+	if caCount == 0 && tpCount == 0 && nsxConfig.CaSecret == "" && nsxConfig.NsxApiUser == "" && nsxConfig.NsxApiPassword == "" {
 		err := errors.New("no ca file or thumbprint or nsx username/password provided")
 		configLog.Error(err, "Validate NsxConfig failed")
 		return err
 	}
-	if nsxConfig.EnvoyPort != 0 && caCount == 0 && tpCount == 0 {
+	if nsxConfig.EnvoyPort != 0 && caCount == 0 && tpCount == 0 && nsxConfig.CaSecret == "" {
 		err := errors.New("no ca file or thumbprint while using envoy mode")
 		configLog.Error(err, "Validate NsxConfig failed")
 		return err
@@ -411,6 +523,75 @@ func (nsxConfig *NsxConfig) validate(enableVPC bool) error {
 	if err := nsxConfig.validateCert(); err != nil {
 		return err
 	}
+	if err := nsxConfig.validateEndpointSelection(); err != nil {
+		return err
+	}
+	if nsxConfig.CircuitBreakerFailureThreshold < 0 || nsxConfig.CircuitBreakerSuccessThreshold < 0 ||
+		nsxConfig.CircuitBreakerOpenTimeout < 0 || nsxConfig.CircuitBreakerHalfOpenRequests < 0 {
+		err := errors.New("invalid field " + "CircuitBreaker")
+		configLog.Error(err, "Validate NsxConfig failed")
+		return err
+	}
+	if err := nsxConfig.validateTransportRecord(); err != nil {
+		return err
+	}
+	if nsxConfig.DriftDetectionInterval < 0 {
+		err := errors.New("invalid field " + "DriftDetectionInterval")
+		configLog.Error(err, "Validate NsxConfig failed", "DriftDetectionInterval", nsxConfig.DriftDetectionInterval)
+		return err
+	}
+	if nsxConfig.StoreRefreshInterval < 0 {
+		err := errors.New("invalid field " + "StoreRefreshInterval")
+		configLog.Error(err, "Validate NsxConfig failed", "StoreRefreshInterval", nsxConfig.StoreRefreshInterval)
+		return err
+	}
+	// the snapshots are saved by the store refresh
+	if nsxConfig.StoreSnapshotDir != "" && nsxConfig.StoreRefreshInterval == 0 {
+		err := errors.New("invalid field " + "StoreSnapshotDir")
+		configLog.Error(err, "Validate NsxConfig failed, store_refresh_interval is required by store_snapshot_dir")
+		return err
+	}
+	return nil
+}
+
+func (nsxConfig *NsxConfig) validateTransportRecord() error {
+	switch nsxConfig.TransportRecordMode {
+	case "":
+		return nil
+	case "record", "replay":
+	default:
+		err := errors.New("invalid field " + "TransportRecordMode")
+		configLog.Error(err, "Validate NsxConfig failed", "TransportRecordMode", nsxConfig.TransportRecordMode)
+		return err
+	}
+	if nsxConfig.TransportRecordDir == "" {
+		err := errors.New("invalid field " + "TransportRecordDir")
+		configLog.Error(err, "Validate NsxConfig failed", "TransportRecordMode", nsxConfig.TransportRecordMode)
+		return err
+	}
+	return nil
+}
+
+func (nsxConfig *NsxConfig) validateEndpointSelection() error {
+	switch nsxConfig.EndpointSelectionStrategy {
+	case "", "least-connections", "weighted-round-robin", "lowest-latency", "first-healthy":
+	default:
+		err := errors.New("invalid field " + "EndpointSelectionStrategy")
+		configLog.Error(err, "Validate NsxConfig failed", "EndpointSelectionStrategy", nsxConfig.EndpointSelectionStrategy)
+		return err
+	}
+	if len(nsxConfig.EndpointWeights) > len(nsxConfig.NsxApiManagers) {
+		err := errors.New("endpoint weight count exceeds manager count")
+		configLog.Error(err, "Validate NsxConfig failed", "weight count", len(nsxConfig.EndpointWeights), "manager count", len(nsxConfig.NsxApiManagers))
+		return err
+	}
+	for _, w := range nsxConfig.EndpointWeights {
+		if w < 0 {
+			err := errors.New("invalid field " + "EndpointWeights")
+			configLog.Error(err, "Validate NsxConfig failed", "EndpointWeights", nsxConfig.EndpointWeights)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -423,6 +604,54 @@ func (coeConfig *CoeConfig) validate() error {
 	return nil
 }
 
+func (k8sConfig *K8sConfig) validate() error {
+	if k8sConfig == nil {
+		return nil
+	}
+	for _, entry := range k8sConfig.SecurityPolicyCategoryAllowList {
+		if namespace, category, ok := strings.Cut(entry, ":"); !ok || namespace == "" || category == "" {
+			err := errors.New("invalid field " + "SecurityPolicyCategoryAllowList")
+			configLog.Error(err, "Validate K8sConfig failed", "SecurityPolicyCategoryAllowList", entry)
+			return err
+		}
+	}
+	return nil
+}
+
+// SecurityPolicyCategoryAllowed returns whether the SecurityPolicies in namespace are allowed to use category by
+// SecurityPolicyCategoryAllowList.
+func (k8sConfig *K8sConfig) SecurityPolicyCategoryAllowed(namespace, category string) bool {
+	if k8sConfig == nil {
+		return false
+	}
+	for _, entry := range k8sConfig.SecurityPolicyCategoryAllowList {
+		allowedNamespace, allowedCategory, _ := strings.Cut(entry, ":")
+		if (allowedNamespace == "*" || allowedNamespace == namespace) && (allowedCategory == "*" || allowedCategory == category) {
+			return true
+		}
+	}
+	return false
+}
+
+func (tracingConfig *TracingConfig) validate() error {
+	if tracingConfig == nil || !tracingConfig.EnableTracing {
+		return nil
+	}
+	switch tracingConfig.TracingExporter {
+	case "otlp-grpc", "otlp-http":
+	default:
+		err := errors.New("invalid field " + "TracingExporter")
+		configLog.Error(err, "Validate TracingConfig failed", "TracingExporter", tracingConfig.TracingExporter)
+		return err
+	}
+	if tracingConfig.TracingSampleRatio < 0 || tracingConfig.TracingSampleRatio > 1 {
+		err := errors.New("invalid field " + "TracingSampleRatio")
+		configLog.Error(err, "Validate TracingConfig failed", "TracingSampleRatio", tracingConfig.TracingSampleRatio)
+		return err
+	}
+	return nil
+}
+
 func (nsxConfig *NsxConfig) ValidateConfigFromCmd() error {
 	return nsxConfig.validate(true)
 }
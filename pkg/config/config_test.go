@@ -57,6 +57,18 @@ func TestConfig_CoeConfig(t *testing.T) {
 
 }
 
+func TestConfig_K8sConfig(t *testing.T) {
+	k8sConfig := &K8sConfig{SecurityPolicyCategoryAllowList: []string{"kube-system:*", "*:Environment"}}
+	assert.NoError(t, k8sConfig.validate())
+	assert.True(t, k8sConfig.SecurityPolicyCategoryAllowed("kube-system", "Emergency"))
+	assert.True(t, k8sConfig.SecurityPolicyCategoryAllowed("ns-1", "Environment"))
+	assert.False(t, k8sConfig.SecurityPolicyCategoryAllowed("ns-1", "Emergency"))
+	assert.False(t, (*K8sConfig)(nil).SecurityPolicyCategoryAllowed("ns-1", "Emergency"))
+
+	k8sConfig.SecurityPolicyCategoryAllowList = []string{"kube-system"}
+	assert.Equal(t, errors.New("invalid field "+"SecurityPolicyCategoryAllowList"), k8sConfig.validate())
+}
+
 func TestConfig_NsxConfig(t *testing.T) {
 	nsxConfig := &NsxConfig{}
 	expect := errors.New("invalid field " + "NsxApiManagers")
@@ -89,6 +101,92 @@ func TestConfig_NsxConfig(t *testing.T) {
 	expect = errors.New("thumbprint count not match manager count")
 	err = nsxConfig.validate(false)
 	assert.Equal(t, err, expect)
+
+	nsxConfig.Thumbprint = []string{"0a:fc"}
+	nsxConfig.EndpointSelectionStrategy = "random"
+	expect = errors.New("invalid field " + "EndpointSelectionStrategy")
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, expect)
+
+	nsxConfig.EndpointSelectionStrategy = "weighted-round-robin"
+	nsxConfig.EndpointWeights = []int{2, 1}
+	expect = errors.New("endpoint weight count exceeds manager count")
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, expect)
+
+	nsxConfig.EndpointWeights = []int{-1}
+	expect = errors.New("invalid field " + "EndpointWeights")
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, expect)
+
+	nsxConfig.EndpointWeights = []int{2}
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, nil)
+
+	nsxConfig.CircuitBreakerOpenTimeout = -1
+	expect = errors.New("invalid field " + "CircuitBreaker")
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, expect)
+
+	nsxConfig.CircuitBreakerOpenTimeout = 30
+	nsxConfig.TransportRecordMode = "capture"
+	expect = errors.New("invalid field " + "TransportRecordMode")
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, expect)
+
+	nsxConfig.TransportRecordMode = "replay"
+	expect = errors.New("invalid field " + "TransportRecordDir")
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, expect)
+
+	nsxConfig.TransportRecordDir = "/tmp/golden"
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, nil)
+
+	nsxConfig.DriftDetectionInterval = -1
+	expect = errors.New("invalid field " + "DriftDetectionInterval")
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, expect)
+
+	nsxConfig.DriftDetectionInterval = 0
+	nsxConfig.StoreRefreshInterval = -1
+	expect = errors.New("invalid field " + "StoreRefreshInterval")
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, expect)
+
+	nsxConfig.StoreRefreshInterval = 0
+	nsxConfig.StoreSnapshotDir = "/var/lib/nsx-operator"
+	expect = errors.New("invalid field " + "StoreSnapshotDir")
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, expect)
+
+	nsxConfig.StoreRefreshInterval = 60
+	err = nsxConfig.validate(false)
+	assert.Equal(t, err, nil)
+}
+
+func TestConfig_TracingConfig(t *testing.T) {
+	var nilConfig *TracingConfig
+	assert.Nil(t, nilConfig.validate())
+
+	tracingConfig := NewNSXOpertorConfig().TracingConfig
+	assert.False(t, tracingConfig.EnableTracing)
+	tracingConfig.TracingExporter = "zipkin"
+	assert.Nil(t, tracingConfig.validate())
+
+	tracingConfig.EnableTracing = true
+	expect := errors.New("invalid field " + "TracingExporter")
+	err := tracingConfig.validate()
+	assert.Equal(t, err, expect)
+
+	tracingConfig.TracingExporter = "otlp-http"
+	tracingConfig.TracingSampleRatio = 1.5
+	expect = errors.New("invalid field " + "TracingSampleRatio")
+	err = tracingConfig.validate()
+	assert.Equal(t, err, expect)
+
+	tracingConfig.TracingSampleRatio = 0.1
+	assert.Nil(t, tracingConfig.validate())
 }
 
 func TestConfig_NewNSXOperatorConfigFromFile(t *testing.T) {
@@ -218,3 +316,22 @@ func TestNsxConfig_GetServiceSize(t *testing.T) {
 		})
 	}
 }
+
+func TestNSXOperatorConfig_UpdateNsxConnection(t *testing.T) {
+	operatorConfig := NewNSXOpertorConfig()
+	operatorConfig.NsxApiManagers = []string{"10.0.0.1"}
+	operatorConfig.DefaultProject = "project-1"
+	operatorConfig.configCache.nsxCA = []byte("dummy\n")
+	operatorConfig.UpdateNsxConnection(&NsxConfig{
+		NsxApiManagers: []string{"10.0.0.2", "10.0.0.3"},
+		NsxApiUser:     "admin",
+		NsxApiPassword: "newpassw0rd",
+		CaFile:         []string{"/etc/nsx-ujo/ca.crt"},
+	})
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.3"}, operatorConfig.NsxApiManagers)
+	assert.Equal(t, "newpassw0rd", operatorConfig.NsxApiPassword)
+	assert.Equal(t, []string{"/etc/nsx-ujo/ca.crt"}, operatorConfig.CaFile)
+	// the fields which need restart are not changed
+	assert.Equal(t, "project-1", operatorConfig.DefaultProject)
+	assert.Nil(t, operatorConfig.configCache.nsxCA)
+}
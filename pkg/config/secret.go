@@ -0,0 +1,316 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/auth/jwt"
+)
+
+var (
+	// SecretFileDir is the directory of the certs and keys read from Secrets, the NSX client loads them from files.
+	// A private directory is created under os.TempDir() on the first write if it's not set.
+	SecretFileDir  string
+	secretDirMutex sync.Mutex
+)
+
+// SecretKeyRef references a key in a Secret.
+type SecretKeyRef struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+// ParseSecretKeyRef parses the Secret reference in namespace/name/key format.
+func ParseSecretKeyRef(ref string) (*SecretKeyRef, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid Secret reference %q, expected namespace/name/key", ref)
+	}
+	return &SecretKeyRef{Namespace: parts[0], Name: parts[1], Key: parts[2]}, nil
+}
+
+func (ref *SecretKeyRef) String() string {
+	return ref.Namespace + "/" + ref.Name + "/" + ref.Key
+}
+
+// secretField is a config field whose value could be read from a Secret.
+type secretField struct {
+	name  string
+	ref   string
+	apply func(value []byte) error
+}
+
+func (operatorConfig *NSXOperatorConfig) secretFields() []secretField {
+	var secretFields []secretField
+	if nsxConfig := operatorConfig.NsxConfig; nsxConfig != nil {
+		secretFields = append(secretFields,
+			secretField{"NsxApiPasswordSecret", nsxConfig.NsxApiPasswordSecret, func(value []byte) error {
+				nsxConfig.NsxApiPassword = trimSecretValue(value)
+				return nil
+			}},
+			secretField{"NsxApiCertSecret", nsxConfig.NsxApiCertSecret, func(value []byte) error {
+				file, err := writeSecretFile("nsx_api_cert.pem", value)
+				nsxConfig.NsxApiCertFile = file
+				return err
+			}},
+			secretField{"NsxApiPrivateKeySecret", nsxConfig.NsxApiPrivateKeySecret, func(value []byte) error {
+				file, err := writeSecretFile("nsx_api_private_key.pem", value)
+				nsxConfig.NsxApiPrivateKeyFile = file
+				return err
+			}},
+			secretField{"CaSecret", nsxConfig.CaSecret, func(value []byte) error {
+				file, err := writeSecretFile("ca.pem", value)
+				nsxConfig.CaFile = []string{file}
+				return err
+			}},
+		)
+	}
+	if vcConfig := operatorConfig.VCConfig; vcConfig != nil {
+		secretFields = append(secretFields,
+			secretField{"VCUserSecret", vcConfig.VCUserSecret, func(value []byte) error {
+				vcConfig.VCUser = trimSecretValue(value)
+				return nil
+			}},
+			secretField{"VCPasswordSecret", vcConfig.VCPasswordSecret, func(value []byte) error {
+				vcConfig.VCPassword = trimSecretValue(value)
+				return nil
+			}},
+		)
+	}
+	return secretFields
+}
+
+func trimSecretValue(value []byte) string {
+	return strings.TrimRight(string(value), "\n\r")
+}
+
+// secretFileDir returns SecretFileDir, the directory is created with os.MkdirTemp so that it's only accessible by the
+// operator and its name is not predictable.
+func secretFileDir() (string, error) {
+	secretDirMutex.Lock()
+	defer secretDirMutex.Unlock()
+	if SecretFileDir == "" {
+		dir, err := os.MkdirTemp("", "nsx-operator-secrets-")
+		if err != nil {
+			configLog.Errorf("Failed to create directory for Secrets, err=%v", err)
+			return "", err
+		}
+		SecretFileDir = dir
+		return SecretFileDir, nil
+	}
+	if err := os.MkdirAll(SecretFileDir, 0700); err != nil {
+		configLog.Errorf("Failed to create directory %s, err=%v", SecretFileDir, err)
+		return "", err
+	}
+	return SecretFileDir, nil
+}
+
+// isSecretFile checks if the file is written by the operator from a Secret.
+func isSecretFile(file string) bool {
+	secretDirMutex.Lock()
+	defer secretDirMutex.Unlock()
+	return SecretFileDir != "" && filepath.Dir(filepath.Clean(file)) == filepath.Clean(SecretFileDir)
+}
+
+func writeSecretFile(name string, value []byte) (string, error) {
+	dir, err := secretFileDir()
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, name)
+	// the file isn't written again if the Secret is not changed, the clients loading it are not disturbed
+	if current, err := os.ReadFile(file); err == nil && bytes.Equal(current, value) {
+		return file, nil
+	}
+	if err := os.WriteFile(file, value, 0600); err != nil {
+		configLog.Errorf("Failed to write file %s, err=%v", file, err)
+		return "", err
+	}
+	return file, nil
+}
+
+func (operatorConfig *NSXOperatorConfig) validateSecretRefs() error {
+	for _, field := range operatorConfig.secretFields() {
+		if field.ref == "" {
+			continue
+		}
+		if _, err := ParseSecretKeyRef(field.ref); err != nil {
+			err = errors.New("invalid field " + field.name)
+			configLog.Error(err, "Validate Secret reference failed", field.name, field.ref)
+			return err
+		}
+	}
+	return nil
+}
+
+// HasSecretRefs returns true if any credential, cert or key is read from a Secret.
+func (operatorConfig *NSXOperatorConfig) HasSecretRefs() bool {
+	for _, field := range operatorConfig.secretFields() {
+		if field.ref != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadSecrets reads the referenced Secrets and overrides the config with their values. The certs and keys are
+// written to SecretFileDir. The new VC credentials are pushed to the JWT token provider.
+func (operatorConfig *NSXOperatorConfig) LoadSecrets(ctx context.Context, kubeClient kubernetes.Interface) error {
+	var vcUser, vcPassword string
+	if operatorConfig.VCConfig != nil {
+		vcUser, vcPassword = operatorConfig.VCUser, operatorConfig.VCPassword
+	}
+	secrets := map[string]*corev1.Secret{}
+	for _, field := range operatorConfig.secretFields() {
+		if field.ref == "" {
+			continue
+		}
+		ref, err := ParseSecretKeyRef(field.ref)
+		if err != nil {
+			return err
+		}
+		secretName := ref.Namespace + "/" + ref.Name
+		secret, ok := secrets[secretName]
+		if !ok {
+			secret, err = kubeClient.CoreV1().Secrets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			if err != nil {
+				configLog.Errorf("Failed to get Secret %s for %s, err=%v", secretName, field.name, err)
+				return err
+			}
+			secrets[secretName] = secret
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			err = fmt.Errorf("key %s not found in Secret %s", ref.Key, secretName)
+			configLog.Errorf("Failed to load %s, err=%v", field.name, err)
+			return err
+		}
+		if err := field.apply(value); err != nil {
+			return err
+		}
+	}
+	operatorConfig.configCache.nsxCA = nil
+
+	if operatorConfig.VCConfig != nil && (vcUser != operatorConfig.VCUser || vcPassword != operatorConfig.VCPassword) {
+		if provider, ok := tokenProvider.(*jwt.JWTTokenProvider); ok {
+			configLog.Info("Updating VC credentials of JWT token provider")
+			provider.UpdateCredentials(operatorConfig.VCUser, operatorConfig.VCPassword)
+		}
+	}
+	return nil
+}
+
+// SecretWatcher calls onChange once the data of any watched Secret is changed, until ctx is done. The calls of
+// onChange are serialized.
+type SecretWatcher struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+	onChange   func()
+
+	// changeMutex serializes the calls of onChange.
+	changeMutex sync.Mutex
+	// mutex guards watched, which maps the namespace/name of the watched Secrets to the cancel func of the informer.
+	mutex   sync.Mutex
+	watched map[string]context.CancelFunc
+}
+
+// NewSecretWatcher creates a SecretWatcher, the Secrets are watched by calling Watch.
+func NewSecretWatcher(ctx context.Context, kubeClient kubernetes.Interface, onChange func()) *SecretWatcher {
+	return &SecretWatcher{ctx: ctx, kubeClient: kubeClient, onChange: onChange, watched: map[string]context.CancelFunc{}}
+}
+
+// Watch watches the Secrets referenced by operatorConfig. It's called again with the reloaded config, then the
+// Secrets which are newly referenced are watched and the ones which are no longer referenced are not.
+func (w *SecretWatcher) Watch(operatorConfig *NSXOperatorConfig) error {
+	refs := map[string]*SecretKeyRef{}
+	for _, field := range operatorConfig.secretFields() {
+		if field.ref == "" {
+			continue
+		}
+		ref, err := ParseSecretKeyRef(field.ref)
+		if err != nil {
+			return err
+		}
+		refs[ref.Namespace+"/"+ref.Name] = ref
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for secretName, cancel := range w.watched {
+		if _, ok := refs[secretName]; !ok {
+			cancel()
+			delete(w.watched, secretName)
+			configLog.Infof("Stopped watching Secret %s", secretName)
+		}
+	}
+	for secretName, ref := range refs {
+		if _, ok := w.watched[secretName]; ok {
+			continue
+		}
+		if err := w.watch(secretName, ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WatchedSecrets returns the namespace/name of the watched Secrets.
+func (w *SecretWatcher) WatchedSecrets() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	var names []string
+	for secretName := range w.watched {
+		names = append(names, secretName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (w *SecretWatcher) watch(secretName string, ref *SecretKeyRef) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(w.kubeClient, 0, informers.WithNamespace(ref.Namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", ref.Name).String()
+		}))
+	informer := factory.Core().V1().Secrets().Informer()
+	_, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSecret, oldOK := oldObj.(*corev1.Secret)
+			newSecret, newOK := newObj.(*corev1.Secret)
+			if !oldOK || !newOK || reflect.DeepEqual(oldSecret.Data, newSecret.Data) {
+				return
+			}
+			configLog.Infof("Secret %s is changed", secretName)
+			w.changeMutex.Lock()
+			defer w.changeMutex.Unlock()
+			w.onChange()
+		},
+	})
+	if err != nil {
+		configLog.Errorf("Failed to watch Secret %s, err=%v", secretName, err)
+		return err
+	}
+	ctx, cancel := context.WithCancel(w.ctx)
+	factory.Start(ctx.Done())
+	w.watched[secretName] = cancel
+	configLog.Infof("Watching Secret %s", secretName)
+	return nil
+}
@@ -0,0 +1,209 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/auth/jwt"
+)
+
+func TestParseSecretKeyRef(t *testing.T) {
+	ref, err := ParseSecretKeyRef("vmware-system-nsx/nsx-secret/password")
+	assert.Nil(t, err)
+	assert.Equal(t, &SecretKeyRef{Namespace: "vmware-system-nsx", Name: "nsx-secret", Key: "password"}, ref)
+	assert.Equal(t, "vmware-system-nsx/nsx-secret/password", ref.String())
+
+	for _, invalid := range []string{"", "nsx-secret/password", "vmware-system-nsx//password", "a/b/c/d"} {
+		_, err = ParseSecretKeyRef(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestNSXOperatorConfig_validateSecretRefs(t *testing.T) {
+	operatorConfig := NewNSXOpertorConfig()
+	assert.Nil(t, operatorConfig.validateSecretRefs())
+	assert.False(t, operatorConfig.HasSecretRefs())
+
+	operatorConfig.VCPasswordSecret = "vmware-system-nsx/vc-secret/password"
+	assert.Nil(t, operatorConfig.validateSecretRefs())
+	assert.True(t, operatorConfig.HasSecretRefs())
+
+	operatorConfig.CaSecret = "ca.crt"
+	assert.Equal(t, errors.New("invalid field CaSecret"), operatorConfig.validateSecretRefs())
+}
+
+func TestNSXOperatorConfig_LoadSecrets(t *testing.T) {
+	originDir, originProvider := SecretFileDir, tokenProvider
+	defer func() {
+		SecretFileDir, tokenProvider = originDir, originProvider
+	}()
+	SecretFileDir = t.TempDir()
+	provider, _ := jwt.NewTokenProvider("127.0.0.1", 443, "vsphere.local", "", "", []byte{}, false, "https")
+	tokenProvider = provider
+
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "vmware-system-nsx", Name: "nsx-secret"},
+			Data: map[string][]byte{
+				"password": []byte("passw0rd\n"),
+				"tls.crt":  []byte("dummy cert"),
+				"tls.key":  []byte("dummy key"),
+				"ca.crt":   []byte("dummy ca"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "vc-secret"},
+			Data: map[string][]byte{
+				"username": []byte("administrator@vsphere.local"),
+				"password": []byte("vcpassw0rd"),
+			},
+		},
+	)
+	operatorConfig := NewNSXOpertorConfig()
+	operatorConfig.NsxApiPassword = "plain"
+	operatorConfig.NsxApiPasswordSecret = "vmware-system-nsx/nsx-secret/password"
+	operatorConfig.NsxApiCertSecret = "vmware-system-nsx/nsx-secret/tls.crt"
+	operatorConfig.NsxApiPrivateKeySecret = "vmware-system-nsx/nsx-secret/tls.key"
+	operatorConfig.CaSecret = "vmware-system-nsx/nsx-secret/ca.crt"
+	operatorConfig.VCUserSecret = "kube-system/vc-secret/username"
+	operatorConfig.VCPasswordSecret = "kube-system/vc-secret/password"
+	operatorConfig.configCache.nsxCA = []byte("old ca")
+
+	assert.Nil(t, operatorConfig.LoadSecrets(context.TODO(), kubeClient))
+	assert.Equal(t, "passw0rd", operatorConfig.NsxApiPassword)
+	assert.Equal(t, "administrator@vsphere.local", operatorConfig.VCUser)
+	assert.Equal(t, "vcpassw0rd", operatorConfig.VCPassword)
+	for file, content := range map[string]string{
+		operatorConfig.NsxApiCertFile:       "dummy cert",
+		operatorConfig.NsxApiPrivateKeyFile: "dummy key",
+		operatorConfig.CaFile[0]:            "dummy ca",
+	} {
+		data, err := os.ReadFile(file)
+		assert.Nil(t, err)
+		assert.Equal(t, content, string(data))
+	}
+	assert.Equal(t, []byte("dummy ca\n"), operatorConfig.GetCACert())
+
+	operatorConfig.VCPasswordSecret = "kube-system/vc-secret/pass"
+	assert.NotNil(t, operatorConfig.LoadSecrets(context.TODO(), kubeClient))
+	operatorConfig.VCPasswordSecret = "kube-system/vc/password"
+	assert.NotNil(t, operatorConfig.LoadSecrets(context.TODO(), kubeClient))
+}
+
+func TestSecretFileDir(t *testing.T) {
+	originDir := SecretFileDir
+	defer func() {
+		SecretFileDir = originDir
+	}()
+	SecretFileDir = ""
+	file, err := writeSecretFile("ca.pem", []byte("dummy ca"))
+	assert.Nil(t, err)
+	defer os.RemoveAll(SecretFileDir)
+	assert.Equal(t, SecretFileDir, filepath.Dir(file))
+	info, err := os.Stat(SecretFileDir)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	// the directory is kept for the following writes
+	dir := SecretFileDir
+	_, err = writeSecretFile("nsx_api_cert.pem", []byte("dummy cert"))
+	assert.Nil(t, err)
+	assert.Equal(t, dir, SecretFileDir)
+	assert.True(t, isSecretFile(file))
+	assert.False(t, isSecretFile("/etc/nsx-ujo/ca.crt"))
+
+	// the file isn't written again with the same content
+	before, err := os.Stat(file)
+	assert.Nil(t, err)
+	time.Sleep(10 * time.Millisecond)
+	_, err = writeSecretFile("ca.pem", []byte("dummy ca"))
+	assert.Nil(t, err)
+	after, err := os.Stat(file)
+	assert.Nil(t, err)
+	assert.Equal(t, before.ModTime(), after.ModTime())
+}
+
+func TestSecretWatcher_Watch(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "vmware-system-nsx", Name: "nsx-secret"},
+		Data:       map[string][]byte{"password": []byte("passw0rd")},
+	}
+	vcSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "vc-secret"},
+		Data:       map[string][]byte{"password": []byte("vcpassw0rd")},
+	}
+	kubeClient := fake.NewSimpleClientset(secret, vcSecret)
+	operatorConfig := NewNSXOpertorConfig()
+	operatorConfig.NsxApiPasswordSecret = "vmware-system-nsx/nsx-secret/password"
+
+	changed := make(chan struct{}, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher := NewSecretWatcher(ctx, kubeClient, func() {
+		changed <- struct{}{}
+	})
+	assert.Nil(t, watcher.Watch(operatorConfig))
+	assert.Equal(t, []string{"vmware-system-nsx/nsx-secret"}, watcher.WatchedSecrets())
+	// wait for the informer to list the Secret
+	time.Sleep(200 * time.Millisecond)
+
+	expectChanged := func(msg string) {
+		select {
+		case <-changed:
+		case <-time.After(5 * time.Second):
+			t.Fatal(msg)
+		}
+	}
+	expectNotChanged := func(msg string) {
+		select {
+		case <-changed:
+			t.Fatal(msg)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	// update without data change is ignored
+	secret.Labels = map[string]string{"foo": "bar"}
+	_, err := kubeClient.CoreV1().Secrets("vmware-system-nsx").Update(context.TODO(), secret, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+	secret = secret.DeepCopy()
+	secret.Data["password"] = []byte("newpassw0rd")
+	_, err = kubeClient.CoreV1().Secrets("vmware-system-nsx").Update(context.TODO(), secret, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+	expectChanged("onChange is not called after the Secret is changed")
+	expectNotChanged("onChange is called without Secret data change")
+
+	// the Secret referenced by the reloaded config is watched, and the one no longer referenced is not
+	reloadedConfig := NewNSXOpertorConfig()
+	reloadedConfig.VCPasswordSecret = "kube-system/vc-secret/password"
+	assert.Nil(t, watcher.Watch(reloadedConfig))
+	assert.Equal(t, []string{"kube-system/vc-secret"}, watcher.WatchedSecrets())
+	time.Sleep(200 * time.Millisecond)
+
+	vcSecret = vcSecret.DeepCopy()
+	vcSecret.Data["password"] = []byte("newvcpassw0rd")
+	_, err = kubeClient.CoreV1().Secrets("kube-system").Update(context.TODO(), vcSecret, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+	expectChanged("onChange is not called after the Secret added on reload is changed")
+
+	secret = secret.DeepCopy()
+	secret.Data["password"] = []byte("passw0rd")
+	_, err = kubeClient.CoreV1().Secrets("vmware-system-nsx").Update(context.TODO(), secret, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+	expectNotChanged("onChange is called after the Secret removed on reload is changed")
+
+	reloadedConfig.VCPasswordSecret = "kube-system"
+	assert.NotNil(t, watcher.Watch(reloadedConfig))
+}
@@ -0,0 +1,120 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// configReloadDelay merges the events of a config update, e.g. a Secret update replaces the cert and key files
+// one after another.
+var configReloadDelay = 2 * time.Second
+
+// watchedFiles returns the config file and the cert, key and CA files referenced by the config. The files written
+// from the Secrets are not watched since they are rewritten on every reload, the Secrets are watched instead.
+func (operatorConfig *NSXOperatorConfig) watchedFiles() []string {
+	files := []string{configFilePath}
+	candidates := []string{operatorConfig.NsxApiCertFile, operatorConfig.NsxApiPrivateKeyFile}
+	candidates = append(candidates, operatorConfig.CaFile...)
+	candidates = append(candidates, operatorConfig.LeafCertFile...)
+	for _, file := range candidates {
+		// CaFile could be the raw content of a cert
+		if file == "" || isSecretFile(file) {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// isWatchedFileEvent checks if the event changes one of the files. The ConfigMap and Secret volumes are updated by
+// swapping the "..data" symlink, so the events of the hidden entries in the directory are also accepted.
+func isWatchedFileEvent(event fsnotify.Event, files []string) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Clean(event.Name)
+	for _, file := range files {
+		if name == filepath.Clean(file) {
+			return true
+		}
+		if filepath.Dir(name) == filepath.Dir(filepath.Clean(file)) && strings.HasPrefix(filepath.Base(name), "..") {
+			return true
+		}
+	}
+	return false
+}
+
+// WatchConfigFiles watches the config file and the cert, key and CA files referenced by it until ctx is done.
+// Once any of them is changed, the config file is loaded again and reload is called with the new config, the
+// config with validation error is ignored. The directories of the files are watched since the files in the mounted
+// volumes are replaced rather than written.
+func (operatorConfig *NSXOperatorConfig) WatchConfigFiles(ctx context.Context, reload func(*NSXOperatorConfig)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		configLog.Errorf("Failed to create config file watcher, err=%v", err)
+		return err
+	}
+	defer watcher.Close()
+
+	watchedDirs := map[string]bool{}
+	files := operatorConfig.watchedFiles()
+	watchDirs := func() {
+		for _, file := range files {
+			dir := filepath.Dir(filepath.Clean(file))
+			if watchedDirs[dir] {
+				continue
+			}
+			if err := watcher.Add(dir); err != nil {
+				configLog.Errorf("Failed to watch directory %s, err=%v", dir, err)
+				continue
+			}
+			watchedDirs[dir] = true
+		}
+	}
+	watchDirs()
+	configLog.Infof("Watching NSX Operator configuration files: %v", files)
+
+	timer := time.NewTimer(configReloadDelay)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if isWatchedFileEvent(event, files) {
+				configLog.Debugf("Config file changed: %s", event)
+				timer.Reset(configReloadDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			configLog.Errorf("Config file watcher error: %v", err)
+		case <-timer.C:
+			newConfig, err := LoadConfigFromFile()
+			if err != nil {
+				configLog.Errorf("Failed to reload NSX Operator configuration file, keep the current config, err=%v", err)
+				continue
+			}
+			configLog.Infof("Reloading NSX Operator configuration")
+			reload(newConfig)
+			files = newConfig.watchedFiles()
+			watchDirs()
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+)
+
+const watcherTestConfig = `[DEFAULT]
+[coe]
+cluster = k8scl-one
+[nsx_v3]
+nsx_api_managers = %s
+nsx_api_password = admin
+nsx_api_user = admin
+ca_file = %s
+[vc]
+`
+
+func TestIsWatchedFileEvent(t *testing.T) {
+	files := []string{"/etc/nsx-operator/nsxop.ini", "/etc/nsx-ujo/ca.crt"}
+	assert.True(t, isWatchedFileEvent(fsnotify.Event{Name: "/etc/nsx-operator/nsxop.ini", Op: fsnotify.Write}, files))
+	assert.True(t, isWatchedFileEvent(fsnotify.Event{Name: "/etc/nsx-ujo/..data", Op: fsnotify.Create}, files))
+	assert.False(t, isWatchedFileEvent(fsnotify.Event{Name: "/etc/nsx-ujo/ca.crt", Op: fsnotify.Chmod}, files))
+	assert.False(t, isWatchedFileEvent(fsnotify.Event{Name: "/etc/nsx-ujo/other.crt", Op: fsnotify.Write}, files))
+	assert.False(t, isWatchedFileEvent(fsnotify.Event{Name: "/etc/other/..data", Op: fsnotify.Create}, files))
+}
+
+func TestWatchConfigFiles(t *testing.T) {
+	origin, originDelay := configFilePath, configReloadDelay
+	defer func() {
+		configFilePath, configReloadDelay = origin, originDelay
+	}()
+	configReloadDelay = 100 * time.Millisecond
+
+	configDir := t.TempDir()
+	certDir := t.TempDir()
+	configFile := filepath.Join(configDir, "nsxop.ini")
+	caFile := filepath.Join(certDir, "ca.crt")
+	assert.Nil(t, os.WriteFile(caFile, []byte("dummy ca"), 0600))
+	writeConfig := func(managers string) {
+		assert.Nil(t, os.WriteFile(configFile, []byte(fmt.Sprintf(watcherTestConfig, managers, caFile)), 0600))
+	}
+	writeConfig("10.0.0.1")
+	UpdateConfigFilePath(configFile)
+	cf, err := LoadConfigFromFile()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{configFile, caFile}, cf.watchedFiles())
+
+	reloaded := make(chan *NSXOperatorConfig, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- cf.WatchConfigFiles(ctx, func(newConfig *NSXOperatorConfig) {
+			reloaded <- newConfig
+		})
+	}()
+	// wait for the watcher to be set up
+	time.Sleep(200 * time.Millisecond)
+
+	writeConfig("10.0.0.1,10.0.0.2")
+	select {
+	case newConfig := <-reloaded:
+		assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, newConfig.NsxApiManagers)
+	case <-time.After(5 * time.Second):
+		t.Fatal("config is not reloaded after the config file is changed")
+	}
+
+	// the cert file is replaced
+	assert.Nil(t, os.WriteFile(caFile+".new", []byte("dummy ca"), 0600))
+	assert.Nil(t, os.Rename(caFile+".new", caFile))
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("config is not reloaded after the cert file is changed")
+	}
+
+	// invalid config is ignored
+	writeConfig("")
+	select {
+	case <-reloaded:
+		t.Fatal("invalid config is reloaded")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	cancel()
+	assert.Nil(t, <-done)
+}
+
+func TestWatchConfigFilesSkipSecretFiles(t *testing.T) {
+	origin, originDelay, originDir := configFilePath, configReloadDelay, SecretFileDir
+	defer func() {
+		configFilePath, configReloadDelay, SecretFileDir = origin, originDelay, originDir
+	}()
+	configReloadDelay = 100 * time.Millisecond
+	SecretFileDir = t.TempDir()
+
+	configDir := t.TempDir()
+	configFile := filepath.Join(configDir, "nsxop.ini")
+	// the CA file is written from the CA Secret by the reload
+	caFile, err := writeSecretFile("ca.pem", []byte("dummy ca"))
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(configFile, []byte(fmt.Sprintf(watcherTestConfig, "10.0.0.1", caFile)), 0600))
+	UpdateConfigFilePath(configFile)
+	cf, err := LoadConfigFromFile()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{configFile}, cf.watchedFiles())
+
+	reloaded := make(chan *NSXOperatorConfig, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	count := 0
+	go func() {
+		done <- cf.WatchConfigFiles(ctx, func(newConfig *NSXOperatorConfig) {
+			// rewrite the Secret file as LoadSecrets does if the Secret is rotated
+			count++
+			_, err := writeSecretFile("ca.pem", []byte(fmt.Sprintf("dummy ca %d", count)))
+			assert.Nil(t, err)
+			reloaded <- newConfig
+		})
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	assert.Nil(t, os.WriteFile(configFile, []byte(fmt.Sprintf(watcherTestConfig, "10.0.0.1,10.0.0.2", caFile)), 0600))
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("config is not reloaded after the config file is changed")
+	}
+	// the Secret file written by the reload doesn't trigger another reload
+	select {
+	case <-reloaded:
+		t.Fatal("config is reloaded again by the Secret file")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	cancel()
+	assert.Nil(t, <-done)
+}
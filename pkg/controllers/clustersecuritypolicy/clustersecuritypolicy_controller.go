@@ -0,0 +1,317 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package clustersecuritypolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	_ "github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+var (
+	log                                = logger.Log
+	ResultNormal                       = common.ResultNormal
+	ResultRequeue                      = common.ResultRequeue
+	ResultRequeueAfter5mins            = common.ResultRequeueAfter5mins
+	MetricResTypeClusterSecurityPolicy = common.MetricResTypeClusterSecurityPolicy
+)
+
+// ClusterSecurityPolicyReconciler reconciles a ClusterSecurityPolicy object
+type ClusterSecurityPolicyReconciler struct {
+	Client        client.Client
+	Scheme        *apimachineryruntime.Scheme
+	Service       *securitypolicy.SecurityPolicyService
+	Recorder      record.EventRecorder
+	StatusUpdater common.StatusUpdater
+	// driftEvents re-enqueues the ClusterSecurityPolicies whose NSX Rules or Groups are changed out of band
+	driftEvents chan event.GenericEvent
+}
+
+func (r *ClusterSecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := &v1alpha1.ClusterSecurityPolicy{}
+	log.Info("Reconciling ClusterSecurityPolicy CR", "clustersecuritypolicy", req.Name)
+	startTime := time.Now()
+	defer func() {
+		log.Info("Finished reconciling ClusterSecurityPolicy CR", "clustersecuritypolicy", req.Name, "duration(ms)", time.Since(startTime).Milliseconds())
+	}()
+
+	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
+
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			if err := r.deleteClusterSecurityPolicyByName(ctx, req.Name); err != nil {
+				var planErr *servicecommon.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(nil, planErr)
+					return ResultNormal, nil
+				}
+				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
+				return ResultRequeue, err
+			}
+			r.StatusUpdater.DeleteSuccess(req.NamespacedName, nil)
+			return ResultNormal, nil
+		}
+		log.Error(err, "Failed to fetch ClusterSecurityPolicy CR", "req", req.Name)
+		return ResultRequeue, err
+	}
+	ctx = common.WithPlanMode(ctx, obj)
+
+	if !r.Service.NSXClient.NSXCheckVersion(nsx.SecurityPolicy) {
+		err := errors.New("NSX version check failed, ClusterSecurityPolicy feature is not supported")
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "", setClusterSecurityPolicyReadyStatusFalse)
+		return ResultRequeueAfter5mins, nil
+	}
+
+	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
+		log.Info("Reconciling CR to delete ClusterSecurityPolicy", "clustersecuritypolicy", req.Name)
+		r.StatusUpdater.IncreaseDeleteTotal()
+		if err := r.Service.DeleteClusterSecurityPolicy(ctx, obj.UID); err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(obj, planErr)
+				return ResultNormal, nil
+			}
+			r.StatusUpdater.DeleteFail(req.NamespacedName, obj, err)
+			return ResultRequeue, err
+		}
+		r.StatusUpdater.DeleteSuccess(req.NamespacedName, obj)
+		return ResultNormal, nil
+	}
+
+	r.StatusUpdater.IncreaseUpdateTotal()
+	log.Info("Reconciling CR to create or update ClusterSecurityPolicy", "clustersecuritypolicy", req.Name)
+	if err := r.Service.CreateOrUpdateClusterSecurityPolicy(ctx, obj); err != nil {
+		var planErr *servicecommon.PlanError
+		if errors.As(err, &planErr) {
+			r.StatusUpdater.UpdatePlanned(obj, planErr)
+			return ResultNormal, nil
+		}
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "", setClusterSecurityPolicyReadyStatusFalse)
+		// The ClusterSecurityPolicy can't be realized until it is changed by the users.
+		if errors.As(err, &nsxutil.RestrictionError{}) || errors.As(err, new(*nsxutil.ValidationError)) {
+			return ResultNormal, nil
+		}
+		return common.ResultForError(err, ResultRequeue)
+	}
+	r.StatusUpdater.UpdateSuccess(ctx, obj, setClusterSecurityPolicyReadyStatusTrue)
+	return ResultNormal, nil
+}
+
+func setClusterSecurityPolicyReadyStatusTrue(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, _ ...interface{}) {
+	newConditions := []v1alpha1.Condition{
+		{
+			Type:               v1alpha1.Ready,
+			Status:             v1.ConditionTrue,
+			Message:            "NSX Security Policy has been successfully created/updated",
+			Reason:             "ClusterSecurityPolicyReady",
+			LastTransitionTime: transitionTime,
+		},
+	}
+	updateClusterSecurityPolicyStatusConditions(client, ctx, obj.(*v1alpha1.ClusterSecurityPolicy), newConditions)
+}
+
+func setClusterSecurityPolicyReadyStatusFalse(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, err error, _ ...interface{}) {
+	newConditions := []v1alpha1.Condition{
+		{
+			Type:   v1alpha1.Ready,
+			Status: v1.ConditionFalse,
+			Message: fmt.Sprintf(
+				"error occurred while processing the ClusterSecurityPolicy CR. Error: %v",
+				err,
+			),
+			Reason:             common.ConditionReason(err, "ClusterSecurityPolicyNotReady"),
+			LastTransitionTime: transitionTime,
+		},
+	}
+	updateClusterSecurityPolicyStatusConditions(client, ctx, obj.(*v1alpha1.ClusterSecurityPolicy), newConditions)
+}
+
+func updateClusterSecurityPolicyStatusConditions(client client.Client, ctx context.Context, csp *v1alpha1.ClusterSecurityPolicy, newConditions []v1alpha1.Condition) {
+	conditionsUpdated := false
+	for i := range newConditions {
+		if mergeClusterSecurityPolicyStatusCondition(csp, &newConditions[i]) {
+			conditionsUpdated = true
+		}
+	}
+	if conditionsUpdated {
+		if err := client.Status().Update(ctx, csp); err != nil {
+			log.Error(err, "Failed to update ClusterSecurityPolicy status", "Name", csp.Name)
+		}
+		log.Debug("Updated ClusterSecurityPolicy", "Name", csp.Name, "New Conditions", newConditions)
+	}
+}
+
+func mergeClusterSecurityPolicyStatusCondition(csp *v1alpha1.ClusterSecurityPolicy, newCondition *v1alpha1.Condition) bool {
+	matchedCondition := getExistingConditionOfType(newCondition.Type, csp.Status.Conditions)
+
+	if reflect.DeepEqual(matchedCondition, newCondition) {
+		log.Trace("Conditions already match", "New Condition", newCondition, "Existing Condition", matchedCondition)
+		return false
+	}
+
+	if matchedCondition != nil {
+		matchedCondition.Reason = newCondition.Reason
+		matchedCondition.Message = newCondition.Message
+		matchedCondition.Status = newCondition.Status
+		matchedCondition.LastTransitionTime = newCondition.LastTransitionTime
+	} else {
+		csp.Status.Conditions = append(csp.Status.Conditions, *newCondition)
+	}
+	return true
+}
+
+func getExistingConditionOfType(conditionType v1alpha1.ConditionType, existingConditions []v1alpha1.Condition) *v1alpha1.Condition {
+	for i := range existingConditions {
+		if existingConditions[i].Type == conditionType {
+			return &existingConditions[i]
+		}
+	}
+	return nil
+}
+
+func (r *ClusterSecurityPolicyReconciler) setupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ClusterSecurityPolicy{}).
+		WithOptions(
+			controller.Options{
+				MaxConcurrentReconciles: common.NumReconcile(),
+			}).
+		WatchesRawSource(source.Channel(r.driftEvents, &handler.EnqueueRequestForObject{})).
+		Complete(r)
+}
+
+// DriftSources returns the NSX Rules and Groups created for ClusterSecurityPolicy CRs checked by the drift detector.
+func (r *ClusterSecurityPolicyReconciler) DriftSources() []*common.DriftSource {
+	owner := func(obj interface{}) client.Object {
+		var tags []model.Tag
+		switch o := obj.(type) {
+		case *model.Rule:
+			tags = o.Tags
+		case *model.Group:
+			tags = o.Tags
+		}
+		name := nsxutil.FindTag(tags, servicecommon.TagScopeClusterSecurityPolicyName)
+		if name == "" {
+			return nil
+		}
+		return &v1alpha1.ClusterSecurityPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	var sources []*common.DriftSource
+	for _, spec := range r.Service.DriftSpecs(servicecommon.ResourceTypeClusterSecurityPolicy) {
+		sources = append(sources, &common.DriftSource{DriftSpec: spec, Service: &r.Service.Service, Owner: owner, Events: r.driftEvents})
+	}
+	return sources
+}
+
+// CollectGarbage deletes the NSX resources of the ClusterSecurityPolicies which have been removed from k8s,
+// it implements the interface GarbageCollector method.
+func (r *ClusterSecurityPolicyReconciler) CollectGarbage(ctx context.Context) error {
+	log.Info("ClusterSecurityPolicy garbage collector started")
+	nsxPolicySet := r.Service.ListClusterSecurityPolicyID()
+	if len(nsxPolicySet) == 0 {
+		return nil
+	}
+
+	crPolicySet, err := r.listClusterSecurityPolicyCRIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errList []error
+	for elem := range nsxPolicySet.Difference(crPolicySet) {
+		log.Debug("GC collected ClusterSecurityPolicy CR", "clusterSecurityPolicyUID", elem)
+		r.StatusUpdater.IncreaseDeleteTotal()
+		if err = r.Service.DeleteClusterSecurityPolicy(ctx, types.UID(elem)); err != nil {
+			errList = append(errList, err)
+			r.StatusUpdater.IncreaseDeleteFailTotal()
+		} else {
+			r.StatusUpdater.IncreaseDeleteSuccessTotal()
+		}
+	}
+	if len(errList) > 0 {
+		return fmt.Errorf("errors found in ClusterSecurityPolicy garbage collection: %s", errList)
+	}
+	return nil
+}
+
+func (r *ClusterSecurityPolicyReconciler) deleteClusterSecurityPolicyByName(ctx context.Context, name string) error {
+	for _, item := range r.Service.ListClusterSecurityPolicyByName(name) {
+		uid := nsxutil.FindTag(item.Tags, servicecommon.TagScopeClusterSecurityPolicyUID)
+		log.Info("Deleting ClusterSecurityPolicy", "clusterSecurityPolicyUID", uid, "nsxSecurityPolicyId", *item.Id)
+		if err := r.Service.DeleteClusterSecurityPolicy(ctx, types.UID(uid)); err != nil {
+			log.Error(err, "Failed to delete ClusterSecurityPolicy", "clusterSecurityPolicyUID", uid, "nsxSecurityPolicyId", *item.Id)
+			return err
+		}
+		log.Info("Successfully deleted ClusterSecurityPolicy", "clusterSecurityPolicyUID", uid, "nsxSecurityPolicyId", *item.Id)
+	}
+	return nil
+}
+
+func (r *ClusterSecurityPolicyReconciler) listClusterSecurityPolicyCRIDs(ctx context.Context) (sets.Set[string], error) {
+	cspList := &v1alpha1.ClusterSecurityPolicyList{}
+	if err := r.Client.List(ctx, cspList); err != nil {
+		log.Error(err, "Failed to list ClusterSecurityPolicy CR")
+		return nil, err
+	}
+	crPolicySet := sets.New[string]()
+	for _, csp := range cspList.Items {
+		crPolicySet.Insert(string(csp.UID))
+	}
+	return crPolicySet, nil
+}
+
+func (r *ClusterSecurityPolicyReconciler) RestoreReconcile() error {
+	return nil
+}
+
+func (r *ClusterSecurityPolicyReconciler) StartController(mgr ctrl.Manager, _ webhook.Server) error {
+	if err := r.setupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to create controller", "controller", "ClusterSecurityPolicy")
+		return err
+	}
+	go common.GenericGarbageCollector(make(chan bool), servicecommon.GCInterval, r.CollectGarbage)
+	return nil
+}
+
+func NewClusterSecurityPolicyReconciler(mgr ctrl.Manager, commonService servicecommon.Service, vpcService servicecommon.VPCServiceProvider) *ClusterSecurityPolicyReconciler {
+	r := &ClusterSecurityPolicyReconciler{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    mgr.GetEventRecorderFor("clustersecuritypolicy-controller"),
+		driftEvents: make(chan event.GenericEvent, 100),
+	}
+	r.Service = securitypolicy.GetSecurityService(commonService, vpcService)
+	r.StatusUpdater = common.NewStatusUpdater(r.Client, r.Service.NSXConfig, r.Recorder, MetricResTypeClusterSecurityPolicy, "SecurityPolicy", "ClusterSecurityPolicy")
+	return r
+}
@@ -0,0 +1,132 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package clustersecuritypolicy
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	ctrcommon "github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+func newFakeReconciler(objs ...*v1alpha1.ClusterSecurityPolicy) *ClusterSecurityPolicyReconciler {
+	scheme := runtime.NewScheme()
+	v1alpha1.AddToScheme(scheme)
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&v1alpha1.ClusterSecurityPolicy{})
+	for _, obj := range objs {
+		builder = builder.WithObjects(obj)
+	}
+	k8sClient := builder.Build()
+	service := &securitypolicy.SecurityPolicyService{
+		Service: common.Service{
+			NSXClient: &nsx.Client{},
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{EnableVPCNetwork: true},
+				NsxConfig: &config.NsxConfig{},
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	return &ClusterSecurityPolicyReconciler{
+		Client:        k8sClient,
+		Scheme:        scheme,
+		Service:       service,
+		Recorder:      recorder,
+		StatusUpdater: ctrcommon.NewStatusUpdater(k8sClient, service.NSXConfig, recorder, MetricResTypeClusterSecurityPolicy, "SecurityPolicy", "ClusterSecurityPolicy"),
+	}
+}
+
+func TestClusterSecurityPolicyReconciler_Reconcile(t *testing.T) {
+	csp := &v1alpha1.ClusterSecurityPolicy{ObjectMeta: metav1.ObjectMeta{Name: "csp-1", UID: "uid-1"}}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "csp-1"}}
+
+	for _, tc := range []struct {
+		name           string
+		createErr      error
+		expectedResult ctrl.Result
+		expectedErr    bool
+		expectedStatus v1.ConditionStatus
+		expectedReason string
+	}{
+		{"success", nil, ResultNormal, false, v1.ConditionTrue, "ClusterSecurityPolicyReady"},
+		{"invalid spec", &nsxutil.ValidationError{Desc: "invalid"}, ResultNormal, false, v1.ConditionFalse, nsxutil.ReasonInvalidConfiguration},
+		{"NSX error", errors.New("NSX error"), ResultRequeue, true, v1.ConditionFalse, "ClusterSecurityPolicyNotReady"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeReconciler(csp.DeepCopy())
+			patches := gomonkey.ApplyMethod(reflect.TypeOf(r.Service.NSXClient), "NSXCheckVersion", func(_ *nsx.Client, _ int) bool {
+				return true
+			})
+			defer patches.Reset()
+			patches.ApplyMethod(reflect.TypeOf(r.Service), "CreateOrUpdateClusterSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, _ *v1alpha1.ClusterSecurityPolicy) error {
+				return tc.createErr
+			})
+
+			result, err := r.Reconcile(context.TODO(), req)
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedErr, err != nil)
+
+			updated := &v1alpha1.ClusterSecurityPolicy{}
+			assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, updated))
+			assert.Len(t, updated.Status.Conditions, 1)
+			assert.Equal(t, tc.expectedStatus, updated.Status.Conditions[0].Status)
+			assert.Equal(t, tc.expectedReason, updated.Status.Conditions[0].Reason)
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		r := newFakeReconciler()
+		var deletedUID types.UID
+		patches := gomonkey.ApplyMethod(reflect.TypeOf(r.Service), "ListClusterSecurityPolicyByName", func(_ *securitypolicy.SecurityPolicyService, name string) []*model.SecurityPolicy {
+			return []*model.SecurityPolicy{{
+				Id:   common.String("csp-id"),
+				Tags: []model.Tag{{Scope: common.String(common.TagScopeClusterSecurityPolicyUID), Tag: common.String("uid-1")}},
+			}}
+		})
+		defer patches.Reset()
+		patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteClusterSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, uid types.UID) error {
+			deletedUID = uid
+			return nil
+		})
+		result, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, ResultNormal, result)
+		assert.Equal(t, types.UID("uid-1"), deletedUID)
+	})
+}
+
+func TestClusterSecurityPolicyReconciler_CollectGarbage(t *testing.T) {
+	r := newFakeReconciler(&v1alpha1.ClusterSecurityPolicy{ObjectMeta: metav1.ObjectMeta{Name: "csp-1", UID: "uid-1"}})
+	deleted := sets.New[string]()
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(r.Service), "ListClusterSecurityPolicyID", func(_ *securitypolicy.SecurityPolicyService) sets.Set[string] {
+		return sets.New[string]("uid-1", "uid-2")
+	})
+	defer patches.Reset()
+	patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteClusterSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, uid types.UID) error {
+		deleted.Insert(string(uid))
+		return nil
+	})
+	assert.NoError(t, r.CollectGarbage(context.TODO()))
+	assert.Equal(t, sets.New[string]("uid-2"), deleted)
+}
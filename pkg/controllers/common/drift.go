@@ -0,0 +1,146 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+// DriftSource is the NSX resources of a controller checked by the DriftDetector.
+type DriftSource struct {
+	servicecommon.DriftSpec
+	Service *servicecommon.Service
+	// Owner returns the CR owning the NSX resource with its namespace and name set, nil if it's unknown.
+	Owner func(obj interface{}) k8sclient.Object
+	// Events re-enqueues the owner CR to the controller, it's watched by the controller with source.Channel.
+	Events chan event.GenericEvent
+}
+
+// DriftSourceProvider is implemented by the reconcilers whose NSX resources are checked by the DriftDetector.
+type DriftSourceProvider interface {
+	DriftSources() []*DriftSource
+}
+
+// DriftDetector compares the NSX resources tagged with the cluster with the stores periodically. An out-of-band
+// change is reported by a Kubernetes Event on the owner CR and the metrics, the owner CR is re-enqueued to restore
+// the intended state if SelfHealing is set.
+type DriftDetector struct {
+	Client      k8sclient.Client
+	Recorder    record.EventRecorder
+	NSXConfig   *config.NSXOperatorConfig
+	SelfHealing bool
+	Sources     []*DriftSource
+	// pending are the drifts found by the last check. The NSX search is eventually consistent with the API calls,
+	// so a drift is reported only if it's found by two consecutive checks.
+	pending map[string]bool
+}
+
+// Start checks the drift every interval until ctx is done.
+func (d *DriftDetector) Start(ctx context.Context, interval time.Duration) {
+	log.Info("Drift detector started", "interval", interval, "selfHealing", d.SelfHealing, "sources", len(d.Sources))
+	cancel := make(chan bool)
+	go func() {
+		<-ctx.Done()
+		close(cancel)
+	}()
+	GenericGarbageCollector(cancel, interval, d.Detect)
+}
+
+// Detect checks the drift of all sources once.
+func (d *DriftDetector) Detect(ctx context.Context) error {
+	found := map[string]bool{}
+	var errList []error
+	for _, source := range d.Sources {
+		drifts, err := source.Service.DetectDrift(source.DriftSpec)
+		if err != nil {
+			log.Error(err, "Failed to detect drift", "resourceType", source.ResourceType)
+			errList = append(errList, err)
+			continue
+		}
+		for i := range drifts {
+			drift := &drifts[i]
+			key := fmt.Sprintf("%s/%s/%s", drift.ResourceType, drift.Type, drift.Key)
+			found[key] = true
+			if !d.pending[key] {
+				log.Debug("Found drift, waiting for the next check to confirm it", "resourceType", drift.ResourceType, "key", drift.Key, "type", drift.Type)
+				continue
+			}
+			d.handle(ctx, source, drift)
+		}
+	}
+	d.pending = found
+	if len(errList) > 0 {
+		return fmt.Errorf("errors found in drift detection: %v", errList)
+	}
+	return nil
+}
+
+func (d *DriftDetector) handle(ctx context.Context, source *DriftSource, drift *servicecommon.Drift) {
+	log.Info("NSX resource is changed out of band", "resourceType", drift.ResourceType, "key", drift.Key, "type", drift.Type)
+	metrics.DriftInc(d.NSXConfig, drift.ResourceType, string(drift.Type))
+	if source.Owner == nil {
+		return
+	}
+	owner := source.Owner(drift.Object())
+	if owner == nil {
+		return
+	}
+	if err := d.Client.Get(ctx, k8sclient.ObjectKeyFromObject(owner), owner); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to get the owner of drifted NSX resource", "resourceType", drift.ResourceType, "key", drift.Key)
+		}
+		// the NSX resources of a deleted CR are collected by the garbage collectors
+		return
+	}
+	d.Recorder.Event(owner, v1.EventTypeWarning, ReasonNSXDrift,
+		fmt.Sprintf("NSX %s %s is %s out of band", drift.ResourceType, drift.Key, map[servicecommon.DriftType]string{
+			servicecommon.DriftModified:   "modified",
+			servicecommon.DriftDeleted:    "deleted",
+			servicecommon.DriftUnexpected: "created",
+		}[drift.Type]))
+
+	if !d.SelfHealing || drift.Type == servicecommon.DriftUnexpected || source.Events == nil {
+		return
+	}
+	// the reconcile compares the intended state with the store, so the store is synced with NSX before the owner
+	// is re-enqueued
+	syncStore(source.Stores, drift)
+	select {
+	case source.Events <- event.GenericEvent{Object: owner}:
+		log.Info("Re-enqueued the owner of drifted NSX resource", "resourceType", drift.ResourceType, "key", drift.Key,
+			"owner", k8sclient.ObjectKeyFromObject(owner))
+	case <-ctx.Done():
+	}
+}
+
+// syncStore replaces the intended resource in the store with the one on NSX.
+func syncStore(stores []*servicecommon.ResourceStore, drift *servicecommon.Drift) {
+	for _, store := range stores {
+		if _, exists, _ := store.Get(drift.Intended); !exists {
+			continue
+		}
+		var err error
+		if drift.Actual != nil {
+			err = store.Update(drift.Actual)
+		} else {
+			err = store.Delete(drift.Intended)
+		}
+		if err != nil {
+			log.Error(err, "Failed to sync store with NSX", "resourceType", drift.ResourceType, "key", drift.Key)
+		}
+		return
+	}
+}
@@ -0,0 +1,98 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	gomonkey "github.com/agiledragon/gomonkey/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+func TestDriftDetector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	v1alpha1.AddToScheme(scheme)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1alpha1.StaticRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "sr-1"}}).Build()
+	recorder := record.NewFakeRecorder(10)
+	cf := &config.NSXOperatorConfig{K8sConfig: &config.K8sConfig{}, DefaultConfig: &config.DefaultConfig{}}
+	cf.EnablePromMetrics = true
+	metrics.InitializePrometheusMetrics()
+
+	store := &servicecommon.ResourceStore{
+		Indexer: cache.NewIndexer(func(obj interface{}) (string, error) {
+			return *obj.(*model.StaticRoutes).Id, nil
+		}, nil),
+		BindingType: model.StaticRoutesBindingType(),
+	}
+	intended := &model.StaticRoutes{Id: servicecommon.String("sr-1"), Network: servicecommon.String("10.0.0.0/24")}
+	actual := &model.StaticRoutes{Id: servicecommon.String("sr-1"), Network: servicecommon.String("10.0.1.0/24")}
+	require.NoError(t, store.Add(intended))
+
+	service := &servicecommon.Service{}
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(service), "DetectDrift", func(_ *servicecommon.Service, spec servicecommon.DriftSpec) ([]servicecommon.Drift, error) {
+		return []servicecommon.Drift{
+			{Type: servicecommon.DriftModified, ResourceType: spec.ResourceType, Key: "sr-1", Intended: intended, Actual: actual},
+			// the owner of sr-2 is deleted
+			{Type: servicecommon.DriftUnexpected, ResourceType: spec.ResourceType, Key: "sr-2", Actual: &model.StaticRoutes{Id: servicecommon.String("sr-2")}},
+		}, nil
+	})
+	defer patches.Reset()
+
+	events := make(chan event.GenericEvent, 10)
+	detector := &DriftDetector{
+		Client:      k8sClient,
+		Recorder:    recorder,
+		NSXConfig:   cf,
+		SelfHealing: true,
+		Sources: []*DriftSource{{
+			DriftSpec: servicecommon.DriftSpec{ResourceType: servicecommon.ResourceTypeStaticRoutes, Stores: []*servicecommon.ResourceStore{store}},
+			Service:   service,
+			Owner: func(obj interface{}) client.Object {
+				return &v1alpha1.StaticRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: *obj.(*model.StaticRoutes).Id}}
+			},
+			Events: events,
+		}},
+	}
+
+	// the drifts are reported only if they're found by two consecutive checks
+	require.NoError(t, detector.Detect(context.TODO()))
+	assert.Empty(t, recorder.Events)
+	assert.Empty(t, events)
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.NSXResourceDriftTotal.WithLabelValues(servicecommon.ResourceTypeStaticRoutes, "Modified")))
+
+	require.NoError(t, detector.Detect(context.TODO()))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.NSXResourceDriftTotal.WithLabelValues(servicecommon.ResourceTypeStaticRoutes, "Modified")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.NSXResourceDriftTotal.WithLabelValues(servicecommon.ResourceTypeStaticRoutes, "Unexpected")))
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning NSXResourceDrift NSX StaticRoutes sr-1 is modified out of band", <-recorder.Events)
+	require.Len(t, events, 1)
+	assert.Equal(t, "sr-1", (<-events).Object.GetName())
+	// the store is synced with NSX so that the reconcile restores the intended state
+	obj := store.GetByKey("sr-1")
+	assert.Equal(t, "10.0.1.0/24", *obj.(*model.StaticRoutes).Network)
+
+	// the owner isn't re-enqueued without self-healing
+	detector.SelfHealing = false
+	require.NoError(t, detector.Detect(context.TODO()))
+	assert.Len(t, recorder.Events, 1)
+	assert.Empty(t, events)
+}
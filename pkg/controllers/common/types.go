@@ -5,11 +5,14 @@ import (
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/realizestate"
 )
 
 const (
 	MetricResTypeSecurityPolicy             = "securitypolicy"
 	MetricResTypeNetworkPolicy              = "networkpolicy"
+	MetricResTypeClusterSecurityPolicy      = "clustersecuritypolicy"
 	MetricResTypeIPPool                     = "ippool"
 	MetricResTypeIPAddressAllocation        = "ipaddressallocation"
 	MetricResTypeNSXServiceAccount          = "nsxserviceaccount"
@@ -47,11 +50,27 @@ var (
 	AnnotationNamespaceVPCError = "nsx.vmware.com/vpc_error"
 )
 
+// ResultRequeueAfterRealization requeues the CR whose NSX resource is pending realization after the realization
+// tracker checks it again.
+func ResultRequeueAfterRealization() ctrl.Result {
+	if tracker := realizestate.DefaultTracker(); tracker != nil {
+		return ctrl.Result{RequeueAfter: tracker.Interval}
+	}
+	return ResultRequeueAfter10sec
+}
+
 const (
 	ReasonSuccessfulDelete = "SuccessfulDelete"
 	ReasonSuccessfulUpdate = "SuccessfulUpdate"
 	ReasonFailDelete       = "FailDelete"
 	ReasonFailUpdate       = "FailUpdate"
+	ReasonNSXDrift         = "NSXResourceDrift"
+	ReasonPlanned          = "Planned"
+
+	// MaxPlanEventLength is the max length of the Event message with the planned changes.
+	MaxPlanEventLength = 1024
+
+	ErrorReasonUnknown = "Unknown"
 )
 
 // GarbageCollector interface with collectGarbage method
@@ -5,22 +5,30 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
 	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
 	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
 	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/tracing"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
@@ -170,7 +178,7 @@ func AllocateSubnetFromSubnetSet(client k8sclient.Client, subnetSet *v1alpha1.Su
 		log.Error(err, "Failed to allocate Subnet")
 		return "", nil, nil, err
 	}
-	nsxSubnet, err := subnetService.CreateOrUpdateSubnet(subnetSet, vpcInfoList[0], tags)
+	nsxSubnet, err := subnetService.CreateOrUpdateSubnet(context.TODO(), subnetSet, vpcInfoList[0], tags)
 	if err != nil {
 		return "", nil, nil, err
 	}
@@ -242,8 +250,10 @@ func NumReconcile() int {
 	return MaxConcurrentReconciles
 }
 
+// GenericGarbageCollector calls f every timeout until cancel is closed. The NSX API requests sent with ctx are
+// background traffic in the NSX API rate limiter.
 func GenericGarbageCollector(cancel chan bool, timeout time.Duration, f func(ctx context.Context) error) {
-	ctx := context.Background()
+	ctx := ratelimiter.WithPriority(context.Background(), ratelimiter.PriorityBackground)
 	ticker := time.NewTicker(timeout)
 	defer ticker.Stop()
 
@@ -286,6 +296,22 @@ func (u *StatusUpdater) UpdateFail(ctx context.Context, obj k8sclient.Object, er
 	}
 	u.Recorder.Event(obj, v1.EventTypeWarning, ReasonFailUpdate, fmt.Sprintf("%v", err))
 	metrics.CounterInc(u.NSXConfig, metrics.ControllerUpdateFailTotal, u.MetricResType)
+	metrics.ErrorInc(u.NSXConfig, u.MetricResType, ErrorReason(err))
+	tracing.RecordError(trace.SpanFromContext(ctx), err)
+}
+
+// UpdatePlanned records the changes planned without applying them to NSX as an Event of the CR, the status of
+// the CR isn't changed. obj is nil if the CR is gone, the changes are only logged then.
+func (u *StatusUpdater) UpdatePlanned(obj k8sclient.Object, planErr *servicecommon.PlanError) {
+	log.Info(fmt.Sprintf("Planned %s CR without applying it to NSX", u.ResourceType), u.ResourceType, obj, "changes", len(planErr.Changes))
+	if obj == nil {
+		return
+	}
+	msg := planErr.Error()
+	if len(msg) > MaxPlanEventLength {
+		msg = msg[:MaxPlanEventLength-3] + "..."
+	}
+	u.Recorder.Event(obj, v1.EventTypeNormal, ReasonPlanned, msg)
 }
 
 func (u *StatusUpdater) DeleteSuccess(namespacedName types.NamespacedName, obj k8sclient.Object) {
@@ -302,6 +328,7 @@ func (u *StatusUpdater) DeleteFail(namespacedName types.NamespacedName, obj k8sc
 		u.Recorder.Event(obj, v1.EventTypeWarning, ReasonFailDelete, fmt.Sprintf("%v", err))
 	}
 	metrics.CounterInc(u.NSXConfig, metrics.ControllerDeleteFailTotal, u.MetricResType)
+	metrics.ErrorInc(u.NSXConfig, u.MetricResType, ErrorReason(err))
 }
 
 func (u *StatusUpdater) IncreaseSyncTotal() {
@@ -324,6 +351,66 @@ func (u *StatusUpdater) IncreaseDeleteFailTotal() {
 	metrics.CounterInc(u.NSXConfig, metrics.ControllerDeleteFailTotal, u.MetricResType)
 }
 
+// StartReconcileSpan starts the span of a reconcile, the NSX API calls made with the returned context are traced
+// as its children. The caller must end the span when the reconcile finishes.
+func (u *StatusUpdater) StartReconcileSpan(ctx context.Context, namespacedName types.NamespacedName) (context.Context, trace.Span) {
+	return tracing.StartSpan(ctx, fmt.Sprintf("Reconcile %s", u.ResourceType),
+		attribute.String("k8s.resource.type", u.MetricResType),
+		attribute.String("k8s.namespace.name", namespacedName.Namespace),
+		attribute.String("k8s.resource.name", namespacedName.Name),
+	)
+}
+
+// ObserveReconcileDuration records the duration of a reconcile which started at startTime.
+func (u *StatusUpdater) ObserveReconcileDuration(startTime time.Time) {
+	metrics.ObserveReconcileDuration(u.NSXConfig, u.MetricResType, time.Since(startTime))
+}
+
+// ErrorReason returns a bounded reason of the error used as metric label, it's the NSX error type for NSX
+// errors, the K8s status reason for K8s API errors and "Unknown" for the others.
+func ErrorReason(err error) string {
+	if err == nil {
+		return ErrorReasonUnknown
+	}
+	var nsxErr nsxutil.NsxError
+	if errors.As(err, &nsxErr) {
+		return reflect.Indirect(reflect.ValueOf(nsxErr)).Type().Name()
+	}
+	if reason := apierrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		return string(reason)
+	}
+	return ErrorReasonUnknown
+}
+
+// ConditionReason returns the reason of the condition of a CR failed by err, it's the realization reason of err if
+// it's known, otherwise defaultReason.
+func ConditionReason(err error, defaultReason string) string {
+	if reason := nsxutil.GetRealizationReason(err); reason != nil {
+		return reason.Reason
+	}
+	return defaultReason
+}
+
+// ResultForError returns the result of a reconcile failed by err. The retryable and unknown errors are returned with
+// result so the CR is retried by the rate limiter, the non-retryable ones need the users to fix the configuration or
+// free the capacity, the CR is checked again after 5 minutes instead.
+func ResultForError(err error, result ctrl.Result) (ctrl.Result, error) {
+	if reason := nsxutil.GetRealizationReason(err); reason != nil && !reason.Retryable {
+		log.Info("Not retrying the non-retryable error", "reason", reason.Reason, "error", err)
+		return ResultRequeueAfter5mins, nil
+	}
+	return result, err
+}
+
+// WithPlanMode returns the context in plan mode if obj has the nsx.vmware.com/plan annotation, the NSX changes of
+// obj made with it, including the deletions, are planned instead of applied to NSX.
+func WithPlanMode(ctx context.Context, obj metav1.Object) context.Context {
+	if servicecommon.IsPlanMode(nil, obj) {
+		return servicecommon.WithPlanMode(ctx)
+	}
+	return ctx
+}
+
 func NewStatusUpdater(client k8sclient.Client, nsxConfig *config.NSXOperatorConfig, recorder record.EventRecorder, metricResType string, nsxResourceType string, resourceType string) StatusUpdater {
 	return StatusUpdater{
 		Client:          client,
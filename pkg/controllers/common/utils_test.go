@@ -7,14 +7,17 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	gomonkey "github.com/agiledragon/gomonkey/v2"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -26,11 +29,13 @@ import (
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
 	pkg_mock "github.com/vmware-tanzu/nsx-operator/pkg/mock"
 	mock_client "github.com/vmware-tanzu/nsx-operator/pkg/mock/controller-runtime/client"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
 	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
@@ -287,6 +292,27 @@ func TestStatusUpdater_DeleteFail(t *testing.T) {
 	statusUpdater.DeleteFail(types.NamespacedName{Name: "name", Namespace: "ns"}, &v1alpha1.Subnet{}, fmt.Errorf("mock error"))
 }
 
+func TestStatusUpdater_UpdatePlanned(t *testing.T) {
+	statusUpdater := createStatusUpdater(t)
+	recorder := record.NewFakeRecorder(2)
+	statusUpdater.Recorder = recorder
+
+	planErr := &servicecommon.PlanError{Changes: []servicecommon.PlannedChange{
+		{Action: servicecommon.PlanActionUpdate, ResourceType: "VpcSubnet", Path: "/orgs/default/projects/p1/vpcs/vpc1/subnets/s1",
+			Diff: []string{`~ display_name: "s1" -> "s2"`}},
+	}}
+	statusUpdater.UpdatePlanned(&v1alpha1.Subnet{}, planErr)
+	assert.Equal(t, "Normal Planned plan mode: changes are not applied to NSX:\n"+
+		"update VpcSubnet /orgs/default/projects/p1/vpcs/vpc1/subnets/s1\n  ~ display_name: \"s1\" -> \"s2\"", <-recorder.Events)
+
+	// the long diff is truncated
+	planErr.Changes[0].Diff = []string{strings.Repeat("+", 2*MaxPlanEventLength)}
+	statusUpdater.UpdatePlanned(&v1alpha1.Subnet{}, planErr)
+	event := <-recorder.Events
+	assert.Len(t, event, len("Normal Planned ")+MaxPlanEventLength)
+	assert.True(t, strings.HasSuffix(event, "..."))
+}
+
 func TestCheckNetworkStack(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1051,3 +1077,44 @@ func TestGetDefaultAccessMode(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorReason(t *testing.T) {
+	assert.Equal(t, ErrorReasonUnknown, ErrorReason(nil))
+	assert.Equal(t, ErrorReasonUnknown, ErrorReason(errors.New("dummy")))
+	assert.Equal(t, "ResourceNotFound", ErrorReason(nsxutil.CreateResourceNotFound("10.0.0.1", "get")))
+	assert.Equal(t, "ResourceNotFound", ErrorReason(fmt.Errorf("wrapped: %w", nsxutil.CreateResourceNotFound("10.0.0.1", "get"))))
+	assert.Equal(t, string(metav1.StatusReasonNotFound), ErrorReason(apierrors.NewNotFound(v1alpha1.Resource("subnet"), "subnet-1")))
+}
+
+func TestConditionReason(t *testing.T) {
+	assert.Equal(t, "SubnetNotReady", ConditionReason(errors.New("dummy"), "SubnetNotReady"))
+	assert.Equal(t, nsxutil.ReasonSubnetIPExhausted, ConditionReason(nsxutil.NewRealizeStateError("no IP", nsxutil.IPAllocationErrorCode), "SubnetNotReady"))
+}
+
+func TestResultForError(t *testing.T) {
+	// the non-retryable errors are checked again later without the rate limiter
+	result, err := ResultForError(fmt.Errorf("wrapped: %w", nsxutil.IPBlockAllExhaustedError{Desc: "exhausted"}), ResultRequeue)
+	assert.NoError(t, err)
+	assert.Equal(t, ResultRequeueAfter5mins, result)
+
+	retryErr := nsxutil.NewRetryRealizeError("not ready")
+	result, err = ResultForError(retryErr, ResultRequeue)
+	assert.Equal(t, retryErr, err)
+	assert.Equal(t, ResultRequeue, result)
+
+	unknownErr := errors.New("dummy")
+	result, err = ResultForError(unknownErr, ResultRequeueAfter10sec)
+	assert.Equal(t, unknownErr, err)
+	assert.Equal(t, ResultRequeueAfter10sec, result)
+}
+
+func TestStatusUpdater_Metrics(t *testing.T) {
+	nsxConfig := &config.NSXOperatorConfig{K8sConfig: &config.K8sConfig{EnablePromMetrics: true}}
+	updater := NewStatusUpdater(nil, nsxConfig, &record.FakeRecorder{}, MetricResTypeSubnet, "Subnet", "Subnet")
+
+	updater.DeleteFail(types.NamespacedName{Name: "subnet-1", Namespace: "ns-1"}, nil, nsxutil.CreateResourceNotFound("10.0.0.1", "delete"))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ControllerErrorTotal.WithLabelValues(MetricResTypeSubnet, "ResourceNotFound")))
+
+	updater.ObserveReconcileDuration(time.Now())
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.ControllerReconcileDuration))
+}
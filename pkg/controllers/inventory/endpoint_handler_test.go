@@ -63,13 +63,14 @@ func createService(t *testing.T) (*inventory.InventoryService, *mockClient.MockC
 		NsxConfig: &config.NsxConfig{NsxApiManagers: []string{"127.0.0.1"}},
 	}
 	nsxApiClient, _ := nsx.CreateNsxtApiClient(cf, httpClient)
+	nsxClient := &nsx.Client{
+		RestConnector: rc,
+		NsxConfig:     cf,
+	}
+	nsxClient.SetNsxApiClient(nsxApiClient)
 	cs := commonservice.Service{
-		Client: k8sClient,
-		NSXClient: &nsx.Client{
-			RestConnector: rc,
-			NsxConfig:     cf,
-			NsxApiClient:  nsxApiClient,
-		},
+		Client:    k8sClient,
+		NSXClient: nsxClient,
 		NSXConfig: &config.NSXOperatorConfig{
 			CoeConfig: &config.CoeConfig{
 				Cluster: "k8scl-one:test",
@@ -6,6 +6,7 @@ package ipaddressallocation
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -55,7 +56,7 @@ func setReadyStatusFalse(client client.Client, ctx context.Context, obj client.O
 				"error occurred while processing the IPAddressAllocation CR. Error: %v",
 				err,
 			),
-			Reason:             "IPAddressAllocationNotReady",
+			Reason:             common.ConditionReason(err, "IPAddressAllocationNotReady"),
 			LastTransitionTime: transitionTime,
 		},
 	}
@@ -88,6 +89,9 @@ func (r *IPAddressAllocationReconciler) Reconcile(ctx context.Context, req ctrl.
 	obj := &v1alpha1.IPAddressAllocation{}
 	log.Info("Reconciling IPAddressAllocation CR", "IPAddressAllocation", req.NamespacedName)
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(time.Now())
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
 		if apierrors.IsNotFound(err) {
 			err = r.Service.DeleteIPAddressAllocationByNamespacedName(req.Namespace, req.Name)
@@ -111,7 +115,7 @@ func (r *IPAddressAllocationReconciler) handleUpdate(ctx context.Context, obj *v
 	updated, err := r.Service.CreateOrUpdateIPAddressAllocation(obj, r.restoreMode)
 	if err != nil {
 		r.StatusUpdater.UpdateFail(ctx, obj, err, "", setReadyStatusFalse)
-		return resultRequeue, err
+		return common.ResultForError(err, resultRequeue)
 	}
 	if updated {
 		r.StatusUpdater.UpdateSuccess(ctx, obj, setReadyStatusTrue)
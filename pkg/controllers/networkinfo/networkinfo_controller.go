@@ -5,6 +5,7 @@ package networkinfo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -97,6 +98,13 @@ func (m *nsUnreadyMessage) getNSNetworkCondition(options ...interface{}) *corev1
 	return cond
 }
 
+// getNSNetworkConditionWithError uses the realization reason of err as the reason of the condition if it's known.
+func (m *nsUnreadyMessage) getNSNetworkConditionWithError(err error) *corev1.NamespaceCondition {
+	cond := m.getNSNetworkCondition(err)
+	cond.Reason = common.ConditionReason(err, cond.Reason)
+	return cond
+}
+
 // NetworkInfoReconciler NetworkInfoReconcile reconciles a NetworkInfo object
 // Actually it is more like a shell, which is used to manage nsx VPC
 type NetworkInfoReconciler struct {
@@ -181,11 +189,19 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}()
 
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	networkInfoCR := &v1alpha1.NetworkInfo{}
 	if err := r.Client.Get(ctx, req.NamespacedName, networkInfoCR); err != nil {
 		if apierrors.IsNotFound(err) {
 			if err := r.deleteVPCsByNamespace(ctx, req.Namespace); err != nil {
+				var planErr *commonservice.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(nil, planErr)
+					return common.ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 				return common.ResultRequeue, err
 			}
@@ -195,11 +211,17 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		log.Error(err, "Unable to fetch NetworkInfo CR", "NetworkInfo", req.NamespacedName)
 		return common.ResultRequeue, err
 	}
+	ctx = common.WithPlanMode(ctx, networkInfoCR)
 
 	// Check if the CR is marked for deletion
 	if !networkInfoCR.ObjectMeta.DeletionTimestamp.IsZero() {
 		r.StatusUpdater.IncreaseDeleteTotal()
 		if err := r.deleteVPCsByNamespace(ctx, networkInfoCR.GetNamespace()); err != nil {
+			var planErr *commonservice.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(networkInfoCR, planErr)
+				return common.ResultNormal, nil
+			}
 			r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 			return common.ResultRequeue, err
 		}
@@ -277,9 +299,14 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 	createdVpc, err := r.Service.CreateOrUpdateVPC(ctx, networkInfoCR, nc, lbProvider, serviceClusterReady, r.restoreMode)
 	if err != nil {
+		var planErr *commonservice.PlanError
+		if errors.As(err, &planErr) {
+			r.StatusUpdater.UpdatePlanned(networkInfoCR, planErr)
+			return common.ResultNormal, nil
+		}
 		r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, "Failed to create or update VPC", setNetworkInfoVPCStatusWithError, nil)
-		setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCCreateUpdateError.getNSNetworkCondition(err))
-		return common.ResultRequeueAfter10sec, err
+		setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCCreateUpdateError.getNSNetworkConditionWithError(err))
+		return common.ResultForError(err, common.ResultRequeueAfter10sec)
 	}
 
 	var privateIPs []string
@@ -314,7 +341,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		hasPrivateCidr := len(privateIPs) > 0
 		if err := r.updateDefaultSubnetSet(ctx, commonservice.DefaultVMNetwork, req.Namespace, nc.Spec.DefaultSubnetSize, hasPrivateCidr, hasVMDefaultSubnets(nc.Spec.Subnets)); err != nil {
 			r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, "Failed to create or update default SubnetSet for VM", setNetworkInfoVPCStatusWithError, nil)
-			setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCCreateUpdateError.getNSNetworkCondition(err))
+			setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCCreateUpdateError.getNSNetworkConditionWithError(err))
 			return common.ResultNormal, err
 		}
 	}
@@ -341,7 +368,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			hasPrivateTgwCidr := len(vpcConnectivityProfile.PrivateTgwIpBlocks) > 0
 			if err := r.updateDefaultSubnetSet(ctx, commonservice.DefaultPodNetwork, req.Namespace, nc.Spec.DefaultSubnetSize, hasPrivateTgwCidr, hasPodDefaultSubnets(nc.Spec.Subnets)); err != nil {
 				r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, "Failed to create or update default SubnetSet for Pod", setNetworkInfoVPCStatusWithError, nil)
-				setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCCreateUpdateError.getNSNetworkCondition(err))
+				setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCCreateUpdateError.getNSNetworkConditionWithError(err))
 				return common.ResultNormal, err
 			}
 		}
@@ -559,7 +586,7 @@ func (r *NetworkInfoReconciler) CollectGarbage(ctx context.Context) error {
 		log.Info("Garbage collecting NSX VPC object", "VPC", nsxVPC.Id, "Namespace", nsxVPCNamespaceName)
 		r.StatusUpdater.IncreaseDeleteTotal()
 
-		if err = r.Service.DeleteVPC(*nsxVPC.Path); err != nil {
+		if err = r.Service.DeleteVPC(ctx, *nsxVPC.Path); err != nil {
 			log.Error(err, "Failed to delete NSX VPC", "VPC", nsxVPC.Id, "Namespace", nsxVPCNamespaceName)
 			r.StatusUpdater.IncreaseDeleteFailTotal()
 			errList = append(errList, err)
@@ -605,12 +632,16 @@ func (r *NetworkInfoReconciler) deleteVPCs(ctx context.Context, staleVPCs []*mod
 		return nil
 	}
 	var deleteErrs []error
+	plan := &commonservice.Plan{}
 	for _, nsxVPC := range staleVPCs {
 		if nsxVPC.Path == nil {
 			log.Error(nil, "VPC path is nil, skipping", "VPC", nsxVPC)
 			continue
 		}
-		if err := r.Service.DeleteVPC(*nsxVPC.Path); err != nil {
+		if err := r.Service.DeleteVPC(ctx, *nsxVPC.Path); err != nil {
+			if plan.AddErr(err) {
+				continue
+			}
 			log.Error(err, "Failed to delete VPC in NSX", "VPC", nsxVPC.Path)
 			deleteErrs = append(deleteErrs, fmt.Errorf("failed to delete VPC %s: %w", *nsxVPC.Path, err))
 		}
@@ -618,6 +649,9 @@ func (r *NetworkInfoReconciler) deleteVPCs(ctx context.Context, staleVPCs []*mod
 	if len(deleteErrs) > 0 {
 		return fmt.Errorf("multiple errors occurred while deleting VPCs: %v", deleteErrs)
 	}
+	if !plan.Empty() {
+		return plan.Err()
+	}
 	// Update the VPCNetworkConfiguration Status
 	vpcNetConfig, err := r.Service.GetVPCNetworkConfigByNamespace(ns)
 	if err != nil {
@@ -106,9 +106,9 @@ func createNetworkInfoReconciler(objs []client.Object) *NetworkInfoReconciler {
 	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
 	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(objs...).Build()
 
-	vpcStore := &vpc.VPCStore{ResourceStore: servicecommon.ResourceStore{
+	vpcStore := &vpc.VPCStore{TypedStore: servicecommon.TypedStore[model.Vpc]{ResourceStore: servicecommon.ResourceStore{
 		BindingType: model.VpcBindingType(),
-	}}
+	}}}
 
 	service := &vpc.VPCService{
 		VpcStore: vpcStore,
@@ -1365,7 +1365,7 @@ func TestNetworkInfoReconciler_deleteStaleVPCs(t *testing.T) {
 				patches.ApplyPrivateMethod(reflect.TypeOf(r), "listNamespaceCRsNameIDSet", func(_ *NetworkInfoReconciler, _ context.Context) (sets.Set[string], sets.Set[string], error) {
 					return sets.Set[string]{}, sets.Set[string]{}, nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ string) error {
+				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ context.Context, _ string) error {
 					return fmt.Errorf("delete failed")
 				})
 				return patches
@@ -1388,7 +1388,7 @@ func TestNetworkInfoReconciler_deleteStaleVPCs(t *testing.T) {
 				patches.ApplyPrivateMethod(reflect.TypeOf(r), "listNamespaceCRsNameIDSet", func(_ *NetworkInfoReconciler, _ context.Context) (sets.Set[string], sets.Set[string], error) {
 					return sets.Set[string]{}, sets.Set[string]{}, nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ string) error {
+				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ context.Context, _ string) error {
 					return nil
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.Service), "GetVPCNetworkConfigByNamespace", func(_ *vpc.VPCService, _ string) (*v1alpha1.VPCNetworkConfiguration, error) {
@@ -1429,7 +1429,7 @@ func TestNetworkInfoReconciler_deleteStaleVPCs(t *testing.T) {
 				patches.ApplyPrivateMethod(reflect.TypeOf(r), "listNamespaceCRsNameIDSet", func(_ *NetworkInfoReconciler, _ context.Context) (sets.Set[string], sets.Set[string], error) {
 					return sets.Set[string]{}, sets.Set[string]{}, nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ string) error {
+				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ context.Context, _ string) error {
 					return nil
 				})
 				patches.ApplyPrivateMethod(reflect.TypeOf(r), "listVPCsByNetworkConfigName", func(_ *NetworkInfoReconciler, _ string) ([]*model.Vpc, error) {
@@ -1521,7 +1521,7 @@ func TestNetworkInfoReconciler_DeleteNetworkInfo(t *testing.T) {
 						},
 					}
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ string) error {
+				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ context.Context, _ string) error {
 					return fmt.Errorf("delete failed")
 				})
 				return patches
@@ -1544,7 +1544,7 @@ func TestNetworkInfoReconciler_DeleteNetworkInfo(t *testing.T) {
 				VPCs: nil,
 			},
 			prepareFuncs: func(r *NetworkInfoReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.Service.VpcStore), "GetByIndex", func(_ *vpc.VPCStore, key string, value string) []*model.Vpc {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&servicecommon.ResourceStore{}), "GetByIndex", func(_ *servicecommon.ResourceStore, key string, value string) []interface{} {
 					return nil
 				})
 				return patches
@@ -1561,7 +1561,7 @@ func TestNetworkInfoReconciler_DeleteNetworkInfo(t *testing.T) {
 				Status:     corev1.NamespaceStatus{},
 			},
 			prepareFuncs: func(r *NetworkInfoReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.Service.VpcStore), "GetByIndex", func(_ *vpc.VPCStore, key string, value string) []*model.Vpc {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&servicecommon.ResourceStore{}), "GetByIndex", func(_ *servicecommon.ResourceStore, key string, value string) []interface{} {
 					id := "fakeNamespaceUID"
 					scope := servicecommon.TagScopeNamespaceUID
 					tag1 := []model.Tag{
@@ -1571,8 +1571,8 @@ func TestNetworkInfoReconciler_DeleteNetworkInfo(t *testing.T) {
 						},
 					}
 					name := "name"
-					return []*model.Vpc{
-						{
+					return []interface{}{
+						&model.Vpc{
 							DisplayName: &name,
 							Tags:        tag1,
 						},
@@ -1655,7 +1655,7 @@ func TestNetworkInfoReconciler_Create(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.Service), "IsSharedVPCNamespaceByNS", func(_ *vpc.VPCService, ctx context.Context, _ string) (bool, error) {
 					return false, nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ string) error {
+				patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ context.Context, _ string) error {
 					return fmt.Errorf("delete failed")
 				})
 				return patches
@@ -1720,7 +1720,7 @@ func TestNetworkInfoReconciler_CollectGarbage(t *testing.T) {
 			vpcPath2 := "/vpc/2"
 			return []model.Vpc{{Path: &vpcPath1}, {Path: &vpcPath2}}
 		})
-		patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ string) error {
+		patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ context.Context, _ string) error {
 			return nil
 		})
 		defer patches.Reset()
@@ -1734,7 +1734,7 @@ func TestNetworkInfoReconciler_CollectGarbage(t *testing.T) {
 			vpcPath2 := "/vpc/2"
 			return []model.Vpc{{Path: &vpcPath1}, {Path: &vpcPath2}}
 		})
-		patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ string) error {
+		patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, _ context.Context, _ string) error {
 			return errors.New("deletion error")
 		})
 		defer patches.Reset()
@@ -8,17 +8,21 @@ import (
 	"reflect"
 
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
 // EnqueueRequestForNamespace handles Namespace events and triggers NetworkPolicy reconciliation
-// when Namespace labels change and there are pods in the namespace.
+// when Namespace labels change and there are pods in the namespace, or when the logging annotation
+// of the Namespace changes.
 type EnqueueRequestForNamespace struct {
 	Client                  client.Client
 	NetworkPolicyReconciler *NetworkPolicyReconciler
@@ -47,6 +51,15 @@ func (e *EnqueueRequestForNamespace) Update(_ context.Context, updateEvent event
 		return
 	}
 
+	if isLoggingAnnotationChanged(updateEvent.ObjectOld.(*v1.Namespace), obj) {
+		if err := reconcileNetworkPolicyInNamespace(e.Client, obj.Name, q); err != nil {
+			log.Error(err, "Failed to reconcile network policy for namespace logging annotation change")
+		}
+	}
+	if reflect.DeepEqual(updateEvent.ObjectOld.GetLabels(), obj.Labels) {
+		return
+	}
+
 	podList := &v1.PodList{}
 	err := e.Client.List(context.Background(), podList, client.InNamespace(obj.Name))
 	if err != nil {
@@ -77,6 +90,24 @@ func (e *EnqueueRequestForNamespace) Update(_ context.Context, updateEvent event
 	}
 }
 
+func isLoggingAnnotationChanged(oldObj, newObj *v1.Namespace) bool {
+	return oldObj.Annotations[servicecommon.AnnotationNetworkPolicyLogging] != newObj.Annotations[servicecommon.AnnotationNetworkPolicyLogging]
+}
+
+// reconcileNetworkPolicyInNamespace enqueues all the NetworkPolicies in namespace.
+func reconcileNetworkPolicyInNamespace(pkgClient client.Client, namespace string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	npList := &networkingv1.NetworkPolicyList{}
+	if err := pkgClient.List(context.Background(), npList, client.InNamespace(namespace)); err != nil {
+		log.Error(err, "Failed to list the network policies", "namespace", namespace)
+		return err
+	}
+	for _, np := range npList.Items {
+		log.Info("Reconcile network policy because of namespace logging annotation change", "namespace", np.Namespace, "name", np.Name)
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: np.Namespace, Name: np.Name}})
+	}
+	return nil
+}
+
 // PredicateFuncsNs filters Namespace events for NetworkPolicy controller
 var PredicateFuncsNs = predicate.Funcs{
 	CreateFunc: func(e event.CreateEvent) bool {
@@ -86,8 +117,8 @@ var PredicateFuncsNs = predicate.Funcs{
 		oldObj := e.ObjectOld.(*v1.Namespace)
 		newObj := e.ObjectNew.(*v1.Namespace)
 		log.Debug("Receive namespace update event", "name", oldObj.Name)
-		if reflect.DeepEqual(oldObj.ObjectMeta.Labels, newObj.ObjectMeta.Labels) {
-			log.Info("Label of namespace is not changed, ignore it", "name", oldObj.Name)
+		if reflect.DeepEqual(oldObj.ObjectMeta.Labels, newObj.ObjectMeta.Labels) && !isLoggingAnnotationChanged(oldObj, newObj) {
+			log.Info("Label and logging annotation of namespace are not changed, ignore it", "name", oldObj.Name)
 			return false
 		}
 		return true
@@ -11,7 +11,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -19,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	mock_client "github.com/vmware-tanzu/nsx-operator/pkg/mock/controller-runtime/client"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 )
 
 // TestEnqueueRequestForNamespace_Create tests the Create method of EnqueueRequestForNamespace
@@ -146,7 +149,8 @@ func TestEnqueueRequestForNamespace_Update(t *testing.T) {
 		},
 		ObjectNew: &v1.Namespace{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "test-ns-new",
+				Name:   "test-ns-new",
+				Labels: map[string]string{"app": "web"},
 			},
 		},
 	}
@@ -171,6 +175,26 @@ func TestEnqueueRequestForNamespace_Update(t *testing.T) {
 	}
 }
 
+func TestEnqueueRequestForNamespace_UpdateLoggingAnnotation(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().WithObjects(
+		&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "np-1", Namespace: "ns-1"}},
+		&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "np-2", Namespace: "ns-2"}},
+	).Build()
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+	e := &EnqueueRequestForNamespace{Client: k8sClient}
+	evt := event.UpdateEvent{
+		ObjectOld: &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1"}},
+		ObjectNew: &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1",
+			Annotations: map[string]string{servicecommon.AnnotationNetworkPolicyLogging: "true"}}},
+	}
+	assert.True(t, PredicateFuncsNs.UpdateFunc(evt))
+	e.Update(context.TODO(), evt, q)
+	assert.Equal(t, 1, q.Len())
+	item, _ := q.Get()
+	assert.Equal(t, types.NamespacedName{Namespace: "ns-1", Name: "np-1"}, item.NamespacedName)
+}
+
 // TestPredicateFuncsNs tests the PredicateFuncsNs variable and its functions
 func TestPredicateFuncsNs(t *testing.T) {
 	testCases := []struct {
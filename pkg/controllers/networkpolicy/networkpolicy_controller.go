@@ -92,10 +92,18 @@ func (r *NetworkPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}()
 
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	if err := r.Client.Get(ctx, req.NamespacedName, networkPolicy); err != nil {
 		if apierrors.IsNotFound(err) {
-			if err := r.deleteNetworkPolicyByName(req.Namespace, req.Name); err != nil {
+			if err := r.deleteNetworkPolicyByName(ctx, req.Namespace, req.Name); err != nil {
+				var planErr *servicecommon.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(nil, planErr)
+					return ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 				return ResultRequeue, err
 			}
@@ -106,12 +114,18 @@ func (r *NetworkPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		log.Error(err, "Failed to fetch NetworkPolicy CR", "req", req.NamespacedName)
 		return ResultRequeue, err
 	}
+	ctx = common.WithPlanMode(ctx, networkPolicy)
 
 	if networkPolicy.ObjectMeta.DeletionTimestamp.IsZero() {
 		r.StatusUpdater.IncreaseUpdateTotal()
 		log.Info("Reconciling CR to create or update networkPolicy", "networkPolicy", req.NamespacedName)
 
-		if err := r.Service.CreateOrUpdateSecurityPolicy(networkPolicy); err != nil {
+		if err := r.Service.CreateOrUpdateSecurityPolicy(ctx, networkPolicy); err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(networkPolicy, planErr)
+				return ResultNormal, nil
+			}
 			if errors.As(err, &nsxutil.RestrictionError{}) {
 				setNetworkPolicyErrorAnnotation(ctx, networkPolicy, r.Client, common.ErrorNoDFWLicense)
 				r.StatusUpdater.UpdateFail(ctx, networkPolicy, err, "", nil)
@@ -129,7 +143,12 @@ func (r *NetworkPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	} else {
 		log.Info("Reconciling CR to delete networkPolicy", "networkPolicy", req.NamespacedName)
 		r.StatusUpdater.IncreaseDeleteTotal()
-		if err := r.deleteNetworkPolicyByName(req.Namespace, req.Name); err != nil {
+		if err := r.deleteNetworkPolicyByName(ctx, req.Namespace, req.Name); err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(networkPolicy, planErr)
+				return ResultNormal, nil
+			}
 			r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 			return ResultRequeue, err
 		}
@@ -193,7 +212,7 @@ func (r *NetworkPolicyReconciler) CollectGarbage(ctx context.Context) error {
 	for elem := range diffSet {
 		log.Debug("GC collected NetworkPolicy", "ID", elem)
 		r.StatusUpdater.IncreaseDeleteTotal()
-		err = r.Service.DeleteSecurityPolicy(types.UID(elem), true, servicecommon.ResourceTypeNetworkPolicy)
+		err = r.Service.DeleteSecurityPolicy(ctx, types.UID(elem), true, servicecommon.ResourceTypeNetworkPolicy)
 		if err != nil {
 			errList = append(errList, err)
 			r.StatusUpdater.IncreaseDeleteFailTotal()
@@ -207,12 +226,12 @@ func (r *NetworkPolicyReconciler) CollectGarbage(ctx context.Context) error {
 	return nil
 }
 
-func (r *NetworkPolicyReconciler) deleteNetworkPolicyByName(ns, name string) error {
+func (r *NetworkPolicyReconciler) deleteNetworkPolicyByName(ctx context.Context, ns, name string) error {
 	nsxSecurityPolicies := r.Service.ListNetworkPolicyByName(ns, name)
 	for _, item := range nsxSecurityPolicies {
 		uid := nsxutil.FindTag(item.Tags, servicecommon.TagScopeNetworkPolicyUID)
 		log.Info("Deleting NetworkPolicy", "networkPolicyUID", uid, "nsxSecurityPolicyId", *item.Id)
-		if err := r.Service.DeleteSecurityPolicy(types.UID(uid), false, servicecommon.ResourceTypeNetworkPolicy); err != nil {
+		if err := r.Service.DeleteSecurityPolicy(ctx, types.UID(uid), false, servicecommon.ResourceTypeNetworkPolicy); err != nil {
 			log.Error(err, "Failed to delete NetworkPolicy", "networkPolicyUID", uid, "nsxSecurityPolicyId", *item.Id)
 			return err
 		}
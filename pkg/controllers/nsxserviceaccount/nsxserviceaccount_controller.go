@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -143,6 +144,9 @@ func (r *NSXServiceAccountReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	log.Info("reconciling CR", "nsxserviceaccount", req.NamespacedName)
 
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(time.Now())
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
 		log.Error(err, "unable to fetch NSXServiceAccount CR", "req", req.NamespacedName)
@@ -5,6 +5,7 @@ package pod
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
@@ -33,6 +34,7 @@ import (
 	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
 	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
@@ -63,11 +65,19 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	}()
 
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	pod := &v1.Pod{}
 	if err := r.Client.Get(ctx, req.NamespacedName, pod); err != nil {
 		if apierrors.IsNotFound(err) {
 			if err := r.deleteSubnetPortByPodName(ctx, req.Namespace, req.Name); err != nil {
+				var planErr *servicecommon.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(nil, planErr)
+					return common.ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 				return common.ResultRequeue, err
 			}
@@ -77,6 +87,7 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		log.Error(err, "Unable to fetch Pod", "Pod", req.NamespacedName)
 		return common.ResultRequeue, err
 	}
+	ctx = common.WithPlanMode(ctx, pod)
 	if len(pod.Spec.NodeName) == 0 {
 		log.Info("Pod is not scheduled on Node yet, skipping", "Pod", req.NamespacedName)
 		return common.ResultNormal, nil
@@ -114,8 +125,16 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 			r.StatusUpdater.UpdateFail(ctx, pod, err, "", nil)
 			return common.ResultRequeue, err
 		}
-		nsxSubnetPortState, _, err := r.SubnetPortService.CreateOrUpdateSubnetPort(pod, nsxSubnet, contextID, &pod.ObjectMeta.Labels, false, r.restoreMode)
+		nsxSubnetPortState, _, err := r.SubnetPortService.CreateOrUpdateSubnetPort(ctx, pod, nsxSubnet, contextID, &pod.ObjectMeta.Labels, false, r.restoreMode)
 		if err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(pod, planErr)
+				return common.ResultNormal, nil
+			}
+			if nsxutil.IsRealizePendingError(err) {
+				return common.ResultRequeueAfterRealization(), nil
+			}
 			r.StatusUpdater.UpdateFail(ctx, pod, err, "", nil)
 			return common.ResultRequeue, err
 		}
@@ -150,7 +169,12 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 			return common.ResultRequeue, err
 		}
 		if subnetPort != nil {
-			if err := r.SubnetPortService.DeleteSubnetPort(subnetPort); err != nil {
+			if err := r.SubnetPortService.DeleteSubnetPort(ctx, subnetPort); err != nil {
+				var planErr *servicecommon.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(pod, planErr)
+					return common.ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, pod, err)
 				return common.ResultRequeue, err
 			}
@@ -347,7 +371,7 @@ func (r *PodReconciler) CollectGarbage(ctx context.Context) error {
 	for elem := range diffSet {
 		log.Debug("GC collected Pod", "NSXSubnetPortID", elem)
 		r.StatusUpdater.IncreaseDeleteTotal()
-		err = r.SubnetPortService.DeleteSubnetPortById(elem)
+		err = r.SubnetPortService.DeleteSubnetPortById(ctx, elem)
 		if err != nil {
 			errList = append(errList, err)
 			r.StatusUpdater.IncreaseDeleteFailTotal()
@@ -411,7 +435,7 @@ func (r *PodReconciler) deleteSubnetPortByPodName(ctx context.Context, ns string
 	nsxSubnetPorts := r.SubnetPortService.ListSubnetPortByPodName(ns, name)
 
 	for _, nsxSubnetPort := range nsxSubnetPorts {
-		if err := r.SubnetPortService.DeleteSubnetPort(nsxSubnetPort); err != nil {
+		if err := r.SubnetPortService.DeleteSubnetPort(ctx, nsxSubnetPort); err != nil {
 			return err
 		}
 	}
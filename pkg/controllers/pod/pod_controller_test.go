@@ -217,7 +217,7 @@ func TestPodReconciler_Reconcile(t *testing.T) {
 						return &model.VpcSubnet{}, nil
 					})
 				patches.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-					func(r *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+					func(r *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 						return nil, false, errors.New("failed to create subnetport")
 					})
 				return patches
@@ -250,7 +250,7 @@ func TestPodReconciler_Reconcile(t *testing.T) {
 						return &model.VpcSubnet{}, nil
 					})
 				patches.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-					func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string) (*model.SegmentPortState, bool, error) {
+					func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string) (*model.SegmentPortState, bool, error) {
 						return &model.SegmentPortState{
 							RealizedBindings: []model.AddressBindingEntry{
 								{
@@ -297,7 +297,7 @@ func TestPodReconciler_Reconcile(t *testing.T) {
 						return &model.VpcSubnet{}, nil
 					})
 				patches.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-					func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string) (*model.SegmentPortState, bool, error) {
+					func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string) (*model.SegmentPortState, bool, error) {
 						return &model.SegmentPortState{
 							RealizedBindings: []model.AddressBindingEntry{
 								{
@@ -329,7 +329,7 @@ func TestPodReconciler_Reconcile(t *testing.T) {
 					return nil
 				})
 				patchesDeleteSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).DeleteSubnetPort,
-					func(s *subnetport.SubnetPortService, port *model.VpcSubnetPort) error {
+					func(s *subnetport.SubnetPortService, _ context.Context, port *model.VpcSubnetPort) error {
 						return nil
 					})
 				patchesDeleteSubnetPort.ApplyFunc((*subnetport.SubnetPortStore).GetVpcSubnetPortByUID,
@@ -352,7 +352,7 @@ func TestPodReconciler_Reconcile(t *testing.T) {
 					return nil
 				})
 				patchesDeleteSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).DeleteSubnetPort,
-					func(s *subnetport.SubnetPortService, port *model.VpcSubnetPort) error {
+					func(s *subnetport.SubnetPortService, _ context.Context, port *model.VpcSubnetPort) error {
 						return errors.New("failed to delete subnetport")
 					})
 				patchesDeleteSubnetPort.ApplyFunc((*subnetport.SubnetPortStore).GetVpcSubnetPortByUID,
@@ -455,7 +455,7 @@ func TestPodReconciler_CollectGarbage(t *testing.T) {
 		})
 	defer patchesGetVpsSubnetPortByUID.Reset()
 	patchesDeleteSubnetPortById := gomonkey.ApplyFunc((*subnetport.SubnetPortService).DeleteSubnetPortById,
-		func(s *subnetport.SubnetPortService, uid string) error {
+		func(s *subnetport.SubnetPortService, _ context.Context, uid string) error {
 			return nil
 		})
 	defer patchesDeleteSubnetPortById.Reset()
@@ -700,9 +700,11 @@ func TestPodReconciler_deleteSubnetPortByPodName(t *testing.T) {
 	podName2 := "pod-2"
 	namespaceScope := "nsx-op/namespace"
 	ns := "ns"
+	subnetPath := "/orgs/default/projects/default/vpcs/vpc-1/subnets/subnet-1"
 	nameScope := "nsx-op/pod_name"
 	sp1 := &model.VpcSubnetPort{
-		Id: &subnetportId1,
+		Id:         &subnetportId1,
+		ParentPath: &subnetPath,
 		Tags: []model.Tag{
 			{
 				Scope: &namespaceScope,
@@ -715,7 +717,8 @@ func TestPodReconciler_deleteSubnetPortByPodName(t *testing.T) {
 		},
 	}
 	sp2 := &model.VpcSubnetPort{
-		Id: &subnetportId2,
+		Id:         &subnetportId2,
+		ParentPath: &subnetPath,
 		Tags: []model.Tag{
 			{
 				Scope: &namespaceScope,
@@ -728,17 +731,13 @@ func TestPodReconciler_deleteSubnetPortByPodName(t *testing.T) {
 		},
 	}
 	r := &PodReconciler{
-		SubnetPortService: &subnetport.SubnetPortService{},
+		SubnetPortService: &subnetport.SubnetPortService{
+			SubnetPortStore: subnetport.SetupStore(),
+		},
 	}
-	patchesGetByIndex := gomonkey.ApplyFunc((*subnetport.SubnetPortStore).GetByIndex,
-		func(s *subnetport.SubnetPortStore, key string, value string) []*model.VpcSubnetPort {
-			subnetPorts := make([]*model.VpcSubnetPort, 0)
-			subnetPorts = append(subnetPorts, sp1, sp2)
-			return subnetPorts
-		})
-	defer patchesGetByIndex.Reset()
+	assert.NoError(t, r.SubnetPortService.SubnetPortStore.Apply([]*model.VpcSubnetPort{sp1, sp2}))
 	patchesDeleteSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).DeleteSubnetPort,
-		func(s *subnetport.SubnetPortService, sp *model.VpcSubnetPort) error {
+		func(s *subnetport.SubnetPortService, _ context.Context, sp *model.VpcSubnetPort) error {
 			assert.Equal(t, sp2, sp)
 			return nil
 		})
@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,8 +26,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
@@ -56,6 +61,8 @@ type SecurityPolicyReconciler struct {
 	Service       *securitypolicy.SecurityPolicyService
 	Recorder      record.EventRecorder
 	StatusUpdater common.StatusUpdater
+	// driftEvents re-enqueues the SecurityPolicies whose NSX Rules or Groups are changed out of band
+	driftEvents chan event.GenericEvent
 }
 
 func k8sClient(mgr ctrl.Manager) client.Client {
@@ -124,10 +131,18 @@ func (r *SecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}()
 
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
 		if apierrors.IsNotFound(err) {
-			if err := r.deleteSecurityPolicyByName(req.Namespace, req.Name); err != nil {
+			if err := r.deleteSecurityPolicyByName(ctx, req.Namespace, req.Name); err != nil {
+				var planErr *servicecommon.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(nil, planErr)
+					return ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 				return ResultRequeue, err
 			}
@@ -138,6 +153,7 @@ func (r *SecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		log.Error(err, "Failed to fetch SecurityPolicy CR", "req", req.NamespacedName)
 		return ResultRequeue, err
 	}
+	ctx = common.WithPlanMode(ctx, obj)
 
 	isZero := false
 	finalizerName := servicecommon.T1SecurityPolicyFinalizerName
@@ -176,7 +192,17 @@ func (r *SecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 
 		log.Info("Reconciling CR to create or update securitypolicy", "securitypolicy", req.NamespacedName)
-		if err := r.Service.CreateOrUpdateSecurityPolicy(realObj); err != nil {
+		if err := r.Service.CreateOrUpdateSecurityPolicy(ctx, realObj); err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(realObj, planErr)
+				return ResultNormal, nil
+			}
+			// The invalid SecurityPolicy can't be realized until it is changed by the users.
+			if errors.As(err, new(*nsxutil.ValidationError)) {
+				r.StatusUpdater.UpdateFail(ctx, realObj, err, "", setSecurityPolicyReadyStatusFalse, r.Service)
+				return ResultNormal, nil
+			}
 			if errors.As(err, &nsxutil.RestrictionError{}) {
 				setSecurityPolicyErrorAnnotation(ctx, realObj, securitypolicy.IsVPCEnabled(r.Service), r.Client, common.ErrorNoDFWLicense)
 				r.StatusUpdater.UpdateFail(ctx, realObj, err, "", setSecurityPolicyReadyStatusFalse, r.Service)
@@ -206,7 +232,12 @@ func (r *SecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			}
 			log.Debug("Removed finalizer", "securitypolicy", req.NamespacedName)
 		}
-		if err := r.Service.DeleteSecurityPolicy(realObj.UID, false, servicecommon.ResourceTypeSecurityPolicy); err != nil {
+		if err := r.Service.DeleteSecurityPolicy(ctx, realObj.UID, false, servicecommon.ResourceTypeSecurityPolicy); err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(realObj, planErr)
+				return ResultNormal, nil
+			}
 			r.StatusUpdater.DeleteFail(req.NamespacedName, realObj, err)
 			return ResultRequeue, err
 		}
@@ -331,9 +362,37 @@ func (r *SecurityPolicyReconciler) setupWithManager(mgr ctrl.Manager) error {
 			&EnqueueRequestForPod{Client: k8sClient(mgr), SecurityPolicyReconciler: r},
 			builder.WithPredicates(PredicateFuncsPod),
 		).
+		WatchesRawSource(source.Channel(r.driftEvents, &handler.EnqueueRequestForObject{})).
 		Complete(r)
 }
 
+// DriftSources returns the NSX Rules and Groups created for SecurityPolicy CRs checked by the drift detector.
+func (r *SecurityPolicyReconciler) DriftSources() []*common.DriftSource {
+	isVPCEnabled := securitypolicy.IsVPCEnabled(r.Service)
+	owner := func(obj interface{}) client.Object {
+		var tags []model.Tag
+		switch o := obj.(type) {
+		case *model.Rule:
+			tags = o.Tags
+		case *model.Group:
+			tags = o.Tags
+		}
+		name, ns := nsxutil.FindTag(tags, servicecommon.TagValueScopeSecurityPolicyName), nsxutil.FindTag(tags, servicecommon.TagScopeNamespace)
+		if name == "" || ns == "" {
+			return nil
+		}
+		if isVPCEnabled {
+			return &crdv1alpha1.SecurityPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+		}
+		return &v1alpha1.SecurityPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+	}
+	var sources []*common.DriftSource
+	for _, spec := range r.Service.DriftSpecs(servicecommon.ResourceTypeSecurityPolicy) {
+		sources = append(sources, &common.DriftSource{DriftSpec: spec, Service: &r.Service.Service, Owner: owner, Events: r.driftEvents})
+	}
+	return sources
+}
+
 // Start setup manager and launch GC
 func (r *SecurityPolicyReconciler) Start(mgr ctrl.Manager) error {
 	err := r.setupWithManager(mgr)
@@ -362,7 +421,7 @@ func (r *SecurityPolicyReconciler) CollectGarbage(ctx context.Context) error {
 	for elem := range diffSet {
 		log.Debug("GC collected SecurityPolicy CR", "securityPolicyUID", elem)
 		r.StatusUpdater.IncreaseDeleteTotal()
-		err = r.Service.DeleteSecurityPolicy(types.UID(elem), true, servicecommon.ResourceTypeSecurityPolicy)
+		err = r.Service.DeleteSecurityPolicy(ctx, types.UID(elem), true, servicecommon.ResourceTypeSecurityPolicy)
 		if err != nil {
 			errList = append(errList, err)
 			r.StatusUpdater.IncreaseDeleteFailTotal()
@@ -376,12 +435,12 @@ func (r *SecurityPolicyReconciler) CollectGarbage(ctx context.Context) error {
 	return nil
 }
 
-func (r *SecurityPolicyReconciler) deleteSecurityPolicyByName(ns, name string) error {
+func (r *SecurityPolicyReconciler) deleteSecurityPolicyByName(ctx context.Context, ns, name string) error {
 	nsxSecurityPolicies := r.Service.ListSecurityPolicyByName(ns, name)
 	for _, item := range nsxSecurityPolicies {
 		uid := nsxutil.FindTag(item.Tags, servicecommon.TagValueScopeSecurityPolicyUID)
 		log.Info("Deleting SecurityPolicy", "securityPolicyUID", uid, "nsxSecurityPolicyId", *item.Id)
-		if err := r.Service.DeleteSecurityPolicy(types.UID(uid), false, servicecommon.ResourceTypeSecurityPolicy); err != nil {
+		if err := r.Service.DeleteSecurityPolicy(ctx, types.UID(uid), false, servicecommon.ResourceTypeSecurityPolicy); err != nil {
 			log.Error(err, "Failed to delete SecurityPolicy", "securityPolicyUID", uid, "nsxSecurityPolicyId", *item.Id)
 			return err
 		}
@@ -484,20 +543,32 @@ func (r *SecurityPolicyReconciler) RestoreReconcile() error {
 	return nil
 }
 
-func (r *SecurityPolicyReconciler) StartController(mgr ctrl.Manager, _ webhook.Server) error {
+func (r *SecurityPolicyReconciler) StartController(mgr ctrl.Manager, hookServer webhook.Server) error {
 	if err := r.Start(mgr); err != nil {
 		log.Error(err, "Failed to create controller", "controller", "SecurityPolicy")
 		return err
 	}
+	// The webhook server is only started in VPC mode, it validates the SecurityPolicies of crd.nsx.vmware.com.
+	if hookServer != nil {
+		hookServer.Register("/validate-crd-nsx-vmware-com-v1alpha1-securitypolicy",
+			&webhook.Admission{
+				Handler: &SecurityPolicyValidator{
+					Client:    mgr.GetClient(),
+					decoder:   admission.NewDecoder(mgr.GetScheme()),
+					k8sConfig: r.Service.NSXConfig.K8sConfig,
+				},
+			})
+	}
 	go common.GenericGarbageCollector(make(chan bool), servicecommon.GCInterval, r.CollectGarbage)
 	return nil
 }
 
 func NewSecurityPolicyReconciler(mgr ctrl.Manager, commonService servicecommon.Service, vpcService servicecommon.VPCServiceProvider) *SecurityPolicyReconciler {
 	securityPolicyReconcile := &SecurityPolicyReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("securitypolicy-controller"),
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    mgr.GetEventRecorderFor("securitypolicy-controller"),
+		driftEvents: make(chan event.GenericEvent, 100),
 	}
 	securityPolicyReconcile.Service = securitypolicy.GetSecurityService(commonService, vpcService)
 	securityPolicyReconcile.StatusUpdater = common.NewStatusUpdater(securityPolicyReconcile.Client, securityPolicyReconcile.Service.NSXConfig, securityPolicyReconcile.Recorder, MetricResTypeSecurityPolicy, "SecurityPolicy", "SecurityPolicy")
@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -41,10 +43,12 @@ import (
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
 	ctrcommon "github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
 	mock_client "github.com/vmware-tanzu/nsx-operator/pkg/mock/controller-runtime/client"
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock/nsxserver"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
@@ -307,7 +311,7 @@ func TestSecurityPolicyReconciler_Reconcile(t *testing.T) {
 	// not found and deletion success
 	errNotFound := apierrors.NewNotFound(v1alpha1.Resource("SecurityPolicy"), "")
 	k8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(errNotFound)
-	deleteSecurityPolicyByNamePatch := gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSecurityPolicyByName", func(_ *SecurityPolicyReconciler, name, ns string) error {
+	deleteSecurityPolicyByNamePatch := gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSecurityPolicyByName", func(_ *SecurityPolicyReconciler, _ context.Context, name, ns string) error {
 		return nil
 	})
 	defer deleteSecurityPolicyByNamePatch.Reset()
@@ -353,7 +357,7 @@ func TestSecurityPolicyReconciler_Reconcile(t *testing.T) {
 		return false, nil
 	})
 	err = errors.New("create or update security policy failed")
-	patch := gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj interface{}) error {
+	patch := gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj interface{}) error {
 		return errors.New("create or update security policy failed")
 	})
 	k8sClient.EXPECT().Status().Times(1).Return(fakewriter)
@@ -362,9 +366,41 @@ func TestSecurityPolicyReconciler_Reconcile(t *testing.T) {
 	assert.Equal(t, ResultRequeue, result)
 	patch.Reset()
 
+	// DeletionTimestamp.IsZero = true, create security policy fail without DFW license, the error annotation is set
+	var realObj *v1alpha1.SecurityPolicy
+	k8sClient.EXPECT().Get(ctx, gomock.Any(), sp).Return(nil).Do(func(_ context.Context, _ client.ObjectKey, obj client.Object, option ...client.GetOption) error {
+		realObj = obj.(*v1alpha1.SecurityPolicy)
+		return nil
+	})
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj interface{}) error {
+		return nsxutil.RestrictionError{Desc: "no DFW license"}
+	})
+	k8sClient.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+	k8sClient.EXPECT().Status().Times(1).Return(fakewriter)
+	result, retErr = r.Reconcile(ctx, req)
+	assert.Nil(t, retErr)
+	assert.Equal(t, ResultNormal, result)
+	assert.Equal(t, ctrcommon.ErrorNoDFWLicense, realObj.Annotations[ctrcommon.NSXOperatorError])
+	patch.Reset()
+
+	// DeletionTimestamp.IsZero = true, create security policy fail with invalid FQDNs, the error annotation isn't set
+	k8sClient.EXPECT().Get(ctx, gomock.Any(), sp).Return(nil).Do(func(_ context.Context, _ client.ObjectKey, obj client.Object, option ...client.GetOption) error {
+		realObj = obj.(*v1alpha1.SecurityPolicy)
+		return nil
+	})
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj interface{}) error {
+		return &nsxutil.ValidationError{Desc: "fqdns is only supported in the destinations of egress rule"}
+	})
+	k8sClient.EXPECT().Status().Times(1).Return(fakewriter)
+	result, retErr = r.Reconcile(ctx, req)
+	assert.Nil(t, retErr)
+	assert.Equal(t, ResultNormal, result)
+	assert.NotContains(t, realObj.Annotations, ctrcommon.NSXOperatorError)
+	patch.Reset()
+
 	// DeletionTimestamp.IsZero = true, Finalizers include util.SecurityPolicyFinalizerName and update success
 	k8sClient.EXPECT().Get(ctx, gomock.Any(), sp).Return(nil)
-	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj interface{}) error {
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj interface{}) error {
 		return nil
 	})
 	k8sClient.EXPECT().Status().Times(1).Return(fakewriter)
@@ -382,7 +418,7 @@ func TestSecurityPolicyReconciler_Reconcile(t *testing.T) {
 		v1sp.Finalizers = []string{common.T1SecurityPolicyFinalizerName}
 		return nil
 	})
-	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj interface{}, isGc bool, createdFor string) error {
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj interface{}, isGc bool, createdFor string) error {
 		assert.FailNow(t, "should not be called")
 		return nil
 	})
@@ -400,7 +436,7 @@ func TestSecurityPolicyReconciler_Reconcile(t *testing.T) {
 		v1sp.ObjectMeta.DeletionTimestamp = &time
 		return nil
 	})
-	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj interface{}, isGc bool, createdFor string) error {
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj interface{}, isGc bool, createdFor string) error {
 		return nil
 	})
 	result, retErr = r.Reconcile(ctx, req)
@@ -417,7 +453,7 @@ func TestSecurityPolicyReconciler_Reconcile(t *testing.T) {
 		return nil
 	})
 	err = errors.New("delete security policy failed")
-	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, UID interface{}, isGc bool, createdFor string) error {
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, UID interface{}, isGc bool, createdFor string) error {
 		return errors.New("delete security policy failed")
 	})
 	k8sClient.EXPECT().Update(ctx, gomock.Any(), gomock.Any()).Return(nil)
@@ -434,7 +470,7 @@ func TestSecurityPolicyReconciler_Reconcile(t *testing.T) {
 		v1sp.Finalizers = []string{common.T1SecurityPolicyFinalizerName}
 		return nil
 	})
-	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj interface{}, isGc bool, createdFor string) error {
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj interface{}, isGc bool, createdFor string) error {
 		return nil
 	})
 	k8sClient.EXPECT().Update(ctx, gomock.Any(), gomock.Any()).Return(nil)
@@ -470,7 +506,7 @@ func TestSecurityPolicyReconciler_GarbageCollector(t *testing.T) {
 	policyList := &v1alpha1.SecurityPolicyList{}
 
 	// gc collect item "2345", local store has more item than k8s cache
-	patch := gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj interface{}, isGc bool, createdFor string) error {
+	patch := gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj interface{}, isGc bool, createdFor string) error {
 		return nil
 	})
 	patch.ApplyMethod(reflect.TypeOf(service), "ListSecurityPolicyID", func(_ *securitypolicy.SecurityPolicyService) sets.Set[string] {
@@ -495,7 +531,7 @@ func TestSecurityPolicyReconciler_GarbageCollector(t *testing.T) {
 		a.Insert("1234")
 		return a
 	})
-	patch.ApplyMethod(reflect.TypeOf(r.Service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj interface{}, isGc bool, createdFor string) error {
+	patch.ApplyMethod(reflect.TypeOf(r.Service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj interface{}, isGc bool, createdFor string) error {
 		assert.FailNow(t, "should not be called")
 		return nil
 	})
@@ -514,7 +550,7 @@ func TestSecurityPolicyReconciler_GarbageCollector(t *testing.T) {
 		a := sets.New[string]()
 		return a
 	})
-	patch.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj types.UID, isGc bool, createdFor string) error {
+	patch.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj types.UID, isGc bool, createdFor string) error {
 		assert.FailNow(t, "should not be called")
 		return nil
 	})
@@ -705,14 +741,14 @@ func TestSecurityPolicyReconciler_deleteSecuritypolicyByName(t *testing.T) {
 		}
 	})
 
-	patch.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj types.UID, isGc bool, createdFor string) error {
+	patch.ApplyMethod(reflect.TypeOf(service), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, _ context.Context, obj types.UID, isGc bool, createdFor string) error {
 		if obj == "uid2" {
 			return errors.New("delete failed")
 		}
 		return nil
 	})
 
-	err := r.deleteSecurityPolicyByName("dummy-ns", "dummy-name")
+	err := r.deleteSecurityPolicyByName(context.TODO(), "dummy-ns", "dummy-name")
 	assert.Error(t, err)
 	patch.Reset()
 }
@@ -756,7 +792,7 @@ func TestSecurityPolicyReconcilerForVPC_Reconcile(t *testing.T) {
 	errNotFound := apierrors.NewNotFound(crdv1alpha1.Resource("SecurityPolicy"), "")
 	k8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(errNotFound)
 	err := errors.New("delete security policy failed")
-	deleteSecurityPolicyByNamePatch := gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSecurityPolicyByName", func(_ *SecurityPolicyReconciler, name, ns string) error {
+	deleteSecurityPolicyByNamePatch := gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSecurityPolicyByName", func(_ *SecurityPolicyReconciler, _ context.Context, name, ns string) error {
 		return errors.New("delete security policy failed")
 	})
 	result, retErr = r.Reconcile(ctx, req)
@@ -766,7 +802,7 @@ func TestSecurityPolicyReconcilerForVPC_Reconcile(t *testing.T) {
 	// not found and deletion success
 	deleteSecurityPolicyByNamePatch.Reset()
 	k8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(errNotFound)
-	deleteSecurityPolicyByNamePatch = gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSecurityPolicyByName", func(_ *SecurityPolicyReconciler, name, ns string) error {
+	deleteSecurityPolicyByNamePatch = gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSecurityPolicyByName", func(_ *SecurityPolicyReconciler, _ context.Context, name, ns string) error {
 		return nil
 	})
 	defer deleteSecurityPolicyByNamePatch.Reset()
@@ -957,3 +993,82 @@ func TestStartSecurityPolicyController(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurityPolicyReconciler_ReconcileWithNSXServer(t *testing.T) {
+	server := nsxserver.NewServer()
+	defer server.Close()
+	nsxClient := server.NewClient("k8scl-one")
+	dfwLicensed := nsxutil.IsLicensed(nsxutil.FeatureDFW)
+	nsxutil.UpdateLicense(nsxutil.FeatureDFW, true)
+	defer nsxutil.UpdateLicense(nsxutil.FeatureDFW, dfwLicensed)
+
+	newScheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(newScheme))
+	require.NoError(t, v1alpha1.AddToScheme(newScheme))
+	allowAction := v1alpha1.RuleActionAllow
+	directionIn := v1alpha1.RuleDirectionIn
+	sp := &v1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "sp1", UID: "sp-uid-1"},
+		Spec: v1alpha1.SecurityPolicySpec{
+			AppliedTo: []v1alpha1.SecurityPolicyTarget{
+				{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+			},
+			Rules: []v1alpha1.SecurityPolicyRule{
+				{
+					Name:      "allow-db",
+					Action:    &allowAction,
+					Direction: &directionIn,
+					Sources: []v1alpha1.SecurityPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}},
+					},
+				},
+			},
+		},
+	}
+	ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(ns, sp).WithStatusSubresource(sp).Build()
+
+	commonService := common.Service{Client: k8sClient, NSXClient: nsxClient, NSXConfig: nsxClient.NsxConfig}
+	service, err := securitypolicy.InitializeSecurityPolicy(commonService, nil, false)
+	require.NoError(t, err)
+	r := &SecurityPolicyReconciler{
+		Client:        k8sClient,
+		Scheme:        newScheme,
+		Service:       service,
+		Recorder:      fakeRecorder{},
+		StatusUpdater: ctrcommon.NewStatusUpdater(k8sClient, service.NSXConfig, fakeRecorder{}, MetricResTypeSecurityPolicy, "SecurityPolicy", "SecurityPolicy"),
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "sp1"}}
+
+	// the SecurityPolicy, its rule and groups are created on NSX
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, ResultNormal, result)
+	// the domain of the cluster is used in T1 mode
+	policyPrefix := "/infra/domains/k8scl-one/security-policies/"
+	groupPrefix := "/infra/domains/k8scl-one/groups/"
+	policies := server.Paths(policyPrefix)
+	require.Len(t, policies, 2)
+	assert.True(t, strings.HasPrefix(policies[1], policies[0]+"/rules/"))
+	assert.NotEmpty(t, server.Paths(groupPrefix))
+	updated := &v1alpha1.SecurityPolicy{}
+	require.NoError(t, k8sClient.Get(ctx, req.NamespacedName, updated))
+	require.Len(t, updated.Status.Conditions, 1)
+	assert.Equal(t, v1.ConditionTrue, updated.Status.Conditions[0].Status)
+
+	// reconciling the unchanged CR doesn't patch NSX again
+	patches := server.RequestCount("PATCH", nsxserver.PolicyAPIPrefix+"/infra")
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, ResultNormal, result)
+	assert.Equal(t, patches, server.RequestCount("PATCH", nsxserver.PolicyAPIPrefix+"/infra"))
+
+	// the NSX resources are deleted with the CR
+	require.NoError(t, k8sClient.Delete(ctx, updated))
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, ResultNormal, result)
+	assert.Empty(t, server.Paths(policyPrefix))
+	assert.Empty(t, server.Paths(groupPrefix))
+}
@@ -0,0 +1,59 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+)
+
+// Create validator instead of using the existing one in controller-runtime because the existing one can't
+// inspect admission.Request in Handle function.
+
+// +kubebuilder:webhook:path=/validate-crd-nsx-vmware-com-v1alpha1-securitypolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=crd.nsx.vmware.com,resources=securitypolicies,verbs=create;update,versions=v1alpha1,name=securitypolicy.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
+
+// SecurityPolicyValidator denies the SecurityPolicies using the categories other than Application in the Namespaces
+// not permitted by the SecurityPolicyCategoryAllowList of the config, so the cluster guardrails in the higher
+// categories can't be overridden by the application teams.
+type SecurityPolicyValidator struct {
+	Client    client.Client
+	decoder   admission.Decoder
+	k8sConfig *config.K8sConfig
+}
+
+// Handle handles admission requests.
+func (v *SecurityPolicyValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation == admissionv1.Delete {
+		return admission.Allowed("")
+	}
+	sp := &v1alpha1.SecurityPolicy{}
+	if err := v.decoder.Decode(req, sp); err != nil {
+		log.Error(err, "Failed to decode SecurityPolicy", "SecurityPolicy", req.Namespace+"/"+req.Name)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	category := sp.Spec.Category
+	if category == "" {
+		category = v1alpha1.SecurityPolicyCategoryApplication
+	}
+	if category != v1alpha1.SecurityPolicyCategoryApplication && !v.k8sConfig.SecurityPolicyCategoryAllowed(sp.Namespace, string(category)) {
+		log.Info("SecurityPolicy category is not allowed", "SecurityPolicy", req.Namespace+"/"+req.Name, "category", category, "user", req.UserInfo.Username)
+		return admission.Denied(fmt.Sprintf("SecurityPolicy %s/%s is not allowed to use the %s category in Namespace %s", sp.Namespace, sp.Name, category, sp.Namespace))
+	}
+	for _, rule := range sp.Spec.Rules {
+		if rule.Action != nil && strings.EqualFold(string(*rule.Action), string(v1alpha1.RuleActionPass)) && category != v1alpha1.SecurityPolicyCategoryEnvironment {
+			return admission.Denied(fmt.Sprintf("SecurityPolicy %s/%s rule %s: Pass action is only supported in the Environment category", sp.Namespace, sp.Name, rule.Name))
+		}
+	}
+	return admission.Allowed("")
+}
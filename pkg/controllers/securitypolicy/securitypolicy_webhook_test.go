@@ -0,0 +1,59 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+)
+
+func TestSecurityPolicyValidator_Handle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	v1alpha1.AddToScheme(scheme)
+	validator := &SecurityPolicyValidator{
+		decoder:   admission.NewDecoder(scheme),
+		k8sConfig: &config.K8sConfig{SecurityPolicyCategoryAllowList: []string{"kube-system:*", "*:Environment"}},
+	}
+	pass := v1alpha1.RuleAction("pass")
+
+	tests := []struct {
+		name      string
+		operation admissionv1.Operation
+		namespace string
+		spec      v1alpha1.SecurityPolicySpec
+		allowed   bool
+	}{
+		{"delete", admissionv1.Delete, "ns-1", v1alpha1.SecurityPolicySpec{Category: v1alpha1.SecurityPolicyCategoryEmergency}, true},
+		{"default category", admissionv1.Create, "ns-1", v1alpha1.SecurityPolicySpec{}, true},
+		{"category not allowed", admissionv1.Create, "ns-1", v1alpha1.SecurityPolicySpec{Category: v1alpha1.SecurityPolicyCategoryEmergency}, false},
+		{"category allowed in namespace", admissionv1.Update, "kube-system", v1alpha1.SecurityPolicySpec{Category: v1alpha1.SecurityPolicyCategoryEmergency}, true},
+		{"category allowed in all namespaces", admissionv1.Create, "ns-1",
+			v1alpha1.SecurityPolicySpec{Category: v1alpha1.SecurityPolicyCategoryEnvironment, Rules: []v1alpha1.SecurityPolicyRule{{Action: &pass}}}, true},
+		{"pass in application category", admissionv1.Create, "ns-1", v1alpha1.SecurityPolicySpec{Rules: []v1alpha1.SecurityPolicyRule{{Action: &pass}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, _ := json.Marshal(&v1alpha1.SecurityPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace, Name: "sp-1"}, Spec: tt.spec})
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: tt.operation,
+					Namespace: tt.namespace,
+					Name:      "sp-1",
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			assert.Equal(t, tt.allowed, validator.Handle(context.TODO(), req).Allowed)
+		})
+	}
+}
@@ -5,9 +5,12 @@ package staticroute
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,6 +20,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -45,13 +51,15 @@ type StaticRouteReconciler struct {
 	Service       *staticroute.StaticRouteService
 	Recorder      record.EventRecorder
 	StatusUpdater common.StatusUpdater
+	// driftEvents re-enqueues the StaticRoutes whose NSX StaticRoutes are changed out of band
+	driftEvents chan event.GenericEvent
 }
 
-func (r *StaticRouteReconciler) deleteStaticRouteByName(ns, name string) error {
+func (r *StaticRouteReconciler) deleteStaticRouteByName(ctx context.Context, ns, name string) error {
 	nsxStaticRoutes := r.Service.ListStaticRouteByName(ns, name)
 	for _, item := range nsxStaticRoutes {
 		log.Info("Deleting StaticRoute", "Namespace", ns, "Name", name, "nsxStaticRouteId", *item.Id)
-		if err := r.Service.DeleteStaticRoute(item); err != nil {
+		if err := r.Service.DeleteStaticRoute(ctx, item); err != nil {
 			log.Error(err, "Failed to delete StaticRoute", "nsxStaticRouteId", *item.Id)
 			return err
 		}
@@ -64,10 +72,18 @@ func (r *StaticRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	obj := &v1alpha1.StaticRoute{}
 	log.Info("reconciling staticroute CR", "staticroute", req.NamespacedName)
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(time.Now())
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
 		if apierrors.IsNotFound(err) {
-			if err := r.deleteStaticRouteByName(req.Namespace, req.Name); err != nil {
+			if err := r.deleteStaticRouteByName(ctx, req.Namespace, req.Name); err != nil {
+				var planErr *commonservice.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(nil, planErr)
+					return ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 				return ResultRequeue, err
 			}
@@ -77,22 +93,32 @@ func (r *StaticRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		log.Error(err, "unable to fetch static route CR", "req", req.NamespacedName)
 		return ResultRequeue, err
 	}
+	ctx = common.WithPlanMode(ctx, obj)
 
 	if obj.ObjectMeta.DeletionTimestamp.IsZero() {
 		r.StatusUpdater.IncreaseUpdateTotal()
-		if err := r.Service.CreateOrUpdateStaticRoute(req.Namespace, obj); err != nil {
+		if err := r.Service.CreateOrUpdateStaticRoute(ctx, req.Namespace, obj); err != nil {
+			var planErr *commonservice.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(obj, planErr)
+				return ResultNormal, nil
+			}
 			r.StatusUpdater.UpdateFail(ctx, obj, err, "", setStaticRouteReadyStatusFalse)
-			// TODO: if error is not retriable, not requeue
 			apierror, errortype := util.DumpAPIError(err)
 			if apierror != nil {
 				log.Info("create or update static route failed", "error", apierror, "error type", errortype)
 			}
-			return ResultRequeue, err
+			return common.ResultForError(err, ResultRequeue)
 		}
 		r.StatusUpdater.UpdateSuccess(ctx, obj, setStaticRouteReadyStatusTrue)
 	} else {
 		r.StatusUpdater.IncreaseDeleteTotal()
-		if err := r.Service.DeleteStaticRouteByCR(obj); err != nil {
+		if err := r.Service.DeleteStaticRouteByCR(ctx, obj); err != nil {
+			var planErr *commonservice.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(obj, planErr)
+				return ResultNormal, nil
+			}
 			r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 			return ResultRequeue, err
 		}
@@ -122,7 +148,7 @@ func setStaticRouteReadyStatusFalse(client client.Client, ctx context.Context, o
 			Type:               v1alpha1.Ready,
 			Status:             v1.ConditionFalse,
 			Message:            fmt.Sprintf("Error occurred while processing the Static Route CR. Please check the config and try again. Error: %v", err),
-			Reason:             "StaticRouteNotReady",
+			Reason:             common.ConditionReason(err, "StaticRouteNotReady"),
 			LastTransitionTime: transitionTime,
 		},
 	}
@@ -172,6 +198,7 @@ func getExistingConditionOfType(conditionType v1alpha1.StaticRouteStatusConditio
 func (r *StaticRouteReconciler) setupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.StaticRoute{}).
+		WatchesRawSource(source.Channel(r.driftEvents, &handler.EnqueueRequestForObject{})).
 		WithOptions(
 			controller.Options{
 				MaxConcurrentReconciles: common.NumReconcile(),
@@ -231,7 +258,7 @@ func (r *StaticRouteReconciler) CollectGarbage(ctx context.Context) error {
 
 		log.Debug("GC collected StaticRoute CR", "UID", elem)
 		r.StatusUpdater.IncreaseDeleteTotal()
-		err = r.Service.DeleteStaticRoute(elem)
+		err = r.Service.DeleteStaticRoute(ctx, elem)
 		if err != nil {
 			errList = append(errList, err)
 			r.StatusUpdater.IncreaseDeleteFailTotal()
@@ -245,6 +272,23 @@ func (r *StaticRouteReconciler) CollectGarbage(ctx context.Context) error {
 	return nil
 }
 
+// DriftSources returns the NSX StaticRoutes checked by the drift detector, the owner is found by the CR name tag.
+func (r *StaticRouteReconciler) DriftSources() []*common.DriftSource {
+	return []*common.DriftSource{{
+		DriftSpec: r.Service.DriftSpec(),
+		Service:   &r.Service.Service,
+		Owner: func(obj interface{}) client.Object {
+			sr := obj.(*model.StaticRoutes)
+			name, ns := util.FindTag(sr.Tags, commonservice.TagScopeStaticRouteCRName), util.FindTag(sr.Tags, commonservice.TagScopeNamespace)
+			if name == "" || ns == "" {
+				return nil
+			}
+			return &v1alpha1.StaticRoute{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+		},
+		Events: r.driftEvents,
+	}}
+}
+
 func (r *StaticRouteReconciler) RestoreReconcile() error {
 	return nil
 }
@@ -264,6 +308,8 @@ func NewStaticRouteReconciler(mgr ctrl.Manager, staticRouteService *staticroute.
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("staticroute-controller"),
+		// buffered so that the drift detector isn't blocked by a busy controller
+		driftEvents: make(chan event.GenericEvent, 100),
 	}
 	staticRouteReconcile.Service = staticRouteService
 	staticRouteReconcile.StatusUpdater = common.NewStatusUpdater(staticRouteReconcile.Client, staticRouteReconcile.Service.NSXConfig, staticRouteReconcile.Recorder, MetricResTypeStaticRoute, "StaticRoute", "StaticRoute")
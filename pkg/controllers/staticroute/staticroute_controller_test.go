@@ -19,6 +19,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -196,7 +197,7 @@ func TestStaticRouteReconciler_Reconcile(t *testing.T) {
 		return nil
 	})
 
-	patch := gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRouteByCR", func(_ *staticroute.StaticRouteService, obj *v1alpha1.StaticRoute) error {
+	patch := gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRouteByCR", func(_ *staticroute.StaticRouteService, _ context.Context, obj *v1alpha1.StaticRoute) error {
 		return nil
 	})
 
@@ -211,7 +212,7 @@ func TestStaticRouteReconciler_Reconcile(t *testing.T) {
 		v1sp.ObjectMeta.DeletionTimestamp = &time
 		return nil
 	})
-	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRouteByCR", func(_ *staticroute.StaticRouteService, obj *v1alpha1.StaticRoute) error {
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRouteByCR", func(_ *staticroute.StaticRouteService, _ context.Context, obj *v1alpha1.StaticRoute) error {
 		return errors.New("delete failed")
 	})
 	k8sClient.EXPECT().Status().Times(1).Return(fakewriter)
@@ -226,7 +227,7 @@ func TestStaticRouteReconciler_Reconcile(t *testing.T) {
 		return nil
 	})
 
-	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateStaticRoute", func(_ *staticroute.StaticRouteService, namespace string, obj *v1alpha1.StaticRoute) error {
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateStaticRoute", func(_ *staticroute.StaticRouteService, _ context.Context, namespace string, obj *v1alpha1.StaticRoute) error {
 		return errors.New("create failed")
 	})
 	k8sClient.EXPECT().Status().Times(1).Return(fakewriter)
@@ -241,7 +242,7 @@ func TestStaticRouteReconciler_Reconcile(t *testing.T) {
 		return nil
 	})
 
-	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateStaticRoute", func(_ *staticroute.StaticRouteService, namespace string, obj *v1alpha1.StaticRoute) error {
+	patch = gomonkey.ApplyMethod(reflect.TypeOf(service), "CreateOrUpdateStaticRoute", func(_ *staticroute.StaticRouteService, _ context.Context, namespace string, obj *v1alpha1.StaticRoute) error {
 		return nil
 	})
 	_, ret = r.Reconcile(ctx, req)
@@ -271,7 +272,7 @@ func TestStaticRouteReconciler_GarbageCollector(t *testing.T) {
 		a = append(a, &model.StaticRoutes{Id: &id2, Path: &path, Tags: tag2})
 		return a
 	})
-	patch.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRoute", func(_ *staticroute.StaticRouteService, nsxStaticRoute *model.StaticRoutes) error {
+	patch.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRoute", func(_ *staticroute.StaticRouteService, _ context.Context, nsxStaticRoute *model.StaticRoutes) error {
 		return nil
 	})
 	defer patch.Reset()
@@ -304,7 +305,7 @@ func TestStaticRouteReconciler_GarbageCollector(t *testing.T) {
 		a = append(a, &model.StaticRoutes{Id: &id, Tags: tag2})
 		return a
 	})
-	patch.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRouteByCR", func(_ *staticroute.StaticRouteService, obj *v1alpha1.StaticRoute) error {
+	patch.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRouteByCR", func(_ *staticroute.StaticRouteService, _ context.Context, obj *v1alpha1.StaticRoute) error {
 		assert.FailNow(t, "should not be called")
 		return nil
 	})
@@ -322,7 +323,7 @@ func TestStaticRouteReconciler_GarbageCollector(t *testing.T) {
 	patch.ApplyMethod(reflect.TypeOf(service), "ListStaticRoute", func(_ *staticroute.StaticRouteService) []*model.StaticRoutes {
 		return []*model.StaticRoutes{}
 	})
-	patch.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRouteByCR", func(_ *staticroute.StaticRouteService, obj *v1alpha1.StaticRoute) error {
+	patch.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRouteByCR", func(_ *staticroute.StaticRouteService, _ context.Context, obj *v1alpha1.StaticRoute) error {
 		assert.FailNow(t, "should not be called")
 		return nil
 	})
@@ -330,6 +331,46 @@ func TestStaticRouteReconciler_GarbageCollector(t *testing.T) {
 	r.CollectGarbage(ctx)
 }
 
+func TestStaticRouteReconciler_GarbageCollectorPlanMode(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	// No call to the StaticRoutesClient is expected, the deletion is only planned.
+	staticRouteClient := mocks.NewMockStaticRoutesClient(mockCtl)
+	nsxConfig := &config.NSXOperatorConfig{NsxConfig: &config.NsxConfig{PlanMode: true}}
+	service := &staticroute.StaticRouteService{
+		Service: common.Service{
+			NSXClient: &nsx.Client{StaticRouteClient: staticRouteClient, NsxConfig: nsxConfig},
+			NSXConfig: nsxConfig,
+		},
+		StaticRouteStore: &staticroute.StaticRouteStore{TypedStore: common.TypedStore[model.StaticRoutes]{ResourceStore: common.ResourceStore{
+			Indexer: cache.NewIndexer(func(obj interface{}) (string, error) {
+				return *obj.(*model.StaticRoutes).Id, nil
+			}, cache.Indexers{}),
+			BindingType: model.StaticRoutesBindingType(),
+		}}},
+	}
+	staleRoute := &model.StaticRoutes{
+		Id:           pointy.String("sr-stale"),
+		Path:         pointy.String("/orgs/default/projects/project-1/vpcs/vpc-1/static-routes/sr-stale"),
+		ResourceType: pointy.String("StaticRoutes"),
+		Tags:         []model.Tag{{Scope: pointy.String(common.TagScopeStaticRouteCRUID), Tag: pointy.String("uid-stale")}},
+	}
+	assert.NoError(t, service.StaticRouteStore.Add(staleRoute))
+
+	scheme := runtime.NewScheme()
+	v1alpha1.AddToScheme(scheme)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &StaticRouteReconciler{
+		Client:        k8sClient,
+		Service:       service,
+		StatusUpdater: ctlcommon.NewStatusUpdater(k8sClient, nsxConfig, fakeRecorder{}, MetricResTypeStaticRoute, "StaticRoute", "StaticRoute"),
+	}
+	err := r.CollectGarbage(context.Background())
+	assert.ErrorContains(t, err, "plan mode")
+	assert.ErrorContains(t, err, "delete StaticRoutes "+*staleRoute.Path)
+	assert.NotNil(t, service.StaticRouteStore.GetByKey("sr-stale"))
+}
+
 func TestStaticRouteReconciler_Start(t *testing.T) {
 	mockCtl := gomock.NewController(t)
 	k8sClient := mock_client.NewMockClient(mockCtl)
@@ -457,14 +498,14 @@ func TestStaticRouteReconciler_deleteStaticRouteByName(t *testing.T) {
 		}
 	})
 
-	patch.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRoute", func(_ *staticroute.StaticRouteService, nsxStaticRoute *model.StaticRoutes) error {
+	patch.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRoute", func(_ *staticroute.StaticRouteService, _ context.Context, nsxStaticRoute *model.StaticRoutes) error {
 		if *nsxStaticRoute.Id == "route-id-2" {
 			return errors.New("delete failed")
 		}
 		return nil
 	})
 
-	err := r.deleteStaticRouteByName("dummy-name", "dummy-ns")
+	err := r.deleteStaticRouteByName(context.TODO(), "dummy-name", "dummy-ns")
 	assert.Error(t, err)
 	patch.Reset()
 }
@@ -22,7 +22,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -55,6 +58,8 @@ type SubnetReconciler struct {
 	Recorder          record.EventRecorder
 	StatusUpdater     common.StatusUpdater
 	queue             workqueue.TypedRateLimitingInterface[reconcile.Request]
+	// driftEvents re-enqueues the Subnets whose NSX VpcSubnets are changed out of band
+	driftEvents chan event.GenericEvent
 }
 
 func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -64,11 +69,19 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}()
 
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 	subnetCR := &v1alpha1.Subnet{}
 
 	if err := r.Client.Get(ctx, req.NamespacedName, subnetCR); err != nil {
 		if apierrors.IsNotFound(err) {
-			if err := r.deleteSubnetByName(req.Name, req.Namespace); err != nil {
+			if err := r.deleteSubnetByName(ctx, req.Name, req.Namespace); err != nil {
+				var planErr *servicecommon.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(nil, planErr)
+					return ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 				return ResultRequeue, err
 			}
@@ -78,6 +91,7 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		log.Error(err, "Unable to fetch Subnet CR", "req", req.NamespacedName)
 		return ResultRequeue, err
 	}
+	ctx = common.WithPlanMode(ctx, subnetCR)
 
 	bindingCRs := r.getSubnetBindingCRsBySubnet(ctx, subnetCR)
 	if len(bindingCRs) > 0 {
@@ -114,7 +128,12 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			return ResultRequeue, err
 		}
 
-		if err := r.deleteSubnetByID(string(subnetCR.GetUID())); err != nil {
+		if err := r.deleteSubnetByID(ctx, string(subnetCR.GetUID())); err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(subnetCR, planErr)
+				return ResultNormal, nil
+			}
 			r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 			return ResultRequeue, err
 		}
@@ -189,7 +208,12 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 
 	// Create or update the subnet in NSX
-	if _, err := r.SubnetService.CreateOrUpdateSubnet(subnetCR, vpcInfoList[0], tags); err != nil {
+	if _, err := r.SubnetService.CreateOrUpdateSubnet(ctx, subnetCR, vpcInfoList[0], tags); err != nil {
+		var planErr *servicecommon.PlanError
+		if errors.As(err, &planErr) {
+			r.StatusUpdater.UpdatePlanned(subnetCR, planErr)
+			return ResultNormal, nil
+		}
 		if errors.As(err, &nsxutil.ExceedTagsError{}) {
 			r.StatusUpdater.UpdateFail(ctx, subnetCR, err, "Tags limit exceeded", setSubnetReadyStatusFalse)
 			return ResultNormal, nil
@@ -203,7 +227,7 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			}
 		}
 		r.StatusUpdater.UpdateFail(ctx, subnetCR, err, "Failed to create/update Subnet", setSubnetReadyStatusFalse)
-		return ResultRequeue, err
+		return common.ResultForError(err, ResultRequeue)
 	}
 	// Update status
 	if err := r.updateSubnetStatus(subnetCR); err != nil {
@@ -228,12 +252,12 @@ func (r *SubnetReconciler) setDefaultIPv4SubnetSizeValue(ctx context.Context, su
 	return nil
 }
 
-func (r *SubnetReconciler) deleteSubnetByID(subnetID string) error {
+func (r *SubnetReconciler) deleteSubnetByID(ctx context.Context, subnetID string) error {
 	nsxSubnets := r.SubnetService.SubnetStore.GetByIndex(servicecommon.TagScopeSubnetCRUID, subnetID)
-	return r.deleteSubnets(nsxSubnets)
+	return r.deleteSubnets(ctx, nsxSubnets)
 }
 
-func (r *SubnetReconciler) deleteSubnets(nsxSubnets []*model.VpcSubnet) error {
+func (r *SubnetReconciler) deleteSubnets(ctx context.Context, nsxSubnets []*model.VpcSubnet) error {
 	if len(nsxSubnets) == 0 {
 		return nil
 	}
@@ -244,7 +268,7 @@ func (r *SubnetReconciler) deleteSubnets(nsxSubnets []*model.VpcSubnet) error {
 			log.Error(err, "Delete Subnet from NSX failed")
 			return err
 		}
-		if err := r.SubnetService.DeleteSubnet(*nsxSubnet); err != nil {
+		if err := r.SubnetService.DeleteSubnet(ctx, *nsxSubnet); err != nil {
 			log.Error(err, "Failed to delete Subnet", "ID", *nsxSubnet.Id)
 			return err
 		}
@@ -255,10 +279,10 @@ func (r *SubnetReconciler) deleteSubnets(nsxSubnets []*model.VpcSubnet) error {
 	return nil
 }
 
-func (r *SubnetReconciler) deleteSubnetByName(name, ns string) error {
+func (r *SubnetReconciler) deleteSubnetByName(ctx context.Context, name, ns string) error {
 	// Since shared subnets are not in the store, we can enter this function safely
 	nsxSubnets := r.SubnetService.ListSubnetByName(ns, name)
-	return r.deleteSubnets(nsxSubnets)
+	return r.deleteSubnets(ctx, nsxSubnets)
 }
 
 func (r *SubnetReconciler) updateSubnetStatus(obj *v1alpha1.Subnet) error {
@@ -342,7 +366,7 @@ func setSubnetReadyStatusFalse(client client.Client, ctx context.Context, obj cl
 			Type:               v1alpha1.Ready,
 			Status:             v1.ConditionFalse,
 			Message:            "NSX Subnet could not be created/updated",
-			Reason:             "SubnetNotReady",
+			Reason:             common.ConditionReason(err, "SubnetNotReady"),
 			LastTransitionTime: transitionTime,
 		},
 	}
@@ -491,6 +515,7 @@ func NewSubnetReconciler(mgr ctrl.Manager, subnetService *subnet.SubnetService,
 		VPCService:        vpcService,
 		BindingService:    bindingService,
 		Recorder:          mgr.GetEventRecorderFor("subnet-controller"),
+		driftEvents:       make(chan event.GenericEvent, 100),
 	}
 	subnetReconciler.StatusUpdater = common.NewStatusUpdater(subnetReconciler.Client, subnetReconciler.SubnetService.NSXConfig, subnetReconciler.Recorder, MetricResTypeSubnet, "Subnet", "Subnet")
 	return subnetReconciler
@@ -545,9 +570,27 @@ func (r *SubnetReconciler) setupWithManager(mgr ctrl.Manager) error {
 			},
 			builder.WithPredicates(common.PredicateFuncsWithSubnetBindings),
 		).
+		WatchesRawSource(source.Channel(r.driftEvents, &handler.EnqueueRequestForObject{})).
 		Complete(r)
 }
 
+// DriftSources returns the NSX VpcSubnets created for Subnet CRs checked by the drift detector.
+func (r *SubnetReconciler) DriftSources() []*common.DriftSource {
+	return []*common.DriftSource{{
+		DriftSpec: r.SubnetService.DriftSpec(),
+		Service:   &r.SubnetService.Service,
+		Owner: func(obj interface{}) client.Object {
+			nsxSubnet := obj.(*model.VpcSubnet)
+			name, ns := nsxutil.FindTag(nsxSubnet.Tags, servicecommon.TagScopeSubnetCRName), nsxutil.FindTag(nsxSubnet.Tags, servicecommon.TagScopeVMNamespace)
+			if name == "" || ns == "" {
+				return nil
+			}
+			return &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+		},
+		Events: r.driftEvents,
+	}}
+}
+
 func (r *SubnetReconciler) getQueue(controllerName string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
 	if r.queue == nil {
 		r.queue = workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter, workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{
@@ -592,7 +635,7 @@ func (r *SubnetReconciler) CollectGarbage(ctx context.Context) error {
 		r.StatusUpdater.IncreaseDeleteTotal()
 
 		log.Info("Subnet garbage collection, cleaning stale Subnets", "Count", len(nsxSubnets))
-		if err := r.deleteSubnets(nsxSubnets); err != nil {
+		if err := r.deleteSubnets(ctx, nsxSubnets); err != nil {
 			errList = append(errList, err)
 			log.Error(err, "Subnet garbage collection, failed to delete NSX subnet", "SubnetUID", subnetID)
 			r.StatusUpdater.IncreaseDeleteFailTotal()
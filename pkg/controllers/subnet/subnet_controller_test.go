@@ -54,10 +54,10 @@ func TestSubnetReconciler_GarbageCollector(t *testing.T) {
 					res := sets.New[string]("fake-id1", "fake-id2")
 					return res
 				})
-				patch.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, _ string) []*model.VpcSubnet {
+				patch.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, _ string, _ string) []interface{} {
 					tags1 := []model.Tag{{Scope: common.String(common.TagScopeSubnetCRUID), Tag: common.String("fake-id1")}}
 					tags2 := []model.Tag{{Scope: common.String(common.TagScopeSubnetCRUID), Tag: common.String("fake-id2")}}
-					var nsxSubnets []*model.VpcSubnet
+					var nsxSubnets []interface{}
 					id1 := "fake-id1"
 					nsxSubnets = append(nsxSubnets, &model.VpcSubnet{Id: &id1, Tags: tags1, Path: common.String("fake-path")})
 					id2 := "fake-id2"
@@ -67,7 +67,7 @@ func TestSubnetReconciler_GarbageCollector(t *testing.T) {
 				patch.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, _ string) (ports []*model.VpcSubnetPort) {
 					return nil
 				})
-				patch.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patch.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return nil
 				})
 				patch.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "DeletePortCount", func(_ *subnetport.SubnetPortService, _ string) {
@@ -84,7 +84,7 @@ func TestSubnetReconciler_GarbageCollector(t *testing.T) {
 					res := sets.New[string]("fake-id2")
 					return res
 				})
-				patch.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patch.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					assert.FailNow(t, "should not be called")
 					return nil
 				})
@@ -105,10 +105,10 @@ func TestSubnetReconciler_GarbageCollector(t *testing.T) {
 					res := sets.New[string]("fake-id1", "fake-id2")
 					return res
 				})
-				patch.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, _ string) []*model.VpcSubnet {
+				patch.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, _ string, _ string) []interface{} {
 					tags1 := []model.Tag{{Scope: common.String(common.TagScopeSubnetCRUID), Tag: common.String("fake-id1")}}
 					tags2 := []model.Tag{{Scope: common.String(common.TagScopeSubnetCRUID), Tag: common.String("fake-id2")}}
-					var nsxSubnets []*model.VpcSubnet
+					var nsxSubnets []interface{}
 					id1 := "fake-id1"
 					nsxSubnets = append(nsxSubnets, &model.VpcSubnet{Id: &id1, Tags: tags1, Path: common.String("fake-path1")})
 					id2 := "fake-id2"
@@ -118,7 +118,7 @@ func TestSubnetReconciler_GarbageCollector(t *testing.T) {
 				patch.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, _ string) (ports []*model.VpcSubnetPort) {
 					return nil
 				})
-				patch.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patch.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return errors.New("delete failed")
 				})
 				return patch
@@ -246,7 +246,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 			name: "Subnet CR not found",
 			req:  ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-subnet"}},
 			patches: func(r *SubnetReconciler) *gomonkey.Patches {
-				return gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnetByName", func(_ *SubnetReconciler, name, ns string) error {
+				return gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnetByName", func(_ *SubnetReconciler, _ context.Context, name, ns string) error {
 					return nil
 				})
 			},
@@ -257,10 +257,10 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 			name: "Delete Subnet CR success",
 			req:  ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: subnetName1}},
 			patches: func(r *SubnetReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnetByID", func(_ *SubnetReconciler, id string) error {
+				patches := gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnetByID", func(_ *SubnetReconciler, _ context.Context, id string) error {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					tags := []model.Tag{
@@ -278,14 +278,14 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 						{Scope: common.String(common.TagScopeVMNamespace), Tag: common.String(ns)},
 					}
 					vpcSubnetDelete := model.VpcSubnet{Id: &id2, Path: &path2, Tags: tagStale}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnetSkip, &vpcSubnetDelete,
 					}
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, _ string) (ports []*model.VpcSubnetPort) {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return nil
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "DeletePortCount", func(_ *subnetport.SubnetPortService, _ string) {
@@ -300,7 +300,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 			name: "Delete Subnet CR failed to delete NSX Subnet",
 			req:  ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-subnet"}},
 			patches: func(r *SubnetReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					tags := []model.Tag{
@@ -316,14 +316,14 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 						{Scope: common.String(common.TagScopeSubnetCRName), Tag: common.String(subnetName)},
 					}
 					vpcSubnetDelete := model.VpcSubnet{Id: &id2, Path: &path2, Tags: tagStale}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnetSkip, &vpcSubnetDelete,
 					}
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, _ string) (ports []*model.VpcSubnetPort) {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return errors.New("failed to delete NSX Subnet")
 				})
 				return patches
@@ -335,7 +335,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 			name: "Delete Subnet CR with stale SubnetPort",
 			req:  ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-subnet"}},
 			patches: func(r *SubnetReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					tags := []model.Tag{
@@ -351,7 +351,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 						{Scope: common.String(common.TagScopeSubnetCRName), Tag: common.String(subnetName)},
 					}
 					vpcSubnetDelete := model.VpcSubnet{Id: &id2, Path: &path2, Tags: tagStale}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnetSkip, &vpcSubnetDelete,
 					}
 				})
@@ -359,7 +359,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 					id := "fake-subnetport-0"
 					return []*model.VpcSubnetPort{{Id: &id}}
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return nil
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "DeletePortCount", func(_ *subnetport.SubnetPortService, _ string) {
@@ -377,7 +377,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.Client), "Get", func(_ client.Client, _ context.Context, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
 					return errors.New("get Subnet CR error")
 				})
-				patches.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnetByName", func(_ *SubnetReconciler, name, ns string) error {
+				patches.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnetByName", func(_ *SubnetReconciler, _ context.Context, name, ns string) error {
 					return nil
 				})
 				return patches
@@ -390,7 +390,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 			name: "Subnet CR with finalizer delete success",
 			req:  ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-subnet"}},
 			patches: func(r *SubnetReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnetByID", func(_ *SubnetReconciler, _ string) error {
+				patches := gomonkey.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnetByID", func(_ *SubnetReconciler, _ context.Context, _ string) error {
 					return nil
 				})
 				patches.ApplyPrivateMethod(reflect.TypeOf(r), "getSubnetBindingCRsBySubnet", func(_ *SubnetReconciler, _ context.Context, _ *v1alpha1.Subnet) []v1alpha1.SubnetConnectionBindingMap {
@@ -408,7 +408,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 			name: "Subnet CR with finalizer",
 			req:  ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-subnet"}},
 			patches: func(r *SubnetReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					tags := []model.Tag{
@@ -424,7 +424,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 						{Scope: common.String(common.TagScopeSubnetCRName), Tag: common.String(subnetName)},
 					}
 					vpcSubnetDelete := model.VpcSubnet{Id: &id2, Path: &path2, Tags: tagStale}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnetSkip, &vpcSubnetDelete,
 					}
 				})
@@ -437,7 +437,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, _ string) (ports []*model.VpcSubnetPort) {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return nil
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "DeletePortCount", func(_ *subnetport.SubnetPortService, _ string) {
@@ -452,7 +452,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 			name: "Subnet CR with finalizer delete failed",
 			req:  ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-subnet"}},
 			patches: func(r *SubnetReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					tags := []model.Tag{
@@ -468,7 +468,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 						{Scope: common.String(common.TagScopeSubnetCRName), Tag: common.String(subnetName)},
 					}
 					vpcSubnetDelete := model.VpcSubnet{Id: &id2, Path: &path2, Tags: tagStale}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnetSkip, &vpcSubnetDelete,
 					}
 				})
@@ -481,7 +481,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, _ string) (ports []*model.VpcSubnetPort) {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return errors.New("delete NSX Subnet failed")
 				})
 				return patches
@@ -513,7 +513,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 						{OrgID: "org-id", ProjectID: "project-id", VPCID: "vpc-id", ID: "fake-id"},
 					}
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, _ context.Context, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
 					return nil, errors.New("create or update failed")
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.VPCService), "IsDefaultNSXProject", func(_ *vpc.VPCService, orgID, projectID string) (bool, error) {
@@ -554,7 +554,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 						{OrgID: "org-id", ProjectID: "project-id", VPCID: "vpc-id", ID: "fake-id"},
 					}
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, _ context.Context, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
 					return nil, nil
 				})
 
@@ -623,7 +623,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 					}
 				})
 
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, _ context.Context, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
 					return nil, nil
 				})
 
@@ -750,7 +750,7 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 						{OrgID: "org-id", ProjectID: "project-id", VPCID: "vpc-id", ID: "fake-id"},
 					}
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, _ context.Context, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
 					return nil, nsxutil.NewNSXApiError(&model.ApiError{
 						ErrorCode:    pointy.Int64(508134),
 						ErrorMessage: pointy.String("Test error message"),
@@ -1164,7 +1164,7 @@ func patchSuccessfulReconcileSubnetWorkflow(r *SubnetReconciler, patches *gomonk
 	patches.ApplyMethod(reflect.TypeOf(r.VPCService), "IsDefaultNSXProject", func(_ *vpc.VPCService, orgID, projectID string) (bool, error) {
 		return false, nil
 	})
-	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, _ client.Object, _ common.VPCResourceInfo, _ []model.Tag) (subnet *model.VpcSubnet, err error) {
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "CreateOrUpdateSubnet", func(_ *subnet.SubnetService, _ context.Context, _ client.Object, _ common.VPCResourceInfo, _ []model.Tag) (subnet *model.VpcSubnet, err error) {
 		return &model.VpcSubnet{
 			Path: common.String("subnet-path"),
 		}, nil
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,6 +28,7 @@ import (
 	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetbinding"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
 
 var (
@@ -87,6 +89,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}()
 
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	bindingMapCR := &v1alpha1.SubnetConnectionBindingMap{}
 	if err := r.Client.Get(ctx, req.NamespacedName, bindingMapCR); err != nil {
@@ -143,13 +148,41 @@ func (r *Reconciler) CollectGarbage(ctx context.Context) error {
 	}
 	bindingMapIdSetInStore := r.SubnetBindingService.ListSubnetConnectionBindingMapCRUIDsInStore()
 
-	if err = r.SubnetBindingService.DeleteMultiSubnetConnectionBindingMapsByCRs(bindingMapIdSetInStore.Difference(bindingMapIdSetByCRs)); err != nil {
-		log.Error(err, "Failed to delete stale SubnetConnectionBindingMaps")
+	staleCRs := bindingMapIdSetInStore.Difference(bindingMapIdSetByCRs)
+	err = r.SubnetBindingService.DeleteMultiSubnetConnectionBindingMapsByCRs(staleCRs)
+	failedCRs := failedBindingMapCRs(staleCRs, err)
+	for uid := range staleCRs {
+		r.StatusUpdater.IncreaseDeleteTotal()
+		if failedCRs.Has(uid) {
+			r.StatusUpdater.IncreaseDeleteFailTotal()
+		} else {
+			r.StatusUpdater.IncreaseDeleteSuccessTotal()
+		}
+	}
+	if err != nil {
+		log.Error(err, "Failed to delete stale SubnetConnectionBindingMaps", "failedCRs", failedCRs.UnsortedList())
 		return err
 	}
 	return nil
 }
 
+// failedBindingMapCRs returns the CRs in staleCRs whose SubnetConnectionBindingMaps are not deleted with err. If NSX
+// rejects only some SubnetConnectionBindingMaps, the others are deleted so only the CRs of the rejected ones fail.
+func failedBindingMapCRs(staleCRs sets.Set[string], err error) sets.Set[string] {
+	if err == nil {
+		return sets.New[string]()
+	}
+	failedObjs, ok := servicecommon.BatchFailedObjects[*model.SubnetConnectionBindingMap](err)
+	if !ok {
+		return staleCRs
+	}
+	failedCRs := sets.New[string]()
+	for _, bindingMap := range failedObjs {
+		failedCRs.Insert(nsxutil.FindTag(bindingMap.Tags, servicecommon.TagScopeSubnetBindingCRUID))
+	}
+	return failedCRs
+}
+
 var PredicateFuncsForBindingMaps = predicate.Funcs{
 	UpdateFunc: func(e event.UpdateEvent) bool {
 		oldBindingMap := e.ObjectOld.(*v1alpha1.SubnetConnectionBindingMap)
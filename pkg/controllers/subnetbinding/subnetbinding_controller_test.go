@@ -273,6 +273,25 @@ func TestCollectGarbage(t *testing.T) {
 	}
 }
 
+func TestFailedBindingMapCRs(t *testing.T) {
+	staleCRs := sets.New[string]("uid-1", "uid-2", "uid-3")
+	assert.Empty(t, failedBindingMapCRs(staleCRs, nil))
+	assert.Equal(t, staleCRs, failedBindingMapCRs(staleCRs, fmt.Errorf("deletion failed")))
+
+	// only the CR of the SubnetConnectionBindingMap rejected by NSX fails
+	batchErr := &common.BatchUpdateError[*model.SubnetConnectionBindingMap]{
+		ResourceType: common.ResourceTypeSubnetConnectionBindingMap,
+		Failures: []common.BatchFailure[*model.SubnetConnectionBindingMap]{{
+			Object: &model.SubnetConnectionBindingMap{
+				Id:   common.String("binding-2"),
+				Tags: []model.Tag{{Scope: common.String(common.TagScopeSubnetBindingCRUID), Tag: common.String("uid-2")}},
+			},
+			Err: fmt.Errorf("invalid binding"),
+		}},
+	}
+	assert.Equal(t, sets.New[string]("uid-2"), failedBindingMapCRs(staleCRs, batchErr))
+}
+
 func TestValidateDependency(t *testing.T) {
 	name := "binding1"
 	namespace := "default"
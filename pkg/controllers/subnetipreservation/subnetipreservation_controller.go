@@ -138,6 +138,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		log.Info("Finished reconciling SubnetIPReservation", "SubnetIPReservation", req.NamespacedName, "duration(ms)", time.Since(startTime).Milliseconds())
 	}()
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	// SubnetIPReservation service can only be supported from NSX 9.1.0 onwards,
 	// So need to check NSX version before starting SubnetIPReservation reconcile
@@ -86,11 +86,19 @@ func (r *SubnetPortReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}()
 
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	subnetPort := &v1alpha1.SubnetPort{}
 	if err := r.Client.Get(ctx, req.NamespacedName, subnetPort); err != nil {
 		if apierrors.IsNotFound(err) {
 			if err := r.deleteSubnetPortByName(ctx, req.Namespace, req.Name); err != nil {
+				var planErr *servicecommon.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(nil, planErr)
+					return common.ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 				return common.ResultRequeue, err
 			}
@@ -100,6 +108,7 @@ func (r *SubnetPortReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		log.Error(err, "Unable to fetch SubnetPort CR", "SubnetPort", req.NamespacedName)
 		return common.ResultRequeue, err
 	}
+	ctx = common.WithPlanMode(ctx, subnetPort)
 
 	if subnetPort.ObjectMeta.DeletionTimestamp.IsZero() {
 		r.StatusUpdater.IncreaseUpdateTotal()
@@ -143,7 +152,7 @@ func (r *SubnetPortReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				log.Error(searchErr, "failed to use the SubnetPort CR to search VpcSubnetPort", "CR UID", subnetPort.GetUID())
 				err = errors.Join(err, searchErr)
 			} else if vpcSubnetPort != nil {
-				if e := r.SubnetPortService.DeleteSubnetPort(vpcSubnetPort); e != nil {
+				if e := r.SubnetPortService.DeleteSubnetPort(ctx, vpcSubnetPort); e != nil {
 					log.Error(e, "Failed to delete the stale SubnetPort", "subnetPort.UID", subnetPort.UID)
 					err = errors.Join(err, e)
 				}
@@ -166,13 +175,21 @@ func (r *SubnetPortReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			r.StatusUpdater.UpdateFail(ctx, subnetPort, err, "Failed to create NSX IPAddressAllocation for AddressBinding restore", setSubnetPortReadyStatusFalse, r.SubnetPortService, r.restoreMode)
 			return common.ResultRequeue, err
 		}
-		nsxSubnetPortState, enableDHCP, err := r.SubnetPortService.CreateOrUpdateSubnetPort(subnetPort, nsxSubnet, "", labels, isVmSubnetPort, r.restoreMode)
+		nsxSubnetPortState, enableDHCP, err := r.SubnetPortService.CreateOrUpdateSubnetPort(ctx, subnetPort, nsxSubnet, "", labels, isVmSubnetPort, r.restoreMode)
 		if err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(subnetPort, planErr)
+				return common.ResultNormal, nil
+			}
+			if nsxutil.IsRealizePendingError(err) {
+				return common.ResultRequeueAfterRealization(), nil
+			}
 			r.StatusUpdater.UpdateFail(ctx, subnetPort, err, "", setSubnetPortReadyStatusFalse, r.SubnetPortService, r.restoreMode)
 			if nsxutil.IsRealizeStateError(err) {
 				return common.ResultRequeueAfter60sec, nil
 			}
-			return common.ResultRequeue, err
+			return common.ResultForError(err, common.ResultRequeue)
 		}
 		if nsxSubnetPortState != nil {
 			if nsxSubnetPortState.ExternalAddressBinding == nil && ab == nil {
@@ -269,7 +286,12 @@ func (r *SubnetPortReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return common.ResultRequeue, err
 		}
 		if vpcSubnetPort != nil {
-			if err = r.SubnetPortService.DeleteSubnetPort(vpcSubnetPort); err != nil {
+			if err = r.SubnetPortService.DeleteSubnetPort(ctx, vpcSubnetPort); err != nil {
+				var planErr *servicecommon.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(subnetPort, planErr)
+					return common.ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 				setAddressBindingStatusBySubnetPort(r.Client, ctx, subnetPort, r.SubnetPortService, metav1.Now(), subnetPortRealizationError)
 				return common.ResultRequeue, err
@@ -384,7 +406,7 @@ func (r *SubnetPortReconciler) deleteSubnetPortByName(ctx context.Context, ns st
 		if nsxSubnetPort.ExternalAddressBinding != nil && nsxSubnetPort.ExternalAddressBinding.ExternalIpAddress != nil && *nsxSubnetPort.ExternalAddressBinding.ExternalIpAddress != "" {
 			externalIpAddress = nsxSubnetPort.ExternalAddressBinding.ExternalIpAddress
 		}
-		if err := r.SubnetPortService.DeleteSubnetPort(nsxSubnetPort); err != nil {
+		if err := r.SubnetPortService.DeleteSubnetPort(ctx, nsxSubnetPort); err != nil {
 			if externalIpAddress != nil {
 				r.collectAddressBindingGarbage(ctx, &ns, externalIpAddress)
 			}
@@ -637,7 +659,7 @@ func (r *SubnetPortReconciler) CollectGarbage(ctx context.Context) error {
 	for elem := range diffSet {
 		log.Debug("GC collected SubnetPort CR", "UID", elem)
 		r.StatusUpdater.IncreaseDeleteTotal()
-		err = r.SubnetPortService.DeleteSubnetPortById(elem)
+		err = r.SubnetPortService.DeleteSubnetPortById(ctx, elem)
 		if err != nil {
 			errList = append(errList, err)
 			r.StatusUpdater.IncreaseDeleteFailTotal()
@@ -717,7 +739,7 @@ func setSubnetPortReadyStatusFalse(client client.Client, ctx context.Context, ob
 				"error occurred while processing the SubnetPort CR. Error: %v",
 				err,
 			),
-			Reason:             "SubnetPortNotReady",
+			Reason:             common.ConditionReason(err, "SubnetPortNotReady"),
 			LastTransitionTime: transitionTime,
 		},
 	}
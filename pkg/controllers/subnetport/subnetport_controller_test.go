@@ -245,7 +245,7 @@ func TestSubnetPortReconciler_Reconcile(t *testing.T) {
 		defer patchesIsSharedSubnetPath.Reset()
 		err := errors.New("CreateOrUpdateSubnetPort failed")
 		patchesCreateOrUpdateSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-			func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+			func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 				return nil, false, err
 			})
 		defer patchesCreateOrUpdateSubnetPort.Reset()
@@ -294,7 +294,7 @@ func TestSubnetPortReconciler_Reconcile(t *testing.T) {
 		defer patchesIsSharedSubnetPath.Reset()
 		err := util.NewRealizeStateError("CreateOrUpdateSubnetPort failed", 0)
 		patchesCreateOrUpdateSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-			func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+			func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 				return nil, false, err
 			})
 		defer patchesCreateOrUpdateSubnetPort.Reset()
@@ -341,7 +341,7 @@ func TestSubnetPortReconciler_Reconcile(t *testing.T) {
 		})
 		defer patchesIsSharedSubnetPath.Reset()
 		patchesCreateOrUpdateSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-			func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+			func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 				return portState, false, nil
 			})
 		defer patchesCreateOrUpdateSubnetPort.Reset()
@@ -391,7 +391,7 @@ func TestSubnetPortReconciler_Reconcile(t *testing.T) {
 			})
 		defer patchesGetSubnetByPath.Reset()
 		patchesCreateOrUpdateSubnetPort.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-			func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+			func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 				return portState2, false, nil
 			})
 		k8sClient.EXPECT().Status().Return(fakewriter)
@@ -417,12 +417,12 @@ func TestSubnetPortReconciler_Reconcile(t *testing.T) {
 			})
 		defer patchesGetByUID.Reset()
 		patchesDeleteSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).DeleteSubnetPort,
-			func(s *subnetport.SubnetPortService, port *model.VpcSubnetPort) error {
+			func(s *subnetport.SubnetPortService, _ context.Context, port *model.VpcSubnetPort) error {
 				return err
 			})
 		defer patchesDeleteSubnetPort.Reset()
 		patchesCreateOrUpdateSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-			func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+			func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 				assert.FailNow(t, "should not be called")
 				return nil, false, nil
 			})
@@ -448,7 +448,7 @@ func TestSubnetPortReconciler_Reconcile(t *testing.T) {
 			})
 		defer patchesGetByUID.Reset()
 		patchesDeleteSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).DeleteSubnetPort,
-			func(s *subnetport.SubnetPortService, port *model.VpcSubnetPort) error {
+			func(s *subnetport.SubnetPortService, _ context.Context, port *model.VpcSubnetPort) error {
 				return nil
 			})
 		defer patchesDeleteSubnetPort.Reset()
@@ -530,7 +530,7 @@ func TestSubnetPortReconciler_Reconcile(t *testing.T) {
 		})
 		defer patchesIsSharedSubnetPath.Reset()
 		patchesCreateOrUpdateSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-			func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+			func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 				return portState, false, nil
 			})
 		defer patchesCreateOrUpdateSubnetPort.Reset()
@@ -642,7 +642,7 @@ func TestSubnetPortReconciler_Reconcile(t *testing.T) {
 		})
 		defer patchesIsSharedSubnetPath.Reset()
 		patchesCreateOrUpdateSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-			func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+			func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 				return dhcpPortState, true, nil
 			})
 		defer patchesCreateOrUpdateSubnetPort.Reset()
@@ -722,7 +722,7 @@ func TestSubnetPortReconciler_Reconcile(t *testing.T) {
 			})
 		defer patchesGetSubnetByPath.Reset()
 		patchesCreateOrUpdateSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).CreateOrUpdateSubnetPort,
-			func(s *subnetport.SubnetPortService, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+			func(s *subnetport.SubnetPortService, _ context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 				return portState, false, nil
 			})
 		defer patchesCreateOrUpdateSubnetPort.Reset()
@@ -781,7 +781,7 @@ func TestSubnetPortReconciler_GarbageCollector(t *testing.T) {
 		})
 	defer patchesGetVpcSubnetPortByUID.Reset()
 	patchesDeleteSubnetPortById := gomonkey.ApplyFunc((*subnetport.SubnetPortService).DeleteSubnetPortById,
-		func(s *subnetport.SubnetPortService, uid string) error {
+		func(s *subnetport.SubnetPortService, _ context.Context, uid string) error {
 			return nil
 		})
 	defer patchesDeleteSubnetPortById.Reset()
@@ -1129,9 +1129,11 @@ func TestSubnetPortReconciler_deleteSubnetPortByName(t *testing.T) {
 	subnetportName1 := "subnetport-1"
 	subnetportName2 := "subnetport-2"
 	ns := "ns"
+	subnetPath := "/orgs/default/projects/default/vpcs/vpc-1/subnets/subnet-1"
 	nameScope := "nsx-op/subnetport_name"
 	sp1 := &model.VpcSubnetPort{
-		Id: &subnetportId1,
+		Id:         &subnetportId1,
+		ParentPath: &subnetPath,
 		Tags: []model.Tag{
 			{
 				Scope: &namespaceScope,
@@ -1144,7 +1146,8 @@ func TestSubnetPortReconciler_deleteSubnetPortByName(t *testing.T) {
 		},
 	}
 	sp2 := &model.VpcSubnetPort{
-		Id: &subnetportId2,
+		Id:         &subnetportId2,
+		ParentPath: &subnetPath,
 		Tags: []model.Tag{
 			{
 				Scope: &namespaceScope,
@@ -1157,17 +1160,13 @@ func TestSubnetPortReconciler_deleteSubnetPortByName(t *testing.T) {
 		},
 	}
 	r := &SubnetPortReconciler{
-		SubnetPortService: &subnetport.SubnetPortService{},
+		SubnetPortService: &subnetport.SubnetPortService{
+			SubnetPortStore: subnetport.SetupStore(),
+		},
 	}
-	patchesGetByIndex := gomonkey.ApplyFunc((*subnetport.SubnetPortStore).GetByIndex,
-		func(s *subnetport.SubnetPortStore, key string, value string) []*model.VpcSubnetPort {
-			subnetPorts := make([]*model.VpcSubnetPort, 0)
-			subnetPorts = append(subnetPorts, sp1, sp2)
-			return subnetPorts
-		})
-	defer patchesGetByIndex.Reset()
+	assert.NoError(t, r.SubnetPortService.SubnetPortStore.Apply([]*model.VpcSubnetPort{sp1, sp2}))
 	patchesDeleteSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).DeleteSubnetPort,
-		func(s *subnetport.SubnetPortService, sp *model.VpcSubnetPort) error {
+		func(s *subnetport.SubnetPortService, _ context.Context, sp *model.VpcSubnetPort) error {
 			assert.Equal(t, sp2, sp)
 			return nil
 		})
@@ -1192,25 +1191,21 @@ func TestSubnetPortReconciler_setReadyStatusTrue(t *testing.T) {
 				},
 			},
 		},
-		SubnetPortStore: &subnetport.SubnetPortStore{},
+		SubnetPortStore: subnetport.SetupStore(),
 	}
-
-	patchesGetByKey := gomonkey.ApplyFunc((*subnetport.SubnetPortStore).GetByKey,
-		func(s *subnetport.SubnetPortStore, key string) *model.VpcSubnetPort {
-			return &model.VpcSubnetPort{
-				Id: &subnetportId1,
-				Tags: []model.Tag{
-					{
-						Scope: &subnetportNamespacedNamescope,
-						Tag:   &subnetportNamespacedName,
-					},
-				},
-				ExternalAddressBinding: &model.ExternalAddressBinding{
-					ExternalIpAddress: &externalIpAddress,
-				},
-			}
-		})
-	defer patchesGetByKey.Reset()
+	assert.NoError(t, subnetPortService.SubnetPortStore.Apply(&model.VpcSubnetPort{
+		Id:         &subnetportId1,
+		ParentPath: servicecommon.String("/orgs/default/projects/default/vpcs/vpc-1/subnets/subnet-1"),
+		Tags: []model.Tag{
+			{
+				Scope: &subnetportNamespacedNamescope,
+				Tag:   &subnetportNamespacedName,
+			},
+		},
+		ExternalAddressBinding: &model.ExternalAddressBinding{
+			ExternalIpAddress: &externalIpAddress,
+		},
+	}))
 
 	patchesGetAddressBindingBySubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).GetAddressBindingBySubnetPort,
 		func(s *subnetport.SubnetPortService, sp *v1alpha1.SubnetPort) *v1alpha1.AddressBinding {
@@ -1780,27 +1775,21 @@ func TestSubnetPortReconciler_RestoreReconcile(t *testing.T) {
 	r := &SubnetPortReconciler{
 		Client: k8sClient,
 		SubnetPortService: &subnetport.SubnetPortService{
-			SubnetPortStore: &subnetport.SubnetPortStore{},
+			SubnetPortStore: subnetport.SetupStore(),
 		},
 	}
-	patches := gomonkey.ApplyFunc((*servicecommon.ResourceStore).ListIndexFuncValues, func(s *servicecommon.ResourceStore, key string) sets.Set[string] {
-		return sets.New[string]("port-1", "port-3", "port-4")
-	})
-	defer patches.Reset()
-
-	patches = gomonkey.ApplyFunc((*subnetport.SubnetPortStore).GetByIndex, func(s *subnetport.SubnetPortStore, index string, value string) []*model.VpcSubnetPort {
-		subnetport := &model.VpcSubnetPort{
-			Attachment: &model.PortAttachment{},
-		}
-		switch value {
-		case "port-3":
-			subnetport.Attachment.Id = servicecommon.String("attachment-id-3")
-		case "port-4":
-			subnetport.Attachment.Id = servicecommon.String("attachment-id-4-update")
-		}
-		return []*model.VpcSubnetPort{subnetport}
-	})
-	defer patches.Reset()
+	for uid, attachmentID := range map[string]*string{
+		"port-1": nil,
+		"port-3": servicecommon.String("attachment-id-3"),
+		"port-4": servicecommon.String("attachment-id-4-update"),
+	} {
+		assert.NoError(t, r.SubnetPortService.SubnetPortStore.Apply(&model.VpcSubnetPort{
+			Id:         servicecommon.String(uid),
+			ParentPath: servicecommon.String("/orgs/default/projects/default/vpcs/vpc-1/subnets/subnet-1"),
+			Tags:       []model.Tag{{Scope: servicecommon.String(servicecommon.TagScopeSubnetPortCRUID), Tag: servicecommon.String(uid)}},
+			Attachment: &model.PortAttachment{Id: attachmentID},
+		}))
+	}
 
 	k8sClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).Do(func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
 		subnetportList := list.(*v1alpha1.SubnetPortList)
@@ -1859,10 +1848,11 @@ func TestSubnetPortReconciler_RestoreReconcile(t *testing.T) {
 	})
 
 	reqList := []reconcile.Request{}
-	patches.ApplyFunc((*SubnetPortReconciler).Reconcile, func(r *SubnetPortReconciler, ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	patches := gomonkey.ApplyFunc((*SubnetPortReconciler).Reconcile, func(r *SubnetPortReconciler, ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
 		reqList = append(reqList, req)
 		return common.ResultNormal, nil
 	})
+	defer patches.Reset()
 	err := r.RestoreReconcile()
 	assert.Nil(t, err)
 	assert.Equal(t, reconcile.Request{NamespacedName: types.NamespacedName{Name: "port-2", Namespace: "ns-1"}}, reqList[0])
@@ -111,10 +111,18 @@ func (r *SubnetSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	subnetsetCR := &v1alpha1.SubnetSet{}
 	r.StatusUpdater.IncreaseSyncTotal()
+	defer r.StatusUpdater.ObserveReconcileDuration(startTime)
+	ctx, span := r.StatusUpdater.StartReconcileSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	if err := r.Client.Get(ctx, req.NamespacedName, subnetsetCR); err != nil {
 		if apierrors.IsNotFound(err) {
 			if err := r.deleteSubnetBySubnetSetName(ctx, req.Name, req.Namespace); err != nil {
+				var planErr *servicecommon.PlanError
+				if errors.As(err, &planErr) {
+					r.StatusUpdater.UpdatePlanned(nil, planErr)
+					return ResultNormal, nil
+				}
 				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 				return ResultRequeue, err
 			}
@@ -124,6 +132,7 @@ func (r *SubnetSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		log.Error(err, "Unable to fetch SubnetSet CR", "SubnetSet", req.NamespacedName)
 		return ResultRequeue, err
 	}
+	ctx = common.WithPlanMode(ctx, subnetsetCR)
 
 	if subnetsetCR.Spec.SubnetNames != nil {
 		// For SubnetSet with pre-created Subnet, update the Status from Subnet CR
@@ -172,8 +181,13 @@ func (r *SubnetSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			return ResultRequeue, err
 		}
 
-		err := r.deleteSubnetForSubnetSet(*subnetsetCR, false, false)
+		err := r.deleteSubnetForSubnetSet(ctx, *subnetsetCR, false, false)
 		if err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(subnetsetCR, planErr)
+				return ResultNormal, nil
+			}
 			r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
 			return ResultRequeue, err
 		}
@@ -245,9 +259,14 @@ func (r *SubnetSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			r.StatusUpdater.UpdateSuccess(ctx, subnetsetCR, setSubnetSetReadyStatusTrue)
 			return ResultNormal, nil
 		}
-		if err := r.SubnetService.UpdateSubnetSet(subnetsetCR.Namespace, nsxSubnets, tags, string(subnetsetCR.Spec.SubnetDHCPConfig.Mode)); err != nil {
+		if err := r.SubnetService.UpdateSubnetSet(ctx, subnetsetCR.Namespace, nsxSubnets, tags, string(subnetsetCR.Spec.SubnetDHCPConfig.Mode)); err != nil {
+			var planErr *servicecommon.PlanError
+			if errors.As(err, &planErr) {
+				r.StatusUpdater.UpdatePlanned(subnetsetCR, planErr)
+				return ResultNormal, nil
+			}
 			r.StatusUpdater.UpdateFail(ctx, subnetsetCR, err, "Failed to update SubnetSet", setSubnetSetReadyStatusFalse)
-			return ResultNormal, err
+			return common.ResultForError(err, ResultNormal)
 		}
 	}
 	r.StatusUpdater.UpdateSuccess(ctx, subnetsetCR, setSubnetSetReadyStatusTrue)
@@ -305,7 +324,7 @@ func setSubnetSetReadyStatusFalse(client client.Client, ctx context.Context, obj
 			Type:               v1alpha1.Ready,
 			Status:             v1.ConditionFalse,
 			Message:            "SubnetSet CR could not be created/updated",
-			Reason:             "SubnetSetNotReady",
+			Reason:             common.ConditionReason(err, "SubnetSetNotReady"),
 			LastTransitionTime: transitionTime,
 		},
 	}
@@ -433,7 +452,7 @@ func (r *SubnetSetReconciler) CollectGarbage(ctx context.Context) error {
 			continue
 		}
 		crdSubnetSetIDsSet.Insert(string(subnetSet.UID))
-		if err := r.deleteSubnetForSubnetSet(subnetSet, true, true); err != nil {
+		if err := r.deleteSubnetForSubnetSet(ctx, subnetSet, true, true); err != nil {
 			errList = append(errList, err)
 			r.StatusUpdater.IncreaseDeleteFailTotal()
 		} else {
@@ -446,7 +465,7 @@ func (r *SubnetSetReconciler) CollectGarbage(ctx context.Context) error {
 	for subnetSetID := range subnetSetIDsToDelete {
 		nsxSubnets := r.SubnetService.ListSubnetCreatedBySubnetSet(subnetSetID)
 		log.Info("SubnetSet garbage collection, cleaning stale Subnets for SubnetSet", "Count", len(nsxSubnets))
-		if _, err := r.deleteSubnets(nsxSubnets, true); err != nil {
+		if _, err := r.deleteSubnets(ctx, nsxSubnets, true); err != nil {
 			errList = append(errList, err)
 			log.Error(err, "SubnetSet garbage collection, failed to delete NSX subnet", "SubnetSetUID", subnetSetID)
 			r.StatusUpdater.IncreaseDeleteFailTotal()
@@ -473,14 +492,14 @@ func (r *SubnetSetReconciler) CollectGarbage(ctx context.Context) error {
 func (r *SubnetSetReconciler) deleteSubnetBySubnetSetName(ctx context.Context, subnetSetName, ns string) error {
 	nsxSubnets := r.SubnetService.ListSubnetBySubnetSetName(ns, subnetSetName)
 	// We also actively delete the SubnetConnectionBindingMaps associated with the empty NSX Subnet that has no SubnetPort.
-	hasStaleSubnetPort, err := r.deleteSubnets(nsxSubnets, true)
+	hasStaleSubnetPort, err := r.deleteSubnets(ctx, nsxSubnets, true)
 	if err != nil || hasStaleSubnetPort {
 		return fmt.Errorf("failed to delete stale Subnets, error: %v, hasStaleSubnetPort: %t", err, hasStaleSubnetPort)
 	}
 	return nil
 }
 
-func (r *SubnetSetReconciler) deleteSubnetForSubnetSet(subnetSet v1alpha1.SubnetSet, updateStatus, ignoreStaleSubnetPort bool) error {
+func (r *SubnetSetReconciler) deleteSubnetForSubnetSet(ctx context.Context, subnetSet v1alpha1.SubnetSet, updateStatus, ignoreStaleSubnetPort bool) error {
 	subnetSetLock := common.WLockSubnetSet(subnetSet.GetUID())
 	nsxSubnets := r.SubnetService.SubnetStore.GetByIndex(servicecommon.TagScopeSubnetSetCRUID, string(subnetSet.GetUID()))
 
@@ -505,7 +524,7 @@ func (r *SubnetSetReconciler) deleteSubnetForSubnetSet(subnetSet v1alpha1.Subnet
 	// corresponding NSX Subnet. This happens in the GC case to scale-in the NSX Subnet if no SubnetPort exists.
 	// For SubnetSet CR deletion event, we don't delete the existing SubnetConnectionBindingMaps but let the
 	// SubnetConnectionBindingMap controller do it after the binding CR is removed.
-	hasStaleSubnetPort, deleteErr := r.deleteSubnets(nsxSubnets, ignoreStaleSubnetPort)
+	hasStaleSubnetPort, deleteErr := r.deleteSubnets(ctx, nsxSubnets, ignoreStaleSubnetPort)
 	common.WUnlockSubnetSet(subnetSet.GetUID(), subnetSetLock)
 	// Skip SubnetSet status update for restore case, as we need the stale status to restore the NSX Subnet
 	if updateStatus && !r.restoreMode {
@@ -525,11 +544,12 @@ func (r *SubnetSetReconciler) deleteSubnetForSubnetSet(subnetSet v1alpha1.Subnet
 // deleteSubnets deletes all the specified NSX Subnets.
 // If any of the Subnets have stale SubnetPorts, they are skipped. The final result returns true.
 // If there is an error while deleting any NSX Subnet, it is skipped, and the final result returns an error.
-func (r *SubnetSetReconciler) deleteSubnets(nsxSubnets []*model.VpcSubnet, deleteBindingMaps bool) (hasStalePort bool, err error) {
+func (r *SubnetSetReconciler) deleteSubnets(ctx context.Context, nsxSubnets []*model.VpcSubnet, deleteBindingMaps bool) (hasStalePort bool, err error) {
 	if len(nsxSubnets) == 0 {
 		return
 	}
 	var deleteErrs []error
+	plan := &servicecommon.Plan{}
 	for _, nsxSubnet := range nsxSubnets {
 
 		if !r.SubnetPortService.IsEmptySubnet(*nsxSubnet.Path) {
@@ -547,7 +567,10 @@ func (r *SubnetSetReconciler) deleteSubnets(nsxSubnets []*model.VpcSubnet, delet
 			}
 		}
 
-		if err := r.SubnetService.DeleteSubnet(*nsxSubnet); err != nil {
+		if err := r.SubnetService.DeleteSubnet(ctx, *nsxSubnet); err != nil {
+			if plan.AddErr(err) {
+				continue
+			}
 			deleteErr := fmt.Errorf("failed to delete NSX Subnet/%s: %+v", *nsxSubnet.Id, err)
 			deleteErrs = append(deleteErrs, deleteErr)
 			log.Error(deleteErr, "Skipping to next Subnet")
@@ -561,6 +584,10 @@ func (r *SubnetSetReconciler) deleteSubnets(nsxSubnets []*model.VpcSubnet, delet
 		err = fmt.Errorf("multiple errors occurred while deleting Subnets: %v", deleteErrs)
 		return
 	}
+	if !plan.Empty() {
+		err = plan.Err()
+		return
+	}
 	log.Info("Successfully deleted all specified NSX Subnets", "subnetCount", len(nsxSubnets))
 	return
 }
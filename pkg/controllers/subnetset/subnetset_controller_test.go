@@ -177,11 +177,11 @@ func TestReconcile(t *testing.T) {
 				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.VPCService), "GetVPCNetworkConfigByNamespace", func(_ *vpc.VPCService, ns string) (*v1alpha1.VPCNetworkConfiguration, error) {
 					return vpcnetworkConfig, nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					vpcSubnet := model.VpcSubnet{Id: &id1, Path: &path}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnet,
 					}
 				})
@@ -213,11 +213,11 @@ func TestReconcile(t *testing.T) {
 				})
 
 				tags := []model.Tag{{Scope: common.String(common.TagScopeVMNamespace), Tag: common.String(ns)}}
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					vpcSubnet := model.VpcSubnet{Id: &id1, Path: &path, Tags: tags}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnet,
 					}
 				})
@@ -244,11 +244,11 @@ func TestReconcile(t *testing.T) {
 					return []*v1alpha1.SubnetConnectionBindingMap{}
 				})
 
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					vpcSubnet := model.VpcSubnet{Id: &id1, Path: &path}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnet,
 					}
 				})
@@ -280,7 +280,7 @@ func TestReconcile(t *testing.T) {
 				patches.ApplyPrivateMethod(reflect.TypeOf(r), "getSubnetBindingCRsBySubnetSet", func(_ *SubnetSetReconciler, _ context.Context, _ *v1alpha1.SubnetSet) []v1alpha1.SubnetConnectionBindingMap {
 					return []v1alpha1.SubnetConnectionBindingMap{}
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "/orgs/default/projects/nsx_operator_e2e_test/vpcs/subnet-e2e_8f36f7fc-90cd-4e65-a816-daf3ecd6a0f9/subnets/fake-path"
 					basicTags1 := util.BuildBasicTags("fakeClusterName", subnetSet, "")
@@ -298,9 +298,9 @@ func TestReconcile(t *testing.T) {
 					vpcSubnet1 := model.VpcSubnet{Id: &id1, Path: &path}
 					vpcSubnet2 := model.VpcSubnet{Id: &id1, Path: &path, Tags: basicTags1}
 					vpcSubnet3 := model.VpcSubnet{Id: &id1, Path: &path, Tags: basicTags2}
-					return []*model.VpcSubnet{&vpcSubnet1, &vpcSubnet2, &vpcSubnet3}
+					return []interface{}{&vpcSubnet1, &vpcSubnet2, &vpcSubnet3}
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "UpdateSubnetSet", func(_ *subnet.SubnetService, ns string, vpcSubnets []*model.VpcSubnet, tags []model.Tag, dhcpMode string) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "UpdateSubnetSet", func(_ *subnet.SubnetService, _ context.Context, ns string, vpcSubnets []*model.VpcSubnet, tags []model.Tag, dhcpMode string) error {
 					return nil
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.VPCService), "GetNetworkStackFromNC", func(_ *vpc.VPCService, config *v1alpha1.VPCNetworkConfiguration) (v1alpha1.NetworkStackType, error) {
@@ -322,7 +322,7 @@ func TestReconcile(t *testing.T) {
 				patches.ApplyPrivateMethod(reflect.TypeOf(r), "getSubnetBindingCRsBySubnetSet", func(_ *SubnetSetReconciler, _ context.Context, _ *v1alpha1.SubnetSet) []v1alpha1.SubnetConnectionBindingMap {
 					return []v1alpha1.SubnetConnectionBindingMap{}
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "/orgs/default/projects/nsx_operator_e2e_test/vpcs/subnet-e2e_8f36f7fc-90cd-4e65-a816-daf3ecd6a0f9/subnets/fake-path"
 					basicTags1 := util.BuildBasicTags("fakeClusterName", subnetSet, "")
@@ -340,7 +340,7 @@ func TestReconcile(t *testing.T) {
 					vpcSubnet1 := model.VpcSubnet{Id: &id1, Path: &path}
 					vpcSubnet2 := model.VpcSubnet{Id: &id1, Path: &path, Tags: basicTags1}
 					vpcSubnet3 := model.VpcSubnet{Id: &id1, Path: &path, Tags: basicTags2}
-					return []*model.VpcSubnet{&vpcSubnet1, &vpcSubnet2, &vpcSubnet3}
+					return []interface{}{&vpcSubnet1, &vpcSubnet2, &vpcSubnet3}
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.VPCService), "ListVPCInfo", func(_ *vpc.VPCService, ns string) []common.VPCResourceInfo {
 					return []common.VPCResourceInfo{{}}
@@ -348,7 +348,7 @@ func TestReconcile(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "RestoreSubnetSet", func(_ *subnet.SubnetService, obj *v1alpha1.SubnetSet, vpcInfo common.VPCResourceInfo, tags []model.Tag) error {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "UpdateSubnetSet", func(_ *subnet.SubnetService, ns string, vpcSubnets []*model.VpcSubnet, tags []model.Tag, dhcpMode string) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "UpdateSubnetSet", func(_ *subnet.SubnetService, _ context.Context, ns string, vpcSubnets []*model.VpcSubnet, tags []model.Tag, dhcpMode string) error {
 					return nil
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.VPCService), "GetVPCNetworkConfigByNamespace", func(_ *vpc.VPCService, ns string) (*v1alpha1.VPCNetworkConfiguration, error) {
@@ -570,8 +570,8 @@ func TestReconcileWithSubnetConnectionBindingMaps(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.Client), "Update", func(_ client.Client, _ context.Context, obj client.Object, opts ...client.UpdateOption) error {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, _ string, _ string) []*model.VpcSubnet {
-					return []*model.VpcSubnet{}
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, _ string, _ string) []interface{} {
+					return []interface{}{}
 				})
 				return patches
 			},
@@ -606,8 +606,8 @@ func TestReconcileWithSubnetConnectionBindingMaps(t *testing.T) {
 					assert.FailNow(t, "Should not update SubnetSet CR finalizer")
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, _ string, _ string) []*model.VpcSubnet {
-					return []*model.VpcSubnet{}
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, _ string, _ string) []interface{} {
+					return []interface{}{}
 				})
 				patches.ApplyFunc(setSubnetSetReadyStatusTrue, func(_ client.Client, _ context.Context, _ client.Object, _ metav1.Time, _ ...interface{}) {
 				})
@@ -624,8 +624,8 @@ func TestReconcileWithSubnetConnectionBindingMaps(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.Client), "Update", func(_ client.Client, _ context.Context, obj client.Object, opts ...client.UpdateOption) error {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, _ string, _ string) []*model.VpcSubnet {
-					return []*model.VpcSubnet{}
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, _ string, _ string) []interface{} {
+					return []interface{}{}
 				})
 				return patches
 			},
@@ -660,8 +660,8 @@ func TestReconcileWithSubnetConnectionBindingMaps(t *testing.T) {
 					assert.FailNow(t, "Should not update SubnetSet CR finalizer")
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, _ string, _ string) []*model.VpcSubnet {
-					return []*model.VpcSubnet{}
+				patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, _ string, _ string) []interface{} {
+					return []interface{}{}
 				})
 				patches.ApplyFunc(setSubnetSetReadyStatusTrue, func(_ client.Client, _ context.Context, _ client.Object, _ metav1.Time, _ ...interface{}) {
 				})
@@ -728,7 +728,7 @@ func TestReconcile_DeleteSubnetSet(t *testing.T) {
 				Status:     v1alpha1.SubnetSetStatus{},
 			},
 			patches: func(r *SubnetSetReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					tags := []model.Tag{
@@ -744,7 +744,7 @@ func TestReconcile_DeleteSubnetSet(t *testing.T) {
 						{Scope: common.String(common.TagScopeSubnetSetCRName), Tag: common.String(subnetSetName)},
 					}
 					vpcSubnetDelete := model.VpcSubnet{Id: &id2, Path: &path2, Tags: tagStale}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnetSkip, &vpcSubnetDelete,
 					}
 				})
@@ -756,7 +756,7 @@ func TestReconcile_DeleteSubnetSet(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, _ string) (ports []*model.VpcSubnetPort) {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return nil
 				})
 				return patches
@@ -767,7 +767,7 @@ func TestReconcile_DeleteSubnetSet(t *testing.T) {
 			name:         "Delete failed with stale SubnetPort and requeue",
 			expectErrStr: "hasStaleSubnetPort: true",
 			patches: func(r *SubnetSetReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					tags := []model.Tag{
@@ -783,7 +783,7 @@ func TestReconcile_DeleteSubnetSet(t *testing.T) {
 						{Scope: common.String(common.TagScopeSubnetSetCRName), Tag: common.String(subnetSetName)},
 					}
 					vpcSubnetDelete := model.VpcSubnet{Id: &id2, Path: &path2, Tags: tagStale}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnetSkip, &vpcSubnetDelete,
 					}
 				})
@@ -795,7 +795,7 @@ func TestReconcile_DeleteSubnetSet(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "IsEmptySubnet", func(_ *subnetport.SubnetPortService, _ string) bool {
 					return false
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return nil
 				})
 				return patches
@@ -806,7 +806,7 @@ func TestReconcile_DeleteSubnetSet(t *testing.T) {
 			name:         "Delete NSX Subnet failed and requeue",
 			expectErrStr: "multiple errors occurred while deleting Subnets",
 			patches: func(r *SubnetSetReconciler) *gomonkey.Patches {
-				patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 					id1 := "fake-id"
 					path := "fake-path"
 					tags := []model.Tag{
@@ -822,7 +822,7 @@ func TestReconcile_DeleteSubnetSet(t *testing.T) {
 						{Scope: common.String(common.TagScopeSubnetSetCRName), Tag: common.String(subnetSetName)},
 					}
 					vpcSubnetDelete := model.VpcSubnet{Id: &id2, Path: &path2, Tags: tagStale}
-					return []*model.VpcSubnet{
+					return []interface{}{
 						&vpcSubnetSkip, &vpcSubnetDelete,
 					}
 				})
@@ -834,7 +834,7 @@ func TestReconcile_DeleteSubnetSet(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, _ string) (ports []*model.VpcSubnetPort) {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 					return errors.New("delete NSX Subnet failed")
 				})
 				return patches
@@ -883,11 +883,11 @@ func TestReconcile_DeleteSubnetSet_WithFinalizer(t *testing.T) {
 
 	r := createFakeSubnetSetReconciler([]client.Object{subnetset})
 
-	patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 		id1 := "fake-id"
 		path := "/orgs/default/projects/nsx_operator_e2e_test/vpcs/subnet-e2e_8f36f7fc-90cd-4e65-a816-daf3ecd6a0f9/subnets/" + id1
 		vpcSubnet := model.VpcSubnet{Id: &id1, Path: &path}
-		return []*model.VpcSubnet{
+		return []interface{}{
 			&vpcSubnet,
 		}
 	})
@@ -906,7 +906,7 @@ func TestReconcile_DeleteSubnetSet_WithFinalizer(t *testing.T) {
 		return nil
 	})
 
-	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 		return nil
 	})
 
@@ -978,11 +978,11 @@ func TestSubnetSetReconciler_CollectGarbage(t *testing.T) {
 	})
 	defer patches.Reset()
 
-	patches.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+	patches.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
 		id1 := "fake-id"
 		path := "/orgs/default/projects/nsx_operator_e2e_test/vpcs/subnet-e2e_8f36f7fc-90cd-4e65-a816-daf3ecd6a0f9/subnets/fake-path"
 		vpcSubnet1 := model.VpcSubnet{Id: &id1, Path: &path}
-		return []*model.VpcSubnet{
+		return []interface{}{
 			&vpcSubnet1,
 		}
 	})
@@ -992,7 +992,7 @@ func TestSubnetSetReconciler_CollectGarbage(t *testing.T) {
 	patches.ApplyMethod(reflect.TypeOf(r.BindingService), "DeleteSubnetConnectionBindingMapsByParentSubnet", func(_ *subnetbinding.BindingService, parentSubnet *model.VpcSubnet) error {
 		return nil
 	})
-	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, subnet model.VpcSubnet) error {
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, subnet model.VpcSubnet) error {
 		return nil
 	})
 
@@ -1054,19 +1054,19 @@ func TestSubnetSetReconciler_deleteSubnetForSubnetSet(t *testing.T) {
 		IpAddresses: []string{"10.0.0.0/28"},
 	}
 
-	patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
-		return []*model.VpcSubnet{
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(_ *common.ResourceStore, key string, value string) []interface{} {
+		return []interface{}{
 			vpcSubnet1,
 			vpcSubnet2,
 		}
 	})
 	defer patches.Reset()
-	patches.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnets", func(_ *SubnetSetReconciler, nsxSubnets []*model.VpcSubnet, deleteBindingMaps bool) (hasStalePort bool, err error) {
+	patches.ApplyPrivateMethod(reflect.TypeOf(r), "deleteSubnets", func(_ *SubnetSetReconciler, _ context.Context, nsxSubnets []*model.VpcSubnet, deleteBindingMaps bool) (hasStalePort bool, err error) {
 		assert.Equal(t, vpcSubnet1, nsxSubnets[0])
 		assert.Equal(t, false, deleteBindingMaps)
 		return false, nil
 	})
-	err := r.deleteSubnetForSubnetSet(subnetSet, true, false)
+	err := r.deleteSubnetForSubnetSet(context.TODO(), subnetSet, true, false)
 	assert.Nil(t, err)
 }
 
@@ -1259,7 +1259,7 @@ func TestDeleteSubnets(t *testing.T) {
 					}
 					return true
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, nsxSubnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, nsxSubnet model.VpcSubnet) error {
 					if *nsxSubnet.Id == "net1" {
 						require.Fail(t, "SubnetService.DeleteSubnet should not be called if stale ports exist")
 					}
@@ -1285,7 +1285,7 @@ func TestDeleteSubnets(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "IsEmptySubnet", func(_ *subnetport.SubnetPortService, path string) bool {
 					return true
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, nsxSubnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, nsxSubnet model.VpcSubnet) error {
 					if *nsxSubnet.Id == "net1" {
 						return fmt.Errorf("net1 deletion failed")
 					}
@@ -1311,7 +1311,7 @@ func TestDeleteSubnets(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "IsEmptySubnet", func(_ *subnetport.SubnetPortService, path string) bool {
 					return true
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, nsxSubnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, nsxSubnet model.VpcSubnet) error {
 					return nil
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "DeletePortCount", func(_ *subnetport.SubnetPortService, _ string) {})
@@ -1340,7 +1340,7 @@ func TestDeleteSubnets(t *testing.T) {
 					}
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, nsxSubnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, nsxSubnet model.VpcSubnet) error {
 					if *nsxSubnet.Id == "net1" {
 						require.Fail(t, "SubnetService.DeleteSubnet should not be called if binding maps are failed to delete")
 					}
@@ -1369,7 +1369,7 @@ func TestDeleteSubnets(t *testing.T) {
 				patches.ApplyMethod(reflect.TypeOf(r.BindingService), "DeleteSubnetConnectionBindingMapsByParentSubnet", func(_ *subnetbinding.BindingService, parentSubnet *model.VpcSubnet) error {
 					return nil
 				})
-				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, nsxSubnet model.VpcSubnet) error {
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ context.Context, nsxSubnet model.VpcSubnet) error {
 					return nil
 				})
 				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "DeletePortCount", func(_ *subnetport.SubnetPortService, _ string) {})
@@ -1390,7 +1390,7 @@ func TestDeleteSubnets(t *testing.T) {
 				defer patches.Reset()
 			}
 
-			hasPorts, err := r.deleteSubnets(tc.nsxSubnets, tc.deleteBindingMaps)
+			hasPorts, err := r.deleteSubnets(context.TODO(), tc.nsxSubnets, tc.deleteBindingMaps)
 			if tc.expErrStr != "" {
 				require.EqualError(t, err, tc.expErrStr)
 			} else {
@@ -0,0 +1,355 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+// Package debug serves the JSON dumps of the service stores and the state of the NSX endpoints for troubleshooting.
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data/serializers/cleanjson"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+const (
+	StoresPath = "/debug/stores"
+	OwnersPath = "/debug/owners"
+	NSXPath    = "/debug/nsx"
+
+	tagScopePrefix = "nsx-op/"
+	tagScopeUID    = "_uid"
+)
+
+var log = logger.Log
+
+// StoreProvider is implemented by the services whose stores are dumped.
+type StoreProvider interface {
+	ResourceStores() map[string]*common.ResourceStore
+}
+
+// Server serves the debug endpoints, it's added to the manager as a metrics server so the requests are
+// authenticated and authorized like the ones of the metrics. The users need the get permission of the
+// /debug/* non-resource URLs.
+type Server struct {
+	nsxClient *nsx.Client
+	stores    map[string]*common.ResourceStore
+	mutex     sync.RWMutex
+}
+
+// Owner is the CR or Namespace owning the NSX resources, it's found by the nsx-op/*_uid tags of the resources.
+type Owner struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	UID       string   `json:"uid"`
+	Paths     []string `json:"paths"`
+}
+
+type storeSummary struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type storeDump struct {
+	Name      string                   `json:"name"`
+	Count     int                      `json:"count"`
+	Resources []map[string]interface{} `json:"resources"`
+}
+
+type nsxState struct {
+	Health      nsx.ClusterHealth    `json:"health"`
+	Endpoints   []nsx.EndpointHealth `json:"endpoints"`
+	RateLimiter nsx.RateLimiterState `json:"rate_limiter"`
+}
+
+// filter selects the resources by the query parameters namespace, uid and path, an empty parameter selects all.
+type filter struct {
+	namespace string
+	uid       string
+	path      string
+}
+
+type tag struct {
+	Scope string `json:"scope"`
+	Tag   string `json:"tag"`
+}
+
+func NewServer(nsxClient *nsx.Client) *Server {
+	return &Server{nsxClient: nsxClient, stores: make(map[string]*common.ResourceStore)}
+}
+
+// AddStores adds the stores of the providers, a store replaces the one of the same name.
+func (s *Server) AddStores(providers ...StoreProvider) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, provider := range providers {
+		for name, store := range provider.ResourceStores() {
+			s.stores[name] = store
+		}
+	}
+}
+
+// Handlers returns the handlers of the debug endpoints by path.
+func (s *Server) Handlers() map[string]http.Handler {
+	return map[string]http.Handler{
+		StoresPath:       http.HandlerFunc(s.listStores),
+		StoresPath + "/": http.HandlerFunc(s.dumpStore),
+		OwnersPath:       http.HandlerFunc(s.listOwners),
+		NSXPath:          http.HandlerFunc(s.nsxState),
+	}
+}
+
+// listStores serves the names and sizes of the stores.
+func (s *Server) listStores(w http.ResponseWriter, r *http.Request) {
+	s.mutex.RLock()
+	summaries := make([]storeSummary, 0, len(s.stores))
+	for name, store := range s.stores {
+		summaries = append(summaries, storeSummary{Name: name, Count: len(store.ListKeys())})
+	}
+	s.mutex.RUnlock()
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	writeJSON(w, summaries)
+}
+
+// dumpStore serves the resources in the store named by the path, filtered by the query parameters.
+func (s *Server) dumpStore(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, StoresPath+"/")
+	s.mutex.RLock()
+	store, ok := s.stores[name]
+	s.mutex.RUnlock()
+	if !ok {
+		http.Error(w, "store "+name+" is not found", http.StatusNotFound)
+		return
+	}
+	resources, err := encodeStore(store)
+	if err != nil {
+		log.Error(err, "Failed to encode store", "store", name)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	f := newFilter(r)
+	dump := storeDump{Name: name, Resources: []map[string]interface{}{}}
+	for _, resource := range resources {
+		if f.match(resource) {
+			dump.Resources = append(dump.Resources, resource)
+		}
+	}
+	dump.Count = len(dump.Resources)
+	writeJSON(w, dump)
+}
+
+// listOwners serves the owners of the resources in all stores selected by the query parameters, with the paths
+// of the resources they own.
+func (s *Server) listOwners(w http.ResponseWriter, r *http.Request) {
+	s.mutex.RLock()
+	stores := make(map[string]*common.ResourceStore, len(s.stores))
+	for name, store := range s.stores {
+		stores[name] = store
+	}
+	s.mutex.RUnlock()
+
+	f := newFilter(r)
+	owners := make(map[string]*Owner)
+	for name, store := range stores {
+		resources, err := encodeStore(store)
+		if err != nil {
+			log.Error(err, "Failed to encode store", "store", name)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, resource := range resources {
+			path, _ := resource["path"].(string)
+			if path == "" || !f.match(resource) {
+				continue
+			}
+			for _, owner := range resourceOwners(resource) {
+				if f.uid != "" && owner.UID != f.uid {
+					continue
+				}
+				key := owner.Kind + "/" + owner.UID
+				if existing, ok := owners[key]; ok {
+					owner = existing
+				} else {
+					owners[key] = owner
+				}
+				owner.Paths = append(owner.Paths, path)
+			}
+		}
+	}
+
+	result := make([]*Owner, 0, len(owners))
+	for _, owner := range owners {
+		sort.Strings(owner.Paths)
+		result = append(result, owner)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	writeJSON(w, result)
+}
+
+// nsxState serves the health of the NSX cluster and its endpoints, and the state of the rate limiter.
+func (s *Server) nsxState(w http.ResponseWriter, r *http.Request) {
+	if s.nsxClient == nil || s.nsxClient.Cluster == nil {
+		http.Error(w, "NSX client is not initialized", http.StatusServiceUnavailable)
+		return
+	}
+	cluster := s.nsxClient.Cluster
+	writeJSON(w, nsxState{
+		Health:      cluster.Health(),
+		Endpoints:   cluster.EndpointsHealth(),
+		RateLimiter: cluster.RateLimiterState(),
+	})
+}
+
+// encodeStore returns the resources in store in the JSON format of the NSX API. The resources of the stores
+// without BindingType, e.g. the inventory stores, are in their own JSON format.
+func encodeStore(store *common.ResourceStore) ([]map[string]interface{}, error) {
+	encoder := cleanjson.NewDataValueToJsonEncoder()
+	objs := store.List()
+	resources := make([]map[string]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		var encoded []byte
+		if store.BindingType != nil {
+			dataValue, errs := common.NewConverter().ConvertToVapi(obj, store.BindingType)
+			for _, err := range errs {
+				return nil, err
+			}
+			s, err := encoder.Encode(dataValue)
+			if err != nil {
+				return nil, err
+			}
+			encoded = []byte(s)
+		} else {
+			var err error
+			if encoded, err = json.Marshal(obj); err != nil {
+				return nil, err
+			}
+		}
+		resource := make(map[string]interface{})
+		if err := json.Unmarshal(encoded, &resource); err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resourceKey(resources[i]) < resourceKey(resources[j])
+	})
+	return resources, nil
+}
+
+func resourceKey(resource map[string]interface{}) string {
+	for _, field := range []string{"path", "id", "external_id"} {
+		if key, ok := resource[field].(string); ok {
+			return key
+		}
+	}
+	return ""
+}
+
+func resourceTags(resource map[string]interface{}) []tag {
+	raw, ok := resource["tags"].([]interface{})
+	if !ok {
+		return nil
+	}
+	tags := make([]tag, 0, len(raw))
+	for _, item := range raw {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		scope, _ := m["scope"].(string)
+		value, _ := m["tag"].(string)
+		tags = append(tags, tag{Scope: scope, Tag: value})
+	}
+	return tags
+}
+
+// resourceOwners returns the owners in the nsx-op/<kind>_uid tags of resource, the name of an owner is in the
+// nsx-op/<kind>_name or nsx-op/<kind> tag.
+func resourceOwners(resource map[string]interface{}) []*Owner {
+	tags := resourceTags(resource)
+	values := make(map[string]string, len(tags))
+	for _, t := range tags {
+		values[t.Scope] = t.Tag
+	}
+	var owners []*Owner
+	for _, t := range tags {
+		if !strings.HasPrefix(t.Scope, tagScopePrefix) || !strings.HasSuffix(t.Scope, tagScopeUID) || t.Tag == "" {
+			continue
+		}
+		base := strings.TrimSuffix(t.Scope, tagScopeUID)
+		owner := &Owner{
+			Kind: strings.TrimSuffix(strings.TrimPrefix(base, tagScopePrefix), "_cr"),
+			UID:  t.Tag,
+		}
+		for _, scope := range []string{base + "_name", base} {
+			if name, ok := values[scope]; ok {
+				owner.Name = name
+				break
+			}
+		}
+		if owner.Kind != "namespace" && owner.Kind != "vm_namespace" {
+			owner.Namespace = values[common.TagScopeNamespace]
+			if owner.Namespace == "" {
+				owner.Namespace = values[common.TagScopeVMNamespace]
+			}
+		}
+		owners = append(owners, owner)
+	}
+	return owners
+}
+
+func newFilter(r *http.Request) filter {
+	query := r.URL.Query()
+	return filter{namespace: query.Get("namespace"), uid: query.Get("uid"), path: query.Get("path")}
+}
+
+// match checks if the resource is in the namespace, owned by the CR of uid and under the path. The inventory
+// objects are matched by the UID of the Kubernetes object in external_id.
+func (f filter) match(resource map[string]interface{}) bool {
+	if f.path != "" {
+		path, _ := resource["path"].(string)
+		if path != f.path && !strings.HasPrefix(path, strings.TrimSuffix(f.path, "/")+"/") {
+			return false
+		}
+	}
+	if f.namespace == "" && f.uid == "" {
+		return true
+	}
+	namespaceMatched, uidMatched := f.namespace == "", f.uid == ""
+	if externalID, _ := resource["external_id"].(string); f.uid != "" && externalID == f.uid {
+		uidMatched = true
+	}
+	for _, t := range resourceTags(resource) {
+		switch {
+		case t.Scope == common.TagScopeNamespace || t.Scope == common.TagScopeVMNamespace:
+			namespaceMatched = namespaceMatched || t.Tag == f.namespace
+		case strings.HasPrefix(t.Scope, tagScopePrefix) && strings.HasSuffix(t.Scope, tagScopeUID):
+			uidMatched = uidMatched || t.Tag == f.uid
+		}
+	}
+	return namespaceMatched && uidMatched
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		log.Error(err, "Failed to write debug response")
+	}
+}
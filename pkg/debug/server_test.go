@@ -0,0 +1,130 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/go-vmware-nsxt/containerinventory"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+const testVPCPath = "/orgs/default/projects/project-1/vpcs/vpc-1"
+
+type testProvider map[string]*common.ResourceStore
+
+func (p testProvider) ResourceStores() map[string]*common.ResourceStore {
+	return p
+}
+
+func newTestPort(id, namespace, name, uid string) *model.VpcSubnetPort {
+	path := testVPCPath + "/subnets/subnet-1/ports/" + id
+	return &model.VpcSubnetPort{Id: common.String(id), Path: &path, Tags: []model.Tag{
+		{Scope: common.String(common.TagScopeNamespace), Tag: common.String(namespace)},
+		{Scope: common.String(common.TagScopeSubnetPortCRName), Tag: common.String(name)},
+		{Scope: common.String(common.TagScopeSubnetPortCRUID), Tag: common.String(uid)},
+	}}
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	portStore := common.NewTypedStore[model.VpcSubnetPort](model.VpcSubnetPortBindingType(), func(port *model.VpcSubnetPort) (string, error) {
+		return *port.Id, nil
+	}, nil)
+	require.NoError(t, portStore.Apply([]*model.VpcSubnetPort{
+		newTestPort("port-1", "ns-1", "port-1", "uid-1"),
+		newTestPort("port-2", "ns-2", "port-2", "uid-2"),
+	}))
+	subnetPath := testVPCPath + "/subnets/subnet-1"
+	subnetStore := common.NewTypedStore[model.VpcSubnet](model.VpcSubnetBindingType(), func(subnet *model.VpcSubnet) (string, error) {
+		return *subnet.Id, nil
+	}, nil)
+	require.NoError(t, subnetStore.Apply(&model.VpcSubnet{Id: common.String("subnet-1"), Path: &subnetPath, Tags: []model.Tag{
+		{Scope: common.String(common.TagScopeNamespace), Tag: common.String("ns-1")},
+		{Scope: common.String(common.TagScopeNamespaceUID), Tag: common.String("ns-uid-1")},
+	}}))
+	instanceStore := &common.ResourceStore{Indexer: cache.NewIndexer(func(obj interface{}) (string, error) {
+		return obj.(*containerinventory.ContainerApplicationInstance).ExternalId, nil
+	}, nil)}
+	require.NoError(t, instanceStore.Add(&containerinventory.ContainerApplicationInstance{ExternalId: "pod-uid-1", DisplayName: "pod-1"}))
+
+	server := NewServer(nil)
+	server.AddStores(testProvider{"subnetports": &portStore.ResourceStore, "subnets": &subnetStore.ResourceStore},
+		testProvider{"inventory/applicationinstances": instanceStore})
+	mux := http.NewServeMux()
+	for path, handler := range server.Handlers() {
+		mux.Handle(path, handler)
+	}
+	return httptest.NewServer(mux)
+}
+
+func get(t *testing.T, url string, v interface{}) int {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	return resp.StatusCode
+}
+
+func TestServer(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+
+	var summaries []storeSummary
+	assert.Equal(t, http.StatusOK, get(t, ts.URL+StoresPath, &summaries))
+	assert.Equal(t, []storeSummary{{"inventory/applicationinstances", 1}, {"subnetports", 2}, {"subnets", 1}}, summaries)
+
+	for _, tc := range []struct {
+		name  string
+		query string
+		ids   []string
+	}{
+		{"all", "", []string{"port-1", "port-2"}},
+		{"namespace", "?namespace=ns-1", []string{"port-1"}},
+		{"uid", "?uid=uid-2", []string{"port-2"}},
+		{"path", "?path=" + testVPCPath + "/subnets/subnet-1/ports/port-1", []string{"port-1"}},
+		{"parent path", "?path=" + testVPCPath, []string{"port-1", "port-2"}},
+		{"no match", "?namespace=ns-1&uid=uid-2", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dump := storeDump{}
+			assert.Equal(t, http.StatusOK, get(t, ts.URL+StoresPath+"/subnetports"+tc.query, &dump))
+			assert.Equal(t, len(tc.ids), dump.Count)
+			var ids []string
+			for _, resource := range dump.Resources {
+				ids = append(ids, resource["id"].(string))
+			}
+			assert.Equal(t, tc.ids, ids)
+		})
+	}
+
+	// the inventory objects are in their own format and matched by external_id
+	dump := storeDump{}
+	assert.Equal(t, http.StatusOK, get(t, ts.URL+StoresPath+"/inventory/applicationinstances?uid=pod-uid-1", &dump))
+	require.Equal(t, 1, dump.Count)
+	assert.Equal(t, "pod-1", dump.Resources[0]["display_name"])
+	assert.Equal(t, http.StatusNotFound, get(t, ts.URL+StoresPath+"/unknown", &dump))
+
+	var owners []Owner
+	assert.Equal(t, http.StatusOK, get(t, ts.URL+OwnersPath+"?namespace=ns-1", &owners))
+	assert.Equal(t, []Owner{
+		{Kind: "namespace", Name: "ns-1", UID: "ns-uid-1", Paths: []string{testVPCPath + "/subnets/subnet-1"}},
+		{Kind: "subnetport", Namespace: "ns-1", Name: "port-1", UID: "uid-1", Paths: []string{testVPCPath + "/subnets/subnet-1/ports/port-1"}},
+	}, owners)
+	assert.Equal(t, http.StatusOK, get(t, ts.URL+OwnersPath+"?uid=uid-2", &owners))
+	require.Len(t, owners, 1)
+	assert.Equal(t, "port-2", owners[0].Name)
+
+	// the NSX state isn't served without the NSX client
+	assert.Equal(t, http.StatusServiceUnavailable, get(t, ts.URL+NSXPath, nil))
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -21,6 +22,18 @@ const (
 	ControllerDeleteTotalKey        = "controller_delete_total"
 	ControllerDeleteSuccessTotalKey = "controller_delete_success_total"
 	ControllerDeleteFailTotalKey    = "controller_delete_fail_total"
+	ControllerReconcileDurationKey  = "controller_reconcile_duration_seconds"
+	ControllerErrorTotalKey         = "controller_error_total"
+	NSXAPIRequestDurationKey        = "nsx_api_request_duration_seconds"
+	NSXAPIRetryTotalKey             = "nsx_api_retry_total"
+	NSXAPIReauthTotalKey            = "nsx_api_reauth_total"
+	NSXAPIRoundTripErrorTotalKey    = "nsx_api_roundtrip_error_total"
+	NSXEndpointConnectionsKey       = "nsx_endpoint_connections"
+	NSXEndpointStatusKey            = "nsx_endpoint_status"
+	NSXEndpointRateLimitKey         = "nsx_endpoint_rate_limit"
+	NSXAPIThrottledTotalKey         = "nsx_api_throttled_total"
+	NSXEndpointThrottledUntilKey    = "nsx_endpoint_throttled_until_seconds"
+	NSXResourceDriftTotalKey        = "nsx_resource_drift_total"
 	ScrapeTimeout                   = 30
 )
 
@@ -98,6 +111,116 @@ var (
 		},
 		[]string{"res_type"},
 	)
+	ControllerReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      ControllerReconcileDurationKey,
+			Help:      "Duration of K8s events reconciled by NSX Operator",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+		},
+		[]string{"res_type"},
+	)
+	ControllerErrorTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      ControllerErrorTotalKey,
+			Help:      "Total number of K8s events failed to be syncronized by NSX Operator, grouped by error reason",
+		},
+		[]string{"res_type", "reason"},
+	)
+	NSXAPIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXAPIRequestDurationKey,
+			Help:      "Latency of the REST API requests sent to NSX managers",
+			Buckets:   []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"endpoint", "method", "path", "status_code"},
+	)
+	NSXAPIRetryTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXAPIRetryTotalKey,
+			Help:      "Total number of retried REST API requests sent to NSX managers",
+		},
+		[]string{"endpoint"},
+	)
+	NSXAPIReauthTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXAPIReauthTotalKey,
+			Help:      "Total number of XSRF session or JWT regenerations triggered by NSX responses",
+		},
+		[]string{"endpoint", "auth_type"},
+	)
+	NSXAPIRoundTripErrorTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXAPIRoundTripErrorTotalKey,
+			Help:      "Total number of REST API requests which failed to reach NSX managers",
+		},
+		[]string{"endpoint", "reason"},
+	)
+	NSXEndpointConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXEndpointConnectionsKey,
+			Help:      "Number of open connections to each NSX manager",
+		},
+		[]string{"endpoint"},
+	)
+	NSXEndpointStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXEndpointStatusKey,
+			Help:      "Status of each NSX manager, 1 for UP and 0 for DOWN",
+		},
+		[]string{"endpoint"},
+	)
+	NSXEndpointRateLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXEndpointRateLimitKey,
+			Help:      "Current API rate limit in requests per second of each NSX manager, 0 means no limit",
+		},
+		[]string{"endpoint"},
+	)
+	NSXAPIThrottledTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXAPIThrottledTotalKey,
+			Help:      "Total number of REST API responses which throttled NSX managers with Retry-After",
+		},
+		[]string{"endpoint"},
+	)
+	NSXEndpointThrottledUntil = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXEndpointThrottledUntilKey,
+			Help:      "Unix time until which the requests to each NSX manager are paused as requested by Retry-After",
+		},
+		[]string{"endpoint"},
+	)
+	NSXResourceDriftTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      NSXResourceDriftTotalKey,
+			Help:      "Total number of out-of-band changes of the NSX resources owned by NSX Operator, grouped by drift type",
+		},
+		[]string{"res_type", "drift_type"},
+	)
 )
 
 var registerMetrics sync.Once
@@ -121,11 +244,31 @@ func InitializePrometheusMetrics() {
 		ControllerDeleteTotal,
 		ControllerDeleteSuccessTotal,
 		ControllerDeleteFailTotal,
+		ControllerReconcileDuration,
+		ControllerErrorTotal,
+		NSXAPIRequestDuration,
+		NSXAPIRetryTotal,
+		NSXAPIReauthTotal,
+		NSXAPIRoundTripErrorTotal,
+		NSXEndpointConnections,
+		NSXEndpointStatus,
+		NSXEndpointRateLimit,
+		NSXAPIThrottledTotal,
+		NSXEndpointThrottledUntil,
+		NSXResourceDriftTotal,
 	)
 }
 
+// AreMetricsExposed checks if Prometheus metrics are enabled by enable_prometheus_metrics, they are always
+// exposed on VMC.
 func AreMetricsExposed(cf *config.NSXOperatorConfig) bool {
-	if cf.EnforcementPoint == "vmc-enforcementpoint" {
+	if cf == nil {
+		return false
+	}
+	if cf.K8sConfig != nil && cf.EnablePromMetrics {
+		return true
+	}
+	if cf.NsxConfig != nil && cf.EnforcementPoint == "vmc-enforcementpoint" {
 		return true
 	}
 	return false
@@ -136,3 +279,21 @@ func CounterInc(cf *config.NSXOperatorConfig, counter *prometheus.CounterVec, re
 		counter.WithLabelValues(res_type).Inc()
 	}
 }
+
+func ErrorInc(cf *config.NSXOperatorConfig, res_type string, reason string) {
+	if AreMetricsExposed(cf) {
+		ControllerErrorTotal.WithLabelValues(res_type, reason).Inc()
+	}
+}
+
+func DriftInc(cf *config.NSXOperatorConfig, res_type string, drift_type string) {
+	if AreMetricsExposed(cf) {
+		NSXResourceDriftTotal.WithLabelValues(res_type, drift_type).Inc()
+	}
+}
+
+func ObserveReconcileDuration(cf *config.NSXOperatorConfig, res_type string, duration time.Duration) {
+	if AreMetricsExposed(cf) {
+		ControllerReconcileDuration.WithLabelValues(res_type).Observe(duration.Seconds())
+	}
+}
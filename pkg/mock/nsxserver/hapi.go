@@ -0,0 +1,218 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsxserver
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	resourceTypeInfra                  = "Infra"
+	resourceTypeChildResourceReference = "ChildResourceReference"
+	childResourceTypePrefix            = "Child"
+)
+
+// segmentByType maps the resource type to the collection in the Policy path.
+var segmentByType = map[string]string{
+	"Org":                         "orgs",
+	"Project":                     "projects",
+	"Vpc":                         "vpcs",
+	"VpcSubnet":                   "subnets",
+	"VpcSubnetPort":               "ports",
+	"SubnetConnectionBindingMap":  "subnet-connection-binding-maps",
+	"StaticRoutes":                "static-routes",
+	"VpcIpAddressAllocation":      "ip-address-allocations",
+	"DynamicIpAddressReservation": "dynamic-ip-reservations",
+	"VpcAttachment":               "attachments",
+	"Domain":                      "domains",
+	"Group":                       "groups",
+	"SecurityPolicy":              "security-policies",
+	"Rule":                        "rules",
+	"Share":                       "shares",
+	"PolicyContextProfile":        "context-profiles",
+	"SharedResource":              "resources",
+	"TlsCertificate":              "certificates",
+	"LBService":                   "lb-services",
+	"LBPool":                      "lb-pools",
+	"LBVirtualServer":             "lb-virtual-servers",
+}
+
+// vpcSegmentByType maps the resource type to the collection in a VPC if it's different from segmentByType.
+var vpcSegmentByType = map[string]string{
+	"LBService":       "vpc-lbs",
+	"LBPool":          "vpc-lb-pools",
+	"LBVirtualServer": "vpc-lb-virtual-servers",
+}
+
+// typeBySegment maps the collection in the Policy path to the resource type.
+var typeBySegment = func() map[string]string {
+	types := map[string]string{}
+	for resourceType, segment := range segmentByType {
+		types[segment] = resourceType
+	}
+	for resourceType, segment := range vpcSegmentByType {
+		types[segment] = resourceType
+	}
+	return types
+}()
+
+// extraRealizedEntityIDs are the IDs of the realized entities besides the object itself, e.g. the gateway interface
+// of a VPC checked by VPCService.
+var extraRealizedEntityIDs = map[string][]string{
+	"Vpc": {"gateway-interface"},
+}
+
+// rejectedError is returned by patchChildren if a child is rejected by RejectPath.
+type rejectedError struct {
+	*injectedError
+	path string
+}
+
+func (e *rejectedError) Error() string {
+	return fmt.Sprintf("the object %s is rejected", e.path)
+}
+
+// patchChildren applies the children of an H-API request to the objects under parent. A ChildResourceReference
+// only locates its children, a Child<Type> wrapper creates, updates or deletes the wrapped object. The children are
+// only validated if dryRun is true.
+func (s *Server) patchChildren(parent string, children []interface{}, dryRun bool) error {
+	for _, c := range children {
+		child, ok := c.(Object)
+		if !ok {
+			return fmt.Errorf("invalid child %v of %s", c, parent)
+		}
+		resourceType, _ := child["resource_type"].(string)
+		if resourceType == resourceTypeChildResourceReference {
+			targetType, _ := child["target_type"].(string)
+			id, _ := child["id"].(string)
+			path, err := childPath(parent, targetType, id)
+			if err != nil {
+				return err
+			}
+			grandchildren, _ := child["children"].([]interface{})
+			if err := s.patchChildren(path, grandchildren, dryRun); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strings.HasPrefix(resourceType, childResourceTypePrefix) {
+			return fmt.Errorf("unsupported child resource type %q of %s", resourceType, parent)
+		}
+		object := wrappedObject(child, strings.TrimPrefix(resourceType, childResourceTypePrefix))
+		if object == nil {
+			return fmt.Errorf("no object is wrapped by %s of %s", resourceType, parent)
+		}
+		objectType, _ := object["resource_type"].(string)
+		if objectType == "" {
+			objectType = strings.TrimPrefix(resourceType, childResourceTypePrefix)
+		}
+		id, _ := object["id"].(string)
+		if id == "" {
+			id, _ = child["id"].(string)
+		}
+		path, err := childPath(parent, objectType, id)
+		if err != nil {
+			return err
+		}
+		if rejected, ok := s.rejected[path]; ok {
+			return &rejectedError{injectedError: rejected, path: path}
+		}
+		grandchildren, _ := object["children"].([]interface{})
+		if dryRun {
+			if err := s.patchChildren(path, grandchildren, dryRun); err != nil {
+				return err
+			}
+			continue
+		}
+		if child["marked_for_delete"] == true || object["marked_for_delete"] == true {
+			s.markForDelete(path)
+			continue
+		}
+		delete(object, "children")
+		s.upsert(path, object, true)
+		if err := s.patchChildren(path, grandchildren, dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wrappedObject returns the object wrapped by a Child<Type>, it's usually the field named by the type,
+// e.g. VpcSubnet of ChildVpcSubnet.
+func wrappedObject(child Object, wrappedType string) Object {
+	if object, ok := child[wrappedType].(Object); ok {
+		return object
+	}
+	for key, value := range child {
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		if object, ok := value.(Object); ok {
+			return object
+		}
+	}
+	return nil
+}
+
+// childPath returns the path of the child of resourceType and id under parent.
+func childPath(parent, resourceType, id string) (string, error) {
+	if resourceType == resourceTypeInfra {
+		return parent + "/infra", nil
+	}
+	segment, ok := segmentByType[resourceType]
+	if !ok {
+		return "", fmt.Errorf("unsupported resource type %q under %s", resourceType, parent)
+	}
+	if vpcSegment, ok := vpcSegmentByType[resourceType]; ok && lastSegment(parentCollection(parent)) == "vpcs" {
+		segment = vpcSegment
+	}
+	if id == "" {
+		return "", fmt.Errorf("no id of %s under %s", resourceType, parent)
+	}
+	return fmt.Sprintf("%s/%s/%s", parent, segment, id), nil
+}
+
+// normalizePath removes the Policy API prefix and the trailing slash from path.
+func normalizePath(path string) string {
+	path = strings.TrimPrefix(path, PolicyAPIPrefix)
+	return strings.TrimSuffix(path, "/")
+}
+
+// rootPath returns the path under which the children of an H-API request of path are created.
+func rootPath(path string) string {
+	if path == "/org-root" {
+		return ""
+	}
+	return path
+}
+
+func lastSegment(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// parentCollection returns the collection containing the object at path, e.g. /orgs/default/projects for
+// /orgs/default/projects/p1.
+func parentCollection(path string) string {
+	if i := strings.LastIndex(path, "/"); i > 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// parentPath returns the path of the parent object, e.g. /orgs/default for /orgs/default/projects/p1 and
+// /orgs/default/projects/p1 for /orgs/default/projects/p1/infra.
+func parentPath(path string) string {
+	if lastSegment(path) == "infra" {
+		return parentCollection(path)
+	}
+	return parentCollection(parentCollection(path))
+}
+
+func resourceTypeOf(path string) string {
+	if lastSegment(path) == "infra" {
+		return resourceTypeInfra
+	}
+	return typeBySegment[lastSegment(parentCollection(path))]
+}
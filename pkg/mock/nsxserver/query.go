@@ -0,0 +1,367 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsxserver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// matcher matches a Policy object against the search query.
+type matcher interface {
+	match(object Object) bool
+}
+
+type andMatcher []matcher
+
+func (m andMatcher) match(object Object) bool {
+	for _, sub := range m {
+		if !sub.match(object) {
+			return false
+		}
+	}
+	return true
+}
+
+type orMatcher []matcher
+
+func (m orMatcher) match(object Object) bool {
+	for _, sub := range m {
+		if sub.match(object) {
+			return true
+		}
+	}
+	return false
+}
+
+type notMatcher struct {
+	matcher
+}
+
+func (m notMatcher) match(object Object) bool {
+	return !m.matcher.match(object)
+}
+
+// fieldMatcher matches the value of the field, the field of a nested object is in dotted format, e.g. tags.scope
+// matches the scope of any tag.
+type fieldMatcher struct {
+	field string
+	value string
+}
+
+func (m fieldMatcher) match(object Object) bool {
+	for _, value := range fieldValues(object, strings.Split(m.field, ".")) {
+		if matchWildcard(m.value, fmt.Sprint(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeMatcher matches the numeric value of the field against a bound, e.g. _last_modified_time:>=1700000000000.
+type rangeMatcher struct {
+	field    string
+	operator string
+	bound    float64
+}
+
+func (m rangeMatcher) match(object Object) bool {
+	for _, value := range fieldValues(object, strings.Split(m.field, ".")) {
+		v, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+		if err != nil {
+			continue
+		}
+		switch m.operator {
+		case ">":
+			if v > m.bound {
+				return true
+			}
+		case ">=":
+			if v >= m.bound {
+				return true
+			}
+		case "<":
+			if v < m.bound {
+				return true
+			}
+		case "<=":
+			if v <= m.bound {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func newFieldMatcher(field, value string) (matcher, error) {
+	for _, operator := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, operator) {
+			bound, err := strconv.ParseFloat(value[len(operator):], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid range %q of field %s", value, field)
+			}
+			return rangeMatcher{field: field, operator: operator, bound: bound}, nil
+		}
+	}
+	return fieldMatcher{field: field, value: value}, nil
+}
+
+// fieldValues returns the values of the field in object, the values of the arrays are flattened.
+func fieldValues(value interface{}, field []string) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		var values []interface{}
+		for _, item := range v {
+			values = append(values, fieldValues(item, field)...)
+		}
+		return values
+	case Object:
+		if len(field) == 0 {
+			return nil
+		}
+		fieldValue, ok := v[field[0]]
+		if !ok {
+			return nil
+		}
+		return fieldValues(fieldValue, field[1:])
+	default:
+		if len(field) > 0 || v == nil {
+			return nil
+		}
+		return []interface{}{v}
+	}
+}
+
+// matchWildcard matches value against pattern in which * matches any sequence of characters and \* matches *.
+func matchWildcard(pattern, value string) bool {
+	var parts []string
+	var part strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			part.WriteByte(pattern[i])
+		case pattern[i] == '*':
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(pattern[i])
+		}
+	}
+	parts = append(parts, part.String())
+	if len(parts) == 1 {
+		return parts[0] == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, p := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, p)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(p):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// queryParser parses the subset of the Lucene syntax used by the NSX search API, e.g.
+// resource_type:VpcSubnet AND tags.scope:nsx-op\/cluster AND tags.tag:c1 AND NOT marked_for_delete:true.
+// A numeric field could be compared with a bound, e.g. _last_modified_time:>1700000000000.
+// A term without AND or OR between them is joined with AND, a field could match a group, e.g. id:(a OR b).
+type queryParser struct {
+	tokens []string
+	pos    int
+}
+
+func parseQuery(query string) (matcher, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return andMatcher{}, nil
+	}
+	p := &queryParser{tokens: tokens}
+	m, err := p.parseOr("")
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in query %q", p.tokens[p.pos], query)
+	}
+	return m, nil
+}
+
+// tokenize splits the query into the parentheses and the terms, the escaped characters are kept escaped in the
+// terms so the wildcards could be told apart.
+func tokenize(query string) ([]string, error) {
+	var tokens []string
+	var token strings.Builder
+	inQuote := false
+	flush := func() {
+		if token.Len() > 0 {
+			tokens = append(tokens, token.String())
+			token.Reset()
+		}
+	}
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(query) {
+				return nil, fmt.Errorf("dangling escape in query %q", query)
+			}
+			token.WriteByte(c)
+			i++
+			token.WriteByte(query[i])
+		case c == '"':
+			inQuote = !inQuote
+		case inQuote:
+			if c == '*' {
+				token.WriteByte('\\')
+			}
+			token.WriteByte(c)
+		case c == ' ' || c == '\t' || c == '\n':
+			flush()
+		case c == '(' || c == ')':
+			flush()
+			tokens = append(tokens, string(c))
+		default:
+			token.WriteByte(c)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote in query %q", query)
+	}
+	flush()
+	return tokens, nil
+}
+
+func (p *queryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *queryParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+// parseOr parses the terms joined by OR, field is the field of the enclosing group if any.
+func (p *queryParser) parseOr(field string) (matcher, error) {
+	var or orMatcher
+	for {
+		and, err := p.parseAnd(field)
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, and)
+		if p.peek() != "OR" {
+			break
+		}
+		p.next()
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *queryParser) parseAnd(field string) (matcher, error) {
+	var and andMatcher
+	for {
+		m, err := p.parseUnary(field)
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, m)
+		token := p.peek()
+		if token == "AND" {
+			p.next()
+			continue
+		}
+		if token == "" || token == "OR" || token == ")" {
+			break
+		}
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *queryParser) parseUnary(field string) (matcher, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of query")
+	case token == "NOT":
+		m, err := p.parseUnary(field)
+		if err != nil {
+			return nil, err
+		}
+		return notMatcher{m}, nil
+	case token == "(":
+		return p.parseGroup(field)
+	case token == ")" || token == "AND" || token == "OR":
+		return nil, fmt.Errorf("unexpected %q in query", token)
+	}
+	if i := indexUnescaped(token, ':'); i >= 0 {
+		termField := unescape(token[:i])
+		value := token[i+1:]
+		if value == "" {
+			if p.peek() != "(" {
+				return nil, fmt.Errorf("no value of field %s", termField)
+			}
+			p.next()
+			return p.parseGroup(termField)
+		}
+		return newFieldMatcher(termField, value)
+	}
+	if field == "" {
+		return nil, fmt.Errorf("no field of term %q", token)
+	}
+	return newFieldMatcher(field, token)
+}
+
+func (p *queryParser) parseGroup(field string) (matcher, error) {
+	m, err := p.parseOr(field)
+	if err != nil {
+		return nil, err
+	}
+	if p.next() != ")" {
+		return nil, fmt.Errorf("missing ) in query")
+	}
+	return m, nil
+}
+
+func indexUnescaped(s string, c byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsxserver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	object := Object{
+		"resource_type":       "VpcSubnet",
+		"display_name":        "subnet-1_abc",
+		"marked_for_delete":   false,
+		"_last_modified_time": json.Number("1700000000000"),
+		"tags": []interface{}{
+			Object{"scope": "nsx-op/cluster", "tag": "k8scl-one"},
+			Object{"scope": "nsx-op/namespace", "tag": "ns-1"},
+		},
+	}
+	tests := []struct {
+		query   string
+		matched bool
+	}{
+		{"", true},
+		{"resource_type:VpcSubnet", true},
+		{"resource_type:Vpc", false},
+		{"resource_type:(Vpc OR VpcSubnet)", true},
+		{"resource_type:VpcSubnet AND tags.scope:nsx-op\\/cluster AND tags.tag:k8scl-one", true},
+		{"resource_type:VpcSubnet tags.tag:k8scl-two", false},
+		{"tags.scope:nsx-op\\/cluster AND NOT tags.tag:ns-1", false},
+		{"NOT marked_for_delete:true", true},
+		{"marked_for_delete:false AND (resource_type:Vpc OR display_name:subnet-*)", true},
+		{"display_name:*_abc", true},
+		{"display_name:subnet\\*", false},
+		{"display_name:\"subnet-1_abc\"", true},
+		{"unknown_field:*", false},
+		{"_last_modified_time:>=1700000000000", true},
+		{"_last_modified_time:>1700000000000", false},
+		{"_last_modified_time:<1700000000001 AND resource_type:VpcSubnet", true},
+		{"display_name:>0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			m, err := parseQuery(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matched, m.match(object))
+		})
+	}
+
+	for _, query := range []string{"resource_type:(Vpc", "AND resource_type:Vpc", "Vpc", "display_name:\"abc", "_last_modified_time:>abc"} {
+		_, err := parseQuery(query)
+		assert.Error(t, err, query)
+	}
+}
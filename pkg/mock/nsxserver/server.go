@@ -0,0 +1,589 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+// Package nsxserver implements an in-memory fake of the NSX Policy API for integration tests. It keeps the Policy
+// objects by path and supports the H-API PATCH of OrgRoot and Infra, the search API, the realized-state API and the
+// CRUD of the Policy objects, so that the services and controllers could be tested against the real nsx.Client.
+package nsxserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data/serializers/cleanjson"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+)
+
+const (
+	// PolicyAPIPrefix is the prefix of the Policy API paths, the objects are stored by the path without it.
+	PolicyAPIPrefix = "/policy/api/v1"
+	// Version is the NSX version reported by the server.
+	Version = "9.1.0"
+
+	// the NSX error codes returned by the server
+	errorCodeNotFound         = 600
+	errorCodeInvalidRequest   = 255
+	errorCodeRevisionMismatch = 604
+	defaultPageSize           = 1000
+
+	xsrfToken = "fake-xsrf-token"
+)
+
+// Object is a Policy object in the JSON format of the NSX API.
+type Object = map[string]interface{}
+
+// Request is an API request received by the server.
+type Request struct {
+	Method string
+	Path   string
+}
+
+type injectedError struct {
+	method     string
+	pathPrefix string
+	count      int
+	statusCode int
+	errorCode  int
+}
+
+// Server is the fake NSX manager. Use NewServer to start it and Close to stop it.
+type Server struct {
+	*httptest.Server
+
+	// KeepMarkedForDelete keeps the objects deleted by the H-API with marked_for_delete=true instead of removing
+	// them, like NSX does while the deletion is being realized. Use Purge to remove them.
+	KeepMarkedForDelete bool
+
+	mutex    sync.Mutex
+	objects  map[string]Object
+	realized map[string][]Object
+	injected []*injectedError
+	rejected map[string]*injectedError
+	requests []Request
+	uniqueID int64
+}
+
+// NewServer starts a TLS server serving the fake NSX API.
+func NewServer() *Server {
+	s := &Server{
+		objects:  map[string]Object{},
+		realized: map[string][]Object{},
+		rejected: map[string]*injectedError{},
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Host returns the address of the server in host:port format, it's used as the NSX manager address.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// NewConfig returns the NSX Operator config connecting to the server, cluster is the name of the Kubernetes cluster
+// which tags the NSX resources.
+func (s *Server) NewConfig(cluster string) *config.NSXOperatorConfig {
+	cf := config.NewNSXOpertorConfig()
+	cf.NsxApiManagers = []string{s.Host()}
+	cf.NsxApiUser = "admin"
+	cf.NsxApiPassword = "admin"
+	cf.Insecure = true
+	cf.HttpTimeout = 10
+	cf.Cluster = cluster
+	return cf
+}
+
+// NewClient creates the nsx.Client connecting to the server.
+func (s *Server) NewClient(cluster string) *nsx.Client {
+	return nsx.GetClient(s.NewConfig(cluster))
+}
+
+// Requests returns the API requests received by the server, the session and health check requests are excluded.
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestCount returns the number of the requests of method whose path starts with pathPrefix.
+func (s *Server) RequestCount(method, pathPrefix string) int {
+	count := 0
+	for _, r := range s.Requests() {
+		if r.Method == method && strings.HasPrefix(r.Path, pathPrefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// InjectError makes the next count requests of method whose path starts with pathPrefix fail with statusCode and
+// the NSX errorCode. An empty method matches all methods.
+func (s *Server) InjectError(method, pathPrefix string, count, statusCode, errorCode int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.injected = append(s.injected, &injectedError{method: method, pathPrefix: pathPrefix, count: count, statusCode: statusCode, errorCode: errorCode})
+}
+
+// RejectPath makes the H-API requests creating, updating or deleting the object at path fail with statusCode and the
+// NSX errorCode. Like NSX, none of the objects in a rejected H-API request is applied.
+func (s *Server) RejectPath(path string, statusCode, errorCode int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rejected[normalizePath(path)] = &injectedError{statusCode: statusCode, errorCode: errorCode}
+}
+
+// AddResource stores the Policy object obj at path, obj is converted to JSON with bindingType,
+// e.g. model.VpcBindingType().
+func (s *Server) AddResource(path string, obj interface{}, bindingType bindings.BindingType) error {
+	dataValue, errs := bindings.NewTypeConverter().ConvertToVapi(obj, bindingType)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	encoded, err := cleanjson.NewDataValueToJsonEncoder().Encode(dataValue)
+	if err != nil {
+		return err
+	}
+	object, err := decodeObject(strings.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.upsert(normalizePath(path), object, false)
+	return nil
+}
+
+// GetResource returns the Policy object at path converted with bindingType, e.g. the value is model.Vpc for
+// model.VpcBindingType().
+func (s *Server) GetResource(path string, bindingType bindings.BindingType) (interface{}, error) {
+	object, ok := s.Object(path)
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", path)
+	}
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(encoded)))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	dataValue, err := cleanjson.NewJsonToDataValueDecoder().Decode(value)
+	if err != nil {
+		return nil, err
+	}
+	converter := bindings.NewTypeConverter()
+	converter.SetPermissive(true)
+	result, errs := converter.ConvertToGolang(dataValue, bindingType)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return result, nil
+}
+
+// Object returns a copy of the Policy object at path.
+func (s *Server) Object(path string) (Object, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	object, ok := s.objects[normalizePath(path)]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(object), true
+}
+
+// Paths returns the sorted paths of the Policy objects under prefix.
+func (s *Server) Paths(prefix string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var paths []string
+	for path := range s.objects {
+		if strings.HasPrefix(path, prefix) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Purge removes the objects marked for delete.
+func (s *Server) Purge() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for path, object := range s.objects {
+		if object["marked_for_delete"] == true {
+			s.remove(path)
+		}
+	}
+}
+
+// SetRealizedEntities sets the realized entities of intentPath returned by the realized-state API, by default an
+// existing object is realized.
+func (s *Server) SetRealizedEntities(intentPath string, entities []Object) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.realized[normalizePath(intentPath)] = entities
+}
+
+// SetRealizedState sets the state of the realized entity of intentPath, e.g. ERROR with the alarm message.
+func (s *Server) SetRealizedState(intentPath, state, alarmMessage string) {
+	intentPath = normalizePath(intentPath)
+	entity := realizedEntity(intentPath, lastSegment(intentPath), "")
+	entity["state"] = state
+	if alarmMessage != "" {
+		entity["alarms"] = []interface{}{Object{"message": alarmMessage}}
+	}
+	s.SetRealizedEntities(intentPath, []Object{entity})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	switch {
+	case path == "/api/session/create":
+		w.Header().Set("X-XSRF-TOKEN", xsrfToken)
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "fake-session"})
+		w.WriteHeader(http.StatusOK)
+		return
+	case path == "/api/v1/reverse-proxy/node/health":
+		writeJSON(w, http.StatusOK, Object{"healthy": true, "components_health": "POLICY:UP, SEARCH:UP, MANAGER:UP, NODE_MGMT:UP, UI:UP"})
+		return
+	case path == "/api/v1/node/version":
+		writeJSON(w, http.StatusOK, Object{"node_version": Version})
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path})
+	if injected := s.takeInjectedError(r.Method, path); injected != nil {
+		writeError(w, injected.statusCode, injected.errorCode, fmt.Sprintf("injected error for %s %s", r.Method, path))
+		return
+	}
+
+	switch {
+	case path == "/api/v1/search/query" || path == PolicyAPIPrefix+"/search/query":
+		s.search(w, r)
+	case path == PolicyAPIPrefix+"/infra/realized-state/realized-entities":
+		s.listRealizedEntities(w, r)
+	case path == PolicyAPIPrefix+"/infra/realized-state/realized-entity":
+		s.getRealizedEntity(w, r)
+	case strings.HasPrefix(path, PolicyAPIPrefix+"/"):
+		s.serveObject(w, r, normalizePath(path))
+	default:
+		writeError(w, http.StatusNotFound, errorCodeNotFound, fmt.Sprintf("API %s %s is not supported", r.Method, path))
+	}
+}
+
+func (s *Server) takeInjectedError(method, path string) *injectedError {
+	for i, injected := range s.injected {
+		if (injected.method == "" || injected.method == method) && strings.HasPrefix(path, injected.pathPrefix) {
+			injected.count--
+			if injected.count <= 0 {
+				s.injected = append(s.injected[:i], s.injected[i+1:]...)
+			}
+			return injected
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, path string) {
+	switch r.Method {
+	case http.MethodGet:
+		if object, ok := s.objects[path]; ok {
+			writeJSON(w, http.StatusOK, object)
+			return
+		}
+		if results, ok := s.list(path, r.URL.Query().Get("include_mark_for_delete_objects") == "true"); ok {
+			writeJSON(w, http.StatusOK, Object{"results": results, "result_count": len(results)})
+			return
+		}
+		// the state of an existing object, e.g. the state of a SubnetPort
+		if strings.HasSuffix(path, "/state") {
+			if _, ok := s.objects[strings.TrimSuffix(path, "/state")]; ok {
+				writeJSON(w, http.StatusOK, Object{})
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, errorCodeNotFound, fmt.Sprintf("The path=[%s] is invalid", path))
+	case http.MethodPatch, http.MethodPut, http.MethodPost:
+		object, err := decodeObject(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errorCodeInvalidRequest, err.Error())
+			return
+		}
+		if r.Method == http.MethodPut {
+			if existing, ok := s.objects[path]; ok && object["_revision"] != nil && fmt.Sprint(object["_revision"]) != fmt.Sprint(existing["_revision"]) {
+				writeError(w, http.StatusPreconditionFailed, errorCodeRevisionMismatch,
+					fmt.Sprintf("The object %s was modified by somebody else", path))
+				return
+			}
+		}
+		children, _ := object["children"].([]interface{})
+		delete(object, "children")
+		if err := s.patchChildren(rootPath(path), children, true); err != nil {
+			if rejected, ok := err.(*rejectedError); ok {
+				writeError(w, rejected.statusCode, rejected.errorCode, err.Error())
+				return
+			}
+			writeError(w, http.StatusBadRequest, errorCodeInvalidRequest, err.Error())
+			return
+		}
+		if path != "/org-root" {
+			object = s.upsert(path, object, r.Method == http.MethodPatch)
+		}
+		if err := s.patchChildren(rootPath(path), children, false); err != nil {
+			writeError(w, http.StatusBadRequest, errorCodeInvalidRequest, err.Error())
+			return
+		}
+		if r.Method == http.MethodPatch {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		writeJSON(w, http.StatusOK, object)
+	case http.MethodDelete:
+		s.remove(path)
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errorCodeInvalidRequest, fmt.Sprintf("method %s is not supported", r.Method))
+	}
+}
+
+// list returns the objects in the collection at path, it returns false if path isn't a collection.
+func (s *Server) list(path string, includeMarkedForDelete bool) ([]Object, bool) {
+	if _, ok := typeBySegment[lastSegment(path)]; !ok {
+		return nil, false
+	}
+	results := []Object{}
+	for _, p := range s.sortedPaths() {
+		object := s.objects[p]
+		if parentCollection(p) != path || (object["marked_for_delete"] == true && !includeMarkedForDelete) {
+			continue
+		}
+		results = append(results, object)
+	}
+	return results, true
+}
+
+// upsert creates or updates the object at path and sets the fields managed by NSX, the fields of the existing
+// object are kept if merge is true.
+func (s *Server) upsert(path string, object Object, merge bool) Object {
+	now := time.Now().UnixMilli()
+	revision := int64(0)
+	existing, ok := s.objects[path]
+	if ok {
+		revision = toInt64(existing["_revision"]) + 1
+		if merge {
+			merged := copyObject(existing)
+			for k, v := range object {
+				merged[k] = v
+			}
+			object = merged
+		}
+		object["_create_time"] = existing["_create_time"]
+		object["unique_id"] = existing["unique_id"]
+	} else {
+		s.uniqueID++
+		object["_create_time"] = now
+		object["unique_id"] = fmt.Sprintf("%08d-0000-4000-8000-000000000000", s.uniqueID)
+	}
+	id := lastSegment(path)
+	object["id"] = id
+	object["relative_path"] = id
+	object["path"] = path
+	object["parent_path"] = parentPath(path)
+	object["marked_for_delete"] = false
+	object["_revision"] = revision
+	object["_last_modified_time"] = now
+	if _, ok := object["resource_type"]; !ok {
+		object["resource_type"] = resourceTypeOf(path)
+	}
+	s.objects[path] = object
+	return object
+}
+
+// remove deletes the object at path and its descendants.
+func (s *Server) remove(path string) {
+	for p := range s.objects {
+		if p == path || strings.HasPrefix(p, path+"/") {
+			delete(s.objects, p)
+			delete(s.realized, p)
+		}
+	}
+}
+
+// markForDelete deletes the object at path and its descendants, they are kept with marked_for_delete=true if
+// KeepMarkedForDelete is set.
+func (s *Server) markForDelete(path string) {
+	if !s.KeepMarkedForDelete {
+		s.remove(path)
+		return
+	}
+	now := time.Now().UnixMilli()
+	for p, object := range s.objects {
+		if p == path || strings.HasPrefix(p, path+"/") {
+			object["marked_for_delete"] = true
+			object["_last_modified_time"] = now
+		}
+	}
+}
+
+func (s *Server) sortedPaths() []string {
+	paths := make([]string, 0, len(s.objects))
+	for path := range s.objects {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	matcher, err := parseQuery(query.Get("query"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errorCodeInvalidRequest, err.Error())
+		return
+	}
+	var matched []Object
+	for _, path := range s.sortedPaths() {
+		if object := s.objects[path]; matcher.match(object) {
+			matched = append(matched, object)
+		}
+	}
+	// the realized entities are searched like NSX indexes them as GenericPolicyRealizedResource
+	for _, entity := range s.allRealizedEntities() {
+		if matcher.match(entity) {
+			matched = append(matched, entity)
+		}
+	}
+	start, _ := strconv.Atoi(query.Get("cursor"))
+	pageSize, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil || pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	start = min(max(start, 0), len(matched))
+	end := min(start+pageSize, len(matched))
+	response := Object{"results": append([]Object{}, matched[start:end]...), "result_count": len(matched)}
+	if end < len(matched) {
+		response["cursor"] = strconv.Itoa(end)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) listRealizedEntities(w http.ResponseWriter, r *http.Request) {
+	results := s.realizedEntities(normalizePath(r.URL.Query().Get("intent_path")))
+	writeJSON(w, http.StatusOK, Object{"results": results, "result_count": len(results)})
+}
+
+// realizedEntities returns the entities set by SetRealizedEntities, or the realized entities of the existing object.
+func (s *Server) realizedEntities(intentPath string) []Object {
+	if results, ok := s.realized[intentPath]; ok {
+		return results
+	}
+	results := []Object{}
+	if object, exists := s.objects[intentPath]; exists && object["marked_for_delete"] != true {
+		resourceType, _ := object["resource_type"].(string)
+		results = append(results, realizedEntity(intentPath, lastSegment(intentPath), resourceType))
+		for _, id := range extraRealizedEntityIDs[resourceType] {
+			results = append(results, realizedEntity(intentPath, id, resourceType))
+		}
+	}
+	return results
+}
+
+func (s *Server) allRealizedEntities() []Object {
+	paths := s.sortedPaths()
+	for path := range s.realized {
+		if _, exists := s.objects[path]; !exists {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	var entities []Object
+	for _, path := range paths {
+		entities = append(entities, s.realizedEntities(path)...)
+	}
+	return entities
+}
+
+func (s *Server) getRealizedEntity(w http.ResponseWriter, r *http.Request) {
+	realizedPath := r.URL.Query().Get("realized_path")
+	for _, entities := range s.realized {
+		for _, entity := range entities {
+			if entity["path"] == realizedPath {
+				writeJSON(w, http.StatusOK, entity)
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, errorCodeNotFound, fmt.Sprintf("The realized path=[%s] is invalid", realizedPath))
+}
+
+func realizedEntity(intentPath, id, resourceType string) Object {
+	return Object{
+		"id":            id,
+		"resource_type": "GenericPolicyRealizedResource",
+		"state":         "REALIZED",
+		"entity_type":   "Realized" + resourceType,
+		"intent_paths":  []interface{}{intentPath},
+		"path":          "/infra/realized-state/enforcement-points/default" + intentPath + "/" + id,
+	}
+}
+
+func decodeObject(reader io.Reader) (Object, error) {
+	object := Object{}
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return object, nil
+}
+
+func copyObject(object Object) Object {
+	c := make(Object, len(object))
+	for k, v := range object {
+		c[k] = v
+	}
+	return c
+}
+
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case json.Number:
+		i, _ := v.Int64()
+		return i
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode, errorCode int, message string) {
+	writeJSON(w, statusCode, Object{
+		"httpStatus":    strings.ToUpper(strings.ReplaceAll(http.StatusText(statusCode), " ", "_")),
+		"error_code":    errorCode,
+		"module_name":   "nsx-operator-fake",
+		"error_message": message,
+	})
+}
@@ -0,0 +1,181 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsxserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/realizestate"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+const (
+	testCluster = "k8scl-one"
+	testVPCPath = "/orgs/default/projects/project-1/vpcs/vpc-1"
+)
+
+func newTestServer(t *testing.T) (*Server, *nsx.Client) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	nsxClient := server.NewClient(testCluster)
+	require.NotNil(t, nsxClient)
+	return server, nsxClient
+}
+
+func newSubnet(id string, tags ...model.Tag) *model.VpcSubnet {
+	path := testVPCPath + "/subnets/" + id
+	parentPath := testVPCPath
+	return &model.VpcSubnet{
+		Id:          &id,
+		DisplayName: &id,
+		Path:        &path,
+		ParentPath:  &parentPath,
+		Tags:        tags,
+	}
+}
+
+func newTag(scope, tag string) model.Tag {
+	return model.Tag{Scope: &scope, Tag: &tag}
+}
+
+func subnetKeyFunc(obj interface{}) (string, error) {
+	if subnet, ok := obj.(*model.VpcSubnet); ok {
+		return *subnet.Path, nil
+	}
+	return "", errors.New("unknown type")
+}
+
+type subnetStore struct {
+	common.ResourceStore
+}
+
+func (s *subnetStore) Apply(obj interface{}) error {
+	return s.Add(obj)
+}
+
+func TestPolicyTreeBuilder(t *testing.T) {
+	server, nsxClient := newTestServer(t)
+	builder, err := common.PolicyPathVpcSubnet.NewPolicyTreeBuilder()
+	require.NoError(t, err)
+
+	subnets := []*model.VpcSubnet{newSubnet("subnet-1"), newSubnet("subnet-2")}
+	require.NoError(t, builder.UpdateMultipleResourcesOnNSX(context.TODO(), subnets, nsxClient))
+	assert.Equal(t, []string{testVPCPath + "/subnets/subnet-1", testVPCPath + "/subnets/subnet-2"}, server.Paths(testVPCPath+"/subnets/"))
+	assert.Equal(t, 1, server.RequestCount("PATCH", PolicyAPIPrefix+"/org-root"))
+
+	subnet, err := nsxClient.SubnetsClient.Get("default", "project-1", "vpc-1", "subnet-1")
+	require.NoError(t, err)
+	assert.Equal(t, "VpcSubnet", *subnet.ResourceType)
+	assert.Equal(t, testVPCPath, *subnet.ParentPath)
+	assert.Equal(t, int64(0), *subnet.Revision)
+
+	// the subnets are deleted with marked_for_delete=true
+	markedForDelete := true
+	subnets[0].MarkedForDelete = &markedForDelete
+	require.NoError(t, builder.UpdateMultipleResourcesOnNSX(context.TODO(), subnets[:1], nsxClient))
+	assert.Equal(t, []string{testVPCPath + "/subnets/subnet-2"}, server.Paths(testVPCPath+"/subnets/"))
+	_, err = nsxClient.SubnetsClient.Get("default", "project-1", "vpc-1", "subnet-1")
+	assert.IsType(t, apierrors.NotFound{}, err)
+
+	// the deleted subnets are kept until they are purged with KeepMarkedForDelete
+	server.KeepMarkedForDelete = true
+	subnets[1].MarkedForDelete = &markedForDelete
+	require.NoError(t, builder.UpdateMultipleResourcesOnNSX(context.TODO(), subnets[1:], nsxClient))
+	object, ok := server.Object(testVPCPath + "/subnets/subnet-2")
+	require.True(t, ok)
+	assert.Equal(t, true, object["marked_for_delete"])
+	list, err := nsxClient.SubnetsClient.List("default", "project-1", "vpc-1", nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, list.Results)
+	server.Purge()
+	assert.Empty(t, server.Paths(testVPCPath+"/subnets/"))
+}
+
+func TestSearchResource(t *testing.T) {
+	server, nsxClient := newTestServer(t)
+	for i, id := range []string{"subnet-1", "subnet-2", "subnet-3"} {
+		cluster := testCluster
+		if i == 2 {
+			cluster = "k8scl-two"
+		}
+		subnet := newSubnet(id, newTag(common.TagScopeCluster, cluster), newTag(common.TagScopeNamespace, "ns-1"))
+		require.NoError(t, server.AddResource(*subnet.Path, subnet, model.VpcSubnetBindingType()))
+	}
+	server.KeepMarkedForDelete = true
+	builder, err := common.PolicyPathVpcSubnet.NewPolicyTreeBuilder()
+	require.NoError(t, err)
+	deleted := newSubnet("subnet-2")
+	markedForDelete := true
+	deleted.MarkedForDelete = &markedForDelete
+	require.NoError(t, builder.UpdateMultipleResourcesOnNSX(context.TODO(), []*model.VpcSubnet{deleted}, nsxClient))
+
+	service := common.Service{NSXClient: nsxClient}
+	store := &subnetStore{ResourceStore: common.ResourceStore{
+		Indexer:     cache.NewIndexer(subnetKeyFunc, cache.Indexers{}),
+		BindingType: model.VpcSubnetBindingType(),
+	}}
+	query := "resource_type:VpcSubnet AND tags.scope:nsx-op\\/cluster AND tags.tag:" + testCluster + " AND marked_for_delete:false"
+	count, err := service.SearchResource(common.ResourceTypeSubnet, query, store, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, []string{testVPCPath + "/subnets/subnet-1"}, store.ListKeys())
+}
+
+func TestCheckRealizeState(t *testing.T) {
+	server, nsxClient := newTestServer(t)
+	service := realizestate.InitializeRealizeState(common.Service{NSXClient: nsxClient})
+	backoff := wait.Backoff{Duration: 10 * time.Millisecond, Steps: 2, Factor: 1}
+
+	vpc := &model.Vpc{}
+	require.NoError(t, server.AddResource(testVPCPath, vpc, model.VpcBindingType()))
+	assert.NoError(t, service.CheckRealizeState(context.TODO(), backoff, testVPCPath, []string{"gateway-interface"}))
+
+	subnetPath := testVPCPath + "/subnets/subnet-1"
+	require.NoError(t, server.AddResource(subnetPath, newSubnet("subnet-1"), model.VpcSubnetBindingType()))
+	server.SetRealizedState(subnetPath, model.GenericPolicyRealizedResource_STATE_ERROR, "no IP available")
+	err := service.CheckRealizeState(context.TODO(), backoff, subnetPath, nil)
+	assert.True(t, nsxutil.IsRealizeStateError(err))
+	assert.Contains(t, err.Error(), "no IP available")
+}
+
+func TestCRUD(t *testing.T) {
+	server, nsxClient := newTestServer(t)
+	subnetPath := testVPCPath + "/subnets/subnet-1"
+	require.NoError(t, server.AddResource(subnetPath, newSubnet("subnet-1"), model.VpcSubnetBindingType()))
+
+	portID := "port-1"
+	port := model.VpcSubnetPort{Id: &portID, DisplayName: &portID}
+	require.NoError(t, nsxClient.PortClient.Patch("default", "project-1", "vpc-1", "subnet-1", portID, port))
+	got, err := server.GetResource(subnetPath+"/ports/port-1", model.VpcSubnetPortBindingType())
+	require.NoError(t, err)
+	assert.Equal(t, subnetPath+"/ports/port-1", *got.(model.VpcSubnetPort).Path)
+
+	// PUT with a stale revision is rejected
+	stale := got.(model.VpcSubnetPort)
+	revision := int64(5)
+	stale.Revision = &revision
+	_, err = nsxClient.PortClient.Update("default", "project-1", "vpc-1", "subnet-1", portID, stale)
+	assert.Error(t, err)
+
+	server.InjectError("DELETE", PolicyAPIPrefix+subnetPath, 1, 500, 500045)
+	assert.Error(t, nsxClient.SubnetsClient.Delete("default", "project-1", "vpc-1", "subnet-1"))
+	require.NoError(t, nsxClient.SubnetsClient.Delete("default", "project-1", "vpc-1", "subnet-1"))
+	_, ok := server.Object(subnetPath + "/ports/port-1")
+	assert.False(t, ok)
+	// DELETE is idempotent
+	require.NoError(t, nsxClient.SubnetsClient.Delete("default", "project-1", "vpc-1", "subnet-1"))
+	assert.Equal(t, 3, server.RequestCount("DELETE", PolicyAPIPrefix+subnetPath))
+}
@@ -84,8 +84,8 @@ func (m *MockSubnetServiceProvider) GetSubnetsByIndex(key, value string) []*mode
 	return arg.Get(0).([]*model.VpcSubnet)
 }
 
-func (m *MockSubnetServiceProvider) CreateOrUpdateSubnet(obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
-	arg := m.Called(obj, vpcInfo, tags)
+func (m *MockSubnetServiceProvider) CreateOrUpdateSubnet(ctx context.Context, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (*model.VpcSubnet, error) {
+	arg := m.Called(ctx, obj, vpcInfo, tags)
 	return arg.Get(0).(*model.VpcSubnet), arg.Error(1)
 }
 
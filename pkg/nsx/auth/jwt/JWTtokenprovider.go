@@ -30,6 +30,12 @@ func (provider *JWTTokenProvider) HeaderValue(token string) string {
 	return "Bearer " + token
 }
 
+// UpdateCredentials replaces the VC user and password, the next JWT is exchanged with a new VAPI session created
+// with them.
+func (provider *JWTTokenProvider) UpdateCredentials(user, password string) {
+	provider.cache.updateCredentials(user, password)
+}
+
 func NewTokenProvider(vcEndpoint string, port int, ssoDomain, user, password string, caCert []byte, insecure bool, scheme string) (auth.TokenProvider, error) {
 	// not load username/password, not create vapi session, defer them to cache.refreshJWT
 	tesClient, err := NewTESClient(vcEndpoint, port, ssoDomain, user, password, caCert, insecure, scheme)
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/sts"
 )
 
 func TestJWTTokenprovider_NewTokenProvider(t *testing.T) {
@@ -22,3 +23,18 @@ func TestJWTTokenprovider_HeaderValue(t *testing.T) {
 	value := provider.HeaderValue("hello")
 	assert.Equal(t, value, "Bearer hello")
 }
+
+func TestJWTTokenprovider_UpdateCredentials(t *testing.T) {
+	provider, _ := NewTokenProvider("127.0.0.1", 443, "vsphere.local", "", "", []byte{}, false, "https")
+	jwtProvider := provider.(*JWTTokenProvider)
+	tesClient := jwtProvider.cache.tesClient
+	assert.True(t, tesClient.reload)
+	tesClient.signer = &sts.Signer{}
+
+	jwtProvider.UpdateCredentials("admin", "passw0rd")
+	assert.False(t, tesClient.reload)
+	assert.Nil(t, tesClient.signer)
+	assert.Equal(t, "admin", tesClient.url.User.Username())
+	password, _ := tesClient.url.User.Password()
+	assert.Equal(t, "passw0rd", password)
+}
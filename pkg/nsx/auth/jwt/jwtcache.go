@@ -63,6 +63,14 @@ func (cache *JWTCache) GetJWT(refreshToken bool) (string, error) {
 	return cache.jwt, nil
 }
 
+func (cache *JWTCache) updateCredentials(user, password string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.tesClient.setUsernamePass(user, password)
+	// renew the VAPI session with the new credentials in the next refresh
+	cache.tesClient.signer = nil
+}
+
 func (cache *JWTCache) refreshJWT() (string, error) {
 	if cache.tesClient.signer == nil {
 		if err := cache.tesClient.reloadUsernamePass(); err != nil {
@@ -153,6 +153,12 @@ func (vcClient *VCClient) reloadUsernamePass() error {
 	return nil
 }
 
+// setUsernamePass sets the user and password which are not reloaded from file anymore.
+func (vcClient *VCClient) setUsernamePass(username, password string) {
+	vcClient.reload = false
+	vcClient.url.User = url.UserPassword(username, password)
+}
+
 // createHOKSigner creates a Hok token for the service account user.
 func (vcClient *VCClient) createHOKSigner() (*sts.Signer, error) {
 	log.Debug("Creating Holder of Key signer")
@@ -0,0 +1,212 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of the circuit breaker of an endpoint.
+type BreakerState string
+
+const (
+	// BreakerClosed means requests are sent to the endpoint normally.
+	BreakerClosed BreakerState = "CLOSED"
+	// BreakerOpen means the endpoint failed too many requests and is skipped until the open timeout expires.
+	BreakerOpen BreakerState = "OPEN"
+	// BreakerHalfOpen means a limited number of trial requests are sent to check if the endpoint recovered.
+	BreakerHalfOpen BreakerState = "HALF_OPEN"
+)
+
+// CircuitBreakerConfig holds the thresholds of the endpoint circuit breaker.
+type CircuitBreakerConfig struct {
+	// Number of consecutive failed requests which opens the breaker. 0 disables the circuit breaker.
+	FailureThreshold int
+	// Number of consecutive successful trial requests in half-open state which closes the breaker.
+	SuccessThreshold int
+	// Time the breaker stays open before trial requests are allowed.
+	OpenTimeout time.Duration
+	// Maximum number of concurrent trial requests in half-open state.
+	HalfOpenMaxRequests int
+}
+
+// circuitBreaker is driven by the outcome of real API requests sent through Transport.RoundTrip,
+// in addition to the keepAlive probe which only checks the health API.
+type circuitBreaker struct {
+	host     string
+	config   CircuitBreakerConfig
+	state    BreakerState
+	failures int
+	success  int
+	inflight int
+	openedAt time.Time
+	sync.Mutex
+}
+
+func newCircuitBreaker(host string, config CircuitBreakerConfig) *circuitBreaker {
+	return &circuitBreaker{host: host, config: defaultBreakerConfig(config), state: BreakerClosed}
+}
+
+func defaultBreakerConfig(config CircuitBreakerConfig) CircuitBreakerConfig {
+	if config.SuccessThreshold <= 0 {
+		config.SuccessThreshold = 1
+	}
+	if config.HalfOpenMaxRequests <= 0 {
+		config.HalfOpenMaxRequests = 1
+	}
+	return config
+}
+
+// enabled checks if the circuit breaker is configured, the caller must hold the lock.
+func (cb *circuitBreaker) enabled() bool {
+	return cb.config.FailureThreshold > 0
+}
+
+// setConfig applies the thresholds of a reloaded config, the breaker is closed if it's disabled by config.
+func (cb *circuitBreaker) setConfig(config CircuitBreakerConfig) {
+	if cb == nil {
+		return
+	}
+	cb.Lock()
+	defer cb.Unlock()
+	cb.config = defaultBreakerConfig(config)
+	if !cb.enabled() {
+		cb.state = BreakerClosed
+		cb.failures = 0
+		cb.success = 0
+		cb.inflight = 0
+	}
+}
+
+// State returns the current state, an expired open breaker is reported as half-open.
+func (cb *circuitBreaker) State() BreakerState {
+	if cb == nil {
+		return BreakerClosed
+	}
+	cb.Lock()
+	defer cb.Unlock()
+	if !cb.enabled() {
+		return BreakerClosed
+	}
+	cb.refresh()
+	return cb.state
+}
+
+// refresh moves an open breaker to half-open once the open timeout expires, the caller must hold the lock.
+func (cb *circuitBreaker) refresh() {
+	if cb.state == BreakerOpen && time.Since(cb.openedAt) >= cb.config.OpenTimeout {
+		log.Info("Circuit breaker is half-open", "endpoint", cb.host, "openedAt", cb.openedAt)
+		cb.state = BreakerHalfOpen
+		cb.success = 0
+		cb.inflight = 0
+	}
+}
+
+// ready checks if a request could be sent to the endpoint without reserving a trial slot.
+func (cb *circuitBreaker) ready() bool {
+	if cb == nil {
+		return true
+	}
+	cb.Lock()
+	defer cb.Unlock()
+	if !cb.enabled() {
+		return true
+	}
+	cb.refresh()
+	switch cb.state {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		return cb.inflight < cb.config.HalfOpenMaxRequests
+	}
+	return true
+}
+
+// allow reserves a trial slot in half-open state, it returns false if the request should not be sent.
+func (cb *circuitBreaker) allow() bool {
+	if cb == nil {
+		return true
+	}
+	cb.Lock()
+	defer cb.Unlock()
+	if !cb.enabled() {
+		return true
+	}
+	cb.refresh()
+	switch cb.state {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if cb.inflight >= cb.config.HalfOpenMaxRequests {
+			return false
+		}
+		cb.inflight++
+	}
+	return true
+}
+
+// record updates the breaker with the outcome of a request which was permitted by allow.
+func (cb *circuitBreaker) record(success bool) {
+	if cb == nil {
+		return
+	}
+	cb.Lock()
+	defer cb.Unlock()
+	if !cb.enabled() {
+		return
+	}
+	switch cb.state {
+	case BreakerClosed:
+		if success {
+			cb.failures = 0
+			return
+		}
+		cb.failures++
+		if cb.failures >= cb.config.FailureThreshold {
+			cb.open()
+		}
+	case BreakerHalfOpen:
+		if cb.inflight > 0 {
+			cb.inflight--
+		}
+		if !success {
+			cb.open()
+			return
+		}
+		cb.success++
+		if cb.success >= cb.config.SuccessThreshold {
+			log.Info("Circuit breaker is closed", "endpoint", cb.host)
+			cb.state = BreakerClosed
+			cb.failures = 0
+			cb.success = 0
+		}
+	}
+}
+
+// release frees the trial slot reserved by allow for a request which isn't sent, the state of the breaker isn't
+// changed.
+func (cb *circuitBreaker) release() {
+	if cb == nil {
+		return
+	}
+	cb.Lock()
+	defer cb.Unlock()
+	if !cb.enabled() {
+		return
+	}
+	if cb.state == BreakerHalfOpen && cb.inflight > 0 {
+		cb.inflight--
+	}
+}
+
+// open trips the breaker, the caller must hold the lock.
+func (cb *circuitBreaker) open() {
+	log.Info("Circuit breaker is open", "endpoint", cb.host, "failures", cb.failures, "openTimeout", cb.config.OpenTimeout)
+	cb.state = BreakerOpen
+	cb.openedAt = time.Now()
+	cb.failures = 0
+	cb.success = 0
+	cb.inflight = 0
+}
@@ -0,0 +1,209 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
+)
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	var nilBreaker *circuitBreaker
+	assert.True(t, nilBreaker.ready())
+	assert.True(t, nilBreaker.allow())
+	nilBreaker.record(false)
+	nilBreaker.release()
+	assert.Equal(t, BreakerClosed, nilBreaker.State())
+
+	cb := newCircuitBreaker("10.0.0.1", CircuitBreakerConfig{})
+	for i := 0; i < 10; i++ {
+		assert.True(t, cb.allow())
+		cb.record(false)
+	}
+	assert.Equal(t, BreakerClosed, cb.State())
+}
+
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	cb := newCircuitBreaker("10.0.0.1", CircuitBreakerConfig{FailureThreshold: 3, SuccessThreshold: 2, OpenTimeout: 50 * time.Millisecond, HalfOpenMaxRequests: 1})
+
+	// success resets the consecutive failures
+	cb.record(false)
+	cb.record(false)
+	cb.record(true)
+	cb.record(false)
+	assert.Equal(t, BreakerClosed, cb.State())
+	cb.record(false)
+	cb.record(false)
+	assert.Equal(t, BreakerOpen, cb.State())
+	assert.False(t, cb.ready())
+	assert.False(t, cb.allow())
+
+	// half-open after the open timeout, only one trial request is permitted
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, BreakerHalfOpen, cb.State())
+	assert.True(t, cb.ready())
+	assert.True(t, cb.allow())
+	assert.False(t, cb.ready())
+	assert.False(t, cb.allow())
+
+	// failed trial opens the breaker again
+	cb.record(false)
+	assert.Equal(t, BreakerOpen, cb.State())
+
+	// successful trials close the breaker
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, cb.allow())
+	cb.record(true)
+	assert.Equal(t, BreakerHalfOpen, cb.State())
+	assert.True(t, cb.allow())
+	cb.record(true)
+	assert.Equal(t, BreakerClosed, cb.State())
+	assert.True(t, cb.ready())
+}
+
+func TestCircuitBreaker_Release(t *testing.T) {
+	cb := newCircuitBreaker("10.0.0.1", CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, OpenTimeout: 50 * time.Millisecond, HalfOpenMaxRequests: 1})
+
+	// release doesn't change a closed breaker
+	assert.True(t, cb.allow())
+	cb.release()
+	assert.Equal(t, BreakerClosed, cb.State())
+
+	cb.record(false)
+	assert.Equal(t, BreakerOpen, cb.State())
+	time.Sleep(60 * time.Millisecond)
+
+	// the released trial slot could be taken by another request
+	assert.True(t, cb.allow())
+	assert.False(t, cb.allow())
+	cb.release()
+	assert.Equal(t, BreakerHalfOpen, cb.State())
+	assert.True(t, cb.ready())
+	assert.True(t, cb.allow())
+
+	// releasing more than reserved doesn't free extra slots
+	cb.release()
+	cb.release()
+	assert.True(t, cb.allow())
+	assert.False(t, cb.allow())
+	cb.record(true)
+	assert.Equal(t, BreakerClosed, cb.State())
+}
+
+func TestCircuitBreaker_SetConfig(t *testing.T) {
+	var nilBreaker *circuitBreaker
+	nilBreaker.setConfig(CircuitBreakerConfig{FailureThreshold: 1})
+
+	cb := newCircuitBreaker("10.0.0.1", CircuitBreakerConfig{})
+	cb.setConfig(CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	cb.record(false)
+	assert.Equal(t, BreakerClosed, cb.State())
+	cb.record(false)
+	assert.Equal(t, BreakerOpen, cb.State())
+
+	// the open breaker is closed once it's disabled
+	cb.setConfig(CircuitBreakerConfig{})
+	assert.Equal(t, BreakerClosed, cb.State())
+	assert.True(t, cb.allow())
+}
+
+func TestSelectEndpointSkipOpenBreaker(t *testing.T) {
+	eps := createSelectorEndpoints()
+	config := CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute}
+	for _, ep := range eps {
+		ep.breaker = newCircuitBreaker(ep.Host(), config)
+	}
+	tr := &Transport{endpoints: eps, selector: NewEndpointSelector(FirstHealthy)}
+
+	eps[0].breaker.record(false)
+	ep, err := tr.selectEndpoint()
+	assert.Nil(t, err)
+	assert.Equal(t, eps[1], ep)
+
+	eps[1].breaker.record(false)
+	eps[2].breaker.record(false)
+	_, err = tr.selectEndpoint()
+	assert.NotNil(t, err)
+
+	cluster := &Cluster{endpoints: eps}
+	assert.Equal(t, RED, cluster.Health())
+	for _, h := range cluster.EndpointsHealth() {
+		assert.Equal(t, UP, h.Status)
+		assert.Equal(t, BreakerOpen, h.Breaker)
+	}
+}
+
+func TestRoundTripOpensBreaker(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error_code":500,"error_message":"internal error"}`))
+	}))
+	defer ts.Close()
+	cluster := &Cluster{config: &Config{CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute}}}
+	tr := cluster.createTransport(idleConnTimeout)
+	client := cluster.createHTTPClient(tr, timeout)
+	noBClient := cluster.createNoBalancerClient(timeout, idleConnTimeout)
+	eps, _ := cluster.createEndpoints([]string{ts.URL[strings.Index(ts.URL, "//")+2:]}, client, noBClient, ratelimiter.NewFixRateLimiter(0), nil)
+	eps[0].status = UP
+	cluster.endpoints = eps
+	tr.endpoints = eps
+	tr.config = cluster.config
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", ts.URL, nil)
+		resp, _ := tr.RoundTrip(req)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	}
+	assert.Equal(t, BreakerOpen, eps[0].BreakerState())
+	assert.Equal(t, RED, cluster.Health())
+
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	_, err := tr.RoundTrip(req)
+	assert.NotNil(t, err)
+}
+
+func TestRoundTripReleasesBreakerSlot(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	cluster := &Cluster{config: &Config{CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 50 * time.Millisecond}}}
+	tr := cluster.createTransport(idleConnTimeout)
+	client := cluster.createHTTPClient(tr, timeout)
+	noBClient := cluster.createNoBalancerClient(timeout, idleConnTimeout)
+	eps, _ := cluster.createEndpoints([]string{ts.URL[strings.Index(ts.URL, "//")+2:]}, client, noBClient, ratelimiter.NewFixRateLimiter(0), nil)
+	eps[0].status = UP
+	cluster.endpoints = eps
+	tr.endpoints = eps
+	tr.config = cluster.config
+
+	eps[0].breaker.record(false)
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, BreakerHalfOpen, eps[0].BreakerState())
+
+	// the trial request is canceled while the endpoint is throttled
+	eps[0].throttleFor(300 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+	_, err := tr.RoundTrip(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, eps[0].breaker.ready())
+
+	// the endpoint recovers with the next trial request
+	req, _ = http.NewRequest("GET", ts.URL, nil)
+	resp, err := tr.RoundTrip(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, BreakerClosed, eps[0].BreakerState())
+}
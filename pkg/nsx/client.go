@@ -7,6 +7,9 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	nsxt "github.com/vmware/go-vmware-nsxt"
@@ -39,6 +42,7 @@ import (
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/recorder"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
 
@@ -53,10 +57,11 @@ const (
 	SubnetIPReservation
 	SubnetMinimalSize8
 	VTEPLessMode
+	SecurityPolicyFQDN
 	AllFeatures
 )
 
-var FeaturesName = [AllFeatures]string{"VPC", "SECURITY_POLICY", "NSX_SERVICE_ACCOUNT", "NSX_SERVICE_ACCOUNT_RESTORE", "NSX_SERVICE_ACCOUNT_CERT_ROTATION", "STATIC_ROUTE", "VPC_PREFERRED_DEFAULT_SNAT_IP", "SUBNET_IP_RESERVATION", "SUBNET_MINIMAL_SIZE_8", "VTEP_LESS_MODE"}
+var FeaturesName = [AllFeatures]string{"VPC", "SECURITY_POLICY", "NSX_SERVICE_ACCOUNT", "NSX_SERVICE_ACCOUNT_RESTORE", "NSX_SERVICE_ACCOUNT_CERT_ROTATION", "STATIC_ROUTE", "VPC_PREFERRED_DEFAULT_SNAT_IP", "SUBNET_IP_RESERVATION", "SUBNET_MINIMAL_SIZE_8", "VTEP_LESS_MODE", "SECURITY_POLICY_FQDN"}
 
 type Client struct {
 	NsxConfig     *config.NSXOperatorConfig
@@ -114,11 +119,23 @@ type Client struct {
 	LbMonitorProfilesClient           infra.LbMonitorProfilesClient
 	SubnetConnectionBindingMapsClient subnets.SubnetConnectionBindingMapsClient
 	DynamicIPReservationsClient       subnets.DynamicIpReservationsClient
-	NsxApiClient                      *nsxt.APIClient
 	VifsClient                        fabric.VifsClient
+	// restConnectorAllowOverwrite is the connector of the SDK clients which overwrite the objects created by others.
+	restConnectorAllowOverwrite client.Connector
+	// reloadState is shared by the copies returned by WithContext.
+	reloadState *clientReloadState
+
+	NSXChecker NSXHealthChecker
+	// NSXVerChecker is shared by the copies returned by WithContext so that the supported features are checked once.
+	NSXVerChecker *NSXVersionChecker
+}
 
-	NSXChecker    NSXHealthChecker
-	NSXVerChecker NSXVersionChecker
+// clientReloadState holds the parts of Client which are replaced on Reload.
+type clientReloadState struct {
+	// mutex serializes the reloads triggered by the config file and the Secret watchers.
+	mutex sync.Mutex
+	// nsxApiClient is recreated on Reload, use NsxApiClient to read it.
+	nsxApiClient atomic.Pointer[nsxt.APIClient]
 }
 
 var (
@@ -157,10 +174,8 @@ func restConnectorAllowOverwrite(c *Cluster) client.Connector {
 	return c.NewRestConnectorAllowOverwrite()
 }
 
-func GetClient(cf *config.NSXOperatorConfig) *Client {
-	// Set log level for vsphere-automation-sdk-go
-	logger := logrus.New()
-	vspherelog.SetLogger(logger)
+// newClusterConfig creates the Config of the Cluster from the NSX Operator config.
+func newClusterConfig(cf *config.NSXOperatorConfig) *Config {
 	// This is the overall timeout for NSX client
 	// NSX server does not have timeout, some of the request may take over one minute.
 	defaultHttpTimeout := 180
@@ -171,66 +186,47 @@ func GetClient(cf *config.NSXOperatorConfig) *Client {
 		ratelimiter.AIMD, cf.GetTokenProvider(), nil, cf.Thumbprint)
 	c.EnvoyHost = cf.EnvoyHost
 	c.EnvoyPort = cf.EnvoyPort
-	cluster, _ := NewCluster(c)
+	c.EndpointSelector = SelectorType(cf.EndpointSelectionStrategy)
+	c.EndpointWeights = cf.EndpointWeights
+	c.CircuitBreaker = CircuitBreakerConfig{
+		FailureThreshold:    cf.CircuitBreakerFailureThreshold,
+		SuccessThreshold:    cf.CircuitBreakerSuccessThreshold,
+		OpenTimeout:         time.Duration(cf.CircuitBreakerOpenTimeout) * time.Second,
+		HalfOpenMaxRequests: cf.CircuitBreakerHalfOpenRequests,
+	}
+	return c
+}
+
+// newRecorder returns the Recorder which records or replays the NSX API calls, it's nil if it's not configured. It's
+// not part of newClusterConfig since the golden files are reset once a recorder is created.
+func newRecorder(cf *config.NSXOperatorConfig) *recorder.Recorder {
+	if cf.NsxConfig == nil || cf.TransportRecordMode == "" {
+		return nil
+	}
+	r, err := recorder.New(recorder.Mode(cf.TransportRecordMode), cf.TransportRecordDir)
+	if err != nil {
+		log.Error(err, "Failed to create NSX API recorder", "mode", cf.TransportRecordMode, "dir", cf.TransportRecordDir)
+		return nil
+	}
+	return r
+}
+
+func GetClient(cf *config.NSXOperatorConfig) *Client {
+	// Set log level for vsphere-automation-sdk-go
+	logger := logrus.New()
+	vspherelog.SetLogger(logger)
+	clusterConfig := newClusterConfig(cf)
+	if r := newRecorder(cf); r != nil {
+		clusterConfig.WrapTransport = r.Wrap
+		clusterConfig.WrapSessionTransport = r.WrapSession
+	}
+	clusterConfig.WrapTransport = newPlanWrapper(cf, clusterConfig.WrapTransport)
+	cluster, _ := NewCluster(clusterConfig)
 
 	connector := restConnector(cluster)
 	connectorAllowOverwrite := restConnectorAllowOverwrite(cluster)
 
-	queryClient := search.NewQueryClient(connector)
-	groupClient := domains.NewGroupsClient(connector)
-	securityClient := domains.NewSecurityPoliciesClient(connector)
-	ruleClient := security_policies.NewRulesClient(connector)
-	infraClient := nsx_policy.NewInfraClient(connector)
-	statusClient := restore.NewStatusClient(restConnector(cluster))
-
-	clusterControlPlanesClient := enforcement_points.NewClusterControlPlanesClient(connector)
-	hostTransportNodesClient := enforcement_points.NewHostTransportNodesClient(connector)
-	realizedEntitiesClient := infra_realized.NewRealizedEntitiesClient(connector)
-	realizedEntityClient := infra_realized.NewRealizedEntityClient(connector)
-	mpQueryClient := mpsearch.NewQueryClient(connector)
-	certificatesClient := trust_management.NewCertificatesClient(connector)
-	principalIdentitiesClient := trust_management.NewPrincipalIdentitiesClient(connector)
-	withCertificateClient := principal_identities.NewWithCertificateClient(connector)
-
-	lbAppProfileClient := infra.NewLbAppProfilesClient(connector)
-	lbPersistenceProfilesClient := infra.NewLbPersistenceProfilesClient(connector)
-	lbMonitorProfilesClient := infra.NewLbMonitorProfilesClient(connector)
-
-	orgRootClient := nsx_policy.NewOrgRootClient(connector)
-	projectInfraClient := projects.NewInfraClient(connector)
-	projectClient := orgs.NewProjectsClient(connector)
-	vpcClient := projects.NewVpcsClient(connector)
-	vpcStateClient := vpcs.NewStateClient(connector)
-	vpcConnectivityProfilesClient := projects.NewVpcConnectivityProfilesClient(connector)
-	ipBlockClient := project_infra.NewIpBlocksClient(connector)
-	staticRouteClient := vpcs.NewStaticRoutesClient(connector)
-	natRulesClient := nat.NewNatRulesClient(connector)
-	vpcGroupClient := vpcs.NewGroupsClient(connector)
-	portClient := subnets.NewPortsClient(connectorAllowOverwrite)
-	portStateClient := ports.NewStateClient(connector)
-	ipPoolClient := subnets.NewIpPoolsClient(connector)
-	ipAllocationClient := ip_pools.NewIpAllocationsClient(connector)
-	statsClient := dhcp_server_config.NewStatsClient(connector)
-	subnetsClient := vpcs.NewSubnetsClient(connector)
-	subnetStatusClient := subnets.NewStatusClient(connector)
-	ipAddressAllocationClient := vpcs.NewIpAddressAllocationsClient(connectorAllowOverwrite)
-	vpcLBSClient := vpcs.NewVpcLbsClient(connector)
-	vpcLbVirtualServersClient := vpcs.NewVpcLbVirtualServersClient(connector)
-	vpcLbPoolsClient := vpcs.NewVpcLbPoolsClient(connector)
-	vpcAttachmentClient := vpcs.NewAttachmentsClient(connector)
-
-	vpcSecurityClient := vpcs.NewSecurityPoliciesClient(connector)
-	vpcRuleClient := vpc_sp.NewRulesClient(connector)
-
-	transitGatewayClient := projects.NewTransitGatewaysClient(connector)
-	transitGatewayAttachmentClient := transit_gateways.NewAttachmentsClient(connector)
-	transitGatewayStateClient := transit_gateways.NewStateClient(connector)
-
-	subnetConnectionBindingMapsClient := subnets.NewSubnetConnectionBindingMapsClient(connector)
-	DynamicIPReservationsClient := subnets.NewDynamicIpReservationsClient(connector)
-
 	nsxApiClient, _ := CreateNsxtApiClient(cf, cluster.client)
-	vifsClient := fabric.NewVifsClient(connector)
 
 	nsxChecker := &NSXHealthChecker{
 		cluster: cluster,
@@ -241,63 +237,17 @@ func GetClient(cf *config.NSXOperatorConfig) *Client {
 	}
 
 	nsxClient := &Client{
-		NsxConfig:                  cf,
-		RestConnector:              connector,
-		QueryClient:                queryClient,
-		GroupClient:                groupClient,
-		SecurityClient:             securityClient,
-		RuleClient:                 ruleClient,
-		InfraClient:                infraClient,
-		StatusClient:               statusClient,
-		Cluster:                    cluster,
-		ClusterControlPlanesClient: clusterControlPlanesClient,
-		HostTransPortNodesClient:   hostTransportNodesClient,
-		RealizedEntitiesClient:     realizedEntitiesClient,
-		RealizedEntityClient:       realizedEntityClient,
-		MPQueryClient:              mpQueryClient,
-		CertificatesClient:         certificatesClient,
-		PrincipalIdentitiesClient:  principalIdentitiesClient,
-		WithCertificateClient:      withCertificateClient,
-
+		NsxConfig:     cf,
+		RestConnector: connector,
+		Cluster:       cluster,
 		// Health clients are now using REST API directly
-
-		OrgRootClient:                     orgRootClient,
-		ProjectInfraClient:                projectInfraClient,
-		VPCClient:                         vpcClient,
-		VPCStateClient:                    vpcStateClient,
-		VPCConnectivityProfilesClient:     vpcConnectivityProfilesClient,
-		IPBlockClient:                     ipBlockClient,
-		StaticRouteClient:                 staticRouteClient,
-		NATRuleClient:                     natRulesClient,
-		VpcGroupClient:                    vpcGroupClient,
-		PortClient:                        portClient,
-		PortStateClient:                   portStateClient,
-		SubnetStatusClient:                subnetStatusClient,
-		VPCSecurityClient:                 vpcSecurityClient,
-		VPCRuleClient:                     vpcRuleClient,
-		VPCLBSClient:                      vpcLBSClient,
-		VpcLbVirtualServersClient:         vpcLbVirtualServersClient,
-		VpcLbPoolsClient:                  vpcLbPoolsClient,
-		VpcAttachmentClient:               vpcAttachmentClient,
-		ProjectClient:                     projectClient,
-		NSXChecker:                        *nsxChecker,
-		NSXVerChecker:                     *nsxVersionChecker,
-		IPPoolClient:                      ipPoolClient,
-		IPAllocationClient:                ipAllocationClient,
-		DhcpServerConfigStatsClient:       statsClient,
-		SubnetsClient:                     subnetsClient,
-		IPAddressAllocationClient:         ipAddressAllocationClient,
-		TransitGatewayClient:              transitGatewayClient,
-		TransitGatewayAttachmentClient:    transitGatewayAttachmentClient,
-		TransitGatewayStateClient:         transitGatewayStateClient,
-		SubnetConnectionBindingMapsClient: subnetConnectionBindingMapsClient,
-		DynamicIPReservationsClient:       DynamicIPReservationsClient,
-		LbAppProfileClient:                lbAppProfileClient,
-		LbPersistenceProfilesClient:       lbPersistenceProfilesClient,
-		LbMonitorProfilesClient:           lbMonitorProfilesClient,
-		NsxApiClient:                      nsxApiClient,
-		VifsClient:                        vifsClient,
+		NSXChecker:                  *nsxChecker,
+		NSXVerChecker:               nsxVersionChecker,
+		restConnectorAllowOverwrite: connectorAllowOverwrite,
+		reloadState:                 &clientReloadState{},
 	}
+	nsxClient.setSDKClients(connector, connectorAllowOverwrite)
+	nsxClient.SetNsxApiClient(nsxApiClient)
 	// NSX version check will be restarted during SecurityPolicy reconcile
 	// So, it's unnecessary to exit even if failed in the first time
 	if !nsxClient.NSXCheckVersion(SecurityPolicy) {
@@ -320,6 +270,64 @@ func GetClient(cf *config.NSXOperatorConfig) *Client {
 	return nsxClient
 }
 
+// setSDKClients creates the SDK clients with the connectors, WithContext creates them again with the connectors
+// carrying the context.
+func (client *Client) setSDKClients(connector, connectorAllowOverwrite client.Connector) {
+	client.QueryClient = search.NewQueryClient(connector)
+	client.GroupClient = domains.NewGroupsClient(connector)
+	client.SecurityClient = domains.NewSecurityPoliciesClient(connector)
+	client.RuleClient = security_policies.NewRulesClient(connector)
+	client.InfraClient = nsx_policy.NewInfraClient(connector)
+	client.StatusClient = restore.NewStatusClient(connector)
+
+	client.ClusterControlPlanesClient = enforcement_points.NewClusterControlPlanesClient(connector)
+	client.HostTransPortNodesClient = enforcement_points.NewHostTransportNodesClient(connector)
+	client.RealizedEntitiesClient = infra_realized.NewRealizedEntitiesClient(connector)
+	client.RealizedEntityClient = infra_realized.NewRealizedEntityClient(connector)
+	client.MPQueryClient = mpsearch.NewQueryClient(connector)
+	client.CertificatesClient = trust_management.NewCertificatesClient(connector)
+	client.PrincipalIdentitiesClient = trust_management.NewPrincipalIdentitiesClient(connector)
+	client.WithCertificateClient = principal_identities.NewWithCertificateClient(connector)
+
+	client.LbAppProfileClient = infra.NewLbAppProfilesClient(connector)
+	client.LbPersistenceProfilesClient = infra.NewLbPersistenceProfilesClient(connector)
+	client.LbMonitorProfilesClient = infra.NewLbMonitorProfilesClient(connector)
+
+	client.OrgRootClient = nsx_policy.NewOrgRootClient(connector)
+	client.ProjectInfraClient = projects.NewInfraClient(connector)
+	client.ProjectClient = orgs.NewProjectsClient(connector)
+	client.VPCClient = projects.NewVpcsClient(connector)
+	client.VPCStateClient = vpcs.NewStateClient(connector)
+	client.VPCConnectivityProfilesClient = projects.NewVpcConnectivityProfilesClient(connector)
+	client.IPBlockClient = project_infra.NewIpBlocksClient(connector)
+	client.StaticRouteClient = vpcs.NewStaticRoutesClient(connector)
+	client.NATRuleClient = nat.NewNatRulesClient(connector)
+	client.VpcGroupClient = vpcs.NewGroupsClient(connector)
+	client.PortClient = subnets.NewPortsClient(connectorAllowOverwrite)
+	client.PortStateClient = ports.NewStateClient(connector)
+	client.IPPoolClient = subnets.NewIpPoolsClient(connector)
+	client.IPAllocationClient = ip_pools.NewIpAllocationsClient(connector)
+	client.DhcpServerConfigStatsClient = dhcp_server_config.NewStatsClient(connector)
+	client.SubnetsClient = vpcs.NewSubnetsClient(connector)
+	client.SubnetStatusClient = subnets.NewStatusClient(connector)
+	client.IPAddressAllocationClient = vpcs.NewIpAddressAllocationsClient(connectorAllowOverwrite)
+	client.VPCLBSClient = vpcs.NewVpcLbsClient(connector)
+	client.VpcLbVirtualServersClient = vpcs.NewVpcLbVirtualServersClient(connector)
+	client.VpcLbPoolsClient = vpcs.NewVpcLbPoolsClient(connector)
+	client.VpcAttachmentClient = vpcs.NewAttachmentsClient(connector)
+
+	client.VPCSecurityClient = vpcs.NewSecurityPoliciesClient(connector)
+	client.VPCRuleClient = vpc_sp.NewRulesClient(connector)
+
+	client.TransitGatewayClient = projects.NewTransitGatewaysClient(connector)
+	client.TransitGatewayAttachmentClient = transit_gateways.NewAttachmentsClient(connector)
+	client.TransitGatewayStateClient = transit_gateways.NewStateClient(connector)
+
+	client.SubnetConnectionBindingMapsClient = subnets.NewSubnetConnectionBindingMapsClient(connector)
+	client.DynamicIPReservationsClient = subnets.NewDynamicIpReservationsClient(connector)
+	client.VifsClient = fabric.NewVifsClient(connector)
+}
+
 func CreateNsxtApiClient(config *config.NSXOperatorConfig, client *http.Client) (*nsxt.APIClient, error) {
 	var defaultRetryOnStatusCodes = []int{
 		http.StatusRequestTimeout,     // 408
@@ -346,7 +354,8 @@ func CreateNsxtApiClient(config *config.NSXOperatorConfig, client *http.Client)
 		CAFile:               config.NsxApiCertFile,
 		Insecure:             config.Insecure,
 		RetriesConfiguration: retriesConfig,
-		HTTPClient:           client,
+		// the client is used by inventory sync, which is background traffic
+		HTTPClient: withPriority(client, ratelimiter.PriorityBackground),
 		// using jwt instead of session
 		SkipSessionAuth: true,
 	}
@@ -358,7 +367,51 @@ func CreateNsxtApiClient(config *config.NSXOperatorConfig, client *http.Client)
 	return nsxClient, nil
 }
 
+// Reload applies the NSX managers, credentials and certificate files in cf to the running Client. The SDK clients
+// are kept since their requests are sent through the reloaded Cluster.
+func (client *Client) Reload(cf *config.NSXOperatorConfig) error {
+	state := client.getReloadState()
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	if err := client.Cluster.Reload(newClusterConfig(cf)); err != nil {
+		return err
+	}
+	client.NsxConfig.UpdateNsxConnection(cf.NsxConfig)
+	nsxApiClient, err := CreateNsxtApiClient(cf, client.Cluster.client)
+	if err != nil {
+		log.Error(err, "Failed to recreate NSX API client")
+		return err
+	}
+	client.SetNsxApiClient(nsxApiClient)
+	return nil
+}
+
+// NsxApiClient returns the NSX API client used by inventory sync.
+func (client *Client) NsxApiClient() *nsxt.APIClient {
+	if client.reloadState == nil {
+		return nil
+	}
+	return client.reloadState.nsxApiClient.Load()
+}
+
+// SetNsxApiClient replaces the NSX API client used by inventory sync.
+func (client *Client) SetNsxApiClient(nsxApiClient *nsxt.APIClient) {
+	client.getReloadState().nsxApiClient.Store(nsxApiClient)
+}
+
+// getReloadState allocates the reload state of the Client built without GetClient, it's done before the Client is
+// shared, e.g. in tests.
+func (client *Client) getReloadState() *clientReloadState {
+	if client.reloadState == nil {
+		client.reloadState = &clientReloadState{}
+	}
+	return client.reloadState
+}
+
 func (client *Client) NSXCheckVersion(feature int) bool {
+	if client.NSXVerChecker == nil {
+		client.NSXVerChecker = &NSXVersionChecker{cluster: client.Cluster}
+	}
 	if client.NSXVerChecker.featureSupported[feature] {
 		return true
 	}
@@ -384,7 +437,7 @@ func (client *Client) NSXCheckVersion(feature int) bool {
 }
 
 func (client *Client) FeatureEnabled(feature int) bool {
-	return client.NSXVerChecker.featureSupported[feature] == true
+	return client.NSXVerChecker != nil && client.NSXVerChecker.featureSupported[feature]
 }
 
 // ValidateLicense validates NSX license. init is used to indicate whether nsx-operator is init or not
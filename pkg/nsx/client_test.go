@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/agiledragon/gomonkey/v2"
@@ -360,3 +361,67 @@ func TestValidateLicense(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Reload(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"healthy": true, "components_health": "POLICY:UP, SEARCH:UP, MANAGER:UP, NODE_MGMT:UP, UI:UP"}`))
+	})
+	ts1 := httptest.NewTLSServer(handler)
+	defer ts1.Close()
+	ts2 := httptest.NewTLSServer(handler)
+	defer ts2.Close()
+	host1 := ts1.URL[strings.Index(ts1.URL, "//")+2:]
+	host2 := ts2.URL[strings.Index(ts2.URL, "//")+2:]
+
+	newConfig := func(host, password string) *config.NSXOperatorConfig {
+		cf := config.NewNSXOpertorConfig()
+		cf.NsxApiManagers = []string{host}
+		cf.NsxApiUser = "admin"
+		cf.NsxApiPassword = password
+		cf.Insecure = true
+		return cf
+	}
+	client := GetClient(newConfig(host1, "passw0rd"))
+	assert.NotNil(t, client)
+	oldApiClient := client.NsxApiClient()
+	assert.NotNil(t, oldApiClient)
+	// the copy returned by WithContext shares the reloaded API client
+	copied := client.WithPriority(ratelimiter.PriorityBackground)
+
+	// the reloads of the config file and Secret watchers run with the readers of the reconcilers
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				_ = client.NsxApiClient()
+				_ = client.NsxConfig.GetNsxApiManagers()
+				_ = client.NsxConfig.GetCACert()
+			}
+		}()
+	}
+	var reloadWG sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		reloadWG.Add(1)
+		go func() {
+			defer reloadWG.Done()
+			assert.Nil(t, client.Reload(newConfig(host2, "newpassw0rd")))
+		}()
+	}
+	reloadWG.Wait()
+	close(stop)
+	wg.Wait()
+
+	assert.Equal(t, []string{host2}, client.NsxConfig.GetNsxApiManagers())
+	assert.Equal(t, "newpassw0rd", client.NsxConfig.NsxApiPassword)
+	assert.NotSame(t, oldApiClient, client.NsxApiClient())
+	assert.Same(t, client.NsxApiClient(), copied.NsxApiClient())
+}
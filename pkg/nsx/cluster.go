@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -49,6 +50,8 @@ const (
 const (
 	NSXGetDelay           = 2 * time.Second
 	GetNsxVersionInterval = 30 * time.Minute
+	// endpointDrainInterval is the interval to check the in-flight requests of a removed endpoint.
+	endpointDrainInterval = 500 * time.Millisecond
 )
 
 // Cluster consists of endpoint and provides http.Client used to send http requests.
@@ -58,6 +61,8 @@ type Cluster struct {
 	transport        *Transport
 	client           *http.Client
 	noBalancerClient *http.Client
+	ratelimiter      ratelimiter.RateLimiter
+	// Mutex protects config and endpoints which are replaced by Reload.
 	sync.Mutex
 	nsxVersion         *NsxVersion
 	lastTimeGetVersion time.Time
@@ -82,7 +87,9 @@ func NewCluster(config *Config) (*Cluster, error) {
 	cluster.client = cluster.createHTTPClient(cluster.transport, time.Duration(config.HTTPTimeout))
 	cluster.noBalancerClient = cluster.createNoBalancerClient(time.Duration(config.HTTPTimeout), time.Duration(config.ConnIdleTimeout))
 
-	r := ratelimiter.NewRateLimiter(config.APIRateMode)
+	// the endpoints share one rate limiter, the background requests get a bounded share of it
+	r := ratelimiter.NewFairRateLimiter(ratelimiter.NewRateLimiter(config.APIRateMode), ratelimiter.DefaultBackgroundShare)
+	cluster.ratelimiter = r
 	eps, err := cluster.createEndpoints(config.APIManagers, cluster.client, cluster.noBalancerClient, r, config.TokenProvider)
 	if err != nil {
 		log.Error(err, "Failed to create cluster")
@@ -92,6 +99,7 @@ func NewCluster(config *Config) (*Cluster, error) {
 	cluster.endpoints = eps
 	cluster.transport.endpoints = eps
 	cluster.transport.config = cluster.config
+	cluster.transport.selector = NewEndpointSelector(config.EndpointSelector)
 	cluster.loadCAforEnvoy()
 	for _, ep := range cluster.endpoints {
 		envoyUrl := cluster.CreateServerUrl(ep.Host(), ep.Scheme())
@@ -122,10 +130,12 @@ func (cluster *Cluster) loadCAforEnvoy() {
 	if !cluster.UsingEnvoy() {
 		return
 	}
-	for i, caFile := range cluster.config.CAFile {
+	config := cluster.getConfig()
+	endpoints := cluster.getEndpoints()
+	for i, caFile := range config.CAFile {
 		cert := util.CertPemBytesToHeader(caFile)
 		if cert != "" {
-			cluster.endpoints[i].caFile = cert
+			endpoints[i].setCertificate(cert, endpoints[i].thumbprint())
 			log.Info("Load CA for envoy sidecar", "caFile", caFile)
 			return
 		} else {
@@ -133,8 +143,8 @@ func (cluster *Cluster) loadCAforEnvoy() {
 		}
 	}
 
-	for i, thumbprint := range cluster.config.Thumbprint {
-		cluster.endpoints[i].Thumbprint = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(thumbprint, ":", "")))
+	for i, thumbprint := range config.Thumbprint {
+		endpoints[i].setCertificate("", strings.ToLower(strings.TrimSpace(strings.ReplaceAll(thumbprint, ":", ""))))
 	}
 }
 
@@ -150,7 +160,7 @@ func (cluster *Cluster) CreateServerUrl(host string, scheme string) string {
 			mgrIP = strings.ReplaceAll(host, ":", "/")
 		}
 
-		cf := cluster.config
+		cf := cluster.getConfig()
 		if len(cf.CAFile) > 0 {
 			envoyUrl = fmt.Sprintf(EnvoyUrlWithCert, cf.EnvoyHost, cf.EnvoyPort, mgrIP)
 		} else if len(cf.Thumbprint) > 0 {
@@ -171,7 +181,8 @@ func (cluster *Cluster) CreateServerUrl(host string, scheme string) string {
 
 // NewRestConnector creates a RestConnector used for SDK client.
 func (cluster *Cluster) NewRestConnector() policyclient.Connector {
-	nsxtUrl := cluster.CreateServerUrl(cluster.endpoints[0].Host(), cluster.endpoints[0].Scheme())
+	ep := cluster.getEndpoints()[0]
+	nsxtUrl := cluster.CreateServerUrl(ep.Host(), ep.Scheme())
 	connector := policyclient.NewConnector(nsxtUrl, policyclient.UsingRest(nil), policyclient.WithHttpClient(cluster.client))
 	connector.NewExecutionContext()
 	return connector
@@ -182,7 +193,8 @@ func SetAllowOverwriteHeader(req *http.Request) error {
 	return nil
 }
 func (cluster *Cluster) NewRestConnectorAllowOverwrite() policyclient.Connector {
-	nsxtUrl := cluster.CreateServerUrl(cluster.endpoints[0].Host(), cluster.endpoints[0].Scheme())
+	ep := cluster.getEndpoints()[0]
+	nsxtUrl := cluster.CreateServerUrl(ep.Host(), ep.Scheme())
 	policyclient.WithRequestProcessors()
 	connector := policyclient.NewConnector(nsxtUrl, policyclient.UsingRest(nil), policyclient.WithHttpClient(cluster.client), policyclient.WithRequestProcessors(SetAllowOverwriteHeader))
 	connector.NewExecutionContext()
@@ -190,24 +202,37 @@ func (cluster *Cluster) NewRestConnectorAllowOverwrite() policyclient.Connector
 }
 
 func (cluster *Cluster) UsingEnvoy() bool {
-	return cluster.config.EnvoyPort != 0
+	return cluster.getConfig().EnvoyPort != 0
+}
+
+func (cluster *Cluster) getConfig() *Config {
+	cluster.Lock()
+	defer cluster.Unlock()
+	return cluster.config
+}
+
+func (cluster *Cluster) getEndpoints() []*Endpoint {
+	cluster.Lock()
+	defer cluster.Unlock()
+	return cluster.endpoints
 }
 
 func (cluster *Cluster) getThumbprint(addr string) string {
 	host := addr[:strings.Index(addr, ":")]
 	var thumbprint string
-	tpCount := len(cluster.config.Thumbprint)
+	config := cluster.getConfig()
+	tpCount := len(config.Thumbprint)
 	if tpCount == 1 {
-		thumbprint = cluster.config.Thumbprint[0]
+		thumbprint = config.Thumbprint[0]
 	}
 	if tpCount > 1 {
-		for index, ep := range cluster.endpoints {
+		for index, ep := range cluster.getEndpoints() {
 			epHost := ep.Host()
 			if pos := strings.Index(ep.Host(), ":"); pos > 0 {
 				epHost = epHost[:pos]
 			}
 			if epHost == host {
-				thumbprint = cluster.config.Thumbprint[index]
+				thumbprint = config.Thumbprint[index]
 				break
 			}
 		}
@@ -218,18 +243,19 @@ func (cluster *Cluster) getThumbprint(addr string) string {
 func (cluster *Cluster) getCaFile(addr string) string {
 	host := addr[:strings.Index(addr, ":")]
 	var cafile string
-	tpCount := len(cluster.config.CAFile)
+	config := cluster.getConfig()
+	tpCount := len(config.CAFile)
 	if tpCount == 1 {
-		cafile = cluster.config.CAFile[0]
+		cafile = config.CAFile[0]
 	}
 	if tpCount > 1 {
-		for index, ep := range cluster.endpoints {
+		for index, ep := range cluster.getEndpoints() {
 			epHost := ep.Host()
 			if pos := strings.Index(ep.Host(), ":"); pos > 0 {
 				epHost = epHost[:pos]
 			}
 			if epHost == host {
-				cafile = cluster.config.CAFile[index]
+				cafile = config.CAFile[index]
 				break
 			}
 		}
@@ -246,7 +272,7 @@ func (cluster *Cluster) createTransport(idle time.Duration) *Transport {
 		dial := func(ctx context.Context, network, addr string) (net.Conn, error) { // #nosec G402: ignore insecure options
 			var config *tls.Config
 			cafile := cluster.getCaFile(addr)
-			caCount := len(cluster.config.CAFile)
+			caCount := len(cluster.getConfig().CAFile)
 			log.Info("Create Transport", "ca file", cafile, "caCount", caCount)
 			if caCount > 0 {
 				caCert, err := os.ReadFile(cafile)
@@ -262,7 +288,7 @@ func (cluster *Cluster) createTransport(idle time.Duration) *Transport {
 				}
 			} else {
 				thumbprint := cluster.getThumbprint(addr)
-				tpCount := len(cluster.config.Thumbprint)
+				tpCount := len(cluster.getConfig().Thumbprint)
 				log.Info("Create Transport", "thumbprint", thumbprint, "tpCount", tpCount)
 				// #nosec G402: ignore insecure options
 				config = &tls.Config{
@@ -297,8 +323,12 @@ func (cluster *Cluster) createTransport(idle time.Duration) *Transport {
 }
 
 func (cluster *Cluster) createHTTPClient(tr *Transport, timeout time.Duration) *http.Client {
+	var rt http.RoundTripper = tr
+	if cluster.config.WrapTransport != nil {
+		rt = cluster.config.WrapTransport(rt)
+	}
 	return &http.Client{
-		Transport: tr,
+		Transport: rt,
 		Timeout:   timeout * time.Second,
 	}
 }
@@ -306,10 +336,13 @@ func (cluster *Cluster) createHTTPClient(tr *Transport, timeout time.Duration) *
 func (cluster *Cluster) createNoBalancerClient(timeout, idle time.Duration) *http.Client {
 	// #nosec G402: ignore insecure options
 	tlsConfig := tls.Config{InsecureSkipVerify: true}
-	transport := &http.Transport{
+	var transport http.RoundTripper = &http.Transport{
 		TLSClientConfig: &tlsConfig,
 		IdleConnTimeout: idle * time.Second,
 	}
+	if cluster.config.WrapSessionTransport != nil {
+		transport = cluster.config.WrapSessionTransport(transport)
+	}
 	noBClient := http.Client{
 		Transport: transport,
 		Timeout:   timeout * time.Second,
@@ -324,33 +357,181 @@ func (cluster *Cluster) createEndpoints(apiManagers []string, client *http.Clien
 		if err != nil {
 			return nil, err
 		}
+		if cluster.config != nil {
+			if i < len(cluster.config.EndpointWeights) {
+				ep.setWeight(cluster.config.EndpointWeights[i])
+			}
+			ep.breaker = newCircuitBreaker(ep.Host(), cluster.config.CircuitBreaker)
+		}
 		eps[i] = ep
 	}
 	return eps, nil
 }
 
 func (cluster *Cluster) createAuthSessions() {
-	for _, ep := range cluster.endpoints {
-		ep.createAuthSession(cluster.config.ClientCertProvider, cluster.config.TokenProvider, cluster.config.Username, cluster.config.Password, jarCache)
+	config := cluster.getConfig()
+	for _, ep := range cluster.getEndpoints() {
+		ep.createAuthSession(config.ClientCertProvider, config.TokenProvider, config.Username, config.Password, jarCache)
 	}
 }
 
+// Reload applies the NSX managers, credentials, certificates, endpoint selector and circuit breaker thresholds in
+// config to the cluster without recreating it. The endpoints of the managers still in config are kept, new endpoints
+// are created for the added managers, and the endpoints of the removed managers are drained. The auth sessions of the
+// kept endpoints are recreated only if the credentials are changed. The idle connections are closed so that the new connections
+// are verified with the new CA files or thumbprints, the in-flight requests complete on their current connections.
+func (cluster *Cluster) Reload(config *Config) error {
+	if len(config.APIManagers) == 0 || slices.Contains(config.APIManagers, "") {
+		err := errors.New("invalid NSX managers")
+		log.Error(err, "Failed to reload cluster", "managers", config.APIManagers)
+		return err
+	}
+	r := cluster.ratelimiter
+	if r == nil {
+		r = ratelimiter.NewRateLimiter(config.APIRateMode)
+	}
+	removed := make(map[string]*Endpoint)
+	for _, ep := range cluster.getEndpoints() {
+		removed[ep.Host()] = ep
+	}
+	eps := make([]*Endpoint, 0, len(config.APIManagers))
+	var added []*Endpoint
+	for i, apiManager := range config.APIManagers {
+		host, _, err := parseURL(apiManager)
+		if err != nil {
+			log.Error(err, "Failed to reload cluster")
+			return err
+		}
+		ep, ok := removed[host]
+		if ok {
+			delete(removed, host)
+		} else {
+			ep, err = NewEndpoint(apiManager, cluster.client, cluster.noBalancerClient, r, config.TokenProvider)
+			if err != nil {
+				log.Error(err, "Failed to reload cluster")
+				return err
+			}
+			ep.breaker = newCircuitBreaker(ep.Host(), config.CircuitBreaker)
+			added = append(added, ep)
+		}
+		weight := 0
+		if i < len(config.EndpointWeights) {
+			weight = config.EndpointWeights[i]
+		}
+		ep.setWeight(weight)
+		eps = append(eps, ep)
+	}
+
+	previous := cluster.getConfig()
+	credentialsChanged := previous == nil || previous.Username != config.Username || previous.Password != config.Password
+	cluster.Lock()
+	cluster.config = config
+	cluster.endpoints = eps
+	cluster.Unlock()
+	cluster.transport.setEndpoints(eps, config)
+	cluster.client.CloseIdleConnections()
+	cluster.noBalancerClient.CloseIdleConnections()
+
+	cluster.loadCAforEnvoy()
+	for _, ep := range eps {
+		ep.SetEnvoyUrl(cluster.CreateServerUrl(ep.Host(), ep.Scheme()))
+		ep.breaker.setConfig(config.CircuitBreaker)
+		// the session of a kept endpoint is still valid if the credentials are not changed
+		if !credentialsChanged && !slices.Contains(added, ep) {
+			continue
+		}
+		ep.setUserPassword(config.Username, config.Password)
+		ep.setXSRFToken("")
+		ep.createAuthSession(config.ClientCertProvider, config.TokenProvider, config.Username, config.Password, jarCache)
+	}
+	for _, ep := range added {
+		ep.setup()
+		go ep.KeepAlive()
+	}
+	for _, ep := range removed {
+		go cluster.drainEndpoint(ep, time.Duration(config.HTTPTimeout)*time.Second)
+	}
+	log.Info("Reloaded cluster", "managers", config.APIManagers, "added", len(added), "removed", len(removed))
+	return nil
+}
+
+// drainEndpoint stops the keepAlive of a removed endpoint once its in-flight requests are finished or timeout
+// is reached.
+func (cluster *Cluster) drainEndpoint(ep *Endpoint, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for ep.ConnNumber() > 0 && time.Now().Before(deadline) {
+		time.Sleep(endpointDrainInterval)
+	}
+	close(ep.stop)
+	deleteEndpointMetrics(ep)
+	log.Info("Removed endpoint from cluster", "endpoint", ep.Host(), "connections", ep.ConnNumber())
+}
+
+// EndpointHealth is the health of one endpoint of the cluster.
+type EndpointHealth struct {
+	Host       string         `json:"host"`
+	Status     EndpointStatus `json:"status"`
+	Breaker    BreakerState   `json:"breaker"`
+	ConnNumber int            `json:"conn_number"`
+	// ThrottledUntil is the end of the throttle window requested by NSX with Retry-After.
+	ThrottledUntil time.Time `json:"throttled_until"`
+}
+
+// RateLimiterState is the state of the rate limiter shared by the endpoints of the cluster.
+type RateLimiterState struct {
+	// Rate is the current rate in requests per second, 0 if the rate limiter is disabled.
+	Rate int `json:"rate"`
+	// Waiting is the number of the interactive requests waiting for a token.
+	Waiting int `json:"waiting"`
+}
+
+// EndpointsHealth returns the keepAlive status, circuit breaker state and throttle window of each endpoint.
+func (cluster *Cluster) EndpointsHealth() []EndpointHealth {
+	endpoints := cluster.getEndpoints()
+	health := make([]EndpointHealth, 0, len(endpoints))
+	for _, ep := range endpoints {
+		health = append(health, EndpointHealth{
+			Host:           ep.Host(),
+			Status:         ep.Status(),
+			Breaker:        ep.BreakerState(),
+			ConnNumber:     ep.ConnNumber(),
+			ThrottledUntil: ep.ThrottledUntil(),
+		})
+	}
+	return health
+}
+
+// RateLimiterState returns the current rate and waiting requests of the rate limiter.
+func (cluster *Cluster) RateLimiterState() RateLimiterState {
+	state := RateLimiterState{}
+	if cluster.ratelimiter == nil {
+		return state
+	}
+	state.Rate = cluster.ratelimiter.Rate()
+	if limiter, ok := cluster.ratelimiter.(*ratelimiter.FairRateLimiter); ok {
+		state.Waiting = limiter.Waiting()
+	}
+	return state
+}
+
 // Health checks cluster health status.
+// An endpoint whose circuit breaker is open is counted as down even if keepAlive reports it UP.
 func (cluster *Cluster) Health() ClusterHealth {
 	down := 0
 	up := 0
-	for _, ep := range cluster.endpoints {
-		if ep.Status() == UP {
+	endpoints := cluster.getEndpoints()
+	for _, ep := range endpoints {
+		if ep.Status() == UP && ep.BreakerState() != BreakerOpen {
 			up++
 		} else {
 			down++
 		}
 	}
 
-	if down == len(cluster.endpoints) {
+	if down == len(endpoints) {
 		return RED
 	}
-	if up == len(cluster.endpoints) {
+	if up == len(endpoints) {
 		return GREEN
 	}
 	return ORANGE
@@ -362,8 +543,8 @@ func (cluster *Cluster) GetVersion() (*NsxVersion, error) {
 		return cluster.nsxVersion, nil
 	}
 
-	ep := cluster.endpoints[0]
-	serverUrl := cluster.CreateServerUrl(cluster.endpoints[0].Host(), cluster.endpoints[0].Scheme())
+	ep := cluster.getEndpoints()[0]
+	serverUrl := cluster.CreateServerUrl(ep.Host(), ep.Scheme())
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/node/version", serverUrl), nil)
 	if err != nil {
 		log.Error(err, "Failed to create HTTP request")
@@ -392,7 +573,7 @@ func (cluster *Cluster) GetVersion() (*NsxVersion, error) {
 
 // HttpGet sends an http GET request to the cluster, exported for use
 func (cluster *Cluster) HttpGet(url string) (map[string]interface{}, error) {
-	resp, err := cluster.httpAction(url, "GET")
+	resp, err := cluster.httpAction(context.TODO(), url, "GET")
 	if err != nil {
 		log.Error(err, "Failed to do HTTP GET operation")
 		return nil, err
@@ -404,7 +585,7 @@ func (cluster *Cluster) HttpGet(url string) (map[string]interface{}, error) {
 
 // HttpGetAndDecode sends an http GET request to the cluster and decode the response to result
 func (cluster *Cluster) HttpGetAndDecode(url string, result interface{}) error {
-	resp, err := cluster.httpAction(url, "GET")
+	resp, err := cluster.httpAction(context.TODO(), url, "GET")
 	if err != nil {
 		log.Error(err, "Failed to do HTTP GET operation")
 		return err
@@ -413,9 +594,9 @@ func (cluster *Cluster) HttpGetAndDecode(url string, result interface{}) error {
 	return err
 }
 
-func (cluster *Cluster) httpAction(url, method string, requestBody ...interface{}) (*http.Response, error) {
-	ep := cluster.endpoints[0]
-	serverUrl := cluster.CreateServerUrl(cluster.endpoints[0].Host(), cluster.endpoints[0].Scheme())
+func (cluster *Cluster) httpAction(ctx context.Context, url, method string, requestBody ...interface{}) (*http.Response, error) {
+	ep := cluster.getEndpoints()[0]
+	serverUrl := cluster.CreateServerUrl(ep.Host(), ep.Scheme())
 	url = fmt.Sprintf("%s/%s", serverUrl, url)
 
 	var bodyReader io.Reader
@@ -434,6 +615,7 @@ func (cluster *Cluster) httpAction(url, method string, requestBody ...interface{
 		log.Error(err, "Failed to create HTTP request")
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	// Set headers for JSON content if we have a request body
 	if len(requestBody) > 0 && requestBody[0] != nil {
@@ -451,7 +633,7 @@ func (cluster *Cluster) httpAction(url, method string, requestBody ...interface{
 
 // HttpDelete sends an http DELETE request to the cluster, exported for use
 func (cluster *Cluster) HttpDelete(url string) error {
-	_, err := cluster.httpAction(url, "DELETE")
+	_, err := cluster.httpAction(context.TODO(), url, "DELETE")
 	if err != nil {
 		log.Error(err, "Failed to do HTTP DELETE operation")
 		return err
@@ -461,7 +643,12 @@ func (cluster *Cluster) HttpDelete(url string) error {
 
 // HttpPost sends an http POST request to the cluster with a JSON body, exported for use
 func (cluster *Cluster) HttpPost(url string, requestBody interface{}) (map[string]interface{}, error) {
-	resp, err := cluster.httpAction(url, "POST", requestBody)
+	return cluster.HttpPostWithContext(context.TODO(), url, requestBody)
+}
+
+// HttpPostWithContext sends an http POST request with ctx, ctx could carry the priority of the request.
+func (cluster *Cluster) HttpPostWithContext(ctx context.Context, url string, requestBody interface{}) (map[string]interface{}, error) {
+	resp, err := cluster.httpAction(ctx, url, "POST", requestBody)
 	if err != nil {
 		log.Error(err, "Failed to do HTTP POST operation")
 		return nil, err
@@ -474,7 +661,7 @@ func (cluster *Cluster) HttpPost(url string, requestBody interface{}) (map[strin
 
 // HttpPatch sends an http PATCH request to the cluster with a JSON body, exported for use
 func (cluster *Cluster) HttpPatch(url string, requestBody interface{}) (map[string]interface{}, error) {
-	resp, err := cluster.httpAction(url, "PATCH", requestBody)
+	resp, err := cluster.httpAction(context.TODO(), url, "PATCH", requestBody)
 	if err != nil {
 		log.Error(err, "Failed to do HTTP PATCH operation")
 		return nil, err
@@ -531,6 +718,12 @@ func (nsxVersion *NsxVersion) featureSupported(feature int) bool {
 	case VTEPLessMode:
 		minVersion = nsx910Version
 		validFeature = true
+	case SecurityPolicyFQDN:
+		// The DFW matches the FQDNs with the DOMAIN_NAME attribute of the context profiles since NSX-T 2.5, so the
+		// FQDN peers need no newer version than the SecurityPolicy. The context profiles of the Projects used in the
+		// VPC network are supported by all the versions supporting VPC.
+		minVersion = nsx320Version
+		validFeature = true
 	}
 
 	if validFeature {
@@ -562,7 +755,7 @@ func (nsxVersion *NsxVersion) featureSupported(feature int) bool {
 }
 
 func (cluster *Cluster) FetchLicense() error {
-	resp, err := cluster.httpAction(LicenseAPI, "GET")
+	resp, err := cluster.httpAction(context.TODO(), LicenseAPI, "GET")
 	if err != nil {
 		log.Error(err, "Failed to get NSX license")
 		return err
@@ -15,6 +15,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
@@ -198,6 +199,11 @@ func TestCluster_enableFeature(t *testing.T) {
 	assert.True(t, nsxVersion.featureSupported(ServiceAccount))
 	assert.True(t, nsxVersion.featureSupported(ServiceAccountRestore))
 	assert.True(t, nsxVersion.featureSupported(ServiceAccountCertRotation))
+	assert.True(t, nsxVersion.featureSupported(SecurityPolicyFQDN))
+	nsxVersion.NodeVersion = "3.2.0"
+	assert.True(t, nsxVersion.featureSupported(SecurityPolicyFQDN))
+	nsxVersion.NodeVersion = "3.1.3"
+	assert.False(t, nsxVersion.featureSupported(SecurityPolicyFQDN))
 
 	// Test case for invalid feature
 	feature := 3
@@ -414,3 +420,100 @@ func TestFetchLicense(t *testing.T) {
 	assert.Nil(t, err)
 
 }
+
+func TestCluster_Reload(t *testing.T) {
+	result := `{
+		"healthy" : true,
+		"components_health" : "POLICY:UP, SEARCH:UP, MANAGER:UP, NODE_MGMT:UP, UI:UP"
+	  }`
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "session/create") {
+			w.Header().Set("X-XSRF-TOKEN", "token-"+r.FormValue("j_password"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(result))
+	})
+	ts1 := httptest.NewTLSServer(handler)
+	defer ts1.Close()
+	ts2 := httptest.NewTLSServer(handler)
+	defer ts2.Close()
+	host1 := ts1.URL[strings.Index(ts1.URL, "//")+2:]
+	host2 := ts2.URL[strings.Index(ts2.URL, "//")+2:]
+
+	config := NewConfig(host1, "admin", "passw0rd", []string{}, 10, 3, 20, 20, true, true, true, ratelimiter.AIMD, nil, nil, nil)
+	cluster, err := NewCluster(config)
+	assert.Nil(t, err)
+	ep1 := cluster.endpoints[0]
+	assert.Equal(t, "token-passw0rd", ep1.XSRFToken())
+
+	// no NSX manager
+	err = cluster.Reload(NewConfig("", "admin", "passw0rd", []string{}, 10, 3, 20, 20, true, true, true, ratelimiter.AIMD, nil, nil, nil))
+	assert.NotNil(t, err)
+	assert.Equal(t, []*Endpoint{ep1}, cluster.getEndpoints())
+
+	// add a manager and rotate the password, the endpoint of the kept manager is reused
+	config = NewConfig(host1+","+host2, "admin", "newpassw0rd", []string{}, 10, 3, 20, 20, true, true, true, ratelimiter.AIMD, nil, nil, nil)
+	config.EndpointWeights = []int{1, 3}
+	err = cluster.Reload(config)
+	assert.Nil(t, err)
+	eps := cluster.getEndpoints()
+	assert.Equal(t, 2, len(eps))
+	assert.Same(t, ep1, eps[0])
+	assert.Equal(t, host2, eps[1].Host())
+	assert.Equal(t, 3, eps[1].Weight())
+	assert.Equal(t, eps, cluster.transport.getEndpoints())
+	assert.Same(t, config, cluster.transport.getConfig())
+	for _, ep := range eps {
+		assert.Equal(t, "token-newpassw0rd", ep.XSRFToken())
+		assert.Equal(t, UP, ep.Status())
+	}
+	assert.Equal(t, GREEN, cluster.Health())
+
+	// remove a manager, the keepAlive of the removed endpoint is stopped after it's drained
+	// the session of the kept endpoint is reused as the credentials are not changed, the breaker thresholds are updated
+	eps[1].setXSRFToken("session-1")
+	config = NewConfig(host2, "admin", "newpassw0rd", []string{}, 10, 3, 20, 20, true, true, true, ratelimiter.AIMD, nil, nil, nil)
+	config.CircuitBreaker = CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute}
+	err = cluster.Reload(config)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Endpoint{eps[1]}, cluster.getEndpoints())
+	assert.Equal(t, "session-1", eps[1].XSRFToken())
+	eps[1].breaker.record(false)
+	assert.Equal(t, BreakerOpen, eps[1].BreakerState())
+	assert.Eventually(t, func() bool {
+		select {
+		case <-ep1.stop:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestCluster_drainEndpoint(t *testing.T) {
+	cluster := &Cluster{}
+	ep := &Endpoint{provider: &address{host: "10.0.0.1", scheme: "https"}, stop: make(chan bool)}
+	ep.increaseConnNumber()
+	stopped := make(chan struct{})
+	go func() {
+		cluster.drainEndpoint(ep, 10*time.Second)
+		close(stopped)
+	}()
+	time.Sleep(2 * endpointDrainInterval)
+	select {
+	case <-stopped:
+		t.Fatal("endpoint is stopped with an in-flight request")
+	default:
+	}
+	ep.decreaseConnNumber()
+	<-stopped
+	_, ok := <-ep.stop
+	assert.False(t, ok)
+
+	// the endpoint is stopped after timeout even if the requests are not finished
+	ep = &Endpoint{provider: &address{host: "10.0.0.2", scheme: "https"}, stop: make(chan bool)}
+	ep.increaseConnNumber()
+	cluster.drainEndpoint(ep, 0)
+	_, ok = <-ep.stop
+	assert.False(t, ok)
+}
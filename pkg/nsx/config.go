@@ -4,6 +4,7 @@
 package nsx
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/auth"
@@ -57,6 +58,19 @@ type Config struct {
 	ClientCertProvider auth.ClientCertProvider
 	EnvoyHost          string
 	EnvoyPort          int
+	// Strategy used to select the NSX manager for each request, LeastConnections is used if not set.
+	EndpointSelector SelectorType
+	// Weights of the NSX managers used by WeightedRoundRobin, in the same order as "APIManagers". Managers without
+	// a weight get weight 1.
+	EndpointWeights []int
+	// Thresholds of the per-endpoint circuit breaker driven by API request outcomes, it's disabled if
+	// "FailureThreshold" is 0.
+	CircuitBreaker CircuitBreakerConfig
+	// WrapTransport wraps the transport of the cluster, e.g. to record and replay the NSX API calls.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+	// WrapSessionTransport wraps the transport of the requests sent to an endpoint directly, i.e. the session
+	// creation, the keepalive and the version requests.
+	WrapSessionTransport func(http.RoundTripper) http.RoundTripper
 }
 
 // NewConfig creates a nsx configuration. It provides default values for those items not in function parameters.
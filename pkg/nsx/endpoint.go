@@ -4,6 +4,7 @@
 package nsx
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -42,7 +43,11 @@ type Endpoint struct {
 	xXSRFToken       string
 	keepaliveperiod  int
 	connnumber       int32
-	stop             chan bool
+	weight           int
+	breaker          *circuitBreaker
+	// throttle pauses the requests as requested by the Retry-After header of NSX
+	throttle ratelimiter.ThrottleWindow
+	stop     chan bool
 	// Used when JWT token is not available, default value is 120s
 	lockWait      time.Duration
 	user          string
@@ -111,11 +116,29 @@ type epHealthy struct {
 }
 
 func (ep *Endpoint) UpdateCAforEnvoy(req *http.Request) {
-	if ep.caFile != "" {
-		req.Header.Set("x-vmware-server-tls-cert", ep.caFile)
+	ep.RLock()
+	caFile := ep.caFile
+	ep.RUnlock()
+	if caFile != "" {
+		req.Header.Set("x-vmware-server-tls-cert", caFile)
 	}
 }
 
+// setCertificate sets the CA or the thumbprint used by the envoy sidecar to verify the endpoint, they are replaced
+// when the cluster is reloaded.
+func (ep *Endpoint) setCertificate(caFile, thumbprint string) {
+	ep.Lock()
+	ep.caFile = caFile
+	ep.Thumbprint = thumbprint
+	ep.Unlock()
+}
+
+func (ep *Endpoint) thumbprint() string {
+	ep.RLock()
+	defer ep.RUnlock()
+	return ep.Thumbprint
+}
+
 func (ep *Endpoint) SetEnvoyUrl(url string) {
 	ep.Lock()
 	ep.envoyUrl = url
@@ -217,6 +240,7 @@ func (ep *Endpoint) setStatus(s EndpointStatus) {
 		ep.status = s
 	}
 	ep.Unlock()
+	updateEndpointMetrics(ep)
 }
 
 func (ep *Endpoint) setXSRFToken(token string) {
@@ -239,12 +263,40 @@ func (ep *Endpoint) Status() EndpointStatus {
 	return ep.status
 }
 
-func (ep *Endpoint) wait() {
+// wait blocks the caller until a rate limiter token is gained for the request of the priority.
+func (ep *Endpoint) wait(priority ratelimiter.Priority) {
+	if limiter, ok := ep.ratelimiter.(ratelimiter.PriorityRateLimiter); ok {
+		limiter.WaitWithPriority(priority)
+		return
+	}
 	ep.ratelimiter.Wait()
 }
 
+// waitThrottle blocks the caller until the throttle window requested by NSX ends or ctx is done.
+func (ep *Endpoint) waitThrottle(ctx context.Context) error {
+	return ep.throttle.Wait(ctx)
+}
+
+// throttleFor pauses the requests to the endpoint for d, it's requested by NSX with the Retry-After header.
+func (ep *Endpoint) throttleFor(d time.Duration) {
+	until := ep.throttle.Extend(d)
+	log.Info("Endpoint is throttled by NSX", "endpoint", ep.Host(), "retryAfter", d, "until", until)
+	observeThrottle(ep, until)
+}
+
+// ThrottledUntil returns the end of the throttle window requested by NSX, it's in the past if the endpoint isn't
+// throttled.
+func (ep *Endpoint) ThrottledUntil() time.Time {
+	return ep.throttle.Until()
+}
+
+func (ep *Endpoint) throttled() bool {
+	return ep.throttle.Remaining() > 0
+}
+
 func (ep *Endpoint) adjustRate(wait time.Duration, status int) {
 	ep.ratelimiter.AdjustRate(wait, status)
+	updateEndpointMetrics(ep)
 }
 
 func (ep *Endpoint) setAliveTime(time time.Time) {
@@ -262,10 +314,33 @@ func (ep *Endpoint) setUserPassword(user, password string) {
 
 func (ep *Endpoint) increaseConnNumber() {
 	atomic.AddInt32(&ep.connnumber, 1)
+	updateEndpointMetrics(ep)
 }
 
 func (ep *Endpoint) decreaseConnNumber() {
 	atomic.AddInt32(&ep.connnumber, -1)
+	updateEndpointMetrics(ep)
+}
+
+func (ep *Endpoint) setWeight(weight int) {
+	ep.Lock()
+	ep.weight = weight
+	ep.Unlock()
+}
+
+// Weight returns the weight of the endpoint used by weighted selection, default is 1.
+func (ep *Endpoint) Weight() int {
+	ep.RLock()
+	defer ep.RUnlock()
+	if ep.weight <= 0 {
+		return 1
+	}
+	return ep.weight
+}
+
+// BreakerState returns the state of the circuit breaker of the endpoint.
+func (ep *Endpoint) BreakerState() BreakerState {
+	return ep.breaker.State()
 }
 
 // ConnNumber get the connection number of nsx-t.
@@ -329,9 +404,15 @@ func (ep *Endpoint) createAuthSession(certProvider auth.ClientCertProvider, toke
 	}
 	ep.setXSRFToken(tokens[0])
 	jar.SetCookies(u, resp.Cookies())
+	// the HTTP clients are shared by the endpoints and they are in use once the cluster is reloaded, the jar is the
+	// same for all the sessions so it's set only once.
 	ep.Lock()
-	ep.noBalancerClient.Jar = jar
-	ep.client.Jar = jar
+	if ep.noBalancerClient.Jar != jar {
+		ep.noBalancerClient.Jar = jar
+	}
+	if ep.client.Jar != jar {
+		ep.client.Jar = jar
+	}
 	ep.Unlock()
 	ep.setStatus(UP)
 	log.Info("Session creation succeeded", "endpoint", u.Host)
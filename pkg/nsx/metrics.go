@@ -0,0 +1,116 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
+)
+
+const (
+	reauthTypeJWT  = "jwt"
+	reauthTypeXSRF = "xsrf"
+
+	roundTripErrorConnectionRefused = "connection_refused"
+	roundTripErrorTimeout           = "timeout"
+	roundTripErrorGeneral           = "general"
+
+	idPlaceholder = "{id}"
+	otherAPIPath  = "other"
+)
+
+// apiLiterals are the API path segments which are neither a resource collection nor a resource ID, e.g. the API
+// prefix and the actions. The other segments of a path alternate between a collection and the ID of a resource in it.
+var apiLiterals = sets.New[string](
+	"policy", "api", "v1", "infra", "search", "query", "aggregate", "status", "state", "statistics", "stats",
+	"realized-state", "realized-entities", "realized-entity", "reverse-proxy", "node", "health", "version", "session",
+	"create", "destroy", "licenses", "licensed-features", "trust-management", "global-infra", "cluster",
+)
+
+// normalizeAPIPath converts the request path to an API path template to keep the metric label cardinality bounded,
+// e.g. /policy/api/v1/orgs/default/projects/p1/vpcs/v1 is converted to /policy/api/v1/orgs/{id}/projects/{id}/vpcs/{id}.
+// The segment following a collection is replaced with a placeholder, and the envoy sidecar prefix is removed.
+// The paths out of the API are converted to otherAPIPath.
+func normalizeAPIPath(path string) string {
+	if i := strings.Index(path, "/policy/api/"); i >= 0 {
+		path = path[i:]
+	} else if i := strings.Index(path, "/api/"); i >= 0 {
+		path = path[i:]
+	} else {
+		return otherAPIPath
+	}
+	segments := strings.Split(path, "/")
+	isID := false
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+			continue
+		case isID:
+			segments[i] = idPlaceholder
+			isID = false
+		default:
+			isID = !apiLiterals.Has(segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func observeAPIRequest(ep *Endpoint, r *http.Request, resp *http.Response, duration time.Duration) {
+	statusCode := "error"
+	if resp != nil {
+		statusCode = strconv.Itoa(resp.StatusCode)
+	}
+	metrics.NSXAPIRequestDuration.WithLabelValues(ep.Host(), r.Method, normalizeAPIPath(r.URL.Path), statusCode).Observe(duration.Seconds())
+}
+
+func observeAPIRetry(ep *Endpoint) {
+	metrics.NSXAPIRetryTotal.WithLabelValues(ep.Host()).Inc()
+}
+
+func observeAPIReauth(ep *Endpoint, authType string) {
+	metrics.NSXAPIReauthTotal.WithLabelValues(ep.Host(), authType).Inc()
+}
+
+func observeRoundTripError(ep *Endpoint, reason string) {
+	metrics.NSXAPIRoundTripErrorTotal.WithLabelValues(ep.Host(), reason).Inc()
+}
+
+func observeThrottle(ep *Endpoint, until time.Time) {
+	host := ep.Host()
+	metrics.NSXAPIThrottledTotal.WithLabelValues(host).Inc()
+	metrics.NSXEndpointThrottledUntil.WithLabelValues(host).Set(float64(until.Unix()))
+}
+
+// updateEndpointMetrics refreshes the gauges of the connection number, status and rate limit of the endpoint.
+func updateEndpointMetrics(ep *Endpoint) {
+	if ep.provider == nil {
+		return
+	}
+	host := ep.Host()
+	metrics.NSXEndpointConnections.WithLabelValues(host).Set(float64(ep.ConnNumber()))
+	status := 0.0
+	if ep.Status() == UP {
+		status = 1
+	}
+	metrics.NSXEndpointStatus.WithLabelValues(host).Set(status)
+	if ep.ratelimiter != nil {
+		metrics.NSXEndpointRateLimit.WithLabelValues(host).Set(float64(ep.ratelimiter.Rate()))
+	}
+}
+
+// deleteEndpointMetrics removes the gauges of an endpoint which is removed from the cluster.
+func deleteEndpointMetrics(ep *Endpoint) {
+	host := ep.Host()
+	metrics.NSXEndpointConnections.DeleteLabelValues(host)
+	metrics.NSXEndpointStatus.DeleteLabelValues(host)
+	metrics.NSXEndpointRateLimit.DeleteLabelValues(host)
+	metrics.NSXAPIThrottledTotal.DeleteLabelValues(host)
+	metrics.NSXEndpointThrottledUntil.DeleteLabelValues(host)
+}
@@ -0,0 +1,109 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
+)
+
+func TestNormalizeAPIPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "/policy/api/v1/orgs/default/projects/proj-1/vpcs/vpc-1/subnets/subnet-1/ports/port-1",
+			want: "/policy/api/v1/orgs/{id}/projects/{id}/vpcs/{id}/subnets/{id}/ports/{id}",
+		},
+		{
+			path: "/policy/api/v1/orgs/default/projects/proj-1/vpcs/vpc-1/subnets/subnet-1/status",
+			want: "/policy/api/v1/orgs/{id}/projects/{id}/vpcs/{id}/subnets/{id}/status",
+		},
+		{
+			path: "/policy/api/v1/infra/domains/default/groups/g1",
+			want: "/policy/api/v1/infra/domains/{id}/groups/{id}",
+		},
+		{
+			path: "/policy/api/v1/search/query",
+			want: "/policy/api/v1/search/query",
+		},
+		{
+			path: "/policy/api/v1/orgs/default",
+			want: "/policy/api/v1/orgs/{id}",
+		},
+		{
+			path: "/external-cert/http1/10.0.0.1/443/policy/api/v1/orgs/default/projects/proj-1",
+			want: "/policy/api/v1/orgs/{id}/projects/{id}",
+		},
+		{
+			path: "/api/v1/node/version",
+			want: "/api/v1/node/version",
+		},
+		{
+			path: "/api/v1/reverse-proxy/node/health",
+			want: "/api/v1/reverse-proxy/node/health",
+		},
+		{
+			path: "/policy/api/v1/infra/lb-pools/pool-1",
+			want: "/policy/api/v1/infra/lb-pools/{id}",
+		},
+		{
+			path: "/policy/api/v1/orgs/default/projects/proj-1/unknown-collection/7f9b1c/children/c-1/",
+			want: "/policy/api/v1/orgs/{id}/projects/{id}/unknown-collection/{id}/children/{id}/",
+		},
+		{
+			path: "/policy/api/v1/infra/realized-state/realized-entities",
+			want: "/policy/api/v1/infra/realized-state/realized-entities",
+		},
+		{
+			path: "/unknown/7f9b1c",
+			want: "other",
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, normalizeAPIPath(tt.path), tt.path)
+	}
+}
+
+func TestRoundTripMetrics(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	cluster := &Cluster{config: &Config{}}
+	tr := cluster.createTransport(idleConnTimeout)
+	client := cluster.createHTTPClient(tr, timeout)
+	noBClient := cluster.createNoBalancerClient(timeout, idleConnTimeout)
+	host := ts.URL[strings.Index(ts.URL, "//")+2:]
+	eps, _ := cluster.createEndpoints([]string{host}, client, noBClient, ratelimiter.NewFixRateLimiter(20), nil)
+	eps[0].setStatus(UP)
+	tr.endpoints = eps
+	tr.config = cluster.config
+
+	req, _ := http.NewRequest("GET", ts.URL+"/policy/api/v1/orgs/default/projects/p1", nil)
+	_, err := tr.RoundTrip(req)
+	assert.Nil(t, err)
+	histogram, err := metrics.NSXAPIRequestDuration.GetMetricWithLabelValues(host, "GET", "/policy/api/v1/orgs/{id}/projects/{id}", "200")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, testutil.CollectAndCount(histogram.(prometheus.Histogram)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.NSXEndpointConnections.WithLabelValues(host)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.NSXEndpointStatus.WithLabelValues(host)))
+	assert.Equal(t, float64(20), testutil.ToFloat64(metrics.NSXEndpointRateLimit.WithLabelValues(host)))
+
+	handleRoundTripError(errors.New("dial tcp: connection refused"), eps[0])
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.NSXAPIRoundTripErrorTotal.WithLabelValues(host, roundTripErrorConnectionRefused)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.NSXEndpointStatus.WithLabelValues(host)))
+}
@@ -0,0 +1,53 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+const planPolicyAPIPrefix = "/policy/api/"
+
+// planTransport doesn't send the requests changing the NSX Policy resources in plan mode, the services planning
+// their changes don't send them in the first place. The payloads of the requests are logged and PlanRequestError is
+// returned instead, which is restored from the SDK error by util.TransNSXApiError.
+type planTransport struct {
+	base http.RoundTripper
+}
+
+func (t *planTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.base.RoundTrip(req)
+	}
+	if !strings.HasPrefix(req.URL.Path, planPolicyAPIPrefix) {
+		return t.base.RoundTrip(req)
+	}
+	var payload []byte
+	if req.Body != nil {
+		payload, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+	log.Info("Plan mode, NSX API request is not sent", "method", req.Method, "path", req.URL.Path, "payload", string(payload))
+	return nil, &util.PlanRequestError{Method: req.Method, Path: req.URL.Path}
+}
+
+// newPlanWrapper returns wrap, which is nil if the transport isn't wrapped, with the requests changing NSX
+// blocked in plan mode.
+func newPlanWrapper(cf *config.NSXOperatorConfig, wrap func(http.RoundTripper) http.RoundTripper) func(http.RoundTripper) http.RoundTripper {
+	if cf.NsxConfig == nil || !cf.PlanMode {
+		return wrap
+	}
+	return func(base http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			base = wrap(base)
+		}
+		return &planTransport{base: base}
+	}
+}
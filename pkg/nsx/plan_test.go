@@ -0,0 +1,75 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	nsx_policy "github.com/vmware/vsphere-automation-sdk-go/services/nsxt"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+type countingTransport struct {
+	requests []string
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req.Method+" "+req.URL.Path)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+}
+
+func TestPlanWrapper(t *testing.T) {
+	cf := &config.NSXOperatorConfig{NsxConfig: &config.NsxConfig{}}
+	assert.Nil(t, newPlanWrapper(cf, nil))
+
+	cf.PlanMode = true
+	base := &countingTransport{}
+	rt := newPlanWrapper(cf, nil)(base)
+	for _, tc := range []struct {
+		method  string
+		path    string
+		planned bool
+	}{
+		{http.MethodGet, "/policy/api/v1/orgs/default/projects/p1/vpcs/vpc1", false},
+		{http.MethodPatch, "/policy/api/v1/orgs-root", true},
+		{http.MethodDelete, "/policy/api/v1/orgs/default/projects/p1/vpcs/vpc1", true},
+		{http.MethodPost, "/api/session/create", false},
+	} {
+		req := httptest.NewRequest(tc.method, "https://nsx"+tc.path, strings.NewReader(`{"id":"vpc1"}`))
+		resp, err := rt.RoundTrip(req)
+		if !tc.planned {
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode, tc.method+" "+tc.path)
+			continue
+		}
+		assert.Nil(t, resp)
+		var planErr *util.PlanError
+		require.ErrorAs(t, err, &planErr, tc.method+" "+tc.path)
+		assert.Equal(t, tc.path, planErr.Changes[0].Path)
+	}
+	assert.Equal(t, []string{"GET /policy/api/v1/orgs/default/projects/p1/vpcs/vpc1", "POST /api/session/create"}, base.requests)
+}
+
+func TestPlanWrapperSDKError(t *testing.T) {
+	cf := &config.NSXOperatorConfig{NsxConfig: &config.NsxConfig{PlanMode: true}}
+	base := &countingTransport{}
+	connector := client.NewConnector("https://nsx", client.UsingRest(nil),
+		client.WithHttpClient(&http.Client{Transport: newPlanWrapper(cf, nil)(base)}))
+	err := util.TransNSXApiError(nsx_policy.NewOrgRootClient(connector).Patch(model.OrgRoot{}, nil))
+
+	var planErr *util.PlanError
+	require.ErrorAs(t, err, &planErr)
+	assert.Equal(t, []util.PlannedChange{{Action: util.PlanActionUpdate, Path: "/policy/api/v1/org-root"}}, planErr.Changes)
+	assert.Empty(t, base.requests)
+}
@@ -0,0 +1,141 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Priority is the class of an NSX API request, the interactive requests get the rate limiter tokens first.
+type Priority int
+
+const (
+	// PriorityInteractive is the default priority, it's used by the reconciles of user-facing resources.
+	PriorityInteractive Priority = iota
+	// PriorityBackground is used by garbage collection, inventory sync, IPBlocksInfo sync and health reporting.
+	PriorityBackground
+)
+
+// DefaultBackgroundShare is the max share of the rate which the background requests could get.
+const DefaultBackgroundShare = 0.2
+
+// minBackgroundRate keeps the background requests going when the rate is low.
+const minBackgroundRate = 0.5
+
+func (p Priority) String() string {
+	if p == PriorityBackground {
+		return "background"
+	}
+	return "interactive"
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx carrying the priority of the NSX API requests sent with it.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority carried by ctx, it's PriorityInteractive if there is none.
+func PriorityFromContext(ctx context.Context) Priority {
+	if ctx == nil {
+		return PriorityInteractive
+	}
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityInteractive
+}
+
+// PriorityRateLimiter is a RateLimiter which serves the requests by priority.
+type PriorityRateLimiter interface {
+	RateLimiter
+	WaitWithPriority(Priority)
+}
+
+// FairRateLimiter shares the tokens of a RateLimiter between the priority classes. The interactive requests are
+// served first, the background requests wait until no interactive request is waiting and are bounded to a share
+// of the rate, so a burst of background requests doesn't hold the tokens the interactive requests need.
+// A background request waits at most RateLimiterTimeout for the interactive requests, so it's never starved.
+type FairRateLimiter struct {
+	RateLimiter
+	backgroundShare float64
+	background      *rate.Limiter
+	interactive     int
+	// idle is closed when no interactive request is waiting
+	idle chan struct{}
+	sync.Mutex
+}
+
+// NewFairRateLimiter creates a FairRateLimiter on top of limiter, the background requests get at most
+// backgroundShare of its rate.
+func NewFairRateLimiter(limiter RateLimiter, backgroundShare float64) *FairRateLimiter {
+	idle := make(chan struct{})
+	close(idle)
+	return &FairRateLimiter{
+		RateLimiter:     limiter,
+		backgroundShare: backgroundShare,
+		background:      rate.NewLimiter(minBackgroundRate, 1),
+		idle:            idle,
+	}
+}
+
+// Waiting returns the number of the interactive requests waiting for a token.
+func (limiter *FairRateLimiter) Waiting() int {
+	limiter.Lock()
+	defer limiter.Unlock()
+	return limiter.interactive
+}
+
+// Wait blocks the caller until a token is gained, the request is served as an interactive one.
+func (limiter *FairRateLimiter) Wait() {
+	limiter.WaitWithPriority(PriorityInteractive)
+}
+
+// WaitWithPriority blocks the caller until a token is gained for the request of the priority.
+func (limiter *FairRateLimiter) WaitWithPriority(priority Priority) {
+	if priority != PriorityBackground {
+		limiter.Lock()
+		limiter.interactive++
+		if limiter.interactive == 1 {
+			limiter.idle = make(chan struct{})
+		}
+		limiter.Unlock()
+		defer func() {
+			limiter.Lock()
+			limiter.interactive--
+			if limiter.interactive == 0 {
+				close(limiter.idle)
+			}
+			limiter.Unlock()
+		}()
+		limiter.RateLimiter.Wait()
+		return
+	}
+
+	r := limiter.Rate()
+	if r == 0 {
+		// the limiter is disabled
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*RateLimiterTimeout)
+	defer cancel()
+	limiter.Lock()
+	idle := limiter.idle
+	limiter.Unlock()
+	select {
+	case <-idle:
+	case <-ctx.Done():
+		log.Debug("Wait for interactive requests timeout, sending background request")
+	}
+	limiter.background.SetLimit(rate.Limit(max(float64(r)*limiter.backgroundShare, minBackgroundRate)))
+	if err := limiter.background.Wait(ctx); err != nil {
+		log.Debug("Wait for background token timeout", "error", err.Error())
+	}
+	limiter.RateLimiter.Wait()
+}
@@ -0,0 +1,80 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityFromContext(t *testing.T) {
+	assert.Equal(t, PriorityInteractive, PriorityFromContext(context.TODO()))
+	ctx := WithPriority(context.TODO(), PriorityBackground)
+	assert.Equal(t, PriorityBackground, PriorityFromContext(ctx))
+	// the priority is kept by the derived context
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	assert.Equal(t, PriorityBackground, PriorityFromContext(ctx))
+	assert.Equal(t, "background", PriorityBackground.String())
+	assert.Equal(t, "interactive", PriorityInteractive.String())
+}
+
+func TestFairRateLimiter_InteractiveFirst(t *testing.T) {
+	limiter := NewFairRateLimiter(NewFixRateLimiter(20), DefaultBackgroundShare)
+	var _ PriorityRateLimiter = limiter
+	limiter.Wait()
+
+	var mutex sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	request := func(priority Priority) {
+		defer wg.Done()
+		limiter.WaitWithPriority(priority)
+		mutex.Lock()
+		order = append(order, priority)
+		mutex.Unlock()
+	}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go request(PriorityInteractive)
+	}
+	time.Sleep(10 * time.Millisecond)
+	assert.Positive(t, limiter.Waiting())
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go request(PriorityBackground)
+	}
+	wg.Wait()
+	assert.Zero(t, limiter.Waiting())
+	assert.Equal(t, []Priority{PriorityInteractive, PriorityInteractive, PriorityInteractive,
+		PriorityBackground, PriorityBackground, PriorityBackground}, order)
+}
+
+func TestFairRateLimiter_BackgroundShare(t *testing.T) {
+	// the background requests get 10 of the 50 tokens per second
+	limiter := NewFairRateLimiter(NewFixRateLimiter(50), DefaultBackgroundShare)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		limiter.WaitWithPriority(PriorityBackground)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 350*time.Millisecond)
+
+	start = time.Now()
+	for i := 0; i < 5; i++ {
+		limiter.WaitWithPriority(PriorityInteractive)
+	}
+	assert.Less(t, time.Since(start), 300*time.Millisecond)
+
+	// the disabled limiter doesn't block
+	limiter = NewFairRateLimiter(NewFixRateLimiter(0), DefaultBackgroundShare)
+	start = time.Now()
+	for i := 0; i < 5; i++ {
+		limiter.WaitWithPriority(PriorityBackground)
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
@@ -50,7 +50,7 @@ const (
 type RateLimiter interface {
 	Wait()
 	AdjustRate(time.Duration, int)
-	rate() int
+	Rate() int
 }
 
 // FixRateLimiter is rate limiter which has fix rate.
@@ -126,7 +126,8 @@ func (limiter *FixRateLimiter) Wait() {
 	}
 }
 
-func (limiter *FixRateLimiter) rate() int {
+// Rate returns the current rate of the limiter, 0 means the limiter is disabled.
+func (limiter *FixRateLimiter) Rate() int {
 	if limiter.disable {
 		return 0
 	}
@@ -158,10 +159,8 @@ func (limiter *AIMDRateLimter) AdjustRate(waitTime time.Duration, statusCode int
 	}
 	limiter.Lock()
 	defer limiter.Unlock()
-	for _, v := range APIReduceRateCodes {
-		if v == statusCode {
-			limiter.neg++
-		}
+	if IsReduceRateCode(statusCode) {
+		limiter.neg++
 	}
 
 	if waitTime.Seconds() > APIWaitMinThreshold {
@@ -189,7 +188,8 @@ func (limiter *AIMDRateLimter) AdjustRate(waitTime time.Duration, statusCode int
 	}
 }
 
-func (limiter *AIMDRateLimter) rate() int {
+// Rate returns the current rate of the limiter, 0 means the limiter is disabled.
+func (limiter *AIMDRateLimter) Rate() int {
 	if limiter.disable {
 		return 0
 	}
@@ -0,0 +1,101 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package ratelimiter
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RetryAfterHeader is sent by NSX with 429/503 to ask the client to pause.
+	RetryAfterHeader = "Retry-After"
+	// MaxRetryAfter caps the pause requested by Retry-After.
+	MaxRetryAfter = 60 * time.Second
+)
+
+// ParseRetryAfter parses the Retry-After header in delay-seconds or HTTP-date format, the result is capped by
+// MaxRetryAfter. It returns 0 if the header is absent, invalid or in the past.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	var d time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		d = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		d = date.Sub(now)
+	} else {
+		log.Debug("Ignore invalid Retry-After header", "value", value)
+		return 0
+	}
+	if d <= 0 {
+		return 0
+	}
+	if d > MaxRetryAfter {
+		return MaxRetryAfter
+	}
+	return d
+}
+
+// IsReduceRateCode checks if the status code asks the client to slow down.
+func IsReduceRateCode(statusCode int) bool {
+	for _, v := range APIReduceRateCodes {
+		if v == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// ThrottleWindow pauses the requests to an endpoint until the time requested by the server.
+type ThrottleWindow struct {
+	until time.Time
+	sync.Mutex
+}
+
+// Extend makes the window last at least d from now, it returns the end of the window.
+func (w *ThrottleWindow) Extend(d time.Duration) time.Time {
+	w.Lock()
+	defer w.Unlock()
+	if until := time.Now().Add(d); until.After(w.until) {
+		w.until = until
+	}
+	return w.until
+}
+
+// Until returns the end of the window, it's in the past if the endpoint isn't throttled.
+func (w *ThrottleWindow) Until() time.Time {
+	w.Lock()
+	defer w.Unlock()
+	return w.until
+}
+
+// Remaining returns the time left in the window.
+func (w *ThrottleWindow) Remaining() time.Duration {
+	if d := time.Until(w.Until()); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Wait blocks the caller until the window ends or ctx is done, it returns the error of ctx in the latter case.
+func (w *ThrottleWindow) Wait(ctx context.Context) error {
+	// the window could be extended by the responses received while waiting
+	for d := w.Remaining(); d > 0; d = w.Remaining() {
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
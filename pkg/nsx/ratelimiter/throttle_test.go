@@ -0,0 +1,78 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package ratelimiter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "5", 5 * time.Second},
+		{"seconds with spaces", " 3 ", 3 * time.Second},
+		{"zero", "0", 0},
+		{"negative", "-1", 0},
+		{"capped", "3600", MaxRetryAfter},
+		{"http date", now.Add(10 * time.Second).UTC().Format(http.TimeFormat), 0},
+		{"past http date", now.Add(-10 * time.Second).UTC().Format(http.TimeFormat), 0},
+		{"invalid", "soon", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseRetryAfter(tt.value, now)
+			if tt.name == "http date" {
+				// HTTP-date has second precision
+				assert.InDelta(t, float64(10*time.Second), float64(got), float64(time.Second))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsReduceRateCode(t *testing.T) {
+	assert.True(t, IsReduceRateCode(http.StatusTooManyRequests))
+	assert.True(t, IsReduceRateCode(http.StatusServiceUnavailable))
+	assert.False(t, IsReduceRateCode(http.StatusOK))
+	assert.False(t, IsReduceRateCode(http.StatusInternalServerError))
+}
+
+func TestThrottleWindow(t *testing.T) {
+	var w ThrottleWindow
+	assert.Equal(t, time.Duration(0), w.Remaining())
+	// not throttled
+	start := time.Now()
+	assert.Nil(t, w.Wait(context.Background()))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	until := w.Extend(200 * time.Millisecond)
+	assert.Equal(t, until, w.Until())
+	// a shorter window doesn't shrink the current one
+	assert.Equal(t, until, w.Extend(10*time.Millisecond))
+	assert.Greater(t, w.Remaining(), 100*time.Millisecond)
+
+	start = time.Now()
+	assert.Nil(t, w.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, time.Duration(0), w.Remaining())
+
+	// the wait is stopped once ctx is done
+	w.Extend(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	assert.Equal(t, context.DeadlineExceeded, w.Wait(ctx))
+	assert.Less(t, time.Since(start), time.Second)
+}
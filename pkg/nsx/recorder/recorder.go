@@ -0,0 +1,459 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+// Package recorder records the NSX API requests and responses into golden files and replays them, so that a
+// reconcile captured against a real NSX could be replayed in the regression tests. A change of the payloads sent
+// to NSX shows up as a diff of the golden files.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+)
+
+// Mode is the mode of the Recorder.
+type Mode string
+
+const (
+	// ModeRecord sends the requests to NSX and records them with the responses.
+	ModeRecord Mode = "record"
+	// ModeReplay serves the requests with the recorded responses, no request is sent to NSX.
+	ModeReplay Mode = "replay"
+
+	// Redacted replaces the credentials in the golden files.
+	Redacted = "REDACTED"
+
+	fileSuffix = ".json"
+
+	sessionCreatePath = "/api/session/create"
+	healthPath        = "/api/v1/reverse-proxy/node/health"
+)
+
+var (
+	log = logger.Log
+
+	// sensitiveHeaders carry the credentials, their values are redacted.
+	sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Xsrf-Token"}
+	// volatileHeaders change with each request, they are not recorded to keep the golden files stable.
+	volatileHeaders = []string{"Date", "Content-Length", "X-Nsx-Requestid", "Traceparent", "Tracestate", "User-Agent", "Vapi-Ctx-Opid"}
+	// sensitiveFormFields are the credentials in the form of the session create request.
+	sensitiveFormFields = regexp.MustCompile(`(j_username|j_password)=[^&]*`)
+	unsafeFileChars     = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// Request is the recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is the recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Interaction is a request with its response, it's stored in a golden file.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Body is a request or response body. A JSON body is stored as indented JSON so the golden files are readable,
+// any other body is stored as a string.
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if len(b) == 0 {
+		return []byte("null"), nil
+	}
+	if json.Valid(b) {
+		return b, nil
+	}
+	return json.Marshal(string(b))
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*b = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+// Recorder records the NSX API calls into the golden files in a directory or replays them from it. Each
+// interaction is stored in its own file named by its sequence, method and path.
+type Recorder struct {
+	mode Mode
+	dir  string
+
+	mutex        sync.Mutex
+	sequence     int
+	interactions []*Interaction
+	used         []bool
+}
+
+// New creates a Recorder in mode with the golden files in dir. The existing golden files are removed in
+// ModeRecord and loaded in ModeReplay.
+func New(mode Mode, dir string) (*Recorder, error) {
+	r := &Recorder{mode: mode, dir: dir}
+	files, err := filepath.Glob(filepath.Join(dir, "*"+fileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	switch mode {
+	case ModeRecord:
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if err := os.Remove(file); err != nil {
+				return nil, err
+			}
+		}
+		log.Info("Recording NSX API calls", "dir", dir)
+	case ModeReplay:
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			interaction := &Interaction{}
+			if err := json.Unmarshal(data, interaction); err != nil {
+				return nil, fmt.Errorf("failed to load golden file %s: %w", file, err)
+			}
+			r.interactions = append(r.interactions, interaction)
+		}
+		r.used = make([]bool, len(r.interactions))
+		log.Info("Replaying NSX API calls", "dir", dir, "count", len(r.interactions))
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %q", mode)
+	}
+	return r, nil
+}
+
+// Wrap returns the RoundTripper which records the calls sent by base, or replays them without calling base.
+func (r *Recorder) Wrap(base http.RoundTripper) http.RoundTripper {
+	return &transport{recorder: r, base: base}
+}
+
+// WrapSession returns the RoundTripper of the requests sent to an endpoint directly. The session creation and the
+// keepalive requests are not recorded since they depend on the timing and the credentials, they are served locally in
+// ModeReplay so no request is sent to NSX. Any other request, e.g. the version query, is recorded or replayed as Wrap.
+func (r *Recorder) WrapSession(base http.RoundTripper) http.RoundTripper {
+	return &sessionTransport{transport: transport{recorder: r, base: base}}
+}
+
+// Interactions returns the recorded or loaded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	interactions := make([]Interaction, 0, len(r.interactions))
+	for _, interaction := range r.interactions {
+		interactions = append(interactions, *interaction)
+	}
+	return interactions
+}
+
+// Unused returns the loaded interactions which are not replayed, it should be empty once the replayed
+// reconcile is done.
+func (r *Recorder) Unused() []Interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, *interaction)
+		}
+	}
+	return unused
+}
+
+type transport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.recorder.mode == ModeReplay {
+		return t.recorder.replay(req, request)
+	}
+
+	if request.Body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(request.Body))
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	interaction := &Interaction{
+		Request: scrubRequest(request),
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       scrubBody(body),
+		},
+	}
+	if err := t.recorder.record(interaction); err != nil {
+		log.Error(err, "Failed to record NSX API call", "method", request.Method, "path", request.Path)
+	}
+	return resp, nil
+}
+
+// CloseIdleConnections closes the idle connections of the base RoundTripper.
+func (t *transport) CloseIdleConnections() {
+	type closeIdler interface {
+		CloseIdleConnections()
+	}
+	if tr, ok := t.base.(closeIdler); ok {
+		tr.CloseIdleConnections()
+	}
+}
+
+type sessionTransport struct {
+	transport
+}
+
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path != sessionCreatePath && req.URL.Path != healthPath {
+		return t.transport.RoundTrip(req)
+	}
+	if t.recorder.mode == ModeReplay {
+		return sessionResponse(req), nil
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// sessionResponse returns the successful response of the session creation or the keepalive request.
+func sessionResponse(req *http.Request) *http.Response {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body := []byte(`{"healthy":true}`)
+	if req.URL.Path == sessionCreatePath {
+		header.Set("X-XSRF-TOKEN", Redacted)
+		body = nil
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", http.StatusOK, http.StatusText(http.StatusOK)),
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func (r *Recorder) record(interaction *Interaction) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.sequence++
+	r.interactions = append(r.interactions, interaction)
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := strings.Trim(unsafeFileChars.ReplaceAllString(strings.SplitN(interaction.Request.Path, "?", 2)[0], "_"), "_")
+	if len(path) > 120 {
+		path = path[len(path)-120:]
+	}
+	file := filepath.Join(r.dir, fmt.Sprintf("%05d-%s-%s%s", r.sequence, interaction.Request.Method, path, fileSuffix))
+	return os.WriteFile(file, append(data, '\n'), 0600)
+}
+
+// replay serves the request with the first unused interaction matching its method, path and normalized body.
+func (r *Recorder) replay(req *http.Request, request *Request) (*http.Response, error) {
+	// the recorded bodies are scrubbed
+	body := normalizeBody(scrubBody(request.Body))
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var candidate *Interaction
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request.Method != request.Method || interaction.Request.Path != request.Path {
+			continue
+		}
+		if !bytes.Equal(normalizeBody(interaction.Request.Body), body) {
+			if candidate == nil {
+				candidate = interaction
+			}
+			continue
+		}
+		r.used[i] = true
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	if candidate != nil {
+		return nil, fmt.Errorf("request body of %s %s doesn't match the recording\nrecorded: %s\nactual:   %s",
+			request.Method, request.Path, normalizeBody(candidate.Request.Body), body)
+	}
+	return nil, fmt.Errorf("no recorded response for %s %s", request.Method, request.Path)
+}
+
+// newRequest reads req into a Request, the body of req is restored so it could still be sent.
+func newRequest(req *http.Request) (*Request, error) {
+	request := &Request{
+		Method: req.Method,
+		Path:   normalizePath(req),
+		Header: req.Header.Clone(),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		if len(body) > 0 {
+			request.Body = body
+		}
+	}
+	return request, nil
+}
+
+// normalizePath returns the path of req with the sorted query, the host is ignored since it depends on the NSX
+// manager selected or the Envoy sidecar.
+func normalizePath(req *http.Request) string {
+	path := req.URL.Path
+	if query := req.URL.Query(); len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path
+}
+
+// normalizeBody returns the JSON body with sorted keys and without spaces, so the bodies are compared regardless
+// of the field order. Any other body is returned as is.
+func normalizeBody(body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return normalized
+}
+
+func scrubRequest(request *Request) Request {
+	return Request{
+		Method: request.Method,
+		Path:   request.Path,
+		Header: scrubHeader(request.Header),
+		Body:   scrubBody(request.Body),
+	}
+}
+
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, key := range volatileHeaders {
+		scrubbed.Del(key)
+	}
+	for _, key := range sensitiveHeaders {
+		if scrubbed.Get(key) != "" {
+			scrubbed.Set(key, Redacted)
+		}
+	}
+	if len(scrubbed) == 0 {
+		return nil
+	}
+	return scrubbed
+}
+
+// scrubBody redacts the credentials in the session create form and the password fields of a JSON body, a JSON
+// body is indented for the readable diffs.
+func scrubBody(body []byte) Body {
+	if len(body) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return Body(sensitiveFormFields.ReplaceAllString(string(body), "${1}="+Redacted))
+	}
+	indented, err := json.MarshalIndent(scrubValue(value), "", "  ")
+	if err != nil {
+		return body
+	}
+	return indented
+}
+
+func scrubValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if strings.Contains(strings.ToLower(key), "password") {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = scrubValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = scrubValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,132 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package recorder
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "JSESSIONID=secret")
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPatch {
+			w.Write(body)
+			return
+		}
+		w.Write([]byte(`{"results":[],"result_count":0}`))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	// the stale golden files are removed
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00009-GET-stale.json"), []byte("{}"), 0600))
+
+	r, err := New(ModeRecord, dir)
+	require.NoError(t, err)
+	client := &http.Client{Transport: r.Wrap(http.DefaultTransport)}
+	req, _ := http.NewRequest(http.MethodPatch, server.URL+"/policy/api/v1/org-root?enforce_revision_check=false",
+		strings.NewReader(`{"resource_type":"OrgRoot","children":[],"password":"admin"}`))
+	req.Header.Set("X-XSRF-TOKEN", "token")
+	req.Header.Set("Authorization", "Bearer jwt")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"password":"admin"`)
+	resp, err = client.Get(server.URL + "/policy/api/v1/search/query?query=resource_type:Vpc&page_size=1000")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.Len(t, files, 2)
+	assert.Equal(t, "00001-PATCH-policy_api_v1_org-root.json", filepath.Base(files[0]))
+	golden, _ := os.ReadFile(files[0])
+	for _, secret := range []string{"token", "jwt", "secret", "admin"} {
+		assert.NotContains(t, string(golden), secret)
+	}
+	assert.Contains(t, string(golden), "\n      \"resource_type\": \"OrgRoot\"")
+
+	r, err = New(ModeReplay, dir)
+	require.NoError(t, err)
+	require.Len(t, r.Unused(), 2)
+	client = &http.Client{Transport: r.Wrap(nil)}
+	// the query is matched regardless of the order and the body regardless of the field order
+	resp, err = client.Get("https://nsx-2/policy/api/v1/search/query?page_size=1000&query=resource_type:Vpc")
+	require.NoError(t, err)
+	body, _ = io.ReadAll(resp.Body)
+	assert.JSONEq(t, `{"results":[],"result_count":0}`, string(body))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	req, _ = http.NewRequest(http.MethodPatch, "https://nsx-2/policy/api/v1/org-root?enforce_revision_check=false",
+		strings.NewReader(`{"children":[{"resource_type":"ChildVpc"}],"resource_type":"OrgRoot"}`))
+	_, err = client.Do(req)
+	require.ErrorContains(t, err, "doesn't match the recording")
+	req, _ = http.NewRequest(http.MethodPatch, "https://nsx-2/policy/api/v1/org-root?enforce_revision_check=false",
+		strings.NewReader(`{"password":"changed","children":[],"resource_type":"OrgRoot"}`))
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, r.Unused())
+
+	// each recorded interaction is replayed once
+	_, err = client.Get("https://nsx-2/policy/api/v1/search/query?page_size=1000&query=resource_type:Vpc")
+	assert.ErrorContains(t, err, "no recorded response")
+
+	_, err = New("capture", dir)
+	assert.Error(t, err)
+}
+
+func TestScrubBody(t *testing.T) {
+	assert.Equal(t, "j_username=REDACTED&j_password=REDACTED", string(scrubBody([]byte("j_username=admin&j_password=pass"))))
+	assert.Equal(t, "{\n  \"nested\": [\n    {\n      \"user_password\": \"REDACTED\"\n    }\n  ]\n}",
+		string(scrubBody([]byte(`{"nested":[{"user_password":"pass"}]}`))))
+	assert.Nil(t, scrubBody(nil))
+}
+
+func TestWrapSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-XSRF-TOKEN", "token")
+		w.Write([]byte(`{"healthy":true,"node_version":"9.1.0"}`))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	r, err := New(ModeRecord, dir)
+	require.NoError(t, err)
+	client := &http.Client{Transport: r.WrapSession(http.DefaultTransport)}
+	for _, path := range []string{"/api/session/create", "/api/v1/reverse-proxy/node/health", "/api/v1/node/version"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	// only the version query is recorded
+	require.Len(t, r.Interactions(), 1)
+	assert.Equal(t, "/api/v1/node/version", r.Interactions()[0].Request.Path)
+
+	// the session and keepalive requests are served locally in replay mode
+	r, err = New(ModeReplay, dir)
+	require.NoError(t, err)
+	client = &http.Client{Transport: r.WrapSession(nil)}
+	resp, err := client.Post("https://nsx-2/api/session/create", "application/x-www-form-urlencoded", strings.NewReader("j_username=admin"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, Redacted, resp.Header.Get("X-XSRF-TOKEN"))
+	resp, err = client.Get("https://nsx-2/api/v1/reverse-proxy/node/health")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.JSONEq(t, `{"healthy":true}`, string(body))
+	resp, err = client.Get("https://nsx-2/api/v1/node/version")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, r.Unused())
+}
@@ -0,0 +1,130 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package recorder_test
+
+import (
+	"context"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock/nsxserver"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+)
+
+var update = flag.Bool("update", false, "record the golden files against the fake NSX")
+
+func createSubnet(t *testing.T, nsxClient *nsx.Client) error {
+	builder, err := common.PolicyPathVpcSubnet.NewPolicyTreeBuilder()
+	require.NoError(t, err)
+	id, path, parentPath := "subnet-1", "/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1", "/orgs/default/projects/project-1/vpcs/vpc-1"
+	subnet := &model.VpcSubnet{Id: &id, DisplayName: &id, Path: &path, ParentPath: &parentPath}
+	if err := builder.UpdateMultipleResourcesOnNSX(context.TODO(), []*model.VpcSubnet{subnet}, nsxClient); err != nil {
+		return err
+	}
+	_, err = nsxClient.SubnetsClient.Get("default", "project-1", "vpc-1", id)
+	return err
+}
+
+// TestReplayPolicyTree records the H-API calls against the fake NSX and replays them once it's gone.
+func TestReplayPolicyTree(t *testing.T) {
+	dir := t.TempDir()
+	server := nsxserver.NewServer()
+	cf := server.NewConfig("k8scl-one")
+	cf.TransportRecordMode = "record"
+	cf.TransportRecordDir = dir
+	require.NoError(t, createSubnet(t, nsx.GetClient(cf)))
+	server.Close()
+
+	cf.TransportRecordMode = "replay"
+	require.NoError(t, createSubnet(t, nsx.GetClient(cf)))
+
+	// the change of the H-API payload fails the replay, the diff is logged by the SDK
+	nsxClient := nsx.GetClient(cf)
+	builder, err := common.PolicyPathVpcSubnet.NewPolicyTreeBuilder()
+	require.NoError(t, err)
+	id, path, parentPath, cidr := "subnet-1", "/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1", "/orgs/default/projects/project-1/vpcs/vpc-1", "10.0.0.0/28"
+	subnet := &model.VpcSubnet{Id: &id, DisplayName: &id, Path: &path, ParentPath: &parentPath, IpAddresses: []string{cidr}}
+	err = builder.UpdateMultipleResourcesOnNSX(context.TODO(), []*model.VpcSubnet{subnet}, nsxClient)
+	assert.Error(t, err)
+}
+
+// runVPCFlow creates the VPC of a NetworkInfo with its VPCNetworkConfiguration and a Subnet in it as the
+// controllers do.
+func runVPCFlow(t *testing.T, cf *config.NSXOperatorConfig) {
+	ctx := context.TODO()
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1", UID: "1cbd4c1b-d1a7-4b8d-9b56-0c9c09d2f1a3"}}
+	networkInfo := &v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: namespace.Name, Namespace: namespace.Name}}
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(namespace, networkInfo).Build()
+	service := common.Service{
+		Client:    k8sClient,
+		NSXClient: nsx.GetClient(cf),
+		NSXConfig: cf,
+	}
+
+	vpcService, err := vpc.InitializeVPC(service)
+	require.NoError(t, err)
+	nc := &v1alpha1.VPCNetworkConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: v1alpha1.VPCNetworkConfigurationSpec{
+			NSXProject:             "/orgs/default/projects/project-1",
+			VPCConnectivityProfile: "/orgs/default/projects/project-1/vpc-connectivity-profiles/default",
+			PrivateIPs:             []string{"172.26.0.0/16"},
+			DefaultSubnetSize:      32,
+		},
+	}
+	nsxVPC, err := vpcService.CreateOrUpdateVPC(ctx, networkInfo, nc, vpc.NoneLB, false, false)
+	require.NoError(t, err)
+	// the NetworkInfo controller updates the VPC state which is required by the Subnet
+	networkInfo.VPCs = []v1alpha1.VPCState{{Name: *nsxVPC.DisplayName, PrivateIPs: nc.Spec.PrivateIPs, NetworkStack: v1alpha1.FullStackVPC}}
+	require.NoError(t, k8sClient.Update(ctx, networkInfo))
+
+	subnetService, err := subnet.InitializeSubnetService(service)
+	require.NoError(t, err)
+	subnetCR := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: namespace.Name, UID: "7f0e5c3a-2b8e-4f43-a4f6-5d1b8c3e9a20"},
+		Spec:       v1alpha1.SubnetSpec{IPv4SubnetSize: 16, AccessMode: v1alpha1.AccessMode(v1alpha1.AccessModePrivate)},
+	}
+	vpcInfo := common.VPCResourceInfo{OrgID: "default", ProjectID: "project-1", VPCID: *nsxVPC.Id}
+	_, err = subnetService.CreateOrUpdateSubnet(ctx, subnetCR, vpcInfo, nil)
+	require.NoError(t, err)
+}
+
+// TestReplayVPCFlow replays the golden files of runVPCFlow, they are recorded against the fake NSX with -update.
+func TestReplayVPCFlow(t *testing.T) {
+	dir := filepath.Join("testdata", "vpc-subnet")
+	if *update {
+		server := nsxserver.NewServer()
+		cf := server.NewConfig("k8scl-one")
+		cf.TransportRecordMode = "record"
+		cf.TransportRecordDir = dir
+		runVPCFlow(t, cf)
+		server.Close()
+	}
+
+	cf := config.NewNSXOpertorConfig()
+	cf.NsxApiManagers = []string{"nsx-replay:443"}
+	cf.Cluster = "k8scl-one"
+	cf.TransportRecordMode = "replay"
+	cf.TransportRecordDir = dir
+	runVPCFlow(t, cf)
+}
@@ -0,0 +1,186 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SelectorType is the strategy used by Transport to pick an endpoint for a request.
+type SelectorType string
+
+const (
+	// LeastConnections picks the endpoint with the fewest open connections.
+	LeastConnections SelectorType = "least-connections"
+	// WeightedRoundRobin spreads requests across endpoints in proportion to their weight.
+	WeightedRoundRobin SelectorType = "weighted-round-robin"
+	// LowestLatency picks the endpoint with the lowest EWMA of observed RoundTrip durations.
+	LowestLatency SelectorType = "lowest-latency"
+	// FirstHealthy always picks the first healthy endpoint in configuration order, which keeps sessions sticky.
+	FirstHealthy SelectorType = "first-healthy"
+)
+
+const (
+	// maxConnPerEndpoint is the connection number above which an endpoint is not selected.
+	maxConnPerEndpoint = 100
+	// latencyEWMAAlpha is the weight of the latest sample in the latency EWMA.
+	latencyEWMAAlpha = 0.3
+)
+
+// EndpointSelector picks one endpoint out of the healthy candidates.
+// Candidates are passed in configuration order and are never empty. The endpoints with maxConnPerEndpoint open
+// connections are not selected, nil is returned if all the candidates reach it.
+type EndpointSelector interface {
+	Select(candidates []*Endpoint) *Endpoint
+	// Observe records the duration of a request sent to the endpoint.
+	Observe(ep *Endpoint, latency time.Duration)
+}
+
+// endpointPruner is implemented by the selectors keeping per endpoint state, prune drops the state of the
+// endpoints that are no longer in the cluster.
+type endpointPruner interface {
+	prune(endpoints []*Endpoint)
+}
+
+// NewEndpointSelector creates an endpoint selector based on SelectorType,
+// unknown or empty types fall back to LeastConnections.
+func NewEndpointSelector(selectorType SelectorType) EndpointSelector {
+	switch selectorType {
+	case WeightedRoundRobin:
+		return &weightedRoundRobinSelector{current: make(map[*Endpoint]int)}
+	case LowestLatency:
+		return &lowestLatencySelector{latency: make(map[*Endpoint]float64)}
+	case FirstHealthy:
+		return &firstHealthySelector{}
+	default:
+		return &leastConnectionsSelector{}
+	}
+}
+
+type leastConnectionsSelector struct{}
+
+func (s *leastConnectionsSelector) Select(candidates []*Endpoint) *Endpoint {
+	small := maxConnPerEndpoint
+	var selected *Endpoint
+	for _, ep := range candidates {
+		conn := ep.ConnNumber()
+		if conn < small {
+			small = conn
+			selected = ep
+		}
+	}
+	return selected
+}
+
+func (s *leastConnectionsSelector) Observe(_ *Endpoint, _ time.Duration) {}
+
+// underConnLimit returns whether the endpoint can take another connection.
+func underConnLimit(ep *Endpoint) bool {
+	return ep.ConnNumber() < maxConnPerEndpoint
+}
+
+// weightedRoundRobinSelector implements the smooth weighted round-robin algorithm,
+// endpoints without a positive weight are treated as weight 1.
+type weightedRoundRobinSelector struct {
+	current map[*Endpoint]int
+	sync.Mutex
+}
+
+func (s *weightedRoundRobinSelector) Select(candidates []*Endpoint) *Endpoint {
+	s.Lock()
+	defer s.Unlock()
+	total := 0
+	var selected *Endpoint
+	for _, ep := range candidates {
+		if !underConnLimit(ep) {
+			continue
+		}
+		w := ep.Weight()
+		total += w
+		s.current[ep] += w
+		if selected == nil || s.current[ep] > s.current[selected] {
+			selected = ep
+		}
+	}
+	if selected != nil {
+		s.current[selected] -= total
+	}
+	return selected
+}
+
+func (s *weightedRoundRobinSelector) Observe(_ *Endpoint, _ time.Duration) {}
+
+func (s *weightedRoundRobinSelector) prune(endpoints []*Endpoint) {
+	s.Lock()
+	defer s.Unlock()
+	maps.DeleteFunc(s.current, func(ep *Endpoint, _ int) bool { return !slices.Contains(endpoints, ep) })
+}
+
+// lowestLatencySelector keeps an EWMA of request durations per endpoint,
+// endpoints without any sample are preferred so that they get measured.
+type lowestLatencySelector struct {
+	latency map[*Endpoint]float64
+	sync.RWMutex
+}
+
+func (s *lowestLatencySelector) Select(candidates []*Endpoint) *Endpoint {
+	s.RLock()
+	defer s.RUnlock()
+	var selected *Endpoint
+	lowest := 0.0
+	for _, ep := range candidates {
+		if !underConnLimit(ep) {
+			continue
+		}
+		l, ok := s.latency[ep]
+		if !ok {
+			return ep
+		}
+		if selected == nil || l < lowest {
+			lowest = l
+			selected = ep
+		}
+	}
+	return selected
+}
+
+func (s *lowestLatencySelector) Observe(ep *Endpoint, latency time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+	sample := float64(latency)
+	if l, ok := s.latency[ep]; ok {
+		s.latency[ep] = latencyEWMAAlpha*sample + (1-latencyEWMAAlpha)*l
+	} else {
+		s.latency[ep] = sample
+	}
+}
+
+func (s *lowestLatencySelector) prune(endpoints []*Endpoint) {
+	s.Lock()
+	defer s.Unlock()
+	maps.DeleteFunc(s.latency, func(ep *Endpoint, _ float64) bool { return !slices.Contains(endpoints, ep) })
+}
+
+// Latency returns the EWMA of request durations observed for the endpoint.
+func (s *lowestLatencySelector) Latency(ep *Endpoint) time.Duration {
+	s.RLock()
+	defer s.RUnlock()
+	return time.Duration(s.latency[ep])
+}
+
+type firstHealthySelector struct{}
+
+func (s *firstHealthySelector) Select(candidates []*Endpoint) *Endpoint {
+	for _, ep := range candidates {
+		if underConnLimit(ep) {
+			return ep
+		}
+	}
+	return nil
+}
+
+func (s *firstHealthySelector) Observe(_ *Endpoint, _ time.Duration) {}
@@ -0,0 +1,180 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createSelectorEndpoints() []*Endpoint {
+	eps := []*Endpoint{{status: UP}, {status: UP}, {status: UP}}
+	eps[0].provider = &address{host: "10.0.0.1", scheme: "https"}
+	eps[1].provider = &address{host: "10.0.0.2", scheme: "https"}
+	eps[2].provider = &address{host: "10.0.0.3", scheme: "https"}
+	return eps
+}
+
+func TestNewEndpointSelector(t *testing.T) {
+	assert.IsType(t, &leastConnectionsSelector{}, NewEndpointSelector(""))
+	assert.IsType(t, &leastConnectionsSelector{}, NewEndpointSelector("unknown"))
+	assert.IsType(t, &leastConnectionsSelector{}, NewEndpointSelector(LeastConnections))
+	assert.IsType(t, &weightedRoundRobinSelector{}, NewEndpointSelector(WeightedRoundRobin))
+	assert.IsType(t, &lowestLatencySelector{}, NewEndpointSelector(LowestLatency))
+	assert.IsType(t, &firstHealthySelector{}, NewEndpointSelector(FirstHealthy))
+}
+
+func TestLeastConnectionsSelector(t *testing.T) {
+	eps := createSelectorEndpoints()
+	s := NewEndpointSelector(LeastConnections)
+
+	eps[0].connnumber = 3
+	eps[1].connnumber = 2
+	eps[2].connnumber = 2
+	assert.Equal(t, eps[1], s.Select(eps))
+
+	eps[0].connnumber = 0
+	eps[1].connnumber = 4
+	eps[2].connnumber = 0
+	assert.Equal(t, eps[0], s.Select(eps))
+
+	// endpoints reaching the connection limit are not selected
+	for _, ep := range eps {
+		ep.connnumber = maxConnPerEndpoint
+	}
+	assert.Nil(t, s.Select(eps))
+}
+
+func TestWeightedRoundRobinSelector(t *testing.T) {
+	eps := createSelectorEndpoints()
+	eps[0].setWeight(3)
+	eps[1].setWeight(1)
+	// eps[2] has no weight and defaults to 1
+	s := NewEndpointSelector(WeightedRoundRobin)
+
+	count := map[string]int{}
+	for i := 0; i < 50; i++ {
+		count[s.Select(eps).Host()]++
+	}
+	assert.Equal(t, 30, count["10.0.0.1"])
+	assert.Equal(t, 10, count["10.0.0.2"])
+	assert.Equal(t, 10, count["10.0.0.3"])
+
+	// smooth round-robin should not pick the heavy endpoint 3 times in a row
+	s = NewEndpointSelector(WeightedRoundRobin)
+	var got []string
+	for i := 0; i < 5; i++ {
+		got = append(got, s.Select(eps).Host())
+	}
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.1", "10.0.0.3", "10.0.0.1"}, got)
+
+	// only a subset of the endpoints is healthy
+	assert.Equal(t, eps[2], s.Select(eps[2:]))
+
+	// the endpoints reaching the connection limit are skipped
+	eps[2].connnumber = maxConnPerEndpoint
+	assert.Nil(t, s.Select(eps[2:]))
+	for i := 0; i < 10; i++ {
+		assert.NotEqual(t, eps[2], s.Select(eps))
+	}
+}
+
+func TestLowestLatencySelector(t *testing.T) {
+	eps := createSelectorEndpoints()
+	s := NewEndpointSelector(LowestLatency)
+
+	// endpoints without samples are preferred
+	assert.Equal(t, eps[0], s.Select(eps))
+	s.Observe(eps[0], 100*time.Millisecond)
+	assert.Equal(t, eps[1], s.Select(eps))
+	s.Observe(eps[1], 20*time.Millisecond)
+	s.Observe(eps[2], 50*time.Millisecond)
+	assert.Equal(t, eps[1], s.Select(eps))
+
+	// EWMA smooths a single slow sample
+	s.Observe(eps[1], 60*time.Millisecond)
+	assert.InDelta(t, float64(32*time.Millisecond), float64(s.(*lowestLatencySelector).Latency(eps[1])), float64(time.Microsecond))
+	assert.Equal(t, eps[1], s.Select(eps))
+
+	// the slow endpoint becomes the worst one after repeated slow samples
+	for i := 0; i < 10; i++ {
+		s.Observe(eps[1], 200*time.Millisecond)
+	}
+	assert.Equal(t, eps[2], s.Select(eps))
+	assert.Equal(t, eps[0], s.Select(eps[:2]))
+
+	// the endpoints reaching the connection limit are skipped
+	eps[2].connnumber = maxConnPerEndpoint
+	assert.Equal(t, eps[0], s.Select(eps))
+	eps[0].connnumber = maxConnPerEndpoint
+	eps[1].connnumber = maxConnPerEndpoint
+	assert.Nil(t, s.Select(eps))
+}
+
+func TestFirstHealthySelector(t *testing.T) {
+	eps := createSelectorEndpoints()
+	s := NewEndpointSelector(FirstHealthy)
+	eps[0].connnumber = 10
+	assert.Equal(t, eps[0], s.Select(eps))
+	assert.Equal(t, eps[1], s.Select(eps[1:]))
+
+	// the endpoints reaching the connection limit are skipped
+	eps[0].connnumber = maxConnPerEndpoint
+	assert.Equal(t, eps[1], s.Select(eps))
+	eps[1].connnumber = maxConnPerEndpoint
+	eps[2].connnumber = maxConnPerEndpoint
+	assert.Nil(t, s.Select(eps))
+}
+
+func TestSelectEndpointWithSelector(t *testing.T) {
+	eps := createSelectorEndpoints()
+	tr := &Transport{endpoints: eps, selector: NewEndpointSelector(FirstHealthy)}
+	ep, err := tr.selectEndpoint()
+	assert.Nil(t, err)
+	assert.Equal(t, eps[0], ep)
+
+	// DOWN endpoints are skipped before the selector runs
+	eps[0].setStatus(DOWN)
+	ep, err = tr.selectEndpoint()
+	assert.Nil(t, err)
+	assert.Equal(t, eps[1], ep)
+
+	for _, ep := range eps {
+		ep.setStatus(DOWN)
+	}
+	_, err = tr.selectEndpoint()
+	assert.NotNil(t, err)
+}
+
+func TestTransportSetEndpointsSelector(t *testing.T) {
+	eps := createSelectorEndpoints()
+	tr := &Transport{}
+	config := &Config{EndpointSelector: LowestLatency}
+	tr.setEndpoints(eps, config)
+	s := tr.endpointSelector()
+	assert.IsType(t, &lowestLatencySelector{}, s)
+	for _, ep := range eps {
+		s.Observe(ep, 10*time.Millisecond)
+	}
+
+	// the state of the removed endpoints is dropped when the strategy is kept
+	tr.setEndpoints(eps[1:], &Config{EndpointSelector: LowestLatency})
+	assert.Same(t, s, tr.endpointSelector())
+	assert.Equal(t, 2, len(s.(*lowestLatencySelector).latency))
+	assert.NotContains(t, s.(*lowestLatencySelector).latency, eps[0])
+
+	wrr := &Transport{}
+	wrr.setEndpoints(eps, &Config{EndpointSelector: WeightedRoundRobin})
+	for i := 0; i < 3; i++ {
+		wrr.endpointSelector().Select(eps)
+	}
+	wrr.setEndpoints(eps[:1], &Config{EndpointSelector: WeightedRoundRobin})
+	assert.Equal(t, 1, len(wrr.endpointSelector().(*weightedRoundRobinSelector).current))
+
+	// the selector is recreated when the strategy is changed
+	tr.setEndpoints(eps[1:], &Config{EndpointSelector: FirstHealthy})
+	assert.IsType(t, &firstHealthySelector{}, tr.endpointSelector())
+}
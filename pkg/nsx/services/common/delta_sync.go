@@ -0,0 +1,236 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+const (
+	lastModifiedTimeField = "_last_modified_time"
+	revisionField         = "_revision"
+)
+
+// deltaSyncOverlap is subtracted from the watermark in the delta queries. The NSX search index is updated
+// asynchronously, a resource could be indexed after the ones modified later than it, so the resources modified
+// shortly before the watermark are queried again. The versions already synced are skipped, applying them again
+// would undo the local updates and deletes made since they were synced.
+var deltaSyncOverlap = time.Minute
+
+// DeltaSyncer keeps a Store in sync with NSX after the initial load by querying only the resources whose
+// _last_modified_time is after the watermark, the latest _last_modified_time seen. The resources are applied with
+// Store.Apply, so the ones marked for delete are removed from the store. The resources purged by NSX before a
+// sync are not seen, they are left to the periodic consistency checks, e.g. the drift detection.
+type DeltaSyncer struct {
+	service      *Service
+	resourceType string
+	// queryParam selects the resources of the store regardless of marked_for_delete
+	queryParam  string
+	store       Store
+	bindingType bindings.BindingType
+	// SnapshotDir is the directory to save the store to after each sync, the snapshot isn't saved if it's empty
+	SnapshotDir string
+
+	mutex     sync.Mutex
+	watermark int64
+	// synced is the version of the resources synced in the overlap window, keyed by path
+	synced map[string]resourceVersion
+}
+
+// resourceVersion is the _revision and _last_modified_time of a resource. NSX doesn't increase _revision when a
+// resource is marked for delete, so both are compared.
+type resourceVersion struct {
+	revision         int64
+	lastModifiedTime int64
+}
+
+func (v resourceVersion) newerThan(other resourceVersion) bool {
+	return v.revision > other.revision || v.lastModifiedTime > other.lastModifiedTime
+}
+
+// NewDeltaSyncer creates the DeltaSyncer of store, store must embed ResourceStore to convert the search results.
+func (service *Service) NewDeltaSyncer(resourceTypeValue string, queryParam string, store Store) (*DeltaSyncer, error) {
+	typed, ok := store.(interface{ ResourceBindingType() bindings.BindingType })
+	if !ok {
+		return nil, fmt.Errorf("store of %s doesn't support delta sync", resourceTypeValue)
+	}
+	return &DeltaSyncer{
+		service:      service,
+		resourceType: resourceTypeValue,
+		queryParam:   queryParam,
+		store:        store,
+		bindingType:  typed.ResourceBindingType(),
+	}, nil
+}
+
+// Watermark returns the latest _last_modified_time in milliseconds of the resources synced.
+func (s *DeltaSyncer) Watermark() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.watermark
+}
+
+// Load adds all resources to the store, the resources marked for delete are skipped.
+func (s *DeltaSyncer) Load() (uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	queryParam := s.queryParam
+	if s.store.IsPolicyAPI() {
+		queryParam += " AND marked_for_delete:false"
+	}
+	defer s.pruneSynced()
+	return s.service.SearchResource(s.resourceType, queryParam, &watermarkStore{Store: s.store, syncer: s}, nil)
+}
+
+// Sync applies the resources modified since the last sync to the store.
+func (s *DeltaSyncer) Sync() (uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	since := s.watermark - deltaSyncOverlap.Milliseconds()
+	if since < 0 {
+		since = 0
+	}
+	queryParam := fmt.Sprintf("%s AND %s:>=%d", s.queryParam, lastModifiedTimeField, since)
+	defer s.pruneSynced()
+	return s.service.SearchResource(s.resourceType, queryParam, &watermarkStore{Store: s.store, syncer: s, apply: true}, nil)
+}
+
+// Run syncs the store and saves the snapshot every interval until stopCh is closed.
+func (s *DeltaSyncer) Run(stopCh <-chan struct{}, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			count, err := s.Sync()
+			if err != nil {
+				log.Error(err, "Failed to refresh store", "resourceType", s.resourceType)
+				continue
+			}
+			log.Debug("Refreshed store", "resourceType", s.resourceType, "count", count, "watermark", s.Watermark())
+			if s.SnapshotDir != "" {
+				if err := s.SaveSnapshot(s.SnapshotDir); err != nil {
+					log.Error(err, "Failed to save store snapshot", "resourceType", s.resourceType)
+				}
+			}
+		}
+	}
+}
+
+// observe records the version of entity and moves the watermark forward. It returns false if the version is
+// already synced.
+func (s *DeltaSyncer) observe(entity *data.StructValue) bool {
+	version := resourceVersion{
+		revision:         integerField(entity, revisionField),
+		lastModifiedTime: integerField(entity, lastModifiedTimeField),
+	}
+	if version.lastModifiedTime > s.watermark {
+		s.watermark = version.lastModifiedTime
+	}
+	key := stringField(entity, "path")
+	if key == "" {
+		key = stringField(entity, "id")
+	}
+	if key == "" {
+		return true
+	}
+	if synced, ok := s.synced[key]; ok && !version.newerThan(synced) {
+		return false
+	}
+	if s.synced == nil {
+		s.synced = map[string]resourceVersion{}
+	}
+	s.synced[key] = version
+	return true
+}
+
+// pruneSynced forgets the versions synced before the overlap window, they aren't queried again.
+func (s *DeltaSyncer) pruneSynced() {
+	since := s.watermark - deltaSyncOverlap.Milliseconds()
+	for key, version := range s.synced {
+		if version.lastModifiedTime < since {
+			delete(s.synced, key)
+		}
+	}
+}
+
+func integerField(entity *data.StructValue, name string) int64 {
+	if field, err := entity.Field(name); err == nil {
+		if value, ok := field.(*data.IntegerValue); ok {
+			return value.Value()
+		}
+	}
+	return 0
+}
+
+func stringField(entity *data.StructValue, name string) string {
+	if field, err := entity.Field(name); err == nil {
+		if value, ok := field.(*data.StringValue); ok {
+			return value.Value()
+		}
+	}
+	return ""
+}
+
+// revisionOf returns the Revision of the NSX resource obj, -1 if it has none.
+func revisionOf(obj interface{}) int64 {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	if v.Kind() != reflect.Struct {
+		return -1
+	}
+	field := v.FieldByName("Revision")
+	if !field.IsValid() || field.Kind() != reflect.Ptr || field.IsNil() {
+		return -1
+	}
+	revision, ok := field.Elem().Interface().(int64)
+	if !ok {
+		return -1
+	}
+	return revision
+}
+
+// watermarkStore is the Store passed to SearchResource by the DeltaSyncer, it records the watermark and applies the
+// search results to the store in the delta sync.
+type watermarkStore struct {
+	Store
+	syncer *DeltaSyncer
+	apply  bool
+}
+
+func (store *watermarkStore) TransResourceToStore(entity *data.StructValue) error {
+	newer := store.syncer.observe(entity)
+	if !store.apply {
+		return store.Store.TransResourceToStore(entity)
+	}
+	if !newer {
+		return nil
+	}
+	obj, errs := NewConverter().ConvertToGolang(entity, store.syncer.bindingType)
+	for _, err := range errs {
+		return err
+	}
+	objAddr := nsxutil.CasttoPointer(obj)
+	if objAddr == nil {
+		return fmt.Errorf("failed to cast to pointer")
+	}
+	// the store holds a newer revision if the resource is updated by the operator after it's indexed
+	if getter, ok := store.Store.(interface {
+		Get(obj interface{}) (interface{}, bool, error)
+	}); ok {
+		if existing, exists, err := getter.Get(objAddr); err == nil && exists && revisionOf(existing) > revisionOf(objAddr) {
+			return nil
+		}
+	}
+	return store.Store.Apply(objAddr)
+}
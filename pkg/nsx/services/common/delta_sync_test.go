@@ -0,0 +1,140 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock/nsxserver"
+)
+
+const (
+	deltaVPCPath     = "/orgs/default/projects/project-1/vpcs/vpc-1"
+	deltaSubnetQuery = "resource_type:VpcSubnet AND tags.scope:nsx-op\\/cluster AND tags.tag:k8scl-one"
+)
+
+func newDeltaSubnetStore() *TypedStore[model.VpcSubnet] {
+	store := NewTypedStore[model.VpcSubnet](model.VpcSubnetBindingType(), func(subnet *model.VpcSubnet) (string, error) {
+		return *subnet.Id, nil
+	}, nil)
+	return &store
+}
+
+func newDeltaSubnet(id, displayName string) *model.VpcSubnet {
+	path, parentPath := deltaVPCPath+"/subnets/"+id, deltaVPCPath
+	return &model.VpcSubnet{Id: String(id), DisplayName: String(displayName), Path: &path, ParentPath: &parentPath,
+		Tags: []model.Tag{{Scope: String(TagScopeCluster), Tag: String("k8scl-one")}}}
+}
+
+func addDeltaSubnet(t *testing.T, server *nsxserver.Server, subnet *model.VpcSubnet) {
+	require.NoError(t, server.AddResource(*subnet.Path, subnet, model.VpcSubnetBindingType()))
+	// the resources are told apart by _last_modified_time in milliseconds
+	time.Sleep(2 * time.Millisecond)
+}
+
+func TestDeltaSyncer(t *testing.T) {
+	server := nsxserver.NewServer()
+	defer server.Close()
+	server.KeepMarkedForDelete = true
+	nsxClient := server.NewClient("k8scl-one")
+	service := &Service{NSXClient: nsxClient, NSXConfig: nsxClient.NsxConfig}
+
+	addSubnet := func(subnet *model.VpcSubnet) {
+		addDeltaSubnet(t, server, subnet)
+	}
+	addSubnet(newDeltaSubnet("subnet-1", "subnet-1"))
+	addSubnet(newDeltaSubnet("subnet-2", "subnet-2"))
+	addSubnet(newDeltaSubnet("subnet-3", "subnet-3"))
+
+	store := newDeltaSubnetStore()
+	syncer, err := service.NewDeltaSyncer(ResourceTypeSubnet, deltaSubnetQuery, store)
+	require.NoError(t, err)
+	count, err := syncer.Load()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+	assert.Len(t, store.List(), 3)
+	watermark := syncer.Watermark()
+	object, _ := server.Object(deltaVPCPath + "/subnets/subnet-3")
+	assert.Equal(t, object["_last_modified_time"], watermark)
+
+	// subnet-1 is modified, subnet-2 is deleted and subnet-4 is created out of band
+	defer func(overlap time.Duration) { deltaSyncOverlap = overlap }(deltaSyncOverlap)
+	deltaSyncOverlap = 0
+	require.NoError(t, store.Delete(newDeltaSubnet("subnet-3", "subnet-3")))
+	addSubnet(newDeltaSubnet("subnet-1", "subnet-1-changed"))
+	addSubnet(newDeltaSubnet("subnet-4", "subnet-4"))
+	builder, err := PolicyPathVpcSubnet.NewPolicyTreeBuilder()
+	require.NoError(t, err)
+	deleted := newDeltaSubnet("subnet-2", "subnet-2")
+	deleted.MarkedForDelete = Bool(true)
+	require.NoError(t, builder.UpdateMultipleResourcesOnNSX(context.TODO(), []*model.VpcSubnet{deleted}, nsxClient))
+
+	count, err = syncer.Sync()
+	require.NoError(t, err)
+	// subnet-3 modified at the watermark is queried again, but it's synced already so the local delete is kept
+	assert.Equal(t, uint64(4), count)
+	assert.Greater(t, syncer.Watermark(), watermark)
+	assert.Equal(t, "subnet-1-changed", *store.GetByKey("subnet-1").DisplayName)
+	assert.Nil(t, store.GetByKey("subnet-2"))
+	assert.Nil(t, store.GetByKey("subnet-3"))
+	assert.NotNil(t, store.GetByKey("subnet-4"))
+
+	// nothing is modified since the last sync
+	watermark = syncer.Watermark()
+	count, err = syncer.Sync()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, watermark, syncer.Watermark())
+}
+
+func TestDeltaSyncerOverlap(t *testing.T) {
+	server := nsxserver.NewServer()
+	defer server.Close()
+	nsxClient := server.NewClient("k8scl-one")
+	service := &Service{NSXClient: nsxClient, NSXConfig: nsxClient.NsxConfig}
+
+	addDeltaSubnet(t, server, newDeltaSubnet("subnet-1", "subnet-1"))
+	addDeltaSubnet(t, server, newDeltaSubnet("subnet-2", "subnet-2"))
+	addDeltaSubnet(t, server, newDeltaSubnet("subnet-3", "subnet-3"))
+	store := newDeltaSubnetStore()
+	syncer, err := service.NewDeltaSyncer(ResourceTypeSubnet, deltaSubnetQuery, store)
+	require.NoError(t, err)
+	_, err = syncer.Load()
+	require.NoError(t, err)
+	require.Len(t, store.List(), 3)
+
+	// subnet-1 is deleted and subnet-2 is updated by the operator inside the overlap window, the search index
+	// isn't updated yet so the versions synced by Load are returned again
+	require.NoError(t, store.Delete(store.GetByKey("subnet-1")))
+	updated := *store.GetByKey("subnet-2")
+	updated.DisplayName = String("subnet-2-updated")
+	updated.Revision = Int64(*updated.Revision + 1)
+	require.NoError(t, store.Apply(&updated))
+	// subnet-4 is indexed late, it's modified before the watermark but not synced yet
+	addDeltaSubnet(t, server, newDeltaSubnet("subnet-4", "subnet-4"))
+	object, _ := server.Object(deltaVPCPath + "/subnets/subnet-4")
+	syncer.mutex.Lock()
+	syncer.watermark = object["_last_modified_time"].(int64) + 10
+	syncer.mutex.Unlock()
+
+	count, err := syncer.Sync()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), count)
+	assert.Nil(t, store.GetByKey("subnet-1"))
+	assert.Equal(t, "subnet-2-updated", *store.GetByKey("subnet-2").DisplayName)
+	assert.NotNil(t, store.GetByKey("subnet-3"))
+	assert.NotNil(t, store.GetByKey("subnet-4"))
+
+	// subnet-1 modified out of band is synced again
+	addDeltaSubnet(t, server, newDeltaSubnet("subnet-1", "subnet-1-changed"))
+	_, err = syncer.Sync()
+	require.NoError(t, err)
+	assert.Equal(t, "subnet-1-changed", *store.GetByKey("subnet-1").DisplayName)
+}
@@ -0,0 +1,147 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+// DriftType is the kind of an out-of-band change of an NSX resource.
+type DriftType string
+
+const (
+	// DriftModified means the NSX resource differs from the store.
+	DriftModified DriftType = "Modified"
+	// DriftDeleted means the NSX resource in the store is deleted from NSX.
+	DriftDeleted DriftType = "Deleted"
+	// DriftUnexpected means the NSX resource tagged with the cluster isn't in the store.
+	DriftUnexpected DriftType = "Unexpected"
+)
+
+// DriftSpec describes the NSX resources of a service checked for out-of-band changes.
+type DriftSpec struct {
+	// ResourceType is the NSX resource type, e.g. ResourceTypeGroup.
+	ResourceType string
+	// Stores keep the intended state of the resources, they share the BindingType.
+	Stores []*ResourceStore
+	// ToComparable converts a resource in the stores or from NSX to Comparable.
+	ToComparable func(obj interface{}) Comparable
+	// Filter selects the resources checked, e.g. the ones owned by a kind of CR. All resources are checked if
+	// it's nil.
+	Filter func(obj interface{}) bool
+}
+
+// Drift is an out-of-band change of an NSX resource.
+type Drift struct {
+	Type         DriftType
+	ResourceType string
+	Key          string
+	// Intended is the resource in the store, it's nil for DriftUnexpected.
+	Intended interface{}
+	// Actual is the resource on NSX, it's nil for DriftDeleted.
+	Actual interface{}
+}
+
+// Object returns the Actual resource, or the Intended one if it's deleted from NSX.
+func (d *Drift) Object() interface{} {
+	if d.Actual != nil {
+		return d.Actual
+	}
+	return d.Intended
+}
+
+// driftCollector is the Store which collects the resources returned by the NSX search.
+type driftCollector struct {
+	ResourceStore
+	objects []interface{}
+}
+
+func (collector *driftCollector) TransResourceToStore(entity *data.StructValue) error {
+	obj, errs := NewConverter().ConvertToGolang(entity, collector.BindingType)
+	for _, err := range errs {
+		return err
+	}
+	objAddr := nsxutil.CasttoPointer(obj)
+	if objAddr == nil {
+		return fmt.Errorf("failed to cast to pointer")
+	}
+	collector.objects = append(collector.objects, objAddr)
+	return nil
+}
+
+func (collector *driftCollector) ListIndexFuncValues(_ string) sets.Set[string] {
+	return sets.New[string]()
+}
+
+func (collector *driftCollector) Apply(_ interface{}) error {
+	return nil
+}
+
+func (collector *driftCollector) IsPolicyAPI() bool {
+	return true
+}
+
+// DetectDrift queries the NSX resources of spec tagged with the cluster and compares them with the stores by
+// CompareResources. The resources deleted by the operator are excluded by marked_for_delete.
+func (service *Service) DetectDrift(spec DriftSpec) ([]Drift, error) {
+	if len(spec.Stores) == 0 {
+		return nil, fmt.Errorf("no store to detect the drift of %s", spec.ResourceType)
+	}
+	queryParam := strings.Join([]string{
+		fmt.Sprintf("%s:%s", ResourceType, spec.ResourceType),
+		formatTagParamScope("tags.scope", TagScopeCluster),
+		formatTagParamTag("tags.tag", service.NSXClient.NsxConfig.Cluster),
+		"marked_for_delete:false",
+	}, " AND ")
+	collector := &driftCollector{ResourceStore: ResourceStore{BindingType: spec.Stores[0].BindingType}}
+	if _, err := service.SearchResource(spec.ResourceType, queryParam, collector, nil); err != nil {
+		return nil, err
+	}
+
+	intended := map[string]interface{}{}
+	var intendedComparable []Comparable
+	for _, store := range spec.Stores {
+		for _, obj := range store.List() {
+			if spec.Filter != nil && !spec.Filter(obj) {
+				continue
+			}
+			c := spec.ToComparable(obj)
+			intended[c.Key()] = obj
+			intendedComparable = append(intendedComparable, c)
+		}
+	}
+	actual := map[string]interface{}{}
+	var actualComparable []Comparable
+	for _, obj := range collector.objects {
+		if spec.Filter != nil && !spec.Filter(obj) {
+			continue
+		}
+		c := spec.ToComparable(obj)
+		actual[c.Key()] = obj
+		actualComparable = append(actualComparable, c)
+	}
+
+	// the NSX resources are the existing ones, the store has the expected ones
+	changed, stale := CompareResources(actualComparable, intendedComparable)
+	var drifts []Drift
+	for _, c := range changed {
+		drift := Drift{Type: DriftModified, ResourceType: spec.ResourceType, Key: c.Key(), Intended: intended[c.Key()]}
+		if obj, ok := actual[c.Key()]; ok {
+			drift.Actual = obj
+		} else {
+			drift.Type = DriftDeleted
+		}
+		drifts = append(drifts, drift)
+	}
+	for _, c := range stale {
+		drifts = append(drifts, Drift{Type: DriftUnexpected, ResourceType: spec.ResourceType, Key: c.Key(), Actual: actual[c.Key()]})
+	}
+	return drifts, nil
+}
@@ -0,0 +1,92 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock/nsxserver"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+type driftRule model.Rule
+
+func (r *driftRule) Key() string {
+	return *r.Id
+}
+
+func (r *driftRule) Value() data.DataValue {
+	return data.NewStringValue(*r.DisplayName)
+}
+
+func TestDetectDrift(t *testing.T) {
+	server := nsxserver.NewServer()
+	defer server.Close()
+	nsxClient := server.NewClient("k8scl-one")
+	service := &Service{NSXClient: nsxClient, NSXConfig: nsxClient.NsxConfig}
+
+	newRule := func(id, displayName, cluster string, owned bool) *model.Rule {
+		tags := []model.Tag{{Scope: String(TagScopeCluster), Tag: String(cluster)}}
+		if owned {
+			tags = append(tags, model.Tag{Scope: String(TagValueScopeSecurityPolicyUID), Tag: String("sp-uid")})
+		}
+		path := "/infra/domains/default/security-policies/sp/rules/" + id
+		return &model.Rule{Id: String(id), DisplayName: String(displayName), Path: String(path), Tags: tags}
+	}
+	store := &ResourceStore{Indexer: cache.NewIndexer(keyFunc, nil), BindingType: model.RuleBindingType()}
+	for _, rule := range []*model.Rule{
+		newRule("rule-1", "rule-1", "k8scl-one", true),
+		newRule("rule-2", "rule-2", "k8scl-one", true),
+		newRule("rule-4", "rule-4", "k8scl-one", false),
+	} {
+		require.NoError(t, store.Add(rule))
+	}
+	for _, rule := range []*model.Rule{
+		newRule("rule-1", "rule-1-changed", "k8scl-one", true),
+		newRule("rule-3", "rule-3", "k8scl-one", true),
+		newRule("rule-5", "rule-5", "k8scl-two", true),
+	} {
+		require.NoError(t, server.AddResource(*rule.Path, rule, model.RuleBindingType()))
+	}
+
+	spec := DriftSpec{
+		ResourceType: ResourceTypeRule,
+		Stores:       []*ResourceStore{store},
+		ToComparable: func(obj interface{}) Comparable {
+			return (*driftRule)(obj.(*model.Rule))
+		},
+		// rule-4 isn't owned by a SecurityPolicy
+		Filter: func(obj interface{}) bool {
+			return nsxutil.FindTag(obj.(*model.Rule).Tags, TagValueScopeSecurityPolicyUID) != ""
+		},
+	}
+	drifts, err := service.DetectDrift(spec)
+	require.NoError(t, err)
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Key < drifts[j].Key })
+	require.Len(t, drifts, 3)
+
+	assert.Equal(t, DriftModified, drifts[0].Type)
+	assert.Equal(t, "rule-1", drifts[0].Key)
+	assert.Equal(t, "rule-1", *drifts[0].Intended.(*model.Rule).DisplayName)
+	assert.Equal(t, "rule-1-changed", *drifts[0].Object().(*model.Rule).DisplayName)
+
+	assert.Equal(t, DriftDeleted, drifts[1].Type)
+	assert.Equal(t, "rule-2", drifts[1].Key)
+	assert.Nil(t, drifts[1].Actual)
+	assert.Equal(t, drifts[1].Intended, drifts[1].Object())
+
+	assert.Equal(t, DriftUnexpected, drifts[2].Type)
+	assert.Equal(t, "rule-3", drifts[2].Key)
+	assert.Nil(t, drifts[2].Intended)
+
+	_, err = service.DetectDrift(DriftSpec{ResourceType: ResourceTypeRule})
+	assert.Error(t, err)
+}
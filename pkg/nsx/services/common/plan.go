@@ -0,0 +1,236 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data/serializers/cleanjson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+// PlanAction is what the operator would do to an NSX resource in plan mode.
+type PlanAction = nsxutil.PlanAction
+
+const (
+	PlanActionCreate = nsxutil.PlanActionCreate
+	PlanActionUpdate = nsxutil.PlanActionUpdate
+	PlanActionDelete = nsxutil.PlanActionDelete
+)
+
+// planIgnoredFields are not compared, the children are planned as resources of their own.
+var planIgnoredFields = sets.New[string]("marked_for_delete", "children")
+
+type planModeKey struct{}
+
+// PlannedChange is a change of an NSX resource rendered in plan mode.
+type PlannedChange = nsxutil.PlannedChange
+
+// PlanError is returned instead of applying the changes to NSX in plan mode.
+type PlanError = nsxutil.PlanError
+
+// WithPlanMode returns the context which makes the PolicyTreeBuilder plan the changes instead of applying them.
+func WithPlanMode(ctx context.Context) context.Context {
+	return context.WithValue(ctx, planModeKey{}, true)
+}
+
+// PlanModeFromContext checks if ctx is in plan mode.
+func PlanModeFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	planMode, _ := ctx.Value(planModeKey{}).(bool)
+	return planMode
+}
+
+// IsPlanMode checks if plan mode is enabled globally by plan_mode or for obj by the nsx.vmware.com/plan annotation.
+// obj can be nil.
+func IsPlanMode(nsxConfig *config.NSXOperatorConfig, obj metav1.Object) bool {
+	if nsxConfig != nil && nsxConfig.NsxConfig != nil && nsxConfig.PlanMode {
+		return true
+	}
+	if obj == nil {
+		return false
+	}
+	return obj.GetAnnotations()[AnnotationPlan] == "true"
+}
+
+// PlanModeEnabled checks if the changes of obj are planned instead of applied to NSX, ctx is in plan mode if the
+// reconciled CR is. obj can be nil, e.g. when deleting the NSX resources of a CR which is gone.
+func (service *Service) PlanModeEnabled(ctx context.Context, obj metav1.Object) bool {
+	return PlanModeFromContext(ctx) || IsPlanMode(service.NSXConfig, obj)
+}
+
+func planModeEnabled(ctx context.Context, nsxClient *nsx.Client) bool {
+	return PlanModeFromContext(ctx) || (nsxClient != nil && IsPlanMode(nsxClient.NsxConfig, nil))
+}
+
+// Plan collects the changes of the NSX resources against the stores in plan mode.
+type Plan struct {
+	changes []PlannedChange
+}
+
+// Add renders the change from the resource in store to desired, which is sent to NSX by PATCH, so only the fields
+// set in desired are compared. desired is deleted if it's marked for delete. Nothing is added if desired is the
+// same as the resource in store.
+func (p *Plan) Add(store *ResourceStore, desired interface{}) error {
+	return p.add(store, desired, isMarkedForDelete(desired))
+}
+
+// Delete renders the deletion of obj, nothing is added if obj isn't in store. obj isn't changed, so it can be
+// the resource in store.
+func (p *Plan) Delete(store *ResourceStore, obj interface{}) error {
+	return p.add(store, obj, true)
+}
+
+func (p *Plan) add(store *ResourceStore, desired interface{}, deleted bool) error {
+	existing, found, err := store.Get(desired)
+	if err != nil {
+		return err
+	}
+	if deleted && !found {
+		return nil
+	}
+	desiredFields, err := encodeFields(desired, store.BindingType)
+	if err != nil {
+		return err
+	}
+	change := PlannedChange{Action: PlanActionCreate, ResourceType: planResourceType(desired, desiredFields)}
+	var existingFields map[string]interface{}
+	if found {
+		if existingFields, err = encodeFields(existing, store.BindingType); err != nil {
+			return err
+		}
+		change.Action = PlanActionUpdate
+	}
+	for _, fields := range []map[string]interface{}{desiredFields, existingFields} {
+		if path, _ := fields["path"].(string); path != "" {
+			change.Path = path
+			break
+		}
+	}
+	if change.Path == "" {
+		change.Path, _ = desiredFields["id"].(string)
+	}
+	if deleted {
+		change.Action = PlanActionDelete
+	} else {
+		change.Diff = diffFields(existingFields, desiredFields)
+		if found && len(change.Diff) == 0 {
+			return nil
+		}
+	}
+	p.changes = append(p.changes, change)
+	return nil
+}
+
+// AddErr adds the changes planned in err, it returns false if err isn't PlanError. It's used to plan the changes of
+// several resources whose services plan them one by one.
+func (p *Plan) AddErr(err error) bool {
+	var planErr *PlanError
+	if !errors.As(err, &planErr) {
+		return false
+	}
+	p.changes = append(p.changes, planErr.Changes...)
+	return true
+}
+
+// Empty checks if no change is planned.
+func (p *Plan) Empty() bool {
+	return len(p.changes) == 0
+}
+
+// Err logs the planned changes and returns them in PlanError.
+func (p *Plan) Err() error {
+	for _, change := range p.changes {
+		log.Info("Planned NSX change", "action", change.Action, "resourceType", change.ResourceType, "path", change.Path, "diff", change.Diff)
+	}
+	return &PlanError{Changes: p.changes}
+}
+
+func encodeFields(obj interface{}, bindingType bindings.BindingType) (map[string]interface{}, error) {
+	dataValue, errs := NewConverter().ConvertToVapi(obj, bindingType)
+	for _, err := range errs {
+		return nil, err
+	}
+	encoded, err := cleanjson.NewDataValueToJsonEncoder().Encode(dataValue)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(encoded), &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func planResourceType(obj interface{}, fields map[string]interface{}) string {
+	if resourceType, _ := fields["resource_type"].(string); resourceType != "" {
+		return resourceType
+	}
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+}
+
+// diffFields compares the top-level fields set in desired with existing, the nested fields are flattened to the
+// lines like "rules[0].action". The read-only fields prefixed with _ are ignored.
+func diffFields(existing, desired map[string]interface{}) []string {
+	var diff []string
+	for field := range desired {
+		if strings.HasPrefix(field, "_") || planIgnoredFields.Has(field) {
+			continue
+		}
+		desiredValues, existingValues := map[string]string{}, map[string]string{}
+		flattenField(field, desired[field], desiredValues)
+		flattenField(field, existing[field], existingValues)
+		for key, value := range desiredValues {
+			if old, ok := existingValues[key]; !ok {
+				diff = append(diff, fmt.Sprintf("+ %s: %s", key, value))
+			} else if old != value {
+				diff = append(diff, fmt.Sprintf("~ %s: %s -> %s", key, old, value))
+			}
+		}
+		for key, value := range existingValues {
+			if _, ok := desiredValues[key]; !ok {
+				diff = append(diff, fmt.Sprintf("- %s: %s", key, value))
+			}
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool { return diff[i][2:] < diff[j][2:] })
+	return diff
+}
+
+func flattenField(key string, value interface{}, values map[string]string) {
+	switch v := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		if len(v) == 0 {
+			values[key] = "{}"
+		}
+		for k, item := range v {
+			flattenField(key+"."+k, item, values)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			values[key] = "[]"
+		}
+		for i, item := range v {
+			flattenField(fmt.Sprintf("%s[%d]", key, i), item, values)
+		}
+	default:
+		encoded, _ := json.Marshal(v)
+		values[key] = string(encoded)
+	}
+}
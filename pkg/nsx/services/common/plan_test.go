@@ -0,0 +1,109 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock/nsxserver"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+func TestPlan(t *testing.T) {
+	store := newDeltaSubnetStore()
+	existing := newDeltaSubnet("subnet-1", "subnet-1")
+	existing.IpAddresses = []string{"10.0.0.0/28"}
+	existing.Tags = append(existing.Tags, model.Tag{Scope: String(TagScopeNamespace), Tag: String("ns-1")})
+	require.NoError(t, store.Apply(existing))
+	require.NoError(t, store.Apply(newDeltaSubnet("subnet-2", "subnet-2")))
+
+	plan := &Plan{}
+	// only the fields set are compared as the resources are patched
+	updated := &model.VpcSubnet{Id: String("subnet-1"), DisplayName: String("subnet-1-changed"), IpAddresses: []string{"10.0.0.0/27"},
+		Tags: []model.Tag{{Scope: String(TagScopeCluster), Tag: String("k8scl-one")}}}
+	require.NoError(t, plan.Add(&store.ResourceStore, updated))
+	require.NoError(t, plan.Add(&store.ResourceStore, &model.VpcSubnet{Id: String("subnet-2"), DisplayName: String("subnet-2")}))
+	require.NoError(t, plan.Add(&store.ResourceStore, &model.VpcSubnet{Id: String("subnet-3"), DisplayName: String("subnet-3"), Ipv4SubnetSize: Int64(16)}))
+	deleted := newDeltaSubnet("subnet-2", "subnet-2")
+	deleted.MarkedForDelete = Bool(true)
+	require.NoError(t, plan.Add(&store.ResourceStore, deleted))
+	deleted = newDeltaSubnet("subnet-4", "subnet-4")
+	deleted.MarkedForDelete = Bool(true)
+	require.NoError(t, plan.Add(&store.ResourceStore, deleted))
+
+	var planErr *PlanError
+	require.True(t, errors.As(plan.Err(), &planErr))
+	assert.Equal(t, []PlannedChange{
+		{Action: PlanActionUpdate, ResourceType: "VpcSubnet", Path: deltaVPCPath + "/subnets/subnet-1", Diff: []string{
+			`~ display_name: "subnet-1" -> "subnet-1-changed"`,
+			`~ ip_addresses[0]: "10.0.0.0/28" -> "10.0.0.0/27"`,
+			`- tags[1].scope: "nsx-op/namespace"`,
+			`- tags[1].tag: "ns-1"`,
+		}},
+		{Action: PlanActionCreate, ResourceType: "VpcSubnet", Path: "subnet-3", Diff: []string{
+			`+ display_name: "subnet-3"`,
+			`+ id: "subnet-3"`,
+			`+ ipv4_subnet_size: 16`,
+		}},
+		{Action: PlanActionDelete, ResourceType: "VpcSubnet", Path: deltaVPCPath + "/subnets/subnet-2"},
+	}, planErr.Changes)
+	assert.Contains(t, planErr.Error(), "create VpcSubnet subnet-3\n  + display_name: \"subnet-3\"")
+	assert.Equal(t, "plan mode: no changes", (&Plan{}).Err().Error())
+	// nothing is applied to the store
+	assert.Equal(t, "subnet-1", *store.GetByKey("subnet-1").DisplayName)
+	assert.Nil(t, store.GetByKey("subnet-3"))
+}
+
+func TestPlanMode(t *testing.T) {
+	server := nsxserver.NewServer()
+	defer server.Close()
+	cf := server.NewConfig("k8scl-one")
+	service := &Service{NSXConfig: cf}
+	obj := &metav1.ObjectMeta{Name: "subnet-1"}
+	assert.False(t, service.PlanModeEnabled(context.TODO(), obj))
+	assert.False(t, service.PlanModeEnabled(context.TODO(), nil))
+	assert.True(t, service.PlanModeEnabled(WithPlanMode(context.TODO()), nil))
+	obj.Annotations = map[string]string{AnnotationPlan: "true"}
+	assert.True(t, service.PlanModeEnabled(context.TODO(), obj))
+
+	builder, err := PolicyPathVpcSubnet.NewPolicyTreeBuilder()
+	require.NoError(t, err)
+	subnet := newDeltaSubnet("subnet-1", "subnet-1")
+	deleted := newDeltaSubnet("subnet-2", "subnet-2")
+	deleted.MarkedForDelete = Bool(true)
+	assertPlanned := func(err error) {
+		var planErr *PlanError
+		require.True(t, errors.As(err, &planErr))
+		assert.Equal(t, []PlannedChange{
+			{Action: PlanActionUpdate, ResourceType: ResourceTypeSubnet, Path: *subnet.Path},
+			{Action: PlanActionDelete, ResourceType: ResourceTypeSubnet, Path: *deleted.Path},
+		}, planErr.Changes)
+	}
+
+	// the CR in plan mode
+	nsxClient := server.NewClient("k8scl-one")
+	assertPlanned(builder.UpdateMultipleResourcesOnNSX(WithPlanMode(context.TODO()), []*model.VpcSubnet{subnet, deleted}, nsxClient))
+	assert.Zero(t, server.RequestCount(http.MethodPatch, nsxserver.PolicyAPIPrefix))
+
+	// all CRs in plan mode, the changes not planned by the services aren't sent to NSX either
+	cf.PlanMode = true
+	assert.True(t, service.PlanModeEnabled(context.TODO(), nil))
+	nsxClient = nsx.GetClient(cf)
+	assertPlanned(builder.UpdateMultipleResourcesOnNSX(context.TODO(), []*model.VpcSubnet{subnet, deleted}, nsxClient))
+	err = nsxClient.SubnetsClient.Patch("default", "project-1", "vpc-1", "subnet-1", *subnet)
+	assert.ErrorContains(t, util.TransNSXApiError(err), "plan mode")
+	assert.Zero(t, server.RequestCount(http.MethodPatch, nsxserver.PolicyAPIPrefix))
+	_, err = nsxClient.SubnetsClient.Get("default", "project-1", "vpc-1", "subnet-1")
+	assert.Error(t, err)
+	assert.Equal(t, 1, server.RequestCount(http.MethodGet, nsxserver.PolicyAPIPrefix))
+}
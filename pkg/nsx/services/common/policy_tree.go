@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"strings"
 
+	apierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/tracing"
 )
 
 const (
@@ -58,6 +62,8 @@ func getNSXResourcePath[T any](obj T) *string {
 		return v.Path
 	case *model.DynamicIpAddressReservation:
 		return v.Path
+	case *model.PolicyContextProfile:
+		return v.Path
 	default:
 		log.Error(nil, "Get NSX resource path", "unknown NSX resource type", v)
 		return nil
@@ -100,6 +106,8 @@ func getNSXResourceId[T any](obj T) *string {
 		return v.Id
 	case *model.DynamicIpAddressReservation:
 		return v.Id
+	case *model.PolicyContextProfile:
+		return v.Id
 	default:
 		log.Error(nil, "Get NSX resource ID", "unknown NSX resource type", v)
 		return nil
@@ -142,6 +150,8 @@ func getNSXResourceName[T any](obj T) *string {
 		return v.DisplayName
 	case *model.DynamicIpAddressReservation:
 		return v.DisplayName
+	case *model.PolicyContextProfile:
+		return v.DisplayName
 	default:
 		log.Error(nil, "Get NSX resource name", "unknown NSX resource type", v)
 		return nil
@@ -182,6 +192,8 @@ func leafWrapper[T any](obj T) (*data.StructValue, error) {
 		return WrapDomain(v)
 	case *model.DynamicIpAddressReservation:
 		return WrapDynamicIpAddressReservation(v)
+	case *model.PolicyContextProfile:
+		return WrapContextProfile(v)
 	default:
 		log.Error(nil, "Leaf wrapper", "unknown NSX resource type", v)
 		return nil, fmt.Errorf("unsupported NSX resource type %v", v)
@@ -270,6 +282,7 @@ var (
 	PolicyResourceSecurityPolicy                                                                       = PolicyResourceType{ModelKey: ResourceTypeSecurityPolicy, PathKey: "security-policies"}
 	PolicyResourceTlsCertificate                                                                       = PolicyResourceType{ModelKey: ResourceTypeTlsCertificate, PathKey: "certificates"}
 	PolicyResourceVpcDynamicIPReservation                                                              = PolicyResourceType{ModelKey: ResourceTypeDynamicIpAddressReservation, PathKey: "dynamic-ip-reservations"}
+	PolicyResourceContextProfile                                                                       = PolicyResourceType{ModelKey: ResourceTypeContextProfile, PathKey: "context-profiles"}
 	PolicyPathVpcSubnet                         PolicyResourcePath[*model.VpcSubnet]                   = []PolicyResourceType{PolicyResourceOrg, PolicyResourceProject, PolicyResourceVpc, PolicyResourceVpcSubnet}
 	PolicyPathVpcSubnetConnectionBindingMap     PolicyResourcePath[*model.SubnetConnectionBindingMap]  = []PolicyResourceType{PolicyResourceOrg, PolicyResourceProject, PolicyResourceVpc, PolicyResourceVpcSubnet, PolicyResourceVpcSubnetConnectionBindingMap}
 	PolicyPathVpcSubnetPort                     PolicyResourcePath[*model.VpcSubnetPort]               = []PolicyResourceType{PolicyResourceOrg, PolicyResourceProject, PolicyResourceVpc, PolicyResourceVpcSubnet, PolicyResourceVpcSubnetPort}
@@ -285,6 +298,8 @@ var (
 	PolicyPathProjectShare                      PolicyResourcePath[*model.Share]                       = []PolicyResourceType{PolicyResourceOrg, PolicyResourceProject, PolicyResourceInfra, PolicyResourceShare}
 	PolicyPathInfraGroup                        PolicyResourcePath[*model.Group]                       = []PolicyResourceType{PolicyResourceInfra, PolicyResourceDomain, PolicyResourceGroup}
 	PolicyPathInfraShare                        PolicyResourcePath[*model.Share]                       = []PolicyResourceType{PolicyResourceInfra, PolicyResourceShare}
+	PolicyPathProjectContextProfile             PolicyResourcePath[*model.PolicyContextProfile]        = []PolicyResourceType{PolicyResourceOrg, PolicyResourceProject, PolicyResourceInfra, PolicyResourceContextProfile}
+	PolicyPathInfraContextProfile               PolicyResourcePath[*model.PolicyContextProfile]        = []PolicyResourceType{PolicyResourceInfra, PolicyResourceContextProfile}
 	PolicyPathInfraSharedResource               PolicyResourcePath[*model.SharedResource]              = []PolicyResourceType{PolicyResourceInfra, PolicyResourceShare, PolicyResourceSharedResource}
 	PolicyPathInfraCert                         PolicyResourcePath[*model.TlsCertificate]              = []PolicyResourceType{PolicyResourceInfra, PolicyResourceTlsCertificate}
 	PolicyPathInfraLBVirtualServer              PolicyResourcePath[*model.LBVirtualServer]             = []PolicyResourceType{PolicyResourceInfra, PolicyResourceInfraLBVirtualServer}
@@ -500,10 +515,17 @@ func (b *PolicyTreeBuilder[T]) parsePathSegments(inputPath string) ([]string, er
 	return pathSegments, nil
 }
 
-func (b *PolicyTreeBuilder[T]) UpdateMultipleResourcesOnNSX(objects []T, nsxClient *nsx.Client) error {
+func (b *PolicyTreeBuilder[T]) UpdateMultipleResourcesOnNSX(ctx context.Context, objects []T, nsxClient *nsx.Client) (err error) {
 	if len(objects) == 0 {
 		return nil
 	}
+	ctx, span := tracing.StartSpan(ctx, "PolicyTreeBuilder.UpdateMultipleResourcesOnNSX",
+		attribute.String("nsx.resource_type", b.leafType), attribute.Int("nsx.resource_count", len(objects)))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+	nsxClient = nsxClient.WithContext(ctx)
 
 	enforceRevisionCheckParam := false
 	if b.rootType == ResourceTypeOrgRoot {
@@ -512,6 +534,9 @@ func (b *PolicyTreeBuilder[T]) UpdateMultipleResourcesOnNSX(objects []T, nsxClie
 			log.Error(err, "Failed to generate OrgRoot with multiple resources", "resourceType", b.leafType)
 			return err
 		}
+		if planModeEnabled(ctx, nsxClient) {
+			return b.planResources(objects, orgRoot, model.OrgRootBindingType())
+		}
 		if err = nsxClient.OrgRootClient.Patch(*orgRoot, &enforceRevisionCheckParam); err != nil {
 			err = util.TransNSXApiError(err)
 			// Log failure for each resource
@@ -554,6 +579,9 @@ func (b *PolicyTreeBuilder[T]) UpdateMultipleResourcesOnNSX(objects []T, nsxClie
 		log.Error(err, "Failed to generate Infra with multiple resources", "resourceType", b.leafType)
 		return err
 	}
+	if planModeEnabled(ctx, nsxClient) {
+		return b.planResources(objects, infraRoot, model.InfraBindingType())
+	}
 	if err = nsxClient.InfraClient.Patch(*infraRoot, &enforceRevisionCheckParam); err != nil {
 		err = util.TransNSXApiError(err)
 		// Log failure for each resource
@@ -592,6 +620,29 @@ func (b *PolicyTreeBuilder[T]) UpdateMultipleResourcesOnNSX(objects []T, nsxClie
 	return nil
 }
 
+// planResources logs the H-API payload of objects built in plan mode instead of sending it to NSX, and returns
+// the changes in PlanError. The builder has no store to compare with, so the objects not marked for delete are
+// planned to be updated without the diff.
+func (b *PolicyTreeBuilder[T]) planResources(objects []T, root interface{}, rootBindingType bindings.BindingType) error {
+	payload, err := encodeFields(root, rootBindingType)
+	if err != nil {
+		return err
+	}
+	log.Info("Plan mode, H-API payload is not sent to NSX", "resourceType", b.leafType, "payload", payload)
+	plan := &Plan{}
+	for _, obj := range objects {
+		change := PlannedChange{Action: PlanActionUpdate, ResourceType: b.leafType}
+		if isMarkedForDelete(obj) {
+			change.Action = PlanActionDelete
+		}
+		if path := b.pathGetter(obj); path != nil {
+			change.Path = *path
+		}
+		plan.changes = append(plan.changes, change)
+	}
+	return plan.Err()
+}
+
 func PagingNSXResources[T any](resources []T, pageSize int) [][]T {
 	totalCount := len(resources)
 	pages := (totalCount + pageSize - 1) / pageSize
@@ -628,6 +679,109 @@ func (p *PolicyResourcePath[T]) NewPolicyTreeBuilder() (*PolicyTreeBuilder[T], e
 	}, nil
 }
 
+// BatchFailure is an object which fails to be updated on NSX, with the NSX error of it.
+type BatchFailure[T any] struct {
+	Object T
+	Err    error
+}
+
+// BatchUpdateError is returned by PagingUpdateResources if some objects are rejected by NSX. The failed batches
+// are bisected until the rejected objects are isolated, the other objects in the batches are still updated.
+type BatchUpdateError[T any] struct {
+	ResourceType string
+	Failures     []BatchFailure[T]
+}
+
+func (e *BatchUpdateError[T]) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		id := "unknown"
+		if resID := getNSXResourceId(failure.Object); resID != nil {
+			id = *resID
+		}
+		if resPath := getNSXResourcePath(failure.Object); resPath != nil {
+			id = *resPath
+		}
+		msgs = append(msgs, fmt.Sprintf("%s: %v", id, failure.Err))
+	}
+	return fmt.Sprintf("failed to update %d %s resources: [%s]", len(e.Failures), e.ResourceType, strings.Join(msgs, "; "))
+}
+
+func (e *BatchUpdateError[T]) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		errs = append(errs, failure.Err)
+	}
+	return errs
+}
+
+// FailedObjects returns the objects rejected by NSX.
+func (e *BatchUpdateError[T]) FailedObjects() []T {
+	objs := make([]T, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		objs = append(objs, failure.Object)
+	}
+	return objs
+}
+
+// BatchFailedObjects returns the objects rejected by NSX if err is the BatchUpdateError returned by
+// PagingUpdateResources, all the other objects are updated then. ok is false if err is not a BatchUpdateError or it
+// is joined with the error failing the whole batches, so any object might not be updated.
+func BatchFailedObjects[T any](err error) (objs []T, ok bool) {
+	batchErr, ok := err.(*BatchUpdateError[T])
+	if !ok {
+		return nil, false
+	}
+	return batchErr.FailedObjects(), true
+}
+
+// isObjectError checks if err is caused by some objects in the patch, e.g. an invalid or conflicting object, so
+// the patch of the other objects could succeed. The errors of NSX availability aren't bisected.
+func isObjectError(err error) bool {
+	var nsxErr *util.NSXApiError
+	if !errors.As(err, &nsxErr) || nsxErr == nil {
+		return false
+	}
+	switch nsxErr.Type() {
+	case apierrors.ErrorType_INVALID_REQUEST, apierrors.ErrorType_INVALID_ARGUMENT, apierrors.ErrorType_INVALID_ELEMENT_CONFIGURATION,
+		apierrors.ErrorType_INVALID_ELEMENT_TYPE, apierrors.ErrorType_UNEXPECTED_INPUT, apierrors.ErrorType_ALREADY_EXISTS,
+		apierrors.ErrorType_NOT_FOUND, apierrors.ErrorType_RESOURCE_IN_USE, apierrors.ErrorType_FEATURE_IN_USE,
+		apierrors.ErrorType_NOT_ALLOWED_IN_CURRENT_STATE, apierrors.ErrorType_UNSUPPORTED:
+		return true
+	}
+	return false
+}
+
+// bisectUpdateResources updates objs on NSX. If NSX rejects the patch because of some objects, objs are split into
+// halves which are retried until the rejected objects are isolated. It returns the objects updated and the ones
+// rejected, err is returned if NSX fails not because of the objects or ctx is done.
+func (builder *PolicyTreeBuilder[T]) bisectUpdateResources(ctx context.Context, objs []T, nsxClient *nsx.Client) (updated []T, failures []BatchFailure[T], err error) {
+	err = builder.UpdateMultipleResourcesOnNSX(ctx, objs, nsxClient)
+	if err == nil {
+		return objs, nil, nil
+	}
+	if !isObjectError(err) {
+		return nil, nil, err
+	}
+	if len(objs) == 1 {
+		return nil, []BatchFailure[T]{{Object: objs[0], Err: err}}, nil
+	}
+	if ctx.Err() != nil {
+		return nil, nil, errors.Join(util.TimeoutFailed, ctx.Err())
+	}
+	mid := len(objs) / 2
+	log.Info("Bisecting failed batch", "resourceType", builder.leafType, "batchResourceCount", len(objs), "reason", err.Error())
+	updated, failures, err = builder.bisectUpdateResources(ctx, objs[:mid], nsxClient)
+	if err != nil {
+		return updated, failures, err
+	}
+	rightUpdated, rightFailures, err := builder.bisectUpdateResources(ctx, objs[mid:], nsxClient)
+	return append(updated, rightUpdated...), append(failures, rightFailures...), err
+}
+
+// PagingUpdateResources updates objs on NSX in the batches of pageSize, updateObjectsFromStoreFn is called with
+// the objects updated. A batch rejected by NSX because of some objects is bisected to update the other objects,
+// the rejected objects are returned in BatchUpdateError.
 func (builder *PolicyTreeBuilder[T]) PagingUpdateResources(ctx context.Context, objs []T, pageSize int, nsxClient *nsx.Client, updateObjectsFromStoreFn func(updatedObjs []T)) error {
 	if len(objs) == 0 {
 		return nil
@@ -640,6 +794,7 @@ func (builder *PolicyTreeBuilder[T]) PagingUpdateResources(ctx context.Context,
 	log.Info("Starting batch deletion", "resourceType", builder.leafType, "totalResources", totalCount, "totalBatches", totalBatches, "batchSize", pageSize)
 
 	var nsxErr error
+	var failures []BatchFailure[T]
 	successCount := 0
 	failedCount := 0
 
@@ -652,21 +807,33 @@ func (builder *PolicyTreeBuilder[T]) PagingUpdateResources(ctx context.Context,
 			log.Info("Batch deletion interrupted by context", "resourceType", builder.leafType, "processedBatches", currentBatch-1, "totalBatches", totalBatches, "successCount", successCount, "failedCount", failedCount)
 			return errors.Join(util.TimeoutFailed, ctx.Err())
 		default:
-			delErr := builder.UpdateMultipleResourcesOnNSX(partialObjs, nsxClient)
-			if delErr == nil {
-				successCount += len(partialObjs)
+			updatedObjs, batchFailures, delErr := builder.bisectUpdateResources(ctx, partialObjs, nsxClient)
+			successCount += len(updatedObjs)
+			if len(updatedObjs) > 0 && updateObjectsFromStoreFn != nil {
+				updateObjectsFromStoreFn(updatedObjs)
+			}
+			failures = append(failures, batchFailures...)
+			if delErr == nil && len(batchFailures) == 0 {
 				log.Info("Batch deletion succeeded", "resourceType", builder.leafType, "batch", fmt.Sprintf("%d/%d", currentBatch, totalBatches), "batchResourceCount", len(partialObjs), "cumulativeSuccess", successCount)
-				if updateObjectsFromStoreFn != nil {
-					updateObjectsFromStoreFn(partialObjs)
-				}
 				continue
 			}
-			failedCount += len(partialObjs)
+			failedCount += len(partialObjs) - len(updatedObjs)
+			if delErr == nil {
+				log.Info("Batch deletion partially succeeded", "resourceType", builder.leafType, "batch", fmt.Sprintf("%d/%d", currentBatch, totalBatches), "batchResourceCount", len(partialObjs), "rejectedResourceCount", len(batchFailures), "cumulativeFailed", failedCount)
+				continue
+			}
 			log.Error(delErr, "Batch deletion failed", "resourceType", builder.leafType, "batch", fmt.Sprintf("%d/%d", currentBatch, totalBatches), "batchResourceCount", len(partialObjs), "cumulativeFailed", failedCount)
 			nsxErr = delErr
 		}
 	}
 
 	log.Info("Batch deletion completed", "resourceType", builder.leafType, "totalResources", totalCount, "successCount", successCount, "failedCount", failedCount)
-	return nsxErr
+	if len(failures) == 0 {
+		return nsxErr
+	}
+	batchErr := &BatchUpdateError[T]{ResourceType: builder.leafType, Failures: failures}
+	if nsxErr == nil {
+		return batchErr
+	}
+	return errors.Join(nsxErr, batchErr)
 }
@@ -2,7 +2,9 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -13,8 +15,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock/nsxserver"
 	orgroot_mocks "github.com/vmware-tanzu/nsx-operator/pkg/mock/orgrootclient"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
 
 type mockInfraClient struct{}
@@ -525,7 +529,7 @@ func testProjectInfraResources(t *testing.T) {
 func testPolicyPathBuilderDeletion[T any](t *testing.T, resourcePath PolicyResourcePath[T], objects []T, nsxClient *nsx.Client) error {
 	builder, err := resourcePath.NewPolicyTreeBuilder()
 	require.Nil(t, err)
-	return builder.UpdateMultipleResourcesOnNSX(objects, nsxClient)
+	return builder.UpdateMultipleResourcesOnNSX(context.TODO(), objects, nsxClient)
 }
 
 func TestBuildRootNodePerformance(t *testing.T) {
@@ -585,3 +589,55 @@ func TestParsePathSegments(t *testing.T) {
 	require.Len(t, segments2, 7)
 	assert.Equal(t, "project-test-share", segments2[len(segments2)-1])
 }
+
+func TestPagingUpdateResourcesBisection(t *testing.T) {
+	server := nsxserver.NewServer()
+	defer server.Close()
+	nsxClient := server.NewClient("k8scl-one")
+	builder, err := PolicyPathVpcSubnet.NewPolicyTreeBuilder()
+	require.NoError(t, err)
+
+	subnets := make([]*model.VpcSubnet, 10)
+	for i := range subnets {
+		subnets[i] = newDeltaSubnet(fmt.Sprintf("subnet-%d", i), fmt.Sprintf("subnet-%d", i))
+	}
+	// the invalid subnets are rejected with the whole H-API request
+	server.RejectPath(*subnets[3].Path, http.StatusBadRequest, 8327)
+	server.RejectPath(*subnets[7].Path, http.StatusBadRequest, 8327)
+
+	var updated []*model.VpcSubnet
+	err = builder.PagingUpdateResources(context.TODO(), subnets, 4, nsxClient, func(objs []*model.VpcSubnet) {
+		updated = append(updated, objs...)
+	})
+	var batchErr *BatchUpdateError[*model.VpcSubnet]
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, []*model.VpcSubnet{subnets[3], subnets[7]}, batchErr.FailedObjects())
+	failedObjs, ok := BatchFailedObjects[*model.VpcSubnet](err)
+	assert.True(t, ok)
+	assert.Equal(t, []*model.VpcSubnet{subnets[3], subnets[7]}, failedObjs)
+	assert.ErrorContains(t, err, *subnets[3].Path+": nsx error code: 8327")
+	var nsxErr *util.NSXApiError
+	assert.ErrorAs(t, err, &nsxErr)
+	assert.Len(t, updated, 8)
+	assert.NotContains(t, updated, subnets[3])
+	assert.NotContains(t, updated, subnets[7])
+	for i, subnet := range subnets {
+		_, found := server.Object(*subnet.Path)
+		assert.Equal(t, i != 3 && i != 7, found, *subnet.Path)
+	}
+	// 3 batches, the 2 failed batches of 4 objects are bisected into 2 halves and 2 quarters
+	assert.Equal(t, 3+2*(2+2), server.RequestCount(http.MethodPatch, nsxserver.PolicyAPIPrefix+"/org-root"))
+
+	// the batch isn't bisected if NSX is unavailable
+	server.InjectError(http.MethodPatch, nsxserver.PolicyAPIPrefix+"/org-root", 1, http.StatusServiceUnavailable, 0)
+	count := server.RequestCount(http.MethodPatch, nsxserver.PolicyAPIPrefix+"/org-root")
+	err = builder.PagingUpdateResources(context.TODO(), subnets[:2], 4, nsxClient, nil)
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &batchErr))
+	_, ok = BatchFailedObjects[*model.VpcSubnet](err)
+	assert.False(t, ok)
+	// any object might not be updated if the error of the rejected objects is joined with the NSX unavailability
+	_, ok = BatchFailedObjects[*model.VpcSubnet](errors.Join(errors.New("service unavailable"), &BatchUpdateError[*model.VpcSubnet]{}))
+	assert.False(t, ok)
+	assert.Equal(t, count+1, server.RequestCount(http.MethodPatch, nsxserver.PolicyAPIPrefix+"/org-root"))
+}
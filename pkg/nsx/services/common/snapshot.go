@@ -0,0 +1,221 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data/serializers/cleanjson"
+
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+const (
+	// snapshotFormatVersion is increased once the snapshot format is changed incompatibly
+	snapshotFormatVersion = 1
+	// snapshotMaxAge limits the age of the snapshots loaded. NSX purges the resources marked for delete after a
+	// while, the deletions before it can't be found by the delta query.
+	snapshotMaxAge = time.Hour
+)
+
+// storeSnapshot is the persisted content of a store refreshed by DeltaSyncer.
+type storeSnapshot struct {
+	FormatVersion int       `json:"format_version"`
+	NSXVersion    string    `json:"nsx_version"`
+	ResourceType  string    `json:"resource_type"`
+	Query         string    `json:"query"`
+	Watermark     int64     `json:"watermark"`
+	CreatedAt     time.Time `json:"created_at"`
+	// Resources are in the JSON format of the NSX API
+	Resources []json.RawMessage `json:"resources"`
+}
+
+// snapshotPath returns the file of the snapshot in dir, the stores of a resource type are told apart by the query.
+func (s *DeltaSyncer) snapshotPath(dir string) string {
+	h := fnv.New32a()
+	h.Write([]byte(s.queryParam))
+	return filepath.Join(dir, fmt.Sprintf("%s-%08x.json.gz", s.resourceType, h.Sum32()))
+}
+
+func (s *DeltaSyncer) nsxVersion() (string, error) {
+	version, err := s.service.NSXClient.Cluster.GetVersion()
+	if err != nil {
+		return "", err
+	}
+	return version.NodeVersion, nil
+}
+
+// SaveSnapshot saves the resources in the store and the watermark to dir.
+func (s *DeltaSyncer) SaveSnapshot(dir string) error {
+	nsxVersion, err := s.nsxVersion()
+	if err != nil {
+		return err
+	}
+	lister, ok := s.store.(interface{ List() []interface{} })
+	if !ok {
+		return fmt.Errorf("store of %s doesn't support snapshot", s.resourceType)
+	}
+	s.mutex.Lock()
+	snapshot := &storeSnapshot{
+		FormatVersion: snapshotFormatVersion,
+		NSXVersion:    nsxVersion,
+		ResourceType:  s.resourceType,
+		Query:         s.queryParam,
+		Watermark:     s.watermark,
+		CreatedAt:     time.Now(),
+	}
+	objs := lister.List()
+	s.mutex.Unlock()
+
+	encoder := cleanjson.NewDataValueToJsonEncoder()
+	for _, obj := range objs {
+		dataValue, errs := NewConverter().ConvertToVapi(obj, s.bindingType)
+		for _, err := range errs {
+			return err
+		}
+		encoded, err := encoder.Encode(dataValue)
+		if err != nil {
+			return err
+		}
+		snapshot.Resources = append(snapshot.Resources, json.RawMessage(encoded))
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	// the snapshot is written to a temporary file and renamed, so a partial snapshot is never loaded
+	file, err := os.CreateTemp(dir, ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	writer := gzip.NewWriter(file)
+	if err := json.NewEncoder(writer).Encode(snapshot); err != nil {
+		file.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), s.snapshotPath(dir)); err != nil {
+		return err
+	}
+	log.Debug("Saved store snapshot", "resourceType", s.resourceType, "count", len(objs), "watermark", snapshot.Watermark)
+	return nil
+}
+
+// LoadSnapshot applies the resources in the snapshot in dir to the store and restores the watermark, the store
+// should be empty. The snapshot is rejected if it's saved by another format version, for another query or NSX
+// version, or it's too old.
+func (s *DeltaSyncer) LoadSnapshot(dir string) (uint64, error) {
+	file, err := os.Open(s.snapshotPath(dir))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return 0, err
+	}
+	snapshot := &storeSnapshot{}
+	if err := json.NewDecoder(reader).Decode(snapshot); err != nil {
+		return 0, err
+	}
+	nsxVersion, err := s.nsxVersion()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case snapshot.FormatVersion != snapshotFormatVersion:
+		return 0, fmt.Errorf("snapshot format version %d isn't supported", snapshot.FormatVersion)
+	case snapshot.ResourceType != s.resourceType || snapshot.Query != s.queryParam:
+		return 0, fmt.Errorf("snapshot is saved for query %q", snapshot.Query)
+	case snapshot.NSXVersion != nsxVersion:
+		return 0, fmt.Errorf("snapshot is saved with NSX version %s, current version is %s", snapshot.NSXVersion, nsxVersion)
+	case time.Since(snapshot.CreatedAt) > snapshotMaxAge:
+		return 0, fmt.Errorf("snapshot is saved at %s, it's older than %s", snapshot.CreatedAt, snapshotMaxAge)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	decoder := cleanjson.NewJsonToDataValueDecoder()
+	count := uint64(0)
+	for _, resource := range snapshot.Resources {
+		jsonDecoder := json.NewDecoder(strings.NewReader(string(resource)))
+		jsonDecoder.UseNumber()
+		var value interface{}
+		if err := jsonDecoder.Decode(&value); err != nil {
+			return count, err
+		}
+		dataValue, err := decoder.Decode(value)
+		if err != nil {
+			return count, err
+		}
+		obj, errs := NewConverter().ConvertToGolang(dataValue, s.bindingType)
+		for _, err := range errs {
+			return count, err
+		}
+		objAddr := nsxutil.CasttoPointer(obj)
+		if objAddr == nil {
+			return count, fmt.Errorf("failed to cast to pointer")
+		}
+		if err := s.store.Apply(objAddr); err != nil {
+			return count, err
+		}
+		count++
+	}
+	s.watermark = snapshot.Watermark
+	return count, nil
+}
+
+// Initialize loads the store from the snapshot in SnapshotDir and applies the resources modified since it was
+// saved. All resources are loaded from NSX if SnapshotDir is empty or the snapshot can't be loaded or synced, the
+// store is cleared and the snapshot is removed in the latter case.
+func (s *DeltaSyncer) Initialize() (uint64, error) {
+	if s.SnapshotDir != "" {
+		count, err := s.LoadSnapshot(s.SnapshotDir)
+		if err == nil {
+			log.Info("Loaded store snapshot", "resourceType", s.resourceType, "count", count, "watermark", s.Watermark())
+			if _, err = s.Sync(); err == nil {
+				return count, nil
+			}
+		}
+		if !os.IsNotExist(err) {
+			log.Info("Ignored store snapshot", "resourceType", s.resourceType, "reason", err.Error())
+			if err := s.reset(); err != nil {
+				return 0, err
+			}
+			if err := os.Remove(s.snapshotPath(s.SnapshotDir)); err != nil && !os.IsNotExist(err) {
+				log.Error(err, "Failed to remove store snapshot", "resourceType", s.resourceType)
+			}
+		}
+	}
+	return s.Load()
+}
+
+// reset removes all resources from the store and the watermark.
+func (s *DeltaSyncer) reset() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.watermark = 0
+	s.synced = nil
+	replacer, ok := s.store.(interface {
+		Replace(list []interface{}, resourceVersion string) error
+	})
+	if !ok {
+		return fmt.Errorf("store of %s doesn't support reset", s.resourceType)
+	}
+	return replacer.Replace([]interface{}{}, "")
+}
@@ -0,0 +1,190 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock/nsxserver"
+)
+
+func TestDeltaSyncerSnapshot(t *testing.T) {
+	server := nsxserver.NewServer()
+	defer server.Close()
+	nsxClient := server.NewClient("k8scl-one")
+	service := &Service{NSXClient: nsxClient, NSXConfig: nsxClient.NsxConfig}
+	dir := t.TempDir()
+
+	subnet := newDeltaSubnet("subnet-1", "subnet-1")
+	subnet.IpAddresses = []string{"10.0.0.0/28"}
+	addDeltaSubnet(t, server, subnet)
+	addDeltaSubnet(t, server, newDeltaSubnet("subnet-2", "subnet-2"))
+
+	// no snapshot is saved yet, the store is loaded from NSX
+	syncer, err := service.NewDeltaSyncer(ResourceTypeSubnet, deltaSubnetQuery, newDeltaSubnetStore())
+	require.NoError(t, err)
+	syncer.SnapshotDir = dir
+	count, err := syncer.Initialize()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	require.NoError(t, syncer.SaveSnapshot(dir))
+	addDeltaSubnet(t, server, newDeltaSubnet("subnet-3", "subnet-3"))
+
+	// the new leader loads the snapshot and applies the changes since it
+	store := newDeltaSubnetStore()
+	restored, err := service.NewDeltaSyncer(ResourceTypeSubnet, deltaSubnetQuery, store)
+	require.NoError(t, err)
+	restored.SnapshotDir = dir
+	count, err = restored.Initialize()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	assert.Len(t, store.List(), 3)
+	assert.Equal(t, []string{"10.0.0.0/28"}, store.GetByKey("subnet-1").IpAddresses)
+	assert.GreaterOrEqual(t, restored.Watermark(), syncer.Watermark())
+
+	// the snapshot of another store isn't loaded
+	other, err := service.NewDeltaSyncer(ResourceTypeSubnet, deltaSubnetQuery+" AND display_name:subnet-1", newDeltaSubnetStore())
+	require.NoError(t, err)
+	_, err = other.LoadSnapshot(dir)
+	assert.True(t, os.IsNotExist(err))
+
+	rewrite := func(modify func(snapshot *storeSnapshot)) {
+		file, err := os.Open(syncer.snapshotPath(dir))
+		require.NoError(t, err)
+		reader, err := gzip.NewReader(file)
+		require.NoError(t, err)
+		snapshot := &storeSnapshot{}
+		require.NoError(t, json.NewDecoder(reader).Decode(snapshot))
+		file.Close()
+		modify(snapshot)
+		file, err = os.Create(syncer.snapshotPath(dir))
+		require.NoError(t, err)
+		writer := gzip.NewWriter(file)
+		require.NoError(t, json.NewEncoder(writer).Encode(snapshot))
+		writer.Close()
+		file.Close()
+	}
+	for _, tc := range []struct {
+		name   string
+		modify func(snapshot *storeSnapshot)
+		err    string
+	}{
+		{"format", func(snapshot *storeSnapshot) { snapshot.FormatVersion = 0 }, "format version 0"},
+		{"nsx", func(snapshot *storeSnapshot) { snapshot.NSXVersion = "4.2.0" }, "NSX version 4.2.0"},
+		{"age", func(snapshot *storeSnapshot) { snapshot.CreatedAt = time.Now().Add(-2 * snapshotMaxAge) }, "older than"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, syncer.SaveSnapshot(dir))
+			rewrite(tc.modify)
+			store := newDeltaSubnetStore()
+			rejected, err := service.NewDeltaSyncer(ResourceTypeSubnet, deltaSubnetQuery, store)
+			require.NoError(t, err)
+			_, err = rejected.LoadSnapshot(dir)
+			assert.ErrorContains(t, err, tc.err)
+			assert.Empty(t, store.List())
+
+			// all resources are loaded from NSX instead
+			rejected.SnapshotDir = dir
+			count, err := rejected.Initialize()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(3), count)
+		})
+	}
+}
+
+func TestDeltaSyncerSnapshotFallback(t *testing.T) {
+	server := nsxserver.NewServer()
+	defer server.Close()
+	nsxClient := server.NewClient("k8scl-one")
+	service := &Service{NSXClient: nsxClient, NSXConfig: nsxClient.NsxConfig}
+	dir := t.TempDir()
+
+	addDeltaSubnet(t, server, newDeltaSubnet("subnet-1", "subnet-1"))
+	addDeltaSubnet(t, server, newDeltaSubnet("subnet-2", "subnet-2"))
+	syncer, err := service.NewDeltaSyncer(ResourceTypeSubnet, deltaSubnetQuery, newDeltaSubnetStore())
+	require.NoError(t, err)
+	_, err = syncer.Load()
+	require.NoError(t, err)
+	save := func(modify func(snapshot *storeSnapshot)) {
+		require.NoError(t, syncer.SaveSnapshot(dir))
+		if modify == nil {
+			return
+		}
+		file, err := os.Open(syncer.snapshotPath(dir))
+		require.NoError(t, err)
+		reader, err := gzip.NewReader(file)
+		require.NoError(t, err)
+		snapshot := &storeSnapshot{}
+		require.NoError(t, json.NewDecoder(reader).Decode(snapshot))
+		file.Close()
+		modify(snapshot)
+		file, err = os.Create(syncer.snapshotPath(dir))
+		require.NoError(t, err)
+		writer := gzip.NewWriter(file)
+		require.NoError(t, json.NewEncoder(writer).Encode(snapshot))
+		writer.Close()
+		file.Close()
+	}
+	// subnet-1 is deleted in NSX after the snapshot is saved
+	removeSubnet1 := func() {
+		builder, err := PolicyPathVpcSubnet.NewPolicyTreeBuilder()
+		require.NoError(t, err)
+		deleted := newDeltaSubnet("subnet-1", "subnet-1")
+		deleted.MarkedForDelete = Bool(true)
+		require.NoError(t, builder.UpdateMultipleResourcesOnNSX(context.TODO(), []*model.VpcSubnet{deleted}, nsxClient))
+	}
+
+	for _, tc := range []struct {
+		name    string
+		prepare func()
+	}{
+		{"corrupt file", func() {
+			save(nil)
+			require.NoError(t, os.WriteFile(syncer.snapshotPath(dir), []byte("corrupt"), 0600))
+		}},
+		{"corrupt resource", func() {
+			// subnet-1 is applied before the corrupt resource fails the load
+			save(func(snapshot *storeSnapshot) {
+				snapshot.Resources = append(snapshot.Resources, json.RawMessage(`{"resource_type":"VpcSubnet","id":1}`))
+			})
+		}},
+		{"sync failure", func() {
+			save(nil)
+			server.InjectError(http.MethodGet, "/policy/api/v1/search", 1, http.StatusBadRequest, 0)
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if server.Paths(deltaVPCPath+"/subnets/subnet-1") == nil {
+				addDeltaSubnet(t, server, newDeltaSubnet("subnet-1", "subnet-1"))
+				_, err := syncer.Sync()
+				require.NoError(t, err)
+			}
+			tc.prepare()
+			removeSubnet1()
+
+			store := newDeltaSubnetStore()
+			restored, err := service.NewDeltaSyncer(ResourceTypeSubnet, deltaSubnetQuery, store)
+			require.NoError(t, err)
+			restored.SnapshotDir = dir
+			count, err := restored.Initialize()
+			require.NoError(t, err)
+			// the store is loaded from NSX without the resources of the snapshot
+			assert.Equal(t, uint64(1), count)
+			assert.Nil(t, store.GetByKey("subnet-1"))
+			assert.NotNil(t, store.GetByKey("subnet-2"))
+			_, err = os.Stat(syncer.snapshotPath(dir))
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
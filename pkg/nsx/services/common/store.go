@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	apierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
@@ -102,6 +103,11 @@ func (resourceStore *ResourceStore) GetByIndex(index string, value string) []int
 	return indexResults
 }
 
+// ResourceBindingType returns the BindingType used to convert the resources of the store.
+func (resourceStore *ResourceStore) ResourceBindingType() bindings.BindingType {
+	return resourceStore.BindingType
+}
+
 func (resourceStore *ResourceStore) IsPolicyAPI() bool {
 	return true
 }
@@ -223,12 +229,33 @@ func (service *Service) InitializeCommonStore(wg *sync.WaitGroup, fatalErrors ch
 		pathUnescape, _ := url.PathUnescape("path%3A")
 		queryParam += " AND " + pathUnescape + path
 	}
+	if interval := service.storeRefreshInterval(); interval > 0 {
+		if syncer, err := service.NewDeltaSyncer(resourceTypeValue, queryParam, store); err == nil {
+			defer wg.Done()
+			syncer.SnapshotDir = service.NSXClient.NsxConfig.StoreSnapshotDir
+			count, err := syncer.Initialize()
+			if err != nil {
+				fatalErrors <- err
+				return
+			}
+			log.Info("Initialized store", "resourceType", resourceTypeValue, "count", count, "refreshInterval", interval)
+			go syncer.Run(service.StopCh, interval)
+			return
+		}
+	}
 	if store.IsPolicyAPI() {
 		queryParam += " AND marked_for_delete:false"
 	}
 	service.PopulateResourcetoStore(wg, fatalErrors, resourceTypeValue, queryParam, store, nil)
 }
 
+func (service *Service) storeRefreshInterval() time.Duration {
+	if service.NSXClient == nil || service.NSXClient.NsxConfig == nil || service.NSXClient.NsxConfig.NsxConfig == nil {
+		return 0
+	}
+	return time.Duration(service.NSXClient.NsxConfig.StoreRefreshInterval) * time.Second
+}
+
 // Helper function to check if any tag has the specified scopes
 func containsTagScope(tags []model.Tag, scopes ...string) bool {
 	for _, tag := range tags {
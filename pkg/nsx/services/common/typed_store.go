@@ -0,0 +1,152 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TypedStore is the ResourceStore of the NSX resources of type *T, e.g. TypedStore[model.VpcSubnet] stores
+// *model.VpcSubnet. The stores of the services embed it instead of ResourceStore to get the typed accessors
+// and the Apply based on MarkedForDelete.
+type TypedStore[T any] struct {
+	ResourceStore
+}
+
+// NewTypedStore creates the TypedStore indexed by indexers, the resources are keyed by keyFunc. The typed index
+// functions could be adapted by TypedIndexFunc.
+func NewTypedStore[T any](bindingType bindings.BindingType, keyFunc func(obj *T) (string, error), indexers cache.Indexers) TypedStore[T] {
+	if indexers == nil {
+		indexers = cache.Indexers{}
+	}
+	return TypedStore[T]{
+		ResourceStore: ResourceStore{
+			Indexer: cache.NewIndexer(func(obj interface{}) (string, error) {
+				switch o := obj.(type) {
+				case *T:
+					return keyFunc(o)
+				case string:
+					return o, nil
+				default:
+					return "", fmt.Errorf("keyFunc doesn't support %T", obj)
+				}
+			}, indexers),
+			BindingType: bindingType,
+		},
+	}
+}
+
+// TypedIndexFunc adapts the typed index function to cache.IndexFunc, the objects of other types are not indexed.
+func TypedIndexFunc[T any](indexFunc func(obj *T) []string) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		o, ok := obj.(*T)
+		if !ok {
+			return []string{}, nil
+		}
+		return indexFunc(o), nil
+	}
+}
+
+// Apply adds or updates the resources in the store, the ones with MarkedForDelete are deleted. i is *T, []*T,
+// []T or *[]T.
+func (s *TypedStore[T]) Apply(i interface{}) error {
+	switch objs := i.(type) {
+	case nil:
+		return nil
+	case *T:
+		if objs == nil {
+			return nil
+		}
+		return s.apply(objs)
+	case []*T:
+		for _, obj := range objs {
+			if err := s.apply(obj); err != nil {
+				return err
+			}
+		}
+	case []T:
+		// the elements are copied, the store must not share them with the slice of the caller
+		for idx := range objs {
+			obj := objs[idx]
+			if err := s.apply(&obj); err != nil {
+				return err
+			}
+		}
+	case *[]T:
+		if objs == nil {
+			return nil
+		}
+		return s.Apply(*objs)
+	default:
+		return fmt.Errorf("store of %T doesn't support %T", new(T), i)
+	}
+	return nil
+}
+
+func (s *TypedStore[T]) apply(obj *T) error {
+	if isMarkedForDelete(obj) {
+		if err := s.Delete(obj); err != nil {
+			return err
+		}
+		log.Debug("Deleted resource from store", "resource", obj)
+		return nil
+	}
+	if err := s.Add(obj); err != nil {
+		return err
+	}
+	log.Debug("Added resource to store", "resource", obj)
+	return nil
+}
+
+// isMarkedForDelete returns the MarkedForDelete of the NSX resource obj.
+func isMarkedForDelete(obj interface{}) bool {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	field := v.FieldByName("MarkedForDelete")
+	if !field.IsValid() || field.Kind() != reflect.Ptr || field.IsNil() {
+		return false
+	}
+	markedForDelete, ok := field.Elem().Interface().(bool)
+	return ok && markedForDelete
+}
+
+// GetByKey returns the resource of key, nil if it isn't found.
+func (s *TypedStore[T]) GetByKey(key string) *T {
+	obj := s.ResourceStore.GetByKey(key)
+	if obj == nil {
+		return nil
+	}
+	return obj.(*T)
+}
+
+// GetByIndex returns the resources whose index is value.
+func (s *TypedStore[T]) GetByIndex(index string, value string) []*T {
+	return toTyped[T](s.ResourceStore.GetByIndex(index, value))
+}
+
+// Snapshot returns all resources in the store.
+func (s *TypedStore[T]) Snapshot() []*T {
+	return toTyped[T](s.List())
+}
+
+// DeleteMultipleObjects deletes objs from the store.
+func (s *TypedStore[T]) DeleteMultipleObjects(objs []*T) {
+	for _, obj := range objs {
+		_ = s.Delete(obj)
+	}
+}
+
+func toTyped[T any](objs []interface{}) []*T {
+	typed := make([]*T, 0, len(objs))
+	for _, obj := range objs {
+		typed = append(typed, obj.(*T))
+	}
+	return typed
+}
@@ -0,0 +1,66 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestTypedStore(t *testing.T) {
+	store := NewTypedStore[model.StaticRoutes](model.StaticRoutesBindingType(), func(route *model.StaticRoutes) (string, error) {
+		return *route.Id, nil
+	}, cache.Indexers{
+		TagScopeNamespace: TypedIndexFunc(func(route *model.StaticRoutes) []string {
+			var namespaces []string
+			for _, tag := range route.Tags {
+				if *tag.Scope == TagScopeNamespace {
+					namespaces = append(namespaces, *tag.Tag)
+				}
+			}
+			return namespaces
+		}),
+	})
+	newRoute := func(id, ns string) *model.StaticRoutes {
+		return &model.StaticRoutes{Id: String(id), Tags: []model.Tag{{Scope: String(TagScopeNamespace), Tag: String(ns)}}}
+	}
+
+	// single resource, slices of pointers and values
+	require.NoError(t, store.Apply(newRoute("route-1", "ns-1")))
+	require.NoError(t, store.Apply([]*model.StaticRoutes{newRoute("route-2", "ns-1"), newRoute("route-3", "ns-2")}))
+	require.NoError(t, store.Apply(&[]model.StaticRoutes{*newRoute("route-4", "ns-2")}))
+	require.NoError(t, store.Apply(nil))
+	assert.Len(t, store.Snapshot(), 4)
+	assert.Equal(t, "route-1", *store.GetByKey("route-1").Id)
+	assert.Nil(t, store.GetByKey("route-5"))
+	assert.ElementsMatch(t, []*model.StaticRoutes{store.GetByKey("route-1"), store.GetByKey("route-2")}, store.GetByIndex(TagScopeNamespace, "ns-1"))
+
+	// the resources marked for delete are removed
+	deleted := newRoute("route-2", "ns-1")
+	deleted.MarkedForDelete = Bool(true)
+	require.NoError(t, store.Apply(deleted))
+	assert.Nil(t, store.GetByKey("route-2"))
+	assert.Len(t, store.GetByIndex(TagScopeNamespace, "ns-1"), 1)
+
+	store.DeleteMultipleObjects(store.GetByIndex(TagScopeNamespace, "ns-2"))
+	assert.Len(t, store.Snapshot(), 1)
+
+	// the elements of a slice of values are copied
+	routes := []model.StaticRoutes{*newRoute("route-5", "ns-3"), *newRoute("route-6", "ns-3")}
+	require.NoError(t, store.Apply(routes))
+	routes[0].DisplayName = String("changed")
+	routes[1] = *newRoute("route-7", "ns-3")
+	assert.Nil(t, store.GetByKey("route-5").DisplayName)
+	assert.Equal(t, "route-6", *store.GetByKey("route-6").Id)
+	store.DeleteMultipleObjects(store.GetByIndex(TagScopeNamespace, "ns-3"))
+
+	// the resources of other types are rejected instead of panicking
+	assert.Error(t, store.Apply(&model.Vpc{Id: String("vpc-1")}))
+	assert.Error(t, store.Add(&model.Vpc{Id: String("vpc-1")}))
+	assert.Len(t, store.Snapshot(), 1)
+}
@@ -4,6 +4,7 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -44,6 +45,8 @@ const (
 	TagScopeSecurityPolicyUID          string = "nsx-op/security_policy_uid"
 	TagScopeNetworkPolicyName          string = "nsx-op/network_policy_name"
 	TagScopeNetworkPolicyUID           string = "nsx-op/network_policy_uid"
+	TagScopeClusterSecurityPolicyName  string = "nsx-op/cluster_security_policy_name"
+	TagScopeClusterSecurityPolicyUID   string = "nsx-op/cluster_security_policy_uid"
 	TagScopeStaticRouteCRName          string = "nsx-op/static_route_name"
 	TagScopeStaticRouteCRUID           string = "nsx-op/static_route_uid"
 	TagScopeRuleID                     string = "nsx-op/rule_id"
@@ -101,6 +104,8 @@ const (
 	AnnotationAssociatedResource       string = "nsx.vmware.com/associated-resource"
 	AnnotationReconfigureNic           string = "nsx/reconfigure-nic"
 	AnnotationPodMAC                   string = "nsx.vmware.com/mac"
+	AnnotationPlan                     string = "nsx.vmware.com/plan"
+	AnnotationNetworkPolicyLogging     string = "nsx.vmware.com/network-policy-logging"
 	LabelCPVM                          string = "iaas.vmware.com/is-cpvm-subnetport"
 	TagScopePodName                    string = "nsx-op/pod_name"
 	TagScopePodUID                     string = "nsx-op/pod_uid"
@@ -126,21 +131,24 @@ const (
 	IndexKeyAttachmentID        = "IndexKeyAttachmentID"
 	GCValidationInterval uint16 = 720
 
-	RuleIngress            = "ingress"
-	RuleEgress             = "egress"
-	RuleActionAllow        = "allow"
-	RuleActionDrop         = "isolation"
-	RuleActionReject       = "reject"
-	RuleAnyPorts           = "all"
-	DefaultProject         = "default"
-	DefaultVpcAttachmentId = "default"
-	SecurityPolicyPrefix   = "sp"
-	NetworkPolicyPrefix    = "np"
-	TargetGroupSuffix      = "scope"
-	SrcGroupSuffix         = "src"
-	DstGroupSuffix         = "dst"
-	IpSetGroupSuffix       = "ipset"
-	ShareSuffix            = "share"
+	RuleIngress                 = "ingress"
+	RuleEgress                  = "egress"
+	RuleActionAllow             = "allow"
+	RuleActionDrop              = "isolation"
+	RuleActionReject            = "reject"
+	RuleActionPass              = "pass"
+	RuleAnyPorts                = "all"
+	DefaultProject              = "default"
+	DefaultVpcAttachmentId      = "default"
+	SecurityPolicyPrefix        = "sp"
+	NetworkPolicyPrefix         = "np"
+	ClusterSecurityPolicyPrefix = "csp"
+	TargetGroupSuffix           = "scope"
+	SrcGroupSuffix              = "src"
+	DstGroupSuffix              = "dst"
+	IpSetGroupSuffix            = "ipset"
+	ShareSuffix                 = "share"
+	FQDNProfileSuffix           = "fqdn"
 
 	GatewayInterfaceId = "gateway-interface"
 	VPCKey             = "/orgs/%s/projects/%s/vpcs/%s"
@@ -158,6 +166,7 @@ var (
 	ResourceTypeDomain                           = "Domain"
 	ResourceTypeSecurityPolicy                   = "SecurityPolicy"
 	ResourceTypeNetworkPolicy                    = "NetworkPolicy"
+	ResourceTypeClusterSecurityPolicy            = "ClusterSecurityPolicy"
 	ResourceTypeGroup                            = "Group"
 	ResourceTypeRule                             = "Rule"
 	ResourceTypeIPBlock                          = "IpAddressBlock"
@@ -173,6 +182,7 @@ var (
 	ResourceTypeShare                            = "Share"
 	ResourceTypeSharedResource                   = "SharedResource"
 	ResourceTypeStaticRoutes                     = "StaticRoutes"
+	ResourceTypeContextProfile                   = "PolicyContextProfile"
 	ResourceTypeChildLBPool                      = "ChildLBPool"
 	ResourceTypeChildLBService                   = "ChildLBService"
 	ResourceTypeChildLBVirtualServer             = "ChildLBVirtualServer"
@@ -180,6 +190,7 @@ var (
 	ResourceTypeChildShare                       = "ChildShare"
 	ResourceTypeChildRule                        = "ChildRule"
 	ResourceTypeChildGroup                       = "ChildGroup"
+	ResourceTypeChildContextProfile              = "ChildPolicyContextProfile"
 	ResourceTypeChildSecurityPolicy              = "ChildSecurityPolicy"
 	ResourceTypeChildStaticRoutes                = "ChildStaticRoutes"
 	ResourceTypeChildSubnetConnectionBindingMap  = "ChildSubnetConnectionBindingMap"
@@ -223,6 +234,15 @@ type Service struct {
 	Client    client.Client
 	NSXClient *nsx.Client
 	NSXConfig *config.NSXOperatorConfig
+	// StopCh stops the background work of the service, e.g. the store refresh, it's closed when the manager is
+	// stopped. The background work runs until the process exits if it's nil.
+	StopCh <-chan struct{}
+}
+
+// NSXClientWithContext returns the NSX client which sends the requests with ctx, so that the NSX API calls
+// are traced as children of the span in ctx.
+func (service *Service) NSXClientWithContext(ctx context.Context) *nsx.Client {
+	return service.NSXClient.WithContext(ctx)
 }
 
 func NewConverter() *bindings.TypeConverter {
@@ -219,6 +219,21 @@ func WrapShare(share *model.Share) (*data.StructValue, error) {
 	return dataValue.(*data.StructValue), nil
 }
 
+func WrapContextProfile(profile *model.PolicyContextProfile) (*data.StructValue, error) {
+	profile.ResourceType = &ResourceTypeContextProfile
+	childProfile := model.ChildPolicyContextProfile{
+		ResourceType:         ResourceTypeChildContextProfile,
+		Id:                   profile.Id,
+		MarkedForDelete:      profile.MarkedForDelete,
+		PolicyContextProfile: profile,
+	}
+	dataValue, errors := NewConverter().ConvertToVapi(childProfile, childProfile.GetType__())
+	if len(errors) > 0 {
+		return nil, errors[0]
+	}
+	return dataValue.(*data.StructValue), nil
+}
+
 func WrapLBService(lbService *model.LBService) (*data.StructValue, error) {
 	lbService.ResourceType = &ResourceTypeLBService
 	childLBService := model.ChildLBService{
@@ -11,6 +11,7 @@ import (
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
 	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
@@ -238,8 +239,9 @@ func (r *SystemHealthReporter) sendHealthStatusToNSX(status HealthStatus) (map[s
 	// Create the URL for the health status API
 	url := "api/v1/systemhealth/container-cluster/ncp/status"
 
-	// Use the HttpPost method from the Cluster instance
-	responseBody, err := r.nsxClient.Cluster.HttpPost(url, requestBody)
+	// Use the HttpPost method from the Cluster instance, health reporting is background traffic
+	ctx := ratelimiter.WithPriority(r.reportCtx, ratelimiter.PriorityBackground)
+	responseBody, err := r.nsxClient.Cluster.HttpPostWithContext(ctx, url, requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send health status: %v", err)
 	}
@@ -51,13 +51,14 @@ func createService(t *testing.T) (*InventoryService, *mockClient.MockClient) {
 		NsxConfig: &config.NsxConfig{NsxApiManagers: []string{"127.0.0.1"}},
 	}
 	nsxApiClient, _ := nsx.CreateNsxtApiClient(cf, httpClient)
+	nsxClient := &nsx.Client{
+		RestConnector: rc,
+		NsxConfig:     cf,
+	}
+	nsxClient.SetNsxApiClient(nsxApiClient)
 	commonservice := commonservice.Service{
-		Client: k8sClient,
-		NSXClient: &nsx.Client{
-			RestConnector: rc,
-			NsxConfig:     cf,
-			NsxApiClient:  nsxApiClient,
-		},
+		Client:    k8sClient,
+		NSXClient: nsxClient,
 		NSXConfig: &config.NSXOperatorConfig{
 			CoeConfig: &config.CoeConfig{
 				Cluster: "k8scl-one:test",
@@ -727,7 +728,7 @@ func TestSynchronizeServiceIDsWithApplicationInstances(t *testing.T) {
 		retry := inventoryService.synchronizeServiceIDsWithApplicationInstances(podUIDs, service)
 		assert.False(t, retry)
 
-		instance := inventoryService.ApplicationInstanceStore.GetByKey("pod-uid-123").(*containerinventory.ContainerApplicationInstance)
+		instance := inventoryService.ApplicationInstanceStore.GetByKey("pod-uid-123")
 		assert.Contains(t, instance.ContainerApplicationIds, "service-uid-234")
 		assert.Contains(t, instance.ContainerApplicationIds, "service-uid-456")
 	})
@@ -763,7 +764,7 @@ func TestSynchronizeServiceIDsWithApplicationInstances(t *testing.T) {
 		defer patches.Reset()
 		inventoryService.removeStaleServiceIDsFromApplicationInstances(podUIDs, service)
 
-		updatedInstance := inventoryService.ApplicationInstanceStore.GetByKey("stale-pod-uid").(*containerinventory.ContainerApplicationInstance)
+		updatedInstance := inventoryService.ApplicationInstanceStore.GetByKey("stale-pod-uid")
 		assert.NotContains(t, updatedInstance.ContainerApplicationIds, string(service.UID))
 	})
 }
@@ -878,12 +879,12 @@ func TestRemoveStaleServiceIDsFromApplicationInstances(t *testing.T) {
 	result = inventoryService.removeStaleServiceIDsFromApplicationInstances(podUIDs, service)
 	assert.False(t, result)
 	// Verify that the stale UID is removed from the first instance
-	updatedInstanceWithStaleID := inventoryService.ApplicationInstanceStore.GetByKey("pod-uid-123").(*containerinventory.ContainerApplicationInstance)
+	updatedInstanceWithStaleID := inventoryService.ApplicationInstanceStore.GetByKey("pod-uid-123")
 	assert.NotContains(t, updatedInstanceWithStaleID.ContainerApplicationIds, "service-uid-789")
 	assert.Contains(t, updatedInstanceWithStaleID.ContainerApplicationIds, "service-uid-456")
 
 	// Verify that the instance with valid IDs remains unchanged
-	updatedInstanceWithValidID := inventoryService.ApplicationInstanceStore.GetByKey("pod-uid-456").(*containerinventory.ContainerApplicationInstance)
+	updatedInstanceWithValidID := inventoryService.ApplicationInstanceStore.GetByKey("pod-uid-456")
 	assert.Contains(t, updatedInstanceWithValidID.ContainerApplicationIds, "service-uid-456")
 	patches.Reset()
 
@@ -1013,7 +1014,7 @@ func TestBuildIngress(t *testing.T) {
 			patches := gomonkey.ApplyMethod(reflect.TypeOf(inventoryService), "GetNamespace", func(_ *InventoryService, namespace string) (*corev1.Namespace, error) {
 				return tt.namespace, nil
 			})
-			patches.ApplyMethod(reflect.TypeOf(inventoryService.IngressPolicyStore), "GetByKey", func(_ *IngressPolicyStore, _ string) interface{} {
+			patches.ApplyMethod(reflect.TypeOf(inventoryService.IngressPolicyStore), "GetByKey", func(_ *IngressPolicyStore, _ string) *containerinventory.ContainerIngressPolicy {
 				if tt.existingPolicy != nil {
 					return tt.existingPolicy
 				} else {
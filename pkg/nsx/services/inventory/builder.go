@@ -28,12 +28,12 @@ func (s *InventoryService) BuildPod(pod *corev1.Pod) (retry bool) {
 		containerApplicationIds = containerApplicationInstance.ContainerApplicationIds
 	}
 
-	preContainerApplicationInstance := s.ApplicationInstanceStore.GetByKey(string(pod.UID))
-	if preContainerApplicationInstance != nil {
+	var preContainerApplicationInstance interface{}
+	if pre := s.ApplicationInstanceStore.GetByKey(string(pod.UID)); pre != nil {
 		if len(containerApplicationIds) == 0 {
-			containerApplicationIds = preContainerApplicationInstance.(*containerinventory.ContainerApplicationInstance).ContainerApplicationIds
+			containerApplicationIds = pre.ContainerApplicationIds
 		}
-		preContainerApplicationInstance = *preContainerApplicationInstance.(*containerinventory.ContainerApplicationInstance)
+		preContainerApplicationInstance = *pre
 	}
 	namespace, err := s.GetNamespace(pod.Namespace)
 	if err != nil {
@@ -154,9 +154,9 @@ func (s *InventoryService) BuildIngress(ingress *networkingv1.Ingress) (retry bo
 		return
 	}
 
-	preIngress := s.IngressPolicyStore.GetByKey(string(ingress.UID))
-	if preIngress != nil {
-		preIngress = *preIngress.(*containerinventory.ContainerIngressPolicy)
+	var preIngress interface{}
+	if pre := s.IngressPolicyStore.GetByKey(string(ingress.UID)); pre != nil {
+		preIngress = *pre
 	}
 
 	// Get network errors from ingress annotations
@@ -286,9 +286,9 @@ func (s *InventoryService) BuildNamespace(namespace *corev1.Namespace) (retry bo
 	log.Info("Building Namespace", "Namespace", namespace.Name)
 	retry = false
 
-	preContainerProject := s.ProjectStore.GetByKey(string(namespace.UID))
-	if preContainerProject != nil {
-		preContainerProject = *preContainerProject.(*containerinventory.ContainerProject)
+	var preContainerProject interface{}
+	if pre := s.ProjectStore.GetByKey(string(namespace.UID)); pre != nil {
+		preContainerProject = *pre
 	}
 
 	// Extract network errors from namespace conditions
@@ -339,9 +339,9 @@ func (s *InventoryService) BuildService(service *corev1.Service) (retry bool) {
 	log.Trace("Building Service", "Service", service.Name, "Namespace", service.Namespace)
 	retry = false
 
-	preContainerApplication := s.ApplicationStore.GetByKey(string(service.UID))
-	if preContainerApplication != nil {
-		preContainerApplication = *preContainerApplication.(*containerinventory.ContainerApplication)
+	var preContainerApplication interface{}
+	if pre := s.ApplicationStore.GetByKey(string(service.UID)); pre != nil {
+		preContainerApplication = *pre
 	}
 
 	namespace := &corev1.Namespace{}
@@ -463,10 +463,11 @@ func (s *InventoryService) applyServiceIDUpdates(instance *containerinventory.Co
 }
 
 func (s *InventoryService) updateServiceIDsForApplicationInstance(podUID string, service *corev1.Service) (retry bool) {
-	applicationInstance := s.ApplicationInstanceStore.GetByKey(podUID)
-	if applicationInstance == nil {
+	instance := s.ApplicationInstanceStore.GetByKey(podUID)
+	if instance == nil {
 		return true
 	}
+	var applicationInstance interface{} = instance
 
 	// Prefer the pendingAdd instance if available
 	if s.pendingAdd[podUID] != nil {
@@ -520,9 +521,9 @@ func (s *InventoryService) BuildNode(node *corev1.Node) (retry bool) {
 	log.Trace("Building Node", "Node", node.Name)
 	retry = false
 
-	preContainerClusterNode := s.ClusterNodeStore.GetByKey(string(node.UID))
-	if preContainerClusterNode != nil {
-		preContainerClusterNode = *preContainerClusterNode.(*containerinventory.ContainerClusterNode)
+	var preContainerClusterNode interface{}
+	if pre := s.ClusterNodeStore.GetByKey(string(node.UID)); pre != nil {
+		preContainerClusterNode = *pre
 	}
 
 	// Extract node IP addresses
@@ -610,9 +611,9 @@ func (s *InventoryService) BuildNetworkPolicy(networkPolicy *networkingv1.Networ
 	log.Trace("Building NetworkPolicy", "NetworkPolicy", networkPolicy.Name, "Namespace", networkPolicy.Namespace)
 	retry = false
 
-	preContainerNetworkPolicy := s.NetworkPolicyStore.GetByKey(string(networkPolicy.UID))
-	if preContainerNetworkPolicy != nil {
-		preContainerNetworkPolicy = *preContainerNetworkPolicy.(*containerinventory.ContainerNetworkPolicy)
+	var preContainerNetworkPolicy interface{}
+	if pre := s.NetworkPolicyStore.GetByKey(string(networkPolicy.UID)); pre != nil {
+		preContainerNetworkPolicy = *pre
 	}
 
 	namespace := &corev1.Namespace{}
@@ -30,7 +30,7 @@ func (s *InventoryService) GetContainerCluster(cleanup bool) (containerinventory
 		log.Error(err, "Failed to get inventory cluster", "ClusterUID", clusterUUID)
 		return containerCluster, err
 	}
-	containerCluster, resp, err := s.NSXClient.NsxApiClient.ContainerClustersApi.GetContainerCluster(context.TODO(), clusterUUID)
+	containerCluster, resp, err := s.NSXClient.NsxApiClient().ContainerClustersApi.GetContainerCluster(context.TODO(), clusterUUID)
 	// there was no error_code in the err, so we need to check the response to return the HttpNotFoundError error
 	if resp != nil && resp.StatusCode == http.StatusNotFound {
 		return containerCluster, nsx_util.HttpNotFoundError
@@ -44,7 +44,7 @@ func (s *InventoryService) GetContainerCluster(cleanup bool) (containerinventory
 func (s *InventoryService) AddContainerCluster(cluster containerinventory.ContainerCluster) (containerinventory.ContainerCluster, error) {
 	log.Info("Send request to NSX to create inventory cluster", "Cluster", cluster)
 	cluster.ClusterType = InventoryClusterTypeSupervisor
-	cluster, _, err := s.NSXClient.NsxApiClient.ContainerClustersApi.AddContainerCluster(context.TODO(), cluster)
+	cluster, _, err := s.NSXClient.NsxApiClient().ContainerClustersApi.AddContainerCluster(context.TODO(), cluster)
 	return cluster, err
 }
 
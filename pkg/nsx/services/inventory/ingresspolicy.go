@@ -23,7 +23,7 @@ func (s *InventoryService) initContainerIngressPolicy(clusterId string) error {
 		if cursor != "" {
 			opts.Cursor = optional.NewString(cursor)
 		}
-		ingressPolicies, _, err := s.NSXClient.NsxApiClient.ContainerClustersApi.ListContainerIngressPolicies(context.Background(), opts)
+		ingressPolicies, _, err := s.NSXClient.NsxApiClient().ContainerClustersApi.ListContainerIngressPolicies(context.Background(), opts)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve ContainerIngressPolicy err: %w", err)
 		}
@@ -122,7 +122,7 @@ func (s *InventoryService) CleanStaleInventoryIngressPolicy() error {
 				log.Error(err, "Clean stale InventoryIngressPolicy", "External Id", ingress.ExternalId)
 				return err
 			}
-		} else if s.IsIngressDeleted(project.(*containerinventory.ContainerProject).DisplayName, ingress.DisplayName, ingress.ExternalId, nil) {
+		} else if s.IsIngressDeleted(project.DisplayName, ingress.DisplayName, ingress.ExternalId, nil) {
 			log.Info("Clean stale InventoryIngressPolicy", "Name", ingress.DisplayName, "External Id", ingress.ExternalId)
 			err := s.DeleteResource(ingress.ExternalId, ContainerIngressPolicy)
 			if err != nil {
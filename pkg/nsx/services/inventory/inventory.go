@@ -52,32 +52,30 @@ func NewInventoryService(service commonservice.Service) *InventoryService {
 		stalePods:     make(map[string]interface{}),
 	}
 
-	// TODO, Inventory store should have its own store
-	inventoryService.ApplicationInstanceStore = &ApplicationInstanceStore{ResourceStore: commonservice.ResourceStore{
-		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{string(ContainerApplicationInstance): indexFunc}),
-	}}
-	inventoryService.ClusterStore = &ClusterStore{ResourceStore: commonservice.ResourceStore{
-		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{string(ContainerCluster): indexFunc}),
-	}}
-	inventoryService.ApplicationStore = &ApplicationStore{ResourceStore: commonservice.ResourceStore{
-		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{string(ContainerApplication): indexFunc}),
-	}}
-	inventoryService.ClusterNodeStore = &ClusterNodeStore{ResourceStore: commonservice.ResourceStore{
-		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{string(ContainerClusterNode): indexFunc}),
-	}}
-	inventoryService.NetworkPolicyStore = &NetworkPolicyStore{ResourceStore: commonservice.ResourceStore{
-		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{string(ContainerNetworkPolicy): indexFunc}),
-	}}
-	inventoryService.IngressPolicyStore = &IngressPolicyStore{ResourceStore: commonservice.ResourceStore{
-		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{string(ContainerIngressPolicy): indexFunc}),
-	}}
-	inventoryService.ProjectStore = &ProjectStore{ResourceStore: commonservice.ResourceStore{
-		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{string(ContainerProject): indexFunc}),
-	}}
+	inventoryService.ApplicationInstanceStore = &ApplicationInstanceStore{TypedStore: newInventoryStore[containerinventory.ContainerApplicationInstance](ContainerApplicationInstance)}
+	inventoryService.ClusterStore = &ClusterStore{TypedStore: newInventoryStore[containerinventory.ContainerCluster](ContainerCluster)}
+	inventoryService.ApplicationStore = &ApplicationStore{TypedStore: newInventoryStore[containerinventory.ContainerApplication](ContainerApplication)}
+	inventoryService.ClusterNodeStore = &ClusterNodeStore{TypedStore: newInventoryStore[containerinventory.ContainerClusterNode](ContainerClusterNode)}
+	inventoryService.NetworkPolicyStore = &NetworkPolicyStore{TypedStore: newInventoryStore[containerinventory.ContainerNetworkPolicy](ContainerNetworkPolicy)}
+	inventoryService.IngressPolicyStore = &IngressPolicyStore{TypedStore: newInventoryStore[containerinventory.ContainerIngressPolicy](ContainerIngressPolicy)}
+	inventoryService.ProjectStore = &ProjectStore{TypedStore: newInventoryStore[containerinventory.ContainerProject](ContainerProject)}
 	inventoryService.Service = service
 	return inventoryService
 }
 
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (s *InventoryService) ResourceStores() map[string]*commonservice.ResourceStore {
+	return map[string]*commonservice.ResourceStore{
+		"inventory/applicationinstances": &s.ApplicationInstanceStore.ResourceStore,
+		"inventory/applications":         &s.ApplicationStore.ResourceStore,
+		"inventory/projects":             &s.ProjectStore.ResourceStore,
+		"inventory/clusternodes":         &s.ClusterNodeStore.ResourceStore,
+		"inventory/networkpolicies":      &s.NetworkPolicyStore.ResourceStore,
+		"inventory/ingresspolicies":      &s.IngressPolicyStore.ResourceStore,
+		"inventory/clusters":             &s.ClusterStore.ResourceStore,
+	}
+}
+
 func (s *InventoryService) Initialize(cleanup bool) error {
 	err := s.initContainerCluster(cleanup)
 	if err != nil {
@@ -238,7 +236,7 @@ func (s *InventoryService) DeleteResource(externalId string, resourceType Invent
 			return nil
 		}
 		s.DeleteInventoryObject(resourceType, externalId, inventoryObject)
-		return s.DeleteContainerApplicationInstance(externalId, inventoryObject.(*containerinventory.ContainerApplicationInstance))
+		return s.DeleteContainerApplicationInstance(externalId, inventoryObject)
 	case ContainerIngressPolicy:
 		inventoryObject := s.IngressPolicyStore.GetByKey(externalId)
 		if inventoryObject == nil {
@@ -293,7 +291,7 @@ func (s *InventoryService) sendNSXRequestAndUpdateInventoryStore(ctx context.Con
 	if len(s.requestBuffer) > 0 {
 		log.Info("Send update to inventory", "ContainerInventoryData", s.requestBuffer)
 		// TODO, check the context.TODO() be replaced by NsxApiClient related todo
-		resp, err := s.NSXClient.NsxApiClient.ContainerInventoryApi.AddContainerInventoryUpdateUpdates(ctx,
+		resp, err := s.NSXClient.NsxApiClient().ContainerInventoryApi.AddContainerInventoryUpdateUpdates(ctx,
 			util.GetClusterUUID(s.NSXConfig.Cluster).String(),
 			containerinventory.ContainerInventoryData{ContainerInventoryObjects: s.requestBuffer})
 
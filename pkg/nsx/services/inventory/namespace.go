@@ -52,7 +52,7 @@ func (s *InventoryService) initContainerProject(clusterId string) error {
 		if cursor != "" {
 			opts.Cursor = optional.NewString(cursor)
 		}
-		projects, _, err := s.NSXClient.NsxApiClient.ContainerProjectsApi.ListContainerProjects(context.Background(), opts)
+		projects, _, err := s.NSXClient.NsxApiClient().ContainerProjectsApi.ListContainerProjects(context.Background(), opts)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve container projects err: %w", err)
 		}
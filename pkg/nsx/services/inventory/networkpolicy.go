@@ -31,7 +31,7 @@ func (s *InventoryService) initContainerNetworkPolicy(clusterId string) error {
 		if cursor != "" {
 			opts.Cursor = optional.NewString(cursor)
 		}
-		networkPolicies, _, err := s.NSXClient.NsxApiClient.ContainerClustersApi.ListContainerNetworkPolicies(context.Background(), opts)
+		networkPolicies, _, err := s.NSXClient.NsxApiClient().ContainerClustersApi.ListContainerNetworkPolicies(context.Background(), opts)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve ContainerNetworkPolicy err: %w", err)
 		}
@@ -84,7 +84,7 @@ func (s *InventoryService) CleanStaleInventoryNetworkPolicy() error {
 				log.Error(err, "Clean stale InventoryNetworkPolicy", "External Id", networkPolicyObj.ExternalId)
 				return err
 			}
-		} else if s.IsNetworkPolicyDeleted(project.(*containerinventory.ContainerProject).DisplayName, networkPolicyObj.DisplayName, networkPolicyObj.ExternalId) {
+		} else if s.IsNetworkPolicyDeleted(project.DisplayName, networkPolicyObj.DisplayName, networkPolicyObj.ExternalId) {
 			log.Info("Clean stale InventoryNetworkPolicy", "Name", networkPolicyObj.DisplayName, "External Id", networkPolicyObj.ExternalId)
 			err := s.DeleteResource(networkPolicyObj.ExternalId, ContainerNetworkPolicy)
 			if err != nil {
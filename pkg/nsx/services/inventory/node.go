@@ -48,7 +48,7 @@ func (s *InventoryService) initContainerClusterNode(clusterId string) error {
 		if cursor != "" {
 			opts.Cursor = optional.NewString(cursor)
 		}
-		nodes, _, err := s.NSXClient.NsxApiClient.ContainerClustersApi.ListContainerClusterNodes(context.Background(), opts)
+		nodes, _, err := s.NSXClient.NsxApiClient().ContainerClustersApi.ListContainerClusterNodes(context.Background(), opts)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve container cluster nodes err: %w", err)
 		}
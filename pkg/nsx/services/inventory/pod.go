@@ -51,7 +51,7 @@ func (s *InventoryService) initContainerApplicationInstance(clusterId string) er
 		if cursor != "" {
 			opts.Cursor = optional.NewString(cursor)
 		}
-		applicationInstances, _, err := s.NSXClient.NsxApiClient.ContainerApplicationsApi.ListContainerApplicationInstances(context.Background(), opts)
+		applicationInstances, _, err := s.NSXClient.NsxApiClient().ContainerApplicationsApi.ListContainerApplicationInstances(context.Background(), opts)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve ContainerApplicationInstances err: %w", err)
 		}
@@ -118,7 +118,7 @@ func (s *InventoryService) CleanStaleInventoryApplicationInstance() error {
 				log.Error(err, "Clean stale InventoryApplicationInstance", "External Id", applicationInstance.ExternalId)
 				return err
 			}
-		} else if s.IsPodDeleted(project.(*containerinventory.ContainerProject).DisplayName, applicationInstance.DisplayName, applicationInstance.ExternalId) {
+		} else if s.IsPodDeleted(project.DisplayName, applicationInstance.DisplayName, applicationInstance.ExternalId) {
 			log.Info("Clean stale pod", "Name", applicationInstance.DisplayName, "External Id", applicationInstance.ExternalId)
 			err := s.DeleteResource(applicationInstance.ExternalId, ContainerApplicationInstance)
 			if err != nil {
@@ -26,7 +26,7 @@ func (s *InventoryService) initContainerApplication(clusterId string) error {
 		if cursor != "" {
 			opts.Cursor = optional.NewString(cursor)
 		}
-		applications, _, err := s.NSXClient.NsxApiClient.ContainerApplicationsApi.ListContainerApplications(context.Background(), opts)
+		applications, _, err := s.NSXClient.NsxApiClient().ContainerApplicationsApi.ListContainerApplications(context.Background(), opts)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve ContainerApplication err: %w", err)
 		}
@@ -79,7 +79,7 @@ func (s *InventoryService) CleanStaleInventoryApplication() error {
 				log.Error(err, "Clean stale InventoryApplication", "External Id", inventoryApplication.ExternalId)
 				return err
 			}
-		} else if s.isApplicationDeleted(project.(*containerinventory.ContainerProject).DisplayName, inventoryApplication.DisplayName, inventoryApplication.ExternalId) {
+		} else if s.isApplicationDeleted(project.DisplayName, inventoryApplication.DisplayName, inventoryApplication.ExternalId) {
 			log.Info("Clean stale inventoryApplication", "Name", inventoryApplication.DisplayName, "External Id", inventoryApplication.ExternalId)
 			err := s.DeleteResource(inventoryApplication.ExternalId, ContainerApplication)
 			if err != nil {
@@ -4,30 +4,39 @@ import (
 	"errors"
 
 	"github.com/vmware/go-vmware-nsxt/containerinventory"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 )
 
 type ApplicationInstanceStore struct {
-	common.ResourceStore
+	common.TypedStore[containerinventory.ContainerApplicationInstance]
 }
 type ApplicationStore struct {
-	common.ResourceStore
+	common.TypedStore[containerinventory.ContainerApplication]
 }
 type ProjectStore struct {
-	common.ResourceStore
+	common.TypedStore[containerinventory.ContainerProject]
 }
 type ClusterNodeStore struct {
-	common.ResourceStore
+	common.TypedStore[containerinventory.ContainerClusterNode]
 }
 type NetworkPolicyStore struct {
-	common.ResourceStore
+	common.TypedStore[containerinventory.ContainerNetworkPolicy]
 }
 type IngressPolicyStore struct {
-	common.ResourceStore
+	common.TypedStore[containerinventory.ContainerIngressPolicy]
 }
 type ClusterStore struct {
-	common.ResourceStore
+	common.TypedStore[containerinventory.ContainerCluster]
+}
+
+// newInventoryStore creates the store of the inventory objects of resourceType, they are keyed and indexed by the
+// external ID. The inventory objects aren't converted from the search results, so the store has no BindingType.
+func newInventoryStore[T any](resourceType InventoryType) common.TypedStore[T] {
+	return common.NewTypedStore[T](nil, func(obj *T) (string, error) {
+		return keyFunc(obj)
+	}, cache.Indexers{string(resourceType): indexFunc})
 }
 
 func keyFunc(obj interface{}) (string, error) {
@@ -44,9 +44,9 @@ func createService(t *testing.T) (*vpc.VPCService, *gomock.Controller, *mocks.Mo
 	mockVpcclient := mocks.NewMockVpcsClient(mockCtrl)
 	k8sClient := mock_client.NewMockClient(mockCtrl)
 
-	vpcStore := &vpc.VPCStore{ResourceStore: common.ResourceStore{
+	vpcStore := &vpc.VPCStore{TypedStore: common.TypedStore[model.Vpc]{ResourceStore: common.ResourceStore{
 		BindingType: model.VpcBindingType(),
-	}}
+	}}}
 
 	service := &vpc.VPCService{
 		Service: common.Service{
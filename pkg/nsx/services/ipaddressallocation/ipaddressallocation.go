@@ -64,6 +64,13 @@ func InitializeIPAddressAllocation(service common.Service, vpcService common.VPC
 	return ipAddressAllocationService, nil
 }
 
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (service *IPAddressAllocationService) ResourceStores() map[string]*common.ResourceStore {
+	return map[string]*common.ResourceStore{
+		"ipaddressallocations": &service.ipAddressAllocationStore.ResourceStore,
+	}
+}
+
 func (service *IPAddressAllocationService) CreateOrUpdateIPAddressAllocation(obj *v1alpha1.IPAddressAllocation, restoreMode bool) (bool, error) {
 	nsxIPAddressAllocation, err := service.BuildIPAddressAllocation(obj, nil, restoreMode)
 	if err != nil {
@@ -40,14 +40,14 @@ func createIPAddressAllocationService(t *testing.T) (*IPAddressAllocationService
 	mockCtrl := gomock.NewController(t)
 	mockVPCIPAddressAllocationclient := mocks.NewMockIPAddressAllocationClient(mockCtrl)
 
-	ipAddressAllocationStore := &IPAddressAllocationStore{ResourceStore: common.ResourceStore{
+	ipAddressAllocationStore := &IPAddressAllocationStore{TypedStore: common.TypedStore[model.VpcIpAddressAllocation]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 			common.TagScopeIPAddressAllocationCRUID: indexByIPAddressAllocation,
 			common.TagScopeAddressBindingCRUID:      indexByAddressBinding,
 			common.TagScopeSubnetPortCRUID:          indexBySubnetPort,
 		}),
 		BindingType: model.VpcIpAddressAllocationBindingType(),
-	}}
+	}}}
 
 	service := &IPAddressAllocationService{
 		Service: common.Service{
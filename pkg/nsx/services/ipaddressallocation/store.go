@@ -68,25 +68,7 @@ var filterTag = func(v []model.Tag, scope string) []string {
 }
 
 type IPAddressAllocationStore struct {
-	common.ResourceStore
-}
-
-func (ipAddressAllocationStore *IPAddressAllocationStore) Apply(i interface{}) error {
-	ipAddressAllocation := i.(*model.VpcIpAddressAllocation)
-	if ipAddressAllocation.MarkedForDelete != nil && *ipAddressAllocation.MarkedForDelete {
-		err := ipAddressAllocationStore.Delete(ipAddressAllocation)
-		if err != nil {
-			return err
-		}
-		log.Debug("delete ipAddressAllocation from store", "ipAddressAllocation", ipAddressAllocation)
-	} else {
-		err := ipAddressAllocationStore.Add(ipAddressAllocation)
-		if err != nil {
-			return err
-		}
-		log.Debug("add ipAddressAllocation to store", "ipAddressAllocation", ipAddressAllocation)
-	}
-	return nil
+	common.TypedStore[model.VpcIpAddressAllocation]
 }
 
 func (service *IPAddressAllocationService) indexedIPAddressAllocation(uid types.UID) (*model.VpcIpAddressAllocation, error) {
@@ -133,14 +115,8 @@ func (ipAddressAllocationStore *IPAddressAllocationStore) GetByVPCPath(vpcPath s
 	return allocations, nil
 }
 
-func (ipAddressAllocationStore *IPAddressAllocationStore) DeleteMultipleObjects(allocations []*model.VpcIpAddressAllocation) {
-	for _, allocation := range allocations {
-		ipAddressAllocationStore.Delete(allocation)
-	}
-}
-
 func buildIPAddressAllocationStore() *IPAddressAllocationStore {
-	return &IPAddressAllocationStore{ResourceStore: common.ResourceStore{
+	return &IPAddressAllocationStore{TypedStore: common.TypedStore[model.VpcIpAddressAllocation]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 			common.TagScopeIPAddressAllocationCRUID: indexByIPAddressAllocation,
 			common.TagScopeAddressBindingCRUID:      indexByAddressBinding,
@@ -148,5 +124,5 @@ func buildIPAddressAllocationStore() *IPAddressAllocationStore {
 			common.IndexByVPCPathFuncKey:            common.IndexByVPCFunc,
 		}),
 		BindingType: model.VpcIpAddressAllocationBindingType(),
-	}}
+	}}}
 }
@@ -19,7 +19,7 @@ func TestIPAddressAllocationStore_CRUDResource(t *testing.T) {
 		Indexer:     ipAddressAllocationCacheIndexer,
 		BindingType: model.VpcIpAddressAllocationBindingType(),
 	}
-	ipAddressAllocationStore := &IPAddressAllocationStore{ResourceStore: resourceStore}
+	ipAddressAllocationStore := &IPAddressAllocationStore{TypedStore: common.TypedStore[model.VpcIpAddressAllocation]{ResourceStore: resourceStore}}
 	type args struct {
 		i interface{}
 	}
@@ -41,14 +41,14 @@ func TestIPAddressAllocationStore_GetByUID(t *testing.T) {
 	p := &model.VpcIpAddressAllocation{Id: String("1"), DisplayName: String("1"),
 		Tags: []model.Tag{{Scope: String(common.TagScopeIPAddressAllocationCRUID),
 			Tag: String("1")}}}
-	ipAddressAllocationStore := &IPAddressAllocationStore{ResourceStore: common.ResourceStore{
+	ipAddressAllocationStore := &IPAddressAllocationStore{TypedStore: common.TypedStore[model.VpcIpAddressAllocation]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 			common.TagScopeIPAddressAllocationCRUID: indexByIPAddressAllocation,
 			common.TagScopeAddressBindingCRUID:      indexByAddressBinding,
 			common.TagScopeSubnetPortCRUID:          indexBySubnetPort,
 		}),
 		BindingType: model.VpcIpAddressBindingType(),
-	}}
+	}}}
 	_ = ipAddressAllocationStore.Apply(p)
 	type args struct {
 		uid types.UID
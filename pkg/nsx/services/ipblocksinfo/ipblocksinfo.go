@@ -17,6 +17,7 @@ import (
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
@@ -54,6 +55,8 @@ func NewIPBlocksInfoSyncTask(syncInterval time.Duration, retryInterval time.Dura
 }
 
 func InitializeIPBlocksInfoService(service common.Service, subnetService common.SubnetServiceProvider) *IPBlocksInfoService {
+	// IPBlocksInfo is synchronized periodically, its NSX API requests are background traffic
+	service.NSXClient = service.NSXClient.WithPriority(ratelimiter.PriorityBackground)
 	ipBlocksInfoService := &IPBlocksInfoService{
 		Service:       service,
 		SyncTask:      NewIPBlocksInfoSyncTask(syncInterval, retryInterval),
@@ -347,10 +350,10 @@ func (s *IPBlocksInfoService) getIPBlockCIDRsByVPCConfig(vpcConfigList []v1alpha
 	externalIPBlockPaths := sets.New[string]()
 	privateTgwIPBlockPaths := sets.New[string]()
 
-	vpcConnectivityProfileStore := &VPCConnectivityProfileStore{ResourceStore: common.ResourceStore{
+	vpcConnectivityProfileStore := &VPCConnectivityProfileStore{TypedStore: common.TypedStore[model.VpcConnectivityProfile]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.VpcConnectivityProfileBindingType(),
-	}}
+	}}}
 	queryParam = fmt.Sprintf("%s:%s", common.ResourceType, common.ResourceTypeVpcConnectivityProfile)
 	count, err = s.SearchResource(common.ResourceTypeVpcConnectivityProfile, queryParam, vpcConnectivityProfileStore, nil)
 	if err != nil {
@@ -359,12 +362,11 @@ func (s *IPBlocksInfoService) getIPBlockCIDRsByVPCConfig(vpcConfigList []v1alpha
 	log.Trace("successfully fetch all VPCConnectivityProfile from NSX", "count", count)
 
 	for profilePath, isDefault := range vpcConnectivityProfileProjectMap {
-		obj := vpcConnectivityProfileStore.GetByKey(profilePath)
-		if obj == nil {
+		vpcConnectivityProfile := vpcConnectivityProfileStore.GetByKey(profilePath)
+		if vpcConnectivityProfile == nil {
 			err = fmt.Errorf("failed to get VPCConnectivityProfile %s from NSX", profilePath)
 			return
 		}
-		vpcConnectivityProfile := obj.(*model.VpcConnectivityProfile)
 		log.Trace("successfully fetch VPCConnectivityProfile", "path", profilePath, "isDefault", isDefault)
 		// save external_ip_blocks path in set for all profile
 		for _, externalIPBlock := range vpcConnectivityProfile.ExternalIpBlocks {
@@ -379,10 +381,10 @@ func (s *IPBlocksInfoService) getIPBlockCIDRsByVPCConfig(vpcConfigList []v1alpha
 	}
 
 	// get IPBlock CIDRs from NSX
-	ipBlockStore := &IPBlockStore{ResourceStore: common.ResourceStore{
+	ipBlockStore := &IPBlockStore{TypedStore: common.TypedStore[model.IpAddressBlock]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.IpAddressBlockBindingType(),
-	}}
+	}}}
 	queryParam = fmt.Sprintf("%s:%s", common.ResourceType, common.ResourceTypeIPBlock)
 	count, err = s.SearchResource(common.ResourceTypeIPBlock, queryParam, ipBlockStore, nil)
 	if err != nil {
@@ -410,13 +412,12 @@ func (s *IPBlocksInfoService) getIPBlockCIDRsAndRangesFromStore(pathSet sets.Set
 	ipCIDRs := []string{}
 	ipRanges := []v1alpha1.IPPoolRange{}
 	for path := range pathSet {
-		obj := ipBlockStore.GetByKey(path)
-		if obj == nil {
+		ipblock := ipBlockStore.GetByKey(path)
+		if ipblock == nil {
 			err := fmt.Errorf("failed to get IPBlock %s from NSX", path)
 			log.Error(err, "get CIDRs/Ranges from ipblock")
 			return nil, nil, err
 		}
-		ipblock := obj.(*model.IpAddressBlock)
 		if ipblock.Cidrs != nil {
 			ipCIDRs = append(ipCIDRs, ipblock.Cidrs...)
 			log.Trace("Successfully get cidrs for IPBlock", "path", path, "cidrs", ipblock.Cidrs)
@@ -311,10 +311,10 @@ func stringPtr(s string) *string {
 
 func TestIPBlocksInfoService_getCIDRsRangesFromStore(t *testing.T) {
 	service := &IPBlocksInfoService{}
-	ipBlockStore := &IPBlockStore{ResourceStore: common.ResourceStore{
+	ipBlockStore := &IPBlockStore{TypedStore: common.TypedStore[model.IpAddressBlock]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.IpAddressBlockBindingType(),
-	}}
+	}}}
 
 	// Helper to add IpAddressBlock to store
 	addBlock := func(path string, cidr *string, cidrs []string, ranges []model.IpPoolRange) {
@@ -26,102 +26,28 @@ func keyFunc(obj interface{}) (string, error) {
 }
 
 type VPCConnectivityProfileStore struct {
-	common.ResourceStore
-}
-
-func (vs *VPCConnectivityProfileStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	vpcProfile := i.(*model.VpcConnectivityProfile)
-	if vpcProfile.MarkedForDelete != nil && *vpcProfile.MarkedForDelete {
-		err := vs.Delete(vpcProfile)
-		log.Debug("delete VpcConnectivityProfile from store", "VpcConnectivityProfile", vpcProfile)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := vs.Add(vpcProfile)
-		log.Debug("add VpcConnectivityProfile to store", "VpcConnectivityProfile", vpcProfile)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	common.TypedStore[model.VpcConnectivityProfile]
 }
 
 type IPBlockStore struct {
-	common.ResourceStore
-}
-
-func (is *IPBlockStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	ipblock := i.(*model.IpAddressBlock)
-	if ipblock.MarkedForDelete != nil && *ipblock.MarkedForDelete {
-		err := is.Delete(ipblock)
-		log.Debug("delete ipblock from store", "IPBlock", ipblock)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := is.Add(ipblock)
-		log.Debug("add IPBlock to store", "IPBlock", ipblock)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	common.TypedStore[model.IpAddressBlock]
 }
 
 type VpcAttachmentStore struct {
-	common.ResourceStore
+	common.TypedStore[model.VpcAttachment]
 }
 
 func NewVpcAttachmentStore() *VpcAttachmentStore {
-	return &VpcAttachmentStore{ResourceStore: common.ResourceStore{
+	return &VpcAttachmentStore{TypedStore: common.TypedStore[model.VpcAttachment]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.VpcAttachmentBindingType(),
-	}}
-}
-
-func (vas *VpcAttachmentStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	attachment := i.(*model.VpcAttachment)
-	if attachment.MarkedForDelete != nil && *attachment.MarkedForDelete {
-		err := vas.Delete(attachment)
-		log.Debug("delete VPC attachment from store", "VpcAttachment", attachment)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := vas.Add(attachment)
-		log.Debug("add VPC attachment to store", "VpcAttachment", attachment)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (vas *VpcAttachmentStore) GetByKey(path string) *model.VpcAttachment {
-	obj := vas.ResourceStore.GetByKey(path)
-	if obj != nil {
-		attachment := obj.(*model.VpcAttachment)
-		return attachment
-	}
-	return nil
+	}}}
 }
 
 func (vas *VpcAttachmentStore) GetByVpcPath(vpcPath string) []*model.VpcAttachment {
 	result := []*model.VpcAttachment{}
-	attachments := vas.ResourceStore.List()
-	for _, item := range attachments {
-		attachment := item.(*model.VpcAttachment)
-		if attachment != nil && *attachment.ParentPath == vpcPath {
+	for _, attachment := range vas.Snapshot() {
+		if *attachment.ParentPath == vpcPath {
 			result = append(result, attachment)
 		}
 	}
@@ -74,10 +74,10 @@ func Test_KeyFunc(t *testing.T) {
 }
 
 func TestVPCConnectivityProfileStore_Apply(t *testing.T) {
-	vpcConnectivityProfileStore := &VPCConnectivityProfileStore{ResourceStore: common.ResourceStore{
+	vpcConnectivityProfileStore := &VPCConnectivityProfileStore{TypedStore: common.TypedStore[model.VpcConnectivityProfile]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.VpcConnectivityProfileBindingType(),
-	}}
+	}}}
 
 	profile1 := model.VpcConnectivityProfile{
 		Path: &fakeVpcProfilePath,
@@ -106,10 +106,10 @@ func TestVPCConnectivityProfileStore_Apply(t *testing.T) {
 }
 
 func TestIPBlockStore_Apply(t *testing.T) {
-	ipBlockStore := &IPBlockStore{ResourceStore: common.ResourceStore{
+	ipBlockStore := &IPBlockStore{TypedStore: common.TypedStore[model.IpAddressBlock]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.IpAddressBlockBindingType(),
-	}}
+	}}}
 
 	ipblock1 := model.IpAddressBlock{
 		Path: &fakeIpBlockPath,
@@ -33,7 +33,7 @@ func InitializeNode(service servicecommon.Service) (*NodeService, error) {
 	nodeService := &NodeService{
 		Service: service,
 		NodeStore: &NodeStore{
-			ResourceStore: servicecommon.ResourceStore{
+			TypedStore: servicecommon.TypedStore[model.HostTransportNode]{ResourceStore: servicecommon.ResourceStore{
 				Indexer: cache.NewIndexer(
 					keyFunc,
 					cache.Indexers{
@@ -41,7 +41,7 @@ func InitializeNode(service servicecommon.Service) (*NodeService, error) {
 					},
 				),
 				BindingType: model.HostTransportNodeBindingType(),
-			},
+			}},
 		},
 	}
 	// TODO: confirm whether we can remove the following initialization because node doesn't have the cluster tag so it's a dry run
@@ -63,6 +63,13 @@ func InitializeNode(service servicecommon.Service) (*NodeService, error) {
 
 }
 
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (service *NodeService) ResourceStores() map[string]*servicecommon.ResourceStore {
+	return map[string]*servicecommon.ResourceStore{
+		"nodes": &service.NodeStore.ResourceStore,
+	}
+}
+
 func (service *NodeService) GetNodeByName(nodeName string) []*model.HostTransportNode {
 	return service.NodeStore.GetByIndex(servicecommon.IndexKeyNodeName, nodeName)
 }
@@ -61,7 +61,7 @@ func createMockNodeService() *NodeService {
 			},
 		},
 		NodeStore: &NodeStore{
-			ResourceStore: servicecommon.ResourceStore{
+			TypedStore: servicecommon.TypedStore[model.HostTransportNode]{ResourceStore: servicecommon.ResourceStore{
 				Indexer: cache.NewIndexer(
 					keyFunc,
 					cache.Indexers{
@@ -69,7 +69,7 @@ func createMockNodeService() *NodeService {
 					},
 				),
 				BindingType: model.HostTransportNodeBindingType(),
-			},
+			}},
 		},
 	}
 }
@@ -10,46 +10,7 @@ import (
 
 // NodeStore is a store for node (NSX HostTransportNode)
 type NodeStore struct {
-	common.ResourceStore
-}
-
-func (nodeStore *NodeStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	node := i.(*model.HostTransportNode)
-	if node.MarkedForDelete != nil && *node.MarkedForDelete {
-		err := nodeStore.Delete(node)
-		log.Debug("delete Node from store", "node", node)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := nodeStore.Add(node)
-		log.Debug("add Node to store", "node", node)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (nodeStore *NodeStore) GetByKey(key string) *model.HostTransportNode {
-	var node model.HostTransportNode
-	obj := nodeStore.ResourceStore.GetByKey(key)
-	if obj != nil {
-		node = obj.(model.HostTransportNode)
-	}
-	return &node
-}
-
-func (nodeStore *NodeStore) GetByIndex(key string, value string) []*model.HostTransportNode {
-	hostTransportNodes := make([]*model.HostTransportNode, 0)
-	objs := nodeStore.ResourceStore.GetByIndex(key, value)
-	for _, node := range objs {
-		hostTransportNodes = append(hostTransportNodes, node.(*model.HostTransportNode))
-	}
-	return hostTransportNodes
+	common.TypedStore[model.HostTransportNode]
 }
 
 // keyFunc is used to get the key of a resource, usually, which is the ID of the resource
@@ -32,7 +32,7 @@ func TestSubnetStore_Apply(t *testing.T) {
 		),
 		BindingType: model.HostTransportNodeBindingType(),
 	}
-	nodeStore := &NodeStore{ResourceStore: resourceStore}
+	nodeStore := &NodeStore{TypedStore: common.TypedStore[model.HostTransportNode]{ResourceStore: resourceStore}}
 	fakeNode := model.HostTransportNode{
 		UniqueId: common.String("node_id"),
 		NodeDeploymentInfo: &model.FabricHostNode{
@@ -93,14 +93,14 @@ func InitializeNSXServiceAccount(service common.Service) (*NSXServiceAccountServ
 }
 
 func (s *NSXServiceAccountService) SetUpStore() {
-	s.PrincipalIdentityStore = &PrincipalIdentityStore{ResourceStore: common.ResourceStore{
+	s.PrincipalIdentityStore = &PrincipalIdentityStore{TypedStore: common.TypedStore[mpmodel.PrincipalIdentity]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{common.TagScopeNSXServiceAccountCRUID: indexFunc}),
 		BindingType: mpmodel.PrincipalIdentityBindingType(),
-	}}
-	s.ClusterControlPlaneStore = &ClusterControlPlaneStore{ResourceStore: common.ResourceStore{
+	}}}
+	s.ClusterControlPlaneStore = &ClusterControlPlaneStore{TypedStore: common.TypedStore[model.ClusterControlPlane]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{common.TagScopeNSXServiceAccountCRUID: indexFunc}),
 		BindingType: model.ClusterControlPlaneBindingType(),
-	}}
+	}}}
 }
 
 func (s *NSXServiceAccountService) CreateOrUpdateNSXServiceAccount(ctx context.Context, obj *v1alpha1.NSXServiceAccount) error {
@@ -166,7 +166,7 @@ func (s *NSXServiceAccountService) CreateOrUpdateNSXServiceAccount(ctx context.C
 	obj.Status.Phase = v1alpha1.NSXServiceAccountPhaseRealized
 	obj.Status.Reason = "Success"
 	obj.Status.Conditions = GenerateNSXServiceAccountConditions(obj.Status.Conditions, obj.Generation, metav1.ConditionTrue, v1alpha1.ConditionReasonRealizationSuccess, "Success.")
-	obj.Status.NSXManagers = s.NSXConfig.NsxApiManagers
+	obj.Status.NSXManagers = s.NSXConfig.GetNsxApiManagers()
 	obj.Status.ClusterID = clusterId
 	obj.Status.ClusterName = normalizedClusterName
 	obj.Status.Secrets = []v1alpha1.NSXSecret{{
@@ -186,13 +186,11 @@ func (s *NSXServiceAccountService) RestoreRealizedNSXServiceAccount(ctx context.
 	// check PI and CCP is missing
 	hasPI := len(s.PrincipalIdentityStore.GetByIndex(common.TagScopeNSXServiceAccountCRUID, string(obj.UID))) > 0
 	hasCCP := len(s.ClusterControlPlaneStore.GetByIndex(common.TagScopeNSXServiceAccountCRUID, string(obj.UID))) > 0
-	piObj := s.PrincipalIdentityStore.GetByKey(normalizedClusterName)
-	ccpObj := s.ClusterControlPlaneStore.GetByKey(normalizedClusterName)
-	var pi *mpmodel.PrincipalIdentity
+	pi := s.PrincipalIdentityStore.GetByKey(normalizedClusterName)
+	ccp := s.ClusterControlPlaneStore.GetByKey(normalizedClusterName)
 	var certificate mpmodel.Certificate
 	detail := true
-	if piObj != nil {
-		pi = piObj.(*mpmodel.PrincipalIdentity)
+	if pi != nil {
 		certificate, _ = s.NSXClient.CertificatesClient.Get(*(pi.CertificateId), &detail)
 	}
 	// read Secret
@@ -208,12 +206,12 @@ func (s *NSXServiceAccountService) RestoreRealizedNSXServiceAccount(ctx context.
 		"certificate.PemEncoded==nil", certificate.PemEncoded == nil,
 		"certificate.PemEncoded", certificate.PemEncoded,
 		"cert", string(cert))
-	if hasPI && hasCCP && piObj != nil && ccpObj != nil {
+	if hasPI && hasCCP && pi != nil && ccp != nil {
 		if string(cert) != "" && (certificate.PemEncoded == nil || *(certificate.PemEncoded) != string(cert)) {
 			return s.updatePICert(pi, normalizedClusterName, string(cert))
 		}
 		return nil
-	} else if hasPI || hasCCP || (piObj != nil) || (ccpObj != nil) {
+	} else if hasPI || hasCCP || (pi != nil) || (ccp != nil) {
 		return fmt.Errorf("PI/CCP doesn't match")
 	}
 	_, err := s.NSXClient.ClusterControlPlanesClient.Get(siteId, enforcementpointId, normalizedClusterName)
@@ -275,8 +273,7 @@ func (s *NSXServiceAccountService) createPIAndCCP(normalizedClusterName string,
 		return "", fmt.Errorf("old PI exists")
 	} else if hasPI && (piObj != nil) {
 		log.Debug("update PI with new cert")
-		pi := piObj.(*mpmodel.PrincipalIdentity)
-		err := s.updatePICert(pi, normalizedClusterName, cert)
+		err := s.updatePICert(piObj, normalizedClusterName, cert)
 		if err != nil {
 			return "failed to update PICert", err
 		}
@@ -374,8 +371,7 @@ func (s *NSXServiceAccountService) DeleteNSXServiceAccount(ctx context.Context,
 	}
 
 	// delete PI
-	if piobj := s.PrincipalIdentityStore.GetByKey(normalizedClusterName); isDeletePI && (piobj != nil) {
-		pi := piobj.(*mpmodel.PrincipalIdentity)
+	if pi := s.PrincipalIdentityStore.GetByKey(normalizedClusterName); isDeletePI && (pi != nil) {
 		if err := s.NSXClient.PrincipalIdentitiesClient.Delete(*pi.Id); err != nil {
 			err = nsxutil.TransNSXApiError(err)
 			log.Error(err, "failed to delete", "PrincipalIdentity", *pi.Name)
@@ -484,14 +480,13 @@ func (s *NSXServiceAccountService) ValidateAndUpdateRealizedNSXServiceAccount(ct
 func (s *NSXServiceAccountService) updatePIAndCCPCert(normalizedClusterName, uid, cert string) error {
 	hasPI := len(s.PrincipalIdentityStore.GetByIndex(common.TagScopeNSXServiceAccountCRUID, uid)) > 0
 	hasCCP := len(s.ClusterControlPlaneStore.GetByIndex(common.TagScopeNSXServiceAccountCRUID, uid)) > 0
-	piObj := s.PrincipalIdentityStore.GetByKey(normalizedClusterName)
-	ccpObj := s.ClusterControlPlaneStore.GetByKey(normalizedClusterName)
-	if !hasPI || !hasCCP || piObj == nil || ccpObj == nil {
+	pi := s.PrincipalIdentityStore.GetByKey(normalizedClusterName)
+	ccp := s.ClusterControlPlaneStore.GetByKey(normalizedClusterName)
+	if !hasPI || !hasCCP || pi == nil || ccp == nil {
 		return fmt.Errorf("missing PI or CCP, cluster=%s", normalizedClusterName)
 	}
 
 	// update ClusterControlPlane cert
-	ccp := ccpObj.(*model.ClusterControlPlane)
 	ccp.Certificate = &cert
 	if ccp2, err := s.NSXClient.ClusterControlPlanesClient.Update(siteId, enforcementpointId, normalizedClusterName, *ccp); err != nil {
 		err = nsxutil.TransNSXApiError(err)
@@ -502,7 +497,6 @@ func (s *NSXServiceAccountService) updatePIAndCCPCert(normalizedClusterName, uid
 	}
 
 	// update PI cert
-	pi := piObj.(*mpmodel.PrincipalIdentity)
 	return s.updatePICert(pi, normalizedClusterName, cert)
 }
 
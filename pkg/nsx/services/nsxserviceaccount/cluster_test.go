@@ -168,7 +168,7 @@ func newFakeCommonService() common.Service {
 			PrincipalIdentitiesClient:  &fakePrincipalIdentitiesClient{},
 			WithCertificateClient:      &fakeWithCertificateClient{},
 			NSXChecker:                 nsx.NSXHealthChecker{},
-			NSXVerChecker:              nsx.NSXVersionChecker{},
+			NSXVerChecker:              &nsx.NSXVersionChecker{},
 			StatusClient:               restore.NewStatusClient(nil),
 		},
 		NSXConfig: &config.NSXOperatorConfig{
@@ -15,21 +15,7 @@ import (
 
 // PrincipalIdentityStore is a store for PrincipalIdentity
 type PrincipalIdentityStore struct {
-	common.ResourceStore
-}
-
-func (s *PrincipalIdentityStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	pi := i.(*mpmodel.PrincipalIdentity)
-	// MP resource doesn't have MarkedForDelete tag.
-	err := s.Add(pi)
-	log.Debug("add PI to store", "pi", pi)
-	if err != nil {
-		return err
-	}
-	return nil
+	common.TypedStore[mpmodel.PrincipalIdentity]
 }
 
 func (s *PrincipalIdentityStore) IsPolicyAPI() bool {
@@ -38,28 +24,7 @@ func (s *PrincipalIdentityStore) IsPolicyAPI() bool {
 
 // ClusterControlPlaneStore is a store for ClusterControlPlane
 type ClusterControlPlaneStore struct {
-	common.ResourceStore
-}
-
-func (s *ClusterControlPlaneStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	ccp := i.(*model.ClusterControlPlane)
-	if ccp.MarkedForDelete != nil && *ccp.MarkedForDelete {
-		err := s.Delete(ccp)
-		log.Debug("delete ClusterCP from store", "ClusterCP", ccp)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := s.Add(ccp)
-		log.Debug("add ClusterCP to store", "ClusterCP", ccp)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	common.TypedStore[model.ClusterControlPlane]
 }
 
 // keyFunc returns the key of the object.
@@ -4,16 +4,20 @@
 package realizestate
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/tracing"
 )
 
 var log = logger.Log
@@ -31,59 +35,113 @@ func InitializeRealizeState(service common.Service) *RealizeStateService {
 // CheckRealizeState allows the caller to check realize status of intentPath with retries.
 // Backoff defines the maximum retries and the wait interval between two retries.
 // Check all the entities, all entities should be in the REALIZED state to be treated as REALIZED
-func (service *RealizeStateService) CheckRealizeState(backoff wait.Backoff, intentPath string, extraIds []string) error {
+// If the realization tracker is running, the tracker is waited for no longer than the retries or until ctx is done.
+func (service *RealizeStateService) CheckRealizeState(ctx context.Context, backoff wait.Backoff, intentPath string, extraIds []string) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "CheckRealizeState", attribute.String("nsx.intent_path", intentPath))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+	if tracker := DefaultTracker(); tracker != nil {
+		// The tracker checks the intent paths of all callers in batches instead of polling them one by one, the
+		// caller waits no longer than the retries of backoff would take.
+		timeout := max(backoffDuration(backoff), tracker.Interval)
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case err = <-tracker.Register(intentPath, extraIds):
+			return err
+		case <-timer.C:
+			return fmt.Errorf("%s not realized in %s", intentPath, timeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	nsxClient := service.NSXClientWithContext(ctx)
 	// TODO， ask NSX if there were multiple realize states could we check only the latest one?
 	return retry.OnError(backoff, func(err error) bool {
 		// Won't retry when realized state is `ERROR`.
 		return !nsxutil.IsRealizeStateError(err)
 	}, func() error {
-		results, err := service.NSXClient.RealizedEntitiesClient.List(intentPath, nil)
+		results, err := nsxClient.RealizedEntitiesClient.List(intentPath, nil)
 		err = nsxutil.TransNSXApiError(err)
 		if err != nil {
 			return err
 		}
-		entitiesRealized := 0
-		extraIdsRealized := 0
-		for _, result := range results.Results {
-			if *result.State == model.GenericPolicyRealizedResource_STATE_REALIZED {
-				for _, id := range extraIds {
-					if *result.Id == id {
-						extraIdsRealized++
-					}
+		return checkRealizedEntities(intentPath, results.Results, extraIds)
+	})
+}
+
+// PollRealizeState checks the realization of intentPath without blocking if the realization tracker is running, it
+// returns RealizePendingError until the tracker resolves intentPath so the caller can requeue. It waits like
+// CheckRealizeState otherwise.
+func (service *RealizeStateService) PollRealizeState(ctx context.Context, backoff wait.Backoff, intentPath string, extraIds []string) error {
+	if tracker := DefaultTracker(); tracker != nil {
+		return tracker.Check(intentPath, extraIds)
+	}
+	return service.CheckRealizeState(ctx, backoff, intentPath, extraIds)
+}
+
+// backoffDuration returns the total time of the waits between the retries of backoff.
+func backoffDuration(backoff wait.Backoff) time.Duration {
+	var total time.Duration
+	for backoff.Steps > 1 {
+		total += backoff.Step()
+	}
+	return total
+}
+
+// checkRealizedEntities returns nil if all realized entities of intentPath including extraIds are REALIZED,
+// RealizeStateError or RetryRealizeError if any is in ERROR state, otherwise the error of not realized.
+func checkRealizedEntities(intentPath string, results []model.GenericPolicyRealizedResource, extraIds []string) error {
+	entitiesRealized := 0
+	extraIdsRealized := 0
+	for _, result := range results {
+		if *result.State == model.GenericPolicyRealizedResource_STATE_REALIZED {
+			for _, id := range extraIds {
+				if *result.Id == id {
+					extraIdsRealized++
 				}
-				entitiesRealized++
-				continue
 			}
-			if *result.State == model.GenericPolicyRealizedResource_STATE_ERROR {
-				log.Error(nil, "Found realized state with error", "result", result)
-				var errMsg []string
-				for _, alarm := range result.Alarms {
-					if alarm.Message != nil {
-						errMsg = append(errMsg, *alarm.Message)
+			entitiesRealized++
+			continue
+		}
+		if *result.State == model.GenericPolicyRealizedResource_STATE_ERROR {
+			log.Error(nil, "Found realized state with error", "result", result)
+			var errMsg []string
+			var errCodes []int
+			for _, alarm := range result.Alarms {
+				if alarm.Message != nil {
+					errMsg = append(errMsg, *alarm.Message)
+				}
+				if alarm.ErrorDetails != nil {
+					if alarm.ErrorDetails.ErrorCode != nil {
+						errCodes = append(errCodes, int(*alarm.ErrorDetails.ErrorCode))
 					}
-					if alarm.ErrorDetails != nil {
-						for _, relatedErr := range alarm.ErrorDetails.RelatedErrors {
-							if relatedErr.ErrorMessage != nil {
-								errMsg = append(errMsg, *relatedErr.ErrorMessage)
-							}
+					for _, relatedErr := range alarm.ErrorDetails.RelatedErrors {
+						if relatedErr.ErrorMessage != nil {
+							errMsg = append(errMsg, *relatedErr.ErrorMessage)
+						}
+						if relatedErr.ErrorCode != nil {
+							errCodes = append(errCodes, int(*relatedErr.ErrorCode))
 						}
-					}
-					if nsxutil.IsRetryRealizeError(alarm) {
-						return nsxutil.NewRetryRealizeError(fmt.Sprintf("%s not realized with errors: %s", intentPath, errMsg))
-					}
-					if nsxutil.IsIPAllocationError(alarm) {
-						return nsxutil.NewRealizeStateError(fmt.Sprintf("%s realized with errors: %s", intentPath, errMsg), nsxutil.IPAllocationErrorCode)
 					}
 				}
-				return nsxutil.NewRealizeStateError(fmt.Sprintf("%s realized with errors: %s", intentPath, errMsg), 0)
+				if nsxutil.IsRetryRealizeError(alarm) {
+					return nsxutil.NewRetryRealizeError(fmt.Sprintf("%s not realized with errors: %s", intentPath, errMsg))
+				}
+				if nsxutil.IsIPAllocationError(alarm) {
+					return nsxutil.NewRealizeStateError(fmt.Sprintf("%s realized with errors: %s", intentPath, errMsg), nsxutil.IPAllocationErrorCode, errCodes...)
+				}
 			}
+			return nsxutil.NewRealizeStateError(fmt.Sprintf("%s realized with errors: %s", intentPath, errMsg), 0, errCodes...)
 		}
-		// extraIdsRealized can be greater than extraIds length as id is not unique in result list.
-		if len(results.Results) != 0 && entitiesRealized == len(results.Results) && extraIdsRealized >= len(extraIds) {
-			return nil
-		}
-		return fmt.Errorf("%s not realized", intentPath)
-	})
+	}
+	// extraIdsRealized can be greater than extraIds length as id is not unique in result list.
+	if len(results) != 0 && entitiesRealized == len(results) && extraIdsRealized >= len(extraIds) {
+		return nil
+	}
+	return fmt.Errorf("%s not realized", intentPath)
 }
 
 func (service *RealizeStateService) GetPolicyInterfaceIP(realizedPath string) (string, error) {
@@ -1,6 +1,7 @@
 package realizestate
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -65,14 +66,14 @@ func TestRealizeStateService_CheckRealizeState(t *testing.T) {
 		Steps:    6,
 	}
 	// default project
-	err := s.CheckRealizeState(backoff, "/orgs/default/projects/default/vpcs/vpc/subnets/subnet/ports/port", []string{})
+	err := s.CheckRealizeState(context.TODO(), backoff, "/orgs/default/projects/default/vpcs/vpc/subnets/subnet/ports/port", []string{})
 
 	realizeStateError, ok := err.(*nsxutil.RealizeStateError)
 	assert.True(t, ok)
 	assert.Equal(t, realizeStateError.Error(), "/orgs/default/projects/default/vpcs/vpc/subnets/subnet/ports/port realized with errors: [mocked error]")
 
 	// non default project
-	err = s.CheckRealizeState(backoff, "/orgs/default/projects/project-quality/vpcs/vpc/subnets/subnet/ports/port", []string{})
+	err = s.CheckRealizeState(context.TODO(), backoff, "/orgs/default/projects/project-quality/vpcs/vpc/subnets/subnet/ports/port", []string{})
 
 	realizeStateError, ok = err.(*nsxutil.RealizeStateError)
 	assert.True(t, ok)
@@ -98,7 +99,7 @@ func TestRealizeStateService_CheckRealizeState(t *testing.T) {
 			},
 		}, nil
 	})
-	err = s.CheckRealizeState(backoff, "/orgs/default/projects/project-quality/vpcs/vpc", []string{common.GatewayInterfaceId})
+	err = s.CheckRealizeState(context.TODO(), backoff, "/orgs/default/projects/project-quality/vpcs/vpc", []string{common.GatewayInterfaceId})
 	assert.Equal(t, err, nil)
 
 	// for lbs, realized with ProviderNotReady and need retry
@@ -129,7 +130,7 @@ func TestRealizeStateService_CheckRealizeState(t *testing.T) {
 		Jitter:   0,
 		Steps:    1,
 	}
-	err = s.CheckRealizeState(backoff, "/orgs/default/projects/default/vpcs/vpc/vpc-lbs/default", []string{})
+	err = s.CheckRealizeState(context.TODO(), backoff, "/orgs/default/projects/default/vpcs/vpc/vpc-lbs/default", []string{})
 	assert.NotEqual(t, err, nil)
 	_, ok = err.(*nsxutil.RetryRealizeError)
 	assert.Equal(t, ok, true)
@@ -162,7 +163,7 @@ func TestRealizeStateService_CheckRealizeState(t *testing.T) {
 			},
 		}, nil
 	})
-	err = s.CheckRealizeState(backoff, "/orgs/default/projects/project-quality/vpcs/vpc/subnets/subnet/", []string{})
+	err = s.CheckRealizeState(context.TODO(), backoff, "/orgs/default/projects/project-quality/vpcs/vpc/subnets/subnet/", []string{})
 
 	realizeStateError, ok = err.(*nsxutil.RealizeStateError)
 	assert.True(t, ok)
@@ -192,7 +193,7 @@ func TestRealizeStateService_CheckRealizeState(t *testing.T) {
 			},
 		}, nil
 	})
-	err = s.CheckRealizeState(backoff, "/orgs/default/projects/project-quality/vpcs/vpc/subnets/subnet/", []string{})
+	err = s.CheckRealizeState(context.TODO(), backoff, "/orgs/default/projects/project-quality/vpcs/vpc/subnets/subnet/", []string{})
 	assert.Equal(t, err, nil)
 
 	// for subnet, need retry
@@ -225,7 +226,7 @@ func TestRealizeStateService_CheckRealizeState(t *testing.T) {
 		Jitter:   0,
 		Steps:    1,
 	}
-	err = s.CheckRealizeState(backoff, "/orgs/default/projects/project-quality/vpcs/vpc/subnets/subnet/", []string{})
+	err = s.CheckRealizeState(context.TODO(), backoff, "/orgs/default/projects/project-quality/vpcs/vpc/subnets/subnet/", []string{})
 	assert.NotEqual(t, err, nil)
 	_, ok = err.(*nsxutil.RealizeStateError)
 	assert.Equal(t, ok, false)
@@ -258,7 +259,7 @@ func TestRealizeStateService_CheckRealizeState(t *testing.T) {
 		Jitter:   0,
 		Steps:    1,
 	}
-	err = s.CheckRealizeState(backoff, "/orgs/default/projects/default/vpcs/vpc/vpc-lbs/default", []string{})
+	err = s.CheckRealizeState(context.TODO(), backoff, "/orgs/default/projects/default/vpcs/vpc/vpc-lbs/default", []string{})
 	assert.NotEqual(t, err, nil)
 	realizedError, ok := err.(*nsxutil.RealizeStateError)
 	assert.Equal(t, ok, true)
@@ -295,7 +296,7 @@ func TestRealizeStateService_CheckRealizeState(t *testing.T) {
 		Jitter:   0,
 		Steps:    1,
 	}
-	err = s.CheckRealizeState(backoff, "/orgs/default/projects/project-quality/vpcs/vpc", []string{})
+	err = s.CheckRealizeState(context.TODO(), backoff, "/orgs/default/projects/project-quality/vpcs/vpc", []string{})
 
 	realizeStateError, ok = err.(*nsxutil.RealizeStateError)
 	assert.True(t, ok)
@@ -303,3 +304,21 @@ func TestRealizeStateService_CheckRealizeState(t *testing.T) {
 
 	patches.Reset()
 }
+
+func TestCheckRealizedEntities_ErrorCodes(t *testing.T) {
+	code := func(c int64) *int64 { return &c }
+	err := checkRealizedEntities("/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1", []model.GenericPolicyRealizedResource{{
+		Id:    common.String("subnet-1"),
+		State: common.String(model.GenericPolicyRealizedResource_STATE_ERROR),
+		Alarms: []model.PolicyAlarmResource{{
+			Message: common.String("overlapped"),
+			ErrorDetails: &model.PolicyApiError{
+				ErrorCode:     code(1),
+				RelatedErrors: []model.PolicyRelatedApiError{{ErrorCode: code(nsxutil.ReservedIPRangesOverlappedErrorCode)}},
+			},
+		}},
+	}}, nil)
+	assert.True(t, nsxutil.IsRealizeStateError(err))
+	assert.Equal(t, []int{1, nsxutil.ReservedIPRangesOverlappedErrorCode}, err.(*nsxutil.RealizeStateError).GetErrorCodes())
+	assert.Equal(t, nsxutil.ReasonInvalidConfiguration, nsxutil.GetRealizationReason(err).Reason)
+}
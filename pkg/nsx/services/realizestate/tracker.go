@@ -0,0 +1,263 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package realizestate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+const (
+	// DefaultTrackerTimeout is about the time CheckRealizeState waits with NSXTRealizeRetry.
+	DefaultTrackerTimeout = 60 * time.Second
+	// DefaultTrackerBatchSize is the max number of intent paths in a search query, it keeps the URL short.
+	DefaultTrackerBatchSize = 50
+
+	resourceTypeRealizedResource = "GenericPolicyRealizedResource"
+	queryEscapedChars            = `\+-!():^[]"{}~*?|&/ `
+)
+
+var defaultTracker atomic.Pointer[Tracker]
+
+// Tracker checks the realization of the intent paths registered by the reconcilers. Instead of polling the
+// realized-state API per intent path, the pending intent paths are checked in batches by the search API every
+// Interval, and the waiters are resolved with nil if the intent path is realized, RealizeStateError if it's
+// realized with errors, or the error of timeout if it isn't realized in Timeout.
+type Tracker struct {
+	service   common.Service
+	Interval  time.Duration
+	Timeout   time.Duration
+	BatchSize int
+
+	mutex   sync.Mutex
+	pending map[string]*trackedPath
+	// results keeps the results of the intent paths registered by Check until Check is called again.
+	results map[string]trackedResult
+}
+
+type trackedPath struct {
+	extraIds   []string
+	deadline   time.Time
+	callbacks  []func(err error)
+	keepResult bool
+}
+
+type trackedResult struct {
+	err        error
+	resolvedAt time.Time
+}
+
+func NewTracker(service common.Service, interval time.Duration) *Tracker {
+	return &Tracker{
+		service:   service,
+		Interval:  interval,
+		Timeout:   DefaultTrackerTimeout,
+		BatchSize: DefaultTrackerBatchSize,
+		pending:   make(map[string]*trackedPath),
+		results:   make(map[string]trackedResult),
+	}
+}
+
+// SetDefaultTracker makes CheckRealizeState and PollRealizeState check the realization by tracker, nil restores
+// the polling per intent path.
+func SetDefaultTracker(tracker *Tracker) {
+	defaultTracker.Store(tracker)
+}
+
+// DefaultTracker returns the tracker set by SetDefaultTracker.
+func DefaultTracker() *Tracker {
+	return defaultTracker.Load()
+}
+
+// Watch registers intentPath, callback is called with the result once it's resolved. The callbacks are called by
+// the goroutine running the tracker, they must not block.
+func (t *Tracker) Watch(intentPath string, extraIds []string, callback func(err error)) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	tracked := t.watch(intentPath, extraIds)
+	tracked.callbacks = append(tracked.callbacks, callback)
+}
+
+// Register registers intentPath and returns the channel receiving the result.
+func (t *Tracker) Register(intentPath string, extraIds []string) <-chan error {
+	result := make(chan error, 1)
+	t.Watch(intentPath, extraIds, func(err error) {
+		result <- err
+	})
+	return result
+}
+
+// Check returns the result of intentPath if it's resolved since the last Check, otherwise it registers intentPath
+// and returns RealizePendingError.
+func (t *Tracker) Check(intentPath string, extraIds []string) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if result, ok := t.results[intentPath]; ok {
+		delete(t.results, intentPath)
+		return result.err
+	}
+	t.watch(intentPath, extraIds).keepResult = true
+	return nsxutil.NewRealizePendingError(fmt.Sprintf("%s realization is pending", intentPath))
+}
+
+// Pending returns the number of the intent paths not resolved yet.
+func (t *Tracker) Pending() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return len(t.pending)
+}
+
+func (t *Tracker) watch(intentPath string, extraIds []string) *trackedPath {
+	tracked, ok := t.pending[intentPath]
+	if !ok {
+		tracked = &trackedPath{deadline: time.Now().Add(t.Timeout)}
+		t.pending[intentPath] = tracked
+	}
+	for _, id := range extraIds {
+		if !slices.Contains(tracked.extraIds, id) {
+			tracked.extraIds = append(tracked.extraIds, id)
+		}
+	}
+	return tracked
+}
+
+// Start checks the pending intent paths every Interval until ctx is done.
+func (t *Tracker) Start(ctx context.Context) {
+	log.Info("Realization tracker started", "interval", t.Interval, "timeout", t.Timeout, "batchSize", t.BatchSize)
+	ticker := time.NewTicker(t.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Poll(ctx)
+		}
+	}
+}
+
+// Poll checks the pending intent paths once and resolves the realized and timed out ones.
+func (t *Tracker) Poll(ctx context.Context) {
+	t.mutex.Lock()
+	paths := make([]string, 0, len(t.pending))
+	for path := range t.pending {
+		paths = append(paths, path)
+	}
+	for path, result := range t.results {
+		if time.Since(result.resolvedAt) > t.Timeout {
+			delete(t.results, path)
+		}
+	}
+	t.mutex.Unlock()
+	sort.Strings(paths)
+
+	for start := 0; start < len(paths); start += t.BatchSize {
+		batch := paths[start:min(start+t.BatchSize, len(paths))]
+		entities, err := t.queryRealizedEntities(ctx, batch)
+		if err != nil {
+			log.Error(err, "Failed to query realized entities", "intentPathCount", len(batch))
+		}
+		for _, path := range batch {
+			t.resolve(path, entities, err)
+		}
+	}
+}
+
+// resolve resolves intentPath if it's realized, realized with errors or timed out. If the query failed, only the
+// timeout is checked.
+func (t *Tracker) resolve(intentPath string, entities map[string][]model.GenericPolicyRealizedResource, queryErr error) {
+	t.mutex.Lock()
+	tracked, ok := t.pending[intentPath]
+	if !ok {
+		t.mutex.Unlock()
+		return
+	}
+	err := queryErr
+	if err == nil {
+		err = checkRealizedEntities(intentPath, entities[intentPath], tracked.extraIds)
+	}
+	if err != nil && !nsxutil.IsRealizeStateError(err) {
+		if time.Now().Before(tracked.deadline) {
+			t.mutex.Unlock()
+			return
+		}
+		err = fmt.Errorf("%s not realized in %s: %w", intentPath, t.Timeout, err)
+	}
+	delete(t.pending, intentPath)
+	if tracked.keepResult {
+		t.results[intentPath] = trackedResult{err: err, resolvedAt: time.Now()}
+	}
+	t.mutex.Unlock()
+
+	for _, callback := range tracked.callbacks {
+		callback(err)
+	}
+}
+
+// queryRealizedEntities searches the realized entities of intentPaths and returns them by intent path.
+func (t *Tracker) queryRealizedEntities(ctx context.Context, intentPaths []string) (map[string][]model.GenericPolicyRealizedResource, error) {
+	escaped := make([]string, 0, len(intentPaths))
+	for _, path := range intentPaths {
+		escaped = append(escaped, escapeQueryValue(path))
+	}
+	query := fmt.Sprintf("%s:%s AND intent_paths:(%s)", common.ResourceType, resourceTypeRealizedResource, strings.Join(escaped, " OR "))
+
+	requested := make(map[string]bool, len(intentPaths))
+	for _, path := range intentPaths {
+		requested[path] = true
+	}
+	entities := make(map[string][]model.GenericPolicyRealizedResource)
+	nsxClient := t.service.NSXClientWithContext(ctx)
+	var cursor *string
+	for {
+		response, err := nsxClient.QueryClient.List(query, cursor, nil, nil, nil, nil)
+		if err != nil {
+			return nil, nsxutil.TransNSXApiError(err)
+		}
+		for _, result := range response.Results {
+			obj, errs := common.NewConverter().ConvertToGolang(result, model.GenericPolicyRealizedResourceBindingType())
+			if len(errs) > 0 {
+				return nil, errs[0]
+			}
+			entity := obj.(model.GenericPolicyRealizedResource)
+			for _, path := range entity.IntentPaths {
+				if requested[path] {
+					entities[path] = append(entities[path], entity)
+				}
+			}
+		}
+		cursor = response.Cursor
+		if cursor == nil || response.ResultCount == nil {
+			break
+		}
+		if next, _ := strconv.ParseInt(*cursor, 10, 64); next >= *response.ResultCount {
+			break
+		}
+	}
+	return entities, nil
+}
+
+// escapeQueryValue escapes the special characters of the search query in value, e.g. / in the paths.
+func escapeQueryValue(value string) string {
+	var b strings.Builder
+	for _, c := range value {
+		if strings.ContainsRune(queryEscapedChars, c) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
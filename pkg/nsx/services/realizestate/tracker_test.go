@@ -0,0 +1,119 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package realizestate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock/nsxserver"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+const testVPCPath = "/orgs/default/projects/project-1/vpcs/vpc-1"
+
+func newTestTracker(t *testing.T) (*nsxserver.Server, *Tracker) {
+	server := nsxserver.NewServer()
+	t.Cleanup(server.Close)
+	service := common.Service{NSXClient: server.NewClient("k8scl-one"), NSXConfig: server.NewConfig("k8scl-one")}
+	return server, NewTracker(service, time.Millisecond)
+}
+
+func addTestSubnet(t *testing.T, server *nsxserver.Server, id string) string {
+	path := testVPCPath + "/subnets/" + id
+	require.NoError(t, server.AddResource(path, model.VpcSubnet{Id: common.String(id), Path: common.String(path), ResourceType: common.String(common.ResourceTypeSubnet)},
+		model.VpcSubnetBindingType()))
+	return path
+}
+
+func TestTracker(t *testing.T) {
+	server, tracker := newTestTracker(t)
+	tracker.BatchSize = 2
+	realized := []string{addTestSubnet(t, server, "subnet-1"), addTestSubnet(t, server, "subnet-2"), addTestSubnet(t, server, "subnet-3")}
+	failed := testVPCPath + "/subnets/subnet-4"
+	server.SetRealizedState(failed, model.GenericPolicyRealizedResource_STATE_ERROR, "mocked error")
+	pending := testVPCPath + "/subnets/subnet-5"
+
+	var results []<-chan error
+	for _, path := range realized {
+		results = append(results, tracker.Register(path, nil))
+	}
+	failedResult := tracker.Register(failed, nil)
+	pendingResult := tracker.Register(pending, nil)
+	assert.Equal(t, 5, tracker.Pending())
+
+	tracker.Poll(context.TODO())
+	// 5 intent paths are checked by 3 search requests
+	assert.Equal(t, 3, server.RequestCount(http.MethodGet, "/policy/api/v1/search/query"))
+	for _, result := range results {
+		assert.NoError(t, <-result)
+	}
+	err := <-failedResult
+	assert.True(t, nsxutil.IsRealizeStateError(err))
+	assert.ErrorContains(t, err, "mocked error")
+	assert.Equal(t, 1, tracker.Pending())
+	assert.Empty(t, pendingResult)
+
+	// the intent path not realized is resolved with the error of timeout
+	tracker.Timeout = 0
+	tracker.watch(pending, nil).deadline = time.Now()
+	tracker.Poll(context.TODO())
+	assert.ErrorContains(t, <-pendingResult, fmt.Sprintf("%s not realized in 0s", pending))
+	assert.Zero(t, tracker.Pending())
+}
+
+func TestTracker_Check(t *testing.T) {
+	server, tracker := newTestTracker(t)
+	path := addTestSubnet(t, server, "subnet-1")
+	extraPath := addTestSubnet(t, server, "subnet-2")
+
+	err := tracker.Check(path, nil)
+	assert.True(t, nsxutil.IsRealizePendingError(err))
+	// the extra ids are checked as CheckRealizeState does
+	assert.True(t, nsxutil.IsRealizePendingError(tracker.Check(extraPath, []string{"port-1"})))
+	assert.Equal(t, 2, tracker.Pending())
+	tracker.Poll(context.TODO())
+	assert.Equal(t, 1, tracker.Pending())
+	assert.NoError(t, tracker.Check(path, nil))
+	// the result is consumed, the intent path is checked again
+	assert.True(t, nsxutil.IsRealizePendingError(tracker.Check(path, nil)))
+}
+
+func TestCheckRealizeState_Tracker(t *testing.T) {
+	server, tracker := newTestTracker(t)
+	SetDefaultTracker(tracker)
+	defer SetDefaultTracker(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go tracker.Start(ctx)
+
+	path := addTestSubnet(t, server, "subnet-1")
+	s := &RealizeStateService{Service: tracker.service}
+	backoff := wait.Backoff{Duration: 100 * time.Millisecond, Steps: 50}
+	assert.NoError(t, s.CheckRealizeState(ctx, backoff, path, nil))
+	assert.Zero(t, server.RequestCount(http.MethodGet, "/policy/api/v1/infra/realized-state/realized-entities"))
+
+	failed := testVPCPath + "/subnets/subnet-2"
+	server.SetRealizedState(failed, model.GenericPolicyRealizedResource_STATE_ERROR, "mocked error")
+	assert.True(t, nsxutil.IsRealizeStateError(s.CheckRealizeState(ctx, backoff, failed, nil)))
+
+	// the caller doesn't wait for the tracker timeout but the retries of backoff
+	pending := testVPCPath + "/subnets/subnet-3"
+	start := time.Now()
+	err := s.CheckRealizeState(ctx, wait.Backoff{Duration: 10 * time.Millisecond, Steps: 3}, pending, nil)
+	assert.ErrorContains(t, err, fmt.Sprintf("%s not realized in 20ms", pending))
+	assert.Less(t, time.Since(start), time.Second)
+	canceledCtx, cancelCheck := context.WithCancel(ctx)
+	cancelCheck()
+	assert.ErrorIs(t, s.CheckRealizeState(canceledCtx, backoff, pending, nil), context.Canceled)
+}
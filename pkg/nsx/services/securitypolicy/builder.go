@@ -19,6 +19,8 @@ import (
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
@@ -39,6 +41,7 @@ const (
 var (
 	String = common.String
 	Int64  = common.Int64
+	Bool   = common.Bool
 )
 
 type GroupScope int
@@ -89,17 +92,18 @@ func (service *SecurityPolicyService) buildSecurityPolicyIDAndName(obj *v1alpha1
 	return nsxSecurityPolicyID, service.buildSecurityPolicyName(obj)
 }
 
-func (service *SecurityPolicyService) buildSecurityPolicy(obj *v1alpha1.SecurityPolicy, createdFor string, vpcInfo *common.VPCResourceInfo, isDefaultProject bool) (*model.SecurityPolicy, *[]model.Group, *[]GroupShare, error) {
+func (service *SecurityPolicyService) buildSecurityPolicy(obj *v1alpha1.SecurityPolicy, createdFor string, vpcInfo *common.VPCResourceInfo, isDefaultProject bool) (*model.SecurityPolicy, *[]model.Group, *[]GroupShare, *[]model.PolicyContextProfile, error) {
 	var nsxRules []model.Rule
 	var nsxGroups []model.Group
 	var nsxShareGroups []model.Group
 	var nsxShares []model.Share
 	var nsxGroupShares []GroupShare
+	var nsxContextProfiles []model.PolicyContextProfile
 
 	log.Debug("Building the model SecurityPolicy from CR SecurityPolicy", "object", *obj)
 	if IsVPCEnabled(service) {
 		if vpcInfo == nil {
-			return nil, nil, nil, fmt.Errorf("vpcInfo is nil when building SecurityPolicy %s", obj.GetName())
+			return nil, nil, nil, nil, fmt.Errorf("vpcInfo is nil when building SecurityPolicy %s", obj.GetName())
 		}
 	}
 
@@ -111,11 +115,17 @@ func (service *SecurityPolicyService) buildSecurityPolicy(obj *v1alpha1.Security
 	nsxSecurityPolicy.DisplayName = String(policyName)
 	// TODO: confirm the sequence number: offset
 	nsxSecurityPolicy.SequenceNumber = Int64(int64(obj.Spec.Priority))
+	if createdFor == common.ResourceTypeSecurityPolicy {
+		if err := service.validatePolicyCategory(obj); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	nsxSecurityPolicy.Category = String(string(getPolicyCategory(obj)))
 
 	policyGroup, policyGroupPath, err := service.buildPolicyGroup(obj, createdFor, vpcInfo)
 	if err != nil {
 		log.Error(err, "Failed to build policy group", "policy", *obj)
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	nsxSecurityPolicy.Scope = []string{policyGroupPath}
@@ -126,10 +136,10 @@ func (service *SecurityPolicyService) buildSecurityPolicy(obj *v1alpha1.Security
 	for ruleIdx, r := range obj.Spec.Rules {
 		rule := r
 		// A rule containing named port may be expanded to multiple rules if the named ports map to multiple port numbers.
-		expandRules, buildGroups, buildGroupShares, err := service.buildRuleAndGroups(obj, &rule, ruleIdx, createdFor, policyGroupPath, vpcInfo, isDefaultProject)
+		expandRules, buildGroups, buildGroupShares, buildContextProfile, err := service.buildRuleAndGroups(obj, &rule, ruleIdx, createdFor, policyGroupPath, vpcInfo, isDefaultProject)
 		if err != nil {
 			log.Error(err, "Failed to build rule and groups", "rule", rule, "ruleIndex", ruleIdx)
-			return nil, nil, nil, err
+			return nil, nil, nil, nil, err
 		}
 
 		for _, nsxRule := range expandRules {
@@ -151,6 +161,10 @@ func (service *SecurityPolicyService) buildSecurityPolicy(obj *v1alpha1.Security
 			}
 		}
 
+		if buildContextProfile != nil {
+			nsxContextProfiles = append(nsxContextProfiles, *buildContextProfile)
+		}
+
 		currentSet.Clear()
 		for _, item := range buildGroupShares {
 			if item != nil {
@@ -168,9 +182,9 @@ func (service *SecurityPolicyService) buildSecurityPolicy(obj *v1alpha1.Security
 	nsxSecurityPolicy.Tags = tags
 	// nsxRules info are included in nsxSecurityPolicy obj
 	log.Info("Built nsxSecurityPolicy", "nsxSecurityPolicy", nsxSecurityPolicy, "nsxGroups", nsxGroups,
-		"nsxShareGroups", nsxShareGroups, "nsxShares", nsxShares)
+		"nsxShareGroups", nsxShareGroups, "nsxShares", nsxShares, "nsxContextProfiles", nsxContextProfiles)
 
-	return nsxSecurityPolicy, &nsxGroups, &nsxGroupShares, nil
+	return nsxSecurityPolicy, &nsxGroups, &nsxGroupShares, &nsxContextProfiles, nil
 }
 
 func (service *SecurityPolicyService) buildPolicyGroup(obj *v1alpha1.SecurityPolicy, createdFor string, vpcInfo *common.VPCResourceInfo) (*model.Group, string, error) {
@@ -277,6 +291,10 @@ func (service *SecurityPolicyService) buildTargetTags(obj *v1alpha1.SecurityPoli
 }
 
 func (service *SecurityPolicyService) buildBasicTags(obj *v1alpha1.SecurityPolicy, createdFor string) []model.Tag {
+	if createdFor == common.ResourceTypeClusterSecurityPolicy {
+		// ClusterSecurityPolicy is not in any Namespace, its owner tags are built from the cluster-scoped CR.
+		return util.BuildBasicTags(getCluster(service), &crdv1alpha1.ClusterSecurityPolicy{ObjectMeta: obj.ObjectMeta}, "")
+	}
 	scopeOwnerName := common.TagValueScopeSecurityPolicyName
 	scopeOwnerUID := common.TagValueScopeSecurityPolicyUID
 	if createdFor == common.ResourceTypeNetworkPolicy {
@@ -451,7 +469,7 @@ func (service *SecurityPolicyService) buildAppliedGroupName(obj *v1alpha1.Securi
 
 func (service *SecurityPolicyService) buildRuleAndGroups(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule,
 	ruleIdx int, createdFor string, policyGroupPath string, vpcInfo *common.VPCResourceInfo, isDefaultProject bool,
-) ([]*model.Rule, []*model.Group, []*GroupShare, error) {
+) ([]*model.Rule, []*model.Group, []*GroupShare, *model.PolicyContextProfile, error) {
 	var ruleGroups []*model.Group
 	var nsxRuleAppliedGroup *model.Group
 	var nsxRuleSrcGroup *model.Group
@@ -461,11 +479,18 @@ func (service *SecurityPolicyService) buildRuleAndGroups(obj *v1alpha1.SecurityP
 	var nsxRuleAppliedGroupPath string
 	var nsxRuleDstGroupPath string
 	var nsxRuleSrcGroupPath string
+	var nsxContextProfile *model.PolicyContextProfile
 	var err error
 
 	ruleDirection, err := getRuleDirection(rule)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+	if err = service.validateRuleFQDNs(rule, ruleDirection); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if err = validateRuleAction(obj, rule); err != nil {
+		return nil, nil, nil, nil, err
 	}
 
 	// Since a named port may map to multiple port numbers, then it would return multiple rules.
@@ -473,18 +498,29 @@ func (service *SecurityPolicyService) buildRuleAndGroups(obj *v1alpha1.SecurityP
 	ruleBaseID := service.buildRuleID(obj, ruleIdx, createdFor)
 	ipSetGroups, nsxRules, err := service.expandRule(obj, rule, ruleIdx, ruleBaseID, createdFor, vpcInfo)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	for _, g := range ipSetGroups {
 		ruleGroups = append(ruleGroups, g)
 	}
 
+	// The FQDN destinations are matched by the context profile of the rules instead of the destination group.
+	if fqdns := getPeersFQDNs(rule.Destinations); len(fqdns) > 0 {
+		nsxContextProfile, err = service.buildRuleContextProfile(obj, ruleIdx, fqdns, ruleBaseID, createdFor, vpcInfo, isDefaultProject)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		for _, nsxRule := range nsxRules {
+			nsxRule.Profiles = []string{*nsxContextProfile.Path}
+		}
+	}
+
 	for _, nsxRule := range nsxRules {
 		if ruleDirection == "IN" {
 			nsxRuleSrcGroup, nsxRuleSrcGroupPath, nsxRuleDstGroupPath, nsxGroupShare, err = service.buildRuleInGroup(
 				obj, rule, nsxRule, ruleIdx, ruleBaseID, createdFor, vpcInfo, isDefaultProject)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, nil, err
 			}
 
 			if nsxRuleSrcGroup != nil {
@@ -494,7 +530,7 @@ func (service *SecurityPolicyService) buildRuleAndGroups(obj *v1alpha1.SecurityP
 			nsxRuleDstGroup, nsxRuleSrcGroupPath, nsxRuleDstGroupPath, nsxGroupShare, err = service.buildRuleOutGroup(
 				obj, rule, nsxRule, ruleIdx, ruleBaseID, createdFor, vpcInfo, isDefaultProject)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, nil, err
 			}
 
 			if nsxRuleDstGroup != nil {
@@ -511,15 +547,18 @@ func (service *SecurityPolicyService) buildRuleAndGroups(obj *v1alpha1.SecurityP
 		nsxRuleAppliedGroup, nsxRuleAppliedGroupPath, err = service.buildRuleAppliedToGroup(
 			obj, rule, ruleIdx, nsxRuleSrcGroupPath, nsxRuleDstGroupPath, createdFor, policyGroupPath, ruleBaseID, vpcInfo)
 		if err != nil {
-			return nil, nil, nil, err
+			return nil, nil, nil, nil, err
 		}
 		ruleGroups = append(ruleGroups, nsxRuleAppliedGroup)
 		nsxRule.Scope = []string{nsxRuleAppliedGroupPath}
 	}
-	return nsxRules, ruleGroups, nsxGroupShares, nil
+	return nsxRules, ruleGroups, nsxGroupShares, nsxContextProfile, nil
 }
 
 func buildRuleServiceEntries(port v1alpha1.SecurityPolicyPort) *data.StructValue {
+	if port.Protocol == v1alpha1.ProtocolICMP || port.Protocol == v1alpha1.ProtocolICMPv6 {
+		return buildRuleICMPServiceEntry(port)
+	}
 	var portRange string
 	sourcePorts := data.NewListValue()
 	destinationPorts := data.NewListValue()
@@ -553,6 +592,129 @@ func buildRuleServiceEntries(port v1alpha1.SecurityPolicyPort) *data.StructValue
 	return serviceEntry
 }
 
+// buildRuleICMPServiceEntry builds the ICMPTypeServiceEntry of the ICMP or ICMPv6 port, the ports of it are ignored.
+func buildRuleICMPServiceEntry(port v1alpha1.SecurityPolicyPort) *data.StructValue {
+	protocol := model.ICMPTypeServiceEntry_PROTOCOL_ICMPV4
+	if port.Protocol == v1alpha1.ProtocolICMPv6 {
+		protocol = model.ICMPTypeServiceEntry_PROTOCOL_ICMPV6
+	}
+	fields := map[string]data.DataValue{
+		"protocol":          data.NewStringValue(protocol),
+		"resource_type":     data.NewStringValue("ICMPTypeServiceEntry"),
+		"marked_for_delete": data.NewBooleanValue(false),
+		"overridden":        data.NewBooleanValue(false),
+	}
+	if port.ICMPType != nil {
+		fields["icmp_type"] = data.NewIntegerValue(int64(*port.ICMPType))
+		if port.ICMPCode != nil {
+			fields["icmp_code"] = data.NewIntegerValue(int64(*port.ICMPCode))
+		}
+	}
+	log.Debug("Built rule ICMP service entry", "protocol", protocol, "icmpType", port.ICMPType, "icmpCode", port.ICMPCode)
+	return data.NewStructValue("", fields)
+}
+
+// getPeersFQDNs returns the FQDNs of the peers.
+func getPeersFQDNs(peers []v1alpha1.SecurityPolicyPeer) []string {
+	var fqdns []string
+	for _, peer := range peers {
+		fqdns = append(fqdns, peer.FQDNs...)
+	}
+	return fqdns
+}
+
+// validateRuleFQDNs checks the FQDN peers of the rule. The FQDNs are only matched in the egress traffic by the context
+// profile of the rule, so the other destinations and the named ports which need the destination groups can't be used
+// together with them.
+func (service *SecurityPolicyService) validateRuleFQDNs(rule *v1alpha1.SecurityPolicyRule, ruleDirection string) error {
+	if len(getPeersFQDNs(rule.Sources)) == 0 && len(getPeersFQDNs(rule.Destinations)) == 0 {
+		return nil
+	}
+	if ruleDirection == "IN" || len(getPeersFQDNs(rule.Sources)) > 0 {
+		return &nsxutil.ValidationError{Desc: "fqdns is only supported in the destinations of egress rule"}
+	}
+	for _, peer := range rule.Destinations {
+		if len(peer.FQDNs) == 0 {
+			return &nsxutil.ValidationError{Desc: "fqdns can not be used with the other destinations in a rule"}
+		}
+	}
+	if service.hasNamedPort(rule) {
+		return &nsxutil.ValidationError{Desc: "named port can not be used with fqdns in a rule"}
+	}
+	if !service.NSXClient.NSXCheckVersion(nsx.SecurityPolicyFQDN) {
+		return &nsxutil.ValidationError{Desc: "fqdns is not supported by the NSX version"}
+	}
+	return nil
+}
+
+// validateRuleAction checks the Pass action of the rule, NSX only supports jumping to the Application category from
+// the rules in the Environment category.
+func validateRuleAction(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule) error {
+	if rule.Action == nil || util.ToUpper(*rule.Action) != ruleActionPass {
+		return nil
+	}
+	if getPolicyCategory(obj) != v1alpha1.SecurityPolicyCategoryEnvironment {
+		return &nsxutil.ValidationError{Desc: "Pass action is only supported in the Environment category"}
+	}
+	return nil
+}
+
+// validatePolicyCategory checks the category other than Application is permitted in the Namespace of the
+// SecurityPolicy by the SecurityPolicyCategoryAllowList. The webhook checks it too, but it is only registered in
+// VPC mode.
+func (service *SecurityPolicyService) validatePolicyCategory(obj *v1alpha1.SecurityPolicy) error {
+	category := getPolicyCategory(obj)
+	if category == v1alpha1.SecurityPolicyCategoryApplication || service.NSXConfig.K8sConfig.SecurityPolicyCategoryAllowed(obj.Namespace, string(category)) {
+		return nil
+	}
+	return &nsxutil.ValidationError{Desc: fmt.Sprintf("%s category is not allowed in Namespace %s", category, obj.Namespace)}
+}
+
+func (service *SecurityPolicyService) buildRuleContextProfilePath(profileID string, vpcInfo *common.VPCResourceInfo, isDefaultProject bool) (string, error) {
+	if IsVPCEnabled(service) && !isDefaultProject {
+		if vpcInfo == nil {
+			return "", fmt.Errorf("vpcInfo is nil when building context profile path %s", profileID)
+		}
+		return fmt.Sprintf("/orgs/%s/projects/%s/infra/context-profiles/%s", vpcInfo.OrgID, vpcInfo.ProjectID, profileID), nil
+	}
+	return fmt.Sprintf("/infra/context-profiles/%s", profileID), nil
+}
+
+// buildRuleContextProfile builds the context profile with the DOMAIN_NAME attribute of the FQDNs for the rule. In VPC
+// network, it's created in the infra of the VPC Project, or /infra for the Default Project.
+func (service *SecurityPolicyService) buildRuleContextProfile(obj *v1alpha1.SecurityPolicy, ruleIdx int, fqdns []string,
+	ruleBaseID, createdFor string, vpcInfo *common.VPCResourceInfo, isDefaultProject bool,
+) (*model.PolicyContextProfile, error) {
+	profileID := util.GenerateID(ruleBaseID, "", common.FQDNProfileSuffix, "")
+	profilePath, err := service.buildRuleContextProfilePath(profileID, vpcInfo, isDefaultProject)
+	if err != nil {
+		return nil, err
+	}
+	ruleHash := service.buildLimitedRuleHashString(&(obj.Spec.Rules[ruleIdx]))
+	profileName := util.GenerateTruncName(common.MaxNameLength, obj.Name, "",
+		strings.Join([]string{ruleHash, common.FQDNProfileSuffix}, common.ConnectorUnderline), "", "")
+
+	tags := append(service.buildBasicTags(obj, createdFor), model.Tag{
+		Scope: String(common.TagScopeRuleID),
+		Tag:   String(ruleBaseID),
+	})
+	nsxContextProfile := &model.PolicyContextProfile{
+		Id:          String(profileID),
+		DisplayName: String(profileName),
+		Path:        String(profilePath),
+		Tags:        tags,
+		Attributes: []model.PolicyAttributes{
+			{
+				Key:      String(model.PolicyAttributes_KEY_DOMAIN_NAME),
+				Datatype: String(model.PolicyAttributes_DATATYPE_STRING),
+				Value:    fqdns,
+			},
+		},
+	}
+	log.Debug("Built rule context profile", "nsxContextProfile", nsxContextProfile)
+	return nsxContextProfile, nil
+}
+
 func (service *SecurityPolicyService) buildRuleAppliedToGroup(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule, ruleIdx int,
 	nsxRuleSrcGroupPath string, nsxRuleDstGroupPath string, createdFor string, policyAppliedGroupPath string, ruleBaseID string, vpcInfo *common.VPCResourceInfo,
 ) (*model.Group, string, error) {
@@ -612,7 +774,7 @@ func (service *SecurityPolicyService) buildRuleOutGroup(obj *v1alpha1.SecurityPo
 	if len(nsxRule.DestinationGroups) > 0 {
 		nsxRuleDstGroupPath = nsxRule.DestinationGroups[0]
 	} else {
-		if len(rule.Destinations) > 0 {
+		if len(rule.Destinations) > 0 && len(nsxRule.Profiles) == 0 {
 			nsxRuleDstGroup, nsxRuleDstGroupPath, nsxGroupShare, err = service.buildRulePeerGroup(obj, rule, ruleIdx, ruleBaseID, false, createdFor, vpcInfo, isDefaultProject)
 			if err != nil {
 				return nil, "", "", nil, err
@@ -690,6 +852,8 @@ func (service *SecurityPolicyService) buildRuleDisplayName(rule *v1alpha1.Securi
 		ruleAct = common.RuleActionDrop
 	case util.ToUpper(v1alpha1.RuleActionReject):
 		ruleAct = common.RuleActionReject
+	case model.Rule_ACTION_JUMP_TO_APPLICATION:
+		ruleAct = common.RuleActionPass
 	}
 	ruleDir := common.RuleEgress
 	if ruleDirection == "IN" {
@@ -726,7 +890,7 @@ func (service *SecurityPolicyService) buildRuleDisplayName(rule *v1alpha1.Securi
 func (service *SecurityPolicyService) buildRuleAppliedGroupByPolicy(obj *v1alpha1.SecurityPolicy, nsxRuleSrcGroupPath string, nsxRuleDstGroupPath string, policyAppliedGroupPath string) (string, error) {
 	var nsxRuleAppliedGroupPath string
 	if len(obj.Spec.AppliedTo) == 0 {
-		return "", nsxutil.ValidationError{Desc: "appliedTo needs to be set in either spec or rules"}
+		return "", &nsxutil.ValidationError{Desc: "appliedTo needs to be set in either spec or rules"}
 	}
 	if nsxRuleSrcGroupPath == "ANY" && nsxRuleDstGroupPath == "ANY" {
 		// NSX-T manager will report error if all the rule's scope/src/dst are "ANY".
@@ -1060,10 +1224,26 @@ func (service *SecurityPolicyService) buildRuleBasicInfo(obj *v1alpha1.SecurityP
 		Services:       []string{"ANY"},
 		Tags:           basicTags,
 	}
+	// Logged and Tag are not set if the logging is not configured, NSX resets them when patching the rule.
+	if isRuleLogged(obj, rule) {
+		nsxRule.Logged = Bool(true)
+	}
+	if rule.LogLabel != "" {
+		nsxRule.Tag = String(rule.LogLabel)
+	}
 	log.Debug("Built rule basic info", "ruleBaseID", ruleBaseID, "nsxRule", nsxRule)
 	return &nsxRule, nil
 }
 
+// isRuleLogged returns whether the packet logging of rule is enabled, the rule level Logging takes precedence over the
+// policy level one.
+func isRuleLogged(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule) bool {
+	if rule.Logging != nil {
+		return *rule.Logging
+	}
+	return obj.Spec.Logging != nil && *obj.Spec.Logging
+}
+
 func (service *SecurityPolicyService) buildPeerTags(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule,
 	ruleBaseID string, isSource bool, groupScope GroupScope, createdFor string,
 ) []model.Tag {
@@ -1094,8 +1274,9 @@ func (service *SecurityPolicyService) buildPeerTags(obj *v1alpha1.SecurityPolicy
 		peerTags = append(peerTags, tag)
 	}
 
-	// In non-VPC network, there is no need to add NSX share createdFor tag for rule peer groups
-	if IsVPCEnabled(service) {
+	// In non-VPC network, there is no need to add NSX share createdFor tag for rule peer groups, the groups of
+	// ClusterSecurityPolicy aren't shared either, they are loaded by the ClusterSecurityPolicy tag.
+	if IsVPCEnabled(service) && createdFor != common.ResourceTypeClusterSecurityPolicy {
 		switch groupScope {
 		case InfraScopeGroup:
 			peerTags = append(peerTags,
@@ -1994,12 +2175,15 @@ func (service *SecurityPolicyService) buildRulePortsNumberString(ports []v1alpha
 }
 
 func (service *SecurityPolicyService) buildLimitedRuleHashString(rule *v1alpha1.SecurityPolicyRule) string {
-	serializedBytes, _ := json.Marshal(rule)
-	return util.Sha1(string(serializedBytes))[:common.HashLength]
+	return service.buildRuleHashString(rule)[:common.HashLength]
 }
 
 func (service *SecurityPolicyService) buildRuleHashString(rule *v1alpha1.SecurityPolicyRule) string {
-	serializedBytes, _ := json.Marshal(rule)
+	// The logging settings are not hashed, the rule ID is kept and the NSX rule is updated when they are changed.
+	hashedRule := *rule
+	hashedRule.Logging = nil
+	hashedRule.LogLabel = ""
+	serializedBytes, _ := json.Marshal(hashedRule)
 	return util.Sha1(string(serializedBytes))
 }
 
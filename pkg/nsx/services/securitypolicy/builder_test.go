@@ -11,15 +11,18 @@ import (
 
 	gomonkey "github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
 	"github.com/vmware-tanzu/nsx-operator/pkg/mock"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
@@ -67,6 +70,7 @@ func Test_BuildSecurityPolicyForT1(t *testing.T) {
 				Id:             &spID,
 				Scope:          []string{"/infra/domains/k8scl-one/groups/sp_uidA_scope"},
 				SequenceNumber: &seq0,
+				Category:       common.String("Application"),
 				Rules: []model.Rule{
 					{
 						DisplayName:       &podSelectorRule0Name00,
@@ -105,6 +109,7 @@ func Test_BuildSecurityPolicyForT1(t *testing.T) {
 				Id:             common.String("sp_uidB"),
 				Scope:          []string{"/infra/domains/k8scl-one/groups/sp_uidB_scope"},
 				SequenceNumber: &seq0,
+				Category:       common.String("Application"),
 				Rules: []model.Rule{
 					{
 						DisplayName:       &vmSelectorRule0Name00,
@@ -168,7 +173,7 @@ func Test_BuildSecurityPolicyForT1(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			observedPolicy, _, _, _ := s.buildSecurityPolicy(tt.inputPolicy, common.ResourceTypeSecurityPolicy, nil, false)
+			observedPolicy, _, _, _, _ := s.buildSecurityPolicy(tt.inputPolicy, common.ResourceTypeSecurityPolicy, nil, false)
 			assert.Equal(t, tt.expectedPolicy, observedPolicy)
 		})
 	}
@@ -252,6 +257,7 @@ func Test_BuildSecurityPolicyForVPC(t *testing.T) {
 				Id:             common.String("spA_re0bz"),
 				Scope:          []string{"/orgs/default/projects/projectQuality/vpcs/vpc1/groups/spA-scope_re0bz"},
 				SequenceNumber: &seq0,
+				Category:       common.String("Application"),
 				Rules: []model.Rule{
 					{
 						DisplayName:       &podSelectorRule0Name00,
@@ -291,6 +297,7 @@ func Test_BuildSecurityPolicyForVPC(t *testing.T) {
 				Id:             common.String("spB_9u8w9"),
 				Scope:          []string{"/orgs/default/projects/projectQuality/vpcs/vpc1/groups/spB-scope_9u8w9"},
 				SequenceNumber: &seq0,
+				Category:       common.String("Application"),
 				Rules: []model.Rule{
 					{
 						DisplayName:       &vmSelectorRule0Name00,
@@ -343,6 +350,7 @@ func Test_BuildSecurityPolicyForVPC(t *testing.T) {
 				Id:             common.String("spA_re0bz"),
 				Scope:          []string{"/orgs/default/projects/default/vpcs/vpc1/groups/spA-scope_re0bz"},
 				SequenceNumber: &seq0,
+				Category:       common.String("Application"),
 				Rules: []model.Rule{
 					{
 						DisplayName:       &podSelectorRule0Name00,
@@ -383,6 +391,7 @@ func Test_BuildSecurityPolicyForVPC(t *testing.T) {
 				Id:             common.String("spB_9u8w9"),
 				Scope:          []string{"/orgs/default/projects/default/vpcs/vpc1/groups/spB-scope_9u8w9"},
 				SequenceNumber: &seq0,
+				Category:       common.String("Application"),
 				Rules: []model.Rule{
 					{
 						DisplayName:       &vmSelectorRule0Name00,
@@ -429,7 +438,7 @@ func Test_BuildSecurityPolicyForVPC(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			observedPolicy, _, _, _ := fakeService.buildSecurityPolicy(tt.inputPolicy, common.ResourceTypeSecurityPolicy, tt.vpcInfo, tt.isDefaultProject)
+			observedPolicy, _, _, _, _ := fakeService.buildSecurityPolicy(tt.inputPolicy, common.ResourceTypeSecurityPolicy, tt.vpcInfo, tt.isDefaultProject)
 			assert.Equal(t, tt.expectedPolicy, observedPolicy)
 		})
 	}
@@ -1222,6 +1231,7 @@ func Test_BuildRulePortsNumberString(t *testing.T) {
 }
 
 func Test_BuildRuleDisplayName(t *testing.T) {
+	passAction := v1alpha1.RuleActionPass
 	tests := []struct {
 		name                    string
 		inputSecurityPolicy     *v1alpha1.SecurityPolicy
@@ -1276,6 +1286,15 @@ func Test_BuildRuleDisplayName(t *testing.T) {
 			namedPort:               newPortInfoForNamedPort(nsxutil.PortAddress{Port: 443}, "TCP"),
 			expectedRuleDisplayName: "TCP.https_UDP.1236.1237.TCP.443_ingress_allow",
 		},
+		{
+			name:                    "build-display-name-for-pass-action",
+			inputSecurityPolicy:     &v1alpha1.SecurityPolicy{Spec: v1alpha1.SecurityPolicySpec{Category: v1alpha1.SecurityPolicyCategoryEnvironment}},
+			inputRule:               &v1alpha1.SecurityPolicyRule{Name: "pass-rule", Action: &passAction, Direction: &directionIn},
+			ruleIdx:                 0,
+			createdFor:              common.ResourceTypeSecurityPolicy,
+			namedPort:               nil,
+			expectedRuleDisplayName: "pass-rule_ingress_pass",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1730,6 +1749,40 @@ func Test_buildRuleServiceEntries(t *testing.T) {
 				)
 			}(),
 		},
+		{
+			name: "ICMP echo request",
+			port: v1alpha1.SecurityPolicyPort{
+				Protocol: v1alpha1.ProtocolICMP,
+				ICMPType: ptr.To[int32](8),
+				ICMPCode: ptr.To[int32](0),
+			},
+			expected: data.NewStructValue(
+				"",
+				map[string]data.DataValue{
+					"protocol":          data.NewStringValue("ICMPv4"),
+					"icmp_type":         data.NewIntegerValue(8),
+					"icmp_code":         data.NewIntegerValue(0),
+					"resource_type":     data.NewStringValue("ICMPTypeServiceEntry"),
+					"marked_for_delete": data.NewBooleanValue(false),
+					"overridden":        data.NewBooleanValue(false),
+				},
+			),
+		},
+		{
+			name: "ICMPv6 of any type",
+			port: v1alpha1.SecurityPolicyPort{
+				Protocol: v1alpha1.ProtocolICMPv6,
+			},
+			expected: data.NewStructValue(
+				"",
+				map[string]data.DataValue{
+					"protocol":          data.NewStringValue("ICMPv6"),
+					"resource_type":     data.NewStringValue("ICMPTypeServiceEntry"),
+					"marked_for_delete": data.NewBooleanValue(false),
+					"overridden":        data.NewBooleanValue(false),
+				},
+			),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1738,6 +1791,228 @@ func Test_buildRuleServiceEntries(t *testing.T) {
 			assert.Equal(t, actual, tt.expected)
 		})
 	}
+
+	// the ICMP service entry is converted to the NSX model
+	entry, errs := common.NewConverter().ConvertToGolang(buildRuleServiceEntries(v1alpha1.SecurityPolicyPort{
+		Protocol: v1alpha1.ProtocolICMPv6, ICMPType: ptr.To[int32](135),
+	}), model.ICMPTypeServiceEntryBindingType())
+	assert.Empty(t, errs)
+	assert.Equal(t, int64(135), *entry.(model.ICMPTypeServiceEntry).IcmpType)
+	assert.Nil(t, entry.(model.ICMPTypeServiceEntry).IcmpCode)
+}
+
+func Test_buildRuleHashString_ICMP(t *testing.T) {
+	svc := &SecurityPolicyService{}
+	rule := &v1alpha1.SecurityPolicyRule{Ports: []v1alpha1.SecurityPolicyPort{{Protocol: "TCP", Port: intstr.FromInt(80)}}}
+	// the hash of the rules without ICMP ports isn't changed by the ICMP fields
+	tcpHash := svc.buildRuleHashString(rule)
+	assert.Equal(t, "bf3a4fffc9879cdd828619969a40962a6f94120f", tcpHash)
+
+	icmpRule := &v1alpha1.SecurityPolicyRule{Ports: []v1alpha1.SecurityPolicyPort{{Protocol: v1alpha1.ProtocolICMP, ICMPType: ptr.To[int32](8)}}}
+	icmpHash := svc.buildRuleHashString(icmpRule)
+	assert.NotEqual(t, tcpHash, icmpHash)
+	icmpRule.Ports[0].ICMPCode = ptr.To[int32](0)
+	assert.NotEqual(t, icmpHash, svc.buildRuleHashString(icmpRule))
+	icmpRule.Ports[0].ICMPType = ptr.To[int32](0)
+	icmpRule.Ports[0].ICMPCode = nil
+	assert.NotEqual(t, icmpHash, svc.buildRuleHashString(icmpRule))
+}
+
+func Test_buildRuleBasicInfo_Logging(t *testing.T) {
+	svc := fakeSecurityPolicyService()
+	allow := v1alpha1.RuleActionAllow
+	directionIn := v1alpha1.RuleDirectionIn
+	obj := &v1alpha1.SecurityPolicy{
+		ObjectMeta: v1.ObjectMeta{UID: "c5db1800-ce4c-11de-bedc-84a0de00c35b", Name: "sp-logging", Namespace: "ns1"},
+		Spec: v1alpha1.SecurityPolicySpec{
+			Rules: []v1alpha1.SecurityPolicyRule{{Action: &allow, Direction: &directionIn}},
+		},
+	}
+	rule := &obj.Spec.Rules[0]
+	buildRule := func() *model.Rule {
+		nsxRule, err := svc.buildRuleBasicInfo(obj, rule, 0, "rule-0", common.ResourceTypeSecurityPolicy, nil)
+		require.NoError(t, err)
+		return nsxRule
+	}
+	ruleHash := svc.buildRuleHashString(rule)
+
+	nsxRule := buildRule()
+	assert.Nil(t, nsxRule.Logged)
+	assert.Nil(t, nsxRule.Tag)
+
+	// the policy level logging applies to the rules which don't set it
+	obj.Spec.Logging = ptr.To(true)
+	assert.True(t, *buildRule().Logged)
+	rule.Logging = ptr.To(false)
+	assert.Nil(t, buildRule().Logged)
+	obj.Spec.Logging = nil
+	rule.Logging = ptr.To(true)
+	rule.LogLabel = "web-allow"
+	nsxRule = buildRule()
+	assert.True(t, *nsxRule.Logged)
+	assert.Equal(t, "web-allow", *nsxRule.Tag)
+
+	// the rule ID is kept when the logging is changed
+	assert.Equal(t, ruleHash, svc.buildRuleHashString(rule))
+}
+
+func Test_buildRuleAndGroups_LoggingInVPC(t *testing.T) {
+	fakeService := fakeSecurityPolicyService()
+	fakeService.NSXConfig.EnableVPCNetwork = true
+	fakeService.setUpStore(common.TagValueScopeSecurityPolicyUID, false)
+	vpcInfo := &common.VPCResourceInfo{OrgID: "default", ProjectID: "project-1", VPCID: "vpc-1"}
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(&fakeService.Service), "GetNamespaceUID", func(s *common.Service, ns string) types.UID {
+		return types.UID(tagValueNSUID)
+	})
+	defer patches.Reset()
+
+	allow := v1alpha1.RuleActionAllow
+	directionIn := v1alpha1.RuleDirectionIn
+	obj := &v1alpha1.SecurityPolicy{
+		ObjectMeta: v1.ObjectMeta{UID: "c5db1800-ce4c-11de-bedc-84a0de00c35b", Name: "sp-logging", Namespace: "ns1"},
+		Spec: v1alpha1.SecurityPolicySpec{
+			AppliedTo: []v1alpha1.SecurityPolicyTarget{{PodSelector: &v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}},
+			Rules: []v1alpha1.SecurityPolicyRule{{
+				Action:    &allow,
+				Direction: &directionIn,
+				Sources:   []v1alpha1.SecurityPolicyPeer{{PodSelector: &v1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}}},
+			}},
+		},
+	}
+	buildRule := func() ([]*model.Rule, []*model.Group) {
+		rules, groups, _, _, err := fakeService.buildRuleAndGroups(obj, &obj.Spec.Rules[0], 0, common.ResourceTypeSecurityPolicy,
+			"/orgs/default/projects/project-1/vpcs/vpc-1/groups/sp-logging-scope", vpcInfo, false)
+		require.NoError(t, err)
+		return rules, groups
+	}
+	groupIDs := func(groups []*model.Group) []string {
+		var ids []string
+		for _, group := range groups {
+			if group != nil {
+				ids = append(ids, *group.Id)
+			}
+		}
+		return ids
+	}
+
+	rules, groups := buildRule()
+	require.Len(t, rules, 1)
+	require.NotEmpty(t, groupIDs(groups))
+	assert.Nil(t, rules[0].Logged)
+
+	// the rule and its groups are updated in place when the logging is toggled
+	obj.Spec.Rules[0].Logging = ptr.To(true)
+	obj.Spec.Rules[0].LogLabel = "client-allow"
+	loggedRules, loggedGroups := buildRule()
+	require.Len(t, loggedRules, 1)
+	assert.True(t, *loggedRules[0].Logged)
+	assert.Equal(t, *rules[0].Id, *loggedRules[0].Id)
+	assert.Equal(t, groupIDs(groups), groupIDs(loggedGroups))
+}
+
+func Test_buildRuleAndGroups_FQDN(t *testing.T) {
+	fakeService := fakeSecurityPolicyService()
+	fakeService.NSXConfig.EnableVPCNetwork = true
+	fakeService.setUpStore(common.TagValueScopeSecurityPolicyUID, false)
+	vpcInfo := &common.VPCResourceInfo{OrgID: "default", ProjectID: "project-1", VPCID: "vpc-1"}
+
+	fqdnSupported := true
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(fakeService.NSXClient), "NSXCheckVersion", func(_ *nsx.Client, _ int) bool {
+		return fqdnSupported
+	})
+	patches.ApplyMethod(reflect.TypeOf(&fakeService.Service), "GetNamespaceUID", func(s *common.Service, ns string) types.UID {
+		return types.UID(tagValueNSUID)
+	})
+	defer patches.Reset()
+
+	allow := v1alpha1.RuleActionAllow
+	directionIn := v1alpha1.RuleDirectionIn
+	directionOut := v1alpha1.RuleDirectionOut
+	fqdns := []string{"www.example.com", "*.example.org"}
+	newPolicy := func(rule v1alpha1.SecurityPolicyRule) *v1alpha1.SecurityPolicy {
+		return &v1alpha1.SecurityPolicy{
+			ObjectMeta: v1.ObjectMeta{UID: "c5db1800-ce4c-11de-bedc-84a0de00c35b", Name: "sp-fqdn", Namespace: "ns1"},
+			Spec: v1alpha1.SecurityPolicySpec{
+				AppliedTo: []v1alpha1.SecurityPolicyTarget{{PodSelector: &v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}},
+				Rules:     []v1alpha1.SecurityPolicyRule{rule},
+			},
+		}
+	}
+	buildRule := func(obj *v1alpha1.SecurityPolicy, isDefaultProject bool) ([]*model.Rule, []*model.Group, *model.PolicyContextProfile, error) {
+		rules, groups, _, profile, err := fakeService.buildRuleAndGroups(obj, &obj.Spec.Rules[0], 0, common.ResourceTypeSecurityPolicy,
+			"/orgs/default/projects/project-1/vpcs/vpc-1/groups/sp-fqdn-scope", vpcInfo, isDefaultProject)
+		return rules, groups, profile, err
+	}
+
+	obj := newPolicy(v1alpha1.SecurityPolicyRule{
+		Action:       &allow,
+		Direction:    &directionOut,
+		Destinations: []v1alpha1.SecurityPolicyPeer{{FQDNs: fqdns[:1]}, {FQDNs: fqdns[1:]}},
+		Ports:        []v1alpha1.SecurityPolicyPort{{Protocol: "TCP", Port: intstr.FromInt(443)}},
+	})
+	rules, groups, profile, err := buildRule(obj, false)
+	require.NoError(t, err)
+	require.NotNil(t, profile)
+	assert.Equal(t, "/orgs/default/projects/project-1/infra/context-profiles/"+*profile.Id, *profile.Path)
+	assert.Equal(t, []model.PolicyAttributes{{
+		Key:      String(model.PolicyAttributes_KEY_DOMAIN_NAME),
+		Datatype: String(model.PolicyAttributes_DATATYPE_STRING),
+		Value:    fqdns,
+	}}, profile.Attributes)
+	assert.Equal(t, nsxutil.FindTag(profile.Tags, common.TagScopeRuleID)+"_"+common.FQDNProfileSuffix, *profile.Id)
+	// the FQDNs are matched by the context profile, no destination group is created
+	require.Len(t, rules, 1)
+	assert.Equal(t, []string{*profile.Path}, rules[0].Profiles)
+	assert.Equal(t, []string{"ANY"}, rules[0].DestinationGroups)
+	for _, group := range groups {
+		assert.Nil(t, group)
+	}
+
+	_, _, profile, err = buildRule(obj, true)
+	require.NoError(t, err)
+	assert.Equal(t, "/infra/context-profiles/"+*profile.Id, *profile.Path)
+
+	for _, tc := range []struct {
+		name      string
+		rule      v1alpha1.SecurityPolicyRule
+		supported bool
+		errMsg    string
+	}{
+		{
+			name:      "ingress rule",
+			rule:      v1alpha1.SecurityPolicyRule{Action: &allow, Direction: &directionIn, Sources: []v1alpha1.SecurityPolicyPeer{{FQDNs: fqdns}}},
+			supported: true,
+			errMsg:    "fqdns is only supported in the destinations of egress rule",
+		},
+		{
+			name: "mixed with IP blocks",
+			rule: v1alpha1.SecurityPolicyRule{Action: &allow, Direction: &directionOut, Destinations: []v1alpha1.SecurityPolicyPeer{
+				{FQDNs: fqdns}, {IPBlocks: []v1alpha1.IPBlock{{CIDR: "10.0.0.0/24"}}},
+			}},
+			supported: true,
+			errMsg:    "fqdns can not be used with the other destinations in a rule",
+		},
+		{
+			name: "named port",
+			rule: v1alpha1.SecurityPolicyRule{Action: &allow, Direction: &directionOut, Destinations: []v1alpha1.SecurityPolicyPeer{{FQDNs: fqdns}},
+				Ports: []v1alpha1.SecurityPolicyPort{{Protocol: "TCP", Port: intstr.FromString("https")}}},
+			supported: true,
+			errMsg:    "named port can not be used with fqdns in a rule",
+		},
+		{
+			name:      "not supported by NSX",
+			rule:      v1alpha1.SecurityPolicyRule{Action: &allow, Direction: &directionOut, Destinations: []v1alpha1.SecurityPolicyPeer{{FQDNs: fqdns}}},
+			supported: false,
+			errMsg:    "fqdns is not supported by the NSX version",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fqdnSupported = tc.supported
+			_, _, _, err := buildRule(newPolicy(tc.rule), false)
+			assert.ErrorAs(t, err, new(*nsxutil.ValidationError))
+			assert.ErrorContains(t, err, tc.errMsg)
+		})
+	}
 }
 
 func Test_dedupBlocks(t *testing.T) {
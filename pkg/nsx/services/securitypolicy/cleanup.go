@@ -11,9 +11,16 @@ import (
 
 // CleanupBeforeVPCDeletion cleans up SecurityPolicy, Rules, and Shares before VPC deletion to avoid dependency issues.
 // SecurityPolicy and Rules must be deleted first because Shares cannot be deleted while they are still being consumed.
+// The ClusterSecurityPolicy resources are not in the VPCs, they are deleted before the VPC ones.
 func (service *SecurityPolicyService) CleanupBeforeVPCDeletion(ctx context.Context) error {
 	log.Info("Cleaning up security policies, rules, and shares before VPC deletion")
 
+	if err := service.cleanupClusterSecurityPolicies(ctx); err != nil {
+		log.Error(err, "Failed to clean up cluster security policies")
+		return err
+	}
+	log.Info("Successfully cleaned all cluster security policies")
+
 	if err := service.cleanupRulesByVPC(ctx, ""); err != nil {
 		log.Error(err, "Failed to clean up rules")
 		return err
@@ -139,6 +146,9 @@ func (service *SecurityPolicyService) cleanupGroupsByVPC(ctx context.Context, vp
 
 // CleanupInfraResources is to clean up the resources created by SecurityPolicyService under path /infra.
 func (service *SecurityPolicyService) CleanupInfraResources(ctx context.Context) error {
+	if err := service.cleanupContextProfiles(ctx); err != nil {
+		return err
+	}
 	for _, config := range []struct {
 		store   *GroupStore
 		builder *common.PolicyTreeBuilder[*model.Group]
@@ -158,6 +168,46 @@ func (service *SecurityPolicyService) CleanupInfraResources(ctx context.Context)
 	return nil
 }
 
+// cleanupContextProfiles is deleting all the NSX context profiles created for the FQDN rules on NSX and in local cache,
+// they are either in /infra or in the infra of the VPC Projects.
+func (service *SecurityPolicyService) cleanupContextProfiles(ctx context.Context) error {
+	cachedObjs := service.contextProfileStore.List()
+	if len(cachedObjs) == 0 {
+		return nil
+	}
+	log.Info("Cleaning up context profiles", "Count", len(cachedObjs))
+	cachedProfiles := make([]*model.PolicyContextProfile, 0)
+	for _, obj := range cachedObjs {
+		profile := obj.(*model.PolicyContextProfile)
+		profile.MarkedForDelete = &MarkedForDelete
+		cachedProfiles = append(cachedProfiles, profile)
+	}
+
+	projectProfiles, infraProfiles := splitContextProfiles(cachedProfiles)
+	for _, config := range []struct {
+		profiles []*model.PolicyContextProfile
+		builder  *common.PolicyTreeBuilder[*model.PolicyContextProfile]
+	}{
+		{
+			profiles: projectProfiles,
+			builder:  service.projectContextProfileBuilder,
+		}, {
+			profiles: infraProfiles,
+			builder:  service.infraContextProfileBuilder,
+		},
+	} {
+		if len(config.profiles) == 0 {
+			continue
+		}
+		if err := config.builder.PagingUpdateResources(ctx, config.profiles, common.DefaultHAPIChildrenCount, service.NSXClient, func(deletedObjs []*model.PolicyContextProfile) {
+			service.contextProfileStore.DeleteMultipleObjects(deletedObjs)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func cleanShares(ctx context.Context, store *ShareStore, builder *common.PolicyTreeBuilder[*model.Share], nsxClient *nsx.Client) error {
 	cachedObjs := store.List()
 	if len(cachedObjs) == 0 {
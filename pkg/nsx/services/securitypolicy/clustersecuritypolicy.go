@@ -0,0 +1,590 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// A ClusterSecurityPolicy is realized as one NSX SecurityPolicy in the infra of the NSX Project of the default
+// VPCNetworkConfiguration, or /infra for the Default Project. Its groups select the Pods and VMs across the Namespaces,
+// so they are created in the same infra domain as the policy and need no shares. NSX enforces the policies in the
+// infra before the ones in the VPCs of the same category, and the Priority orders the ClusterSecurityPolicies.
+
+// convertClusterSecurityPolicy converts the ClusterSecurityPolicy to an internal SecurityPolicy to build the rules with
+// the SecurityPolicy builders, the appliedTo targets are built by the ClusterSecurityPolicy builders.
+func convertClusterSecurityPolicy(csp *crdv1alpha1.ClusterSecurityPolicy) *v1alpha1.SecurityPolicy {
+	sp := &crdv1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: csp.Name,
+			UID:  csp.UID,
+		},
+		Spec: crdv1alpha1.SecurityPolicySpec{
+			Priority: csp.Spec.Priority,
+			Category: csp.Spec.Category,
+			Logging:  csp.Spec.Logging,
+		},
+	}
+	for _, rule := range csp.Spec.Rules {
+		sp.Spec.Rules = append(sp.Spec.Rules, crdv1alpha1.SecurityPolicyRule{
+			Action:       rule.Action,
+			Direction:    rule.Direction,
+			Sources:      convertClusterSecurityPolicyPeers(rule.Sources),
+			Destinations: convertClusterSecurityPolicyPeers(rule.Destinations),
+			Ports:        rule.Ports,
+			Name:         rule.Name,
+			Logging:      rule.Logging,
+			LogLabel:     rule.LogLabel,
+		})
+	}
+	return VPCToT1(sp)
+}
+
+// convertClusterSecurityPolicyPeers selects the Pods or VMs of the peers without NamespaceSelector in all the Namespaces.
+func convertClusterSecurityPolicyPeers(peers []crdv1alpha1.SecurityPolicyPeer) []crdv1alpha1.SecurityPolicyPeer {
+	var clusterPeers []crdv1alpha1.SecurityPolicyPeer
+	for _, p := range peers {
+		peer := *p.DeepCopy()
+		if peer.NamespaceSelector == nil && (peer.PodSelector != nil || peer.VMSelector != nil) {
+			peer.NamespaceSelector = &metav1.LabelSelector{}
+		}
+		clusterPeers = append(clusterPeers, peer)
+	}
+	return clusterPeers
+}
+
+// convertClusterSecurityPolicyTargets converts the targets to the peers to build the group expressions, the Pods or VMs
+// of the targets without NamespaceSelector are selected in all the Namespaces.
+func convertClusterSecurityPolicyTargets(targets []crdv1alpha1.ClusterSecurityPolicyTarget) ([]v1alpha1.SecurityPolicyPeer, error) {
+	peers := make([]v1alpha1.SecurityPolicyPeer, 0, len(targets))
+	for _, target := range targets {
+		if target.NamespaceSelector == nil && target.PodSelector == nil && target.VMSelector == nil {
+			return nil, &nsxutil.ValidationError{Desc: "one of namespaceSelector, podSelector and vmSelector needs to be set in appliedTo"}
+		}
+		peer := v1alpha1.SecurityPolicyPeer{
+			NamespaceSelector: target.NamespaceSelector.DeepCopy(),
+			PodSelector:       target.PodSelector.DeepCopy(),
+			VMSelector:        target.VMSelector.DeepCopy(),
+		}
+		if peer.NamespaceSelector == nil {
+			peer.NamespaceSelector = &metav1.LabelSelector{}
+		}
+		peers = append(peers, peer)
+	}
+	return peers, nil
+}
+
+// getClusterSecurityPolicyProject returns the NSX Project of the default VPCNetworkConfiguration, the
+// ClusterSecurityPolicies are realized in its infra.
+func (service *SecurityPolicyService) getClusterSecurityPolicyProject() (*common.VPCResourceInfo, bool, error) {
+	nc, err := service.vpcService.GetDefaultNetworkConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	orgID, projectID, err := common.NSXProjectPathToId(nc.Spec.NSXProject)
+	if err != nil {
+		return nil, false, err
+	}
+	isDefaultProject, err := service.vpcService.IsDefaultNSXProject(orgID, projectID)
+	if err != nil {
+		log.Error(err, "Failed to check if NSX Project is default", "nsxProjectID", projectID)
+		return nil, false, err
+	}
+	return &common.VPCResourceInfo{OrgID: orgID, ProjectID: projectID}, isDefaultProject, nil
+}
+
+func buildClusterSecurityPolicyDomainPath(vpcInfo *common.VPCResourceInfo, isDefaultProject bool) string {
+	if isDefaultProject {
+		return fmt.Sprintf("/infra/domains/%s", getDefaultProjectDomain())
+	}
+	return fmt.Sprintf("/orgs/%s/projects/%s/infra/domains/%s", vpcInfo.OrgID, vpcInfo.ProjectID, getVPCProjectDomain())
+}
+
+func (service *SecurityPolicyService) buildClusterRuleID(obj *v1alpha1.SecurityPolicy, ruleIdx int) string {
+	ruleHash := service.buildRuleHashString(&(obj.Spec.Rules[ruleIdx]))[:common.HashLength]
+	return strings.Join([]string{common.ClusterSecurityPolicyPrefix, string(obj.UID), ruleHash, strconv.Itoa(ruleIdx)}, common.ConnectorUnderline)
+}
+
+func (service *SecurityPolicyService) buildClusterSecurityPolicy(csp *crdv1alpha1.ClusterSecurityPolicy, vpcInfo *common.VPCResourceInfo, isDefaultProject bool) (*model.SecurityPolicy, []model.Group, []model.PolicyContextProfile, error) {
+	var nsxGroups []model.Group
+	var nsxContextProfiles []model.PolicyContextProfile
+
+	log.Debug("Building the model SecurityPolicy from CR ClusterSecurityPolicy", "object", *csp)
+	obj := convertClusterSecurityPolicy(csp)
+	domainPath := buildClusterSecurityPolicyDomainPath(vpcInfo, isDefaultProject)
+	policyID := util.GenerateID(string(csp.UID), common.ClusterSecurityPolicyPrefix, "", "")
+	nsxSecurityPolicy := &model.SecurityPolicy{
+		Id:             String(policyID),
+		DisplayName:    String(service.buildSecurityPolicyName(obj)),
+		Path:           String(fmt.Sprintf("%s/security-policies/%s", domainPath, policyID)),
+		ParentPath:     String(domainPath),
+		SequenceNumber: Int64(int64(csp.Spec.Priority)),
+		Category:       String(string(getPolicyCategory(obj))),
+		Tags:           service.buildBasicTags(obj, common.ResourceTypeClusterSecurityPolicy),
+	}
+
+	policyGroupPath := "ANY"
+	if len(csp.Spec.AppliedTo) > 0 {
+		groupID := util.GenerateID(string(csp.UID), common.ClusterSecurityPolicyPrefix, common.TargetGroupSuffix, "")
+		policyGroup, err := service.buildClusterTargetGroup(obj, csp.Spec.AppliedTo, -1, groupID, "", vpcInfo, isDefaultProject)
+		if err != nil {
+			log.Error(err, "Failed to build policy group", "clusterSecurityPolicy", csp.Name)
+			return nil, nil, nil, err
+		}
+		nsxGroups = append(nsxGroups, *policyGroup)
+		policyGroupPath = *policyGroup.Path
+	}
+	nsxSecurityPolicy.Scope = []string{policyGroupPath}
+
+	for ruleIdx := range csp.Spec.Rules {
+		nsxRules, ruleGroups, nsxContextProfile, err := service.buildClusterRuleAndGroups(csp, obj, ruleIdx, policyGroupPath, vpcInfo, isDefaultProject)
+		if err != nil {
+			log.Error(err, "Failed to build rule and groups", "rule", csp.Spec.Rules[ruleIdx], "ruleIndex", ruleIdx)
+			return nil, nil, nil, err
+		}
+		nsxSecurityPolicy.Rules = append(nsxSecurityPolicy.Rules, nsxRules...)
+		nsxGroups = append(nsxGroups, ruleGroups...)
+		if nsxContextProfile != nil {
+			nsxContextProfiles = append(nsxContextProfiles, *nsxContextProfile)
+		}
+	}
+	log.Info("Built nsxSecurityPolicy for ClusterSecurityPolicy", "nsxSecurityPolicy", nsxSecurityPolicy, "nsxGroups", nsxGroups,
+		"nsxContextProfiles", nsxContextProfiles)
+	return nsxSecurityPolicy, nsxGroups, nsxContextProfiles, nil
+}
+
+// buildClusterRuleAndGroups builds the NSX rule of the ClusterSecurityPolicy rule with its appliedTo and peer groups,
+// the named ports are not supported as they are resolved from the Pods in the Namespace of the SecurityPolicy.
+func (service *SecurityPolicyService) buildClusterRuleAndGroups(csp *crdv1alpha1.ClusterSecurityPolicy, obj *v1alpha1.SecurityPolicy, ruleIdx int,
+	policyGroupPath string, vpcInfo *common.VPCResourceInfo, isDefaultProject bool,
+) ([]model.Rule, []model.Group, *model.PolicyContextProfile, error) {
+	var ruleGroups []model.Group
+	var nsxContextProfile *model.PolicyContextProfile
+	createdFor := common.ResourceTypeClusterSecurityPolicy
+	rule := &obj.Spec.Rules[ruleIdx]
+
+	ruleDirection, err := getRuleDirection(rule)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err = service.validateRuleFQDNs(rule, ruleDirection); err != nil {
+		return nil, nil, nil, err
+	}
+	if err = validateRuleAction(obj, rule); err != nil {
+		return nil, nil, nil, err
+	}
+	if service.hasNamedPort(rule) {
+		return nil, nil, nil, nsxutil.RestrictionError{Desc: "named port is not supported in ClusterSecurityPolicy"}
+	}
+
+	ruleBaseID := service.buildClusterRuleID(obj, ruleIdx)
+	_, nsxRules, err := service.expandRule(obj, rule, ruleIdx, ruleBaseID, createdFor, vpcInfo)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	nsxRule := nsxRules[0]
+
+	if fqdns := getPeersFQDNs(rule.Destinations); len(fqdns) > 0 {
+		nsxContextProfile, err = service.buildRuleContextProfile(obj, ruleIdx, fqdns, ruleBaseID, createdFor, vpcInfo, isDefaultProject)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		nsxRule.Profiles = []string{*nsxContextProfile.Path}
+	}
+
+	srcGroupPath, dstGroupPath := "ANY", "ANY"
+	if ruleDirection == "IN" && len(rule.Sources) > 0 {
+		srcGroup, err := service.buildClusterRulePeerGroup(obj, rule, ruleIdx, ruleBaseID, true, vpcInfo, isDefaultProject)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		ruleGroups = append(ruleGroups, *srcGroup)
+		srcGroupPath = *srcGroup.Path
+	} else if ruleDirection == "OUT" && len(rule.Destinations) > 0 && len(nsxRule.Profiles) == 0 {
+		dstGroup, err := service.buildClusterRulePeerGroup(obj, rule, ruleIdx, ruleBaseID, false, vpcInfo, isDefaultProject)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		ruleGroups = append(ruleGroups, *dstGroup)
+		dstGroupPath = *dstGroup.Path
+	}
+	nsxRule.SourceGroups = []string{srcGroupPath}
+	nsxRule.DestinationGroups = []string{dstGroupPath}
+
+	if appliedTo := csp.Spec.Rules[ruleIdx].AppliedTo; len(appliedTo) > 0 {
+		groupID := util.GenerateID(ruleBaseID, "", common.TargetGroupSuffix, "")
+		ruleGroup, err := service.buildClusterTargetGroup(obj, appliedTo, ruleIdx, groupID, ruleBaseID, vpcInfo, isDefaultProject)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		ruleGroups = append(ruleGroups, *ruleGroup)
+		nsxRule.Scope = []string{*ruleGroup.Path}
+	} else if len(csp.Spec.AppliedTo) == 0 {
+		return nil, nil, nil, &nsxutil.ValidationError{Desc: "appliedTo needs to be set in either spec or rules"}
+	} else if srcGroupPath == "ANY" && dstGroupPath == "ANY" {
+		// NSX rejects the rule whose scope, sources and destinations are all "ANY".
+		nsxRule.Scope = []string{policyGroupPath}
+	} else {
+		nsxRule.Scope = []string{"ANY"}
+	}
+	return []model.Rule{*nsxRule}, ruleGroups, nsxContextProfile, nil
+}
+
+// buildClusterTargetGroup builds the appliedTo group of the policy if ruleIdx is -1, otherwise the one of the rule.
+func (service *SecurityPolicyService) buildClusterTargetGroup(obj *v1alpha1.SecurityPolicy, targets []crdv1alpha1.ClusterSecurityPolicyTarget, ruleIdx int,
+	groupID, ruleBaseID string, vpcInfo *common.VPCResourceInfo, isDefaultProject bool,
+) (*model.Group, error) {
+	groupScope := getClusterSecurityPolicyGroupScope(isDefaultProject)
+	groupPath, err := service.buildRulePeerGroupPath(groupID, groupScope, vpcInfo)
+	if err != nil {
+		return nil, err
+	}
+	serializedBytes, _ := json.Marshal(targets)
+	tags := []model.Tag{
+		{
+			Scope: String(common.TagScopeGroupType),
+			Tag:   String(common.TagValueGroupScope),
+		},
+		{
+			Scope: String(common.TagScopeSelectorHash),
+			Tag:   String(util.Sha1(string(serializedBytes))),
+		},
+	}
+	tags = append(tags, service.buildBasicTags(obj, common.ResourceTypeClusterSecurityPolicy)...)
+	if len(ruleBaseID) > 0 {
+		tags = append(tags, model.Tag{
+			Scope: String(common.TagScopeRuleID),
+			Tag:   String(ruleBaseID),
+		})
+	}
+	group := &model.Group{
+		Id:          String(groupID),
+		DisplayName: String(service.buildAppliedGroupName(obj, ruleIdx)),
+		Path:        String(groupPath),
+		Tags:        tags,
+	}
+
+	peers, err := convertClusterSecurityPolicyTargets(targets)
+	if err != nil {
+		return nil, err
+	}
+	if err = service.updateClusterGroupExpressions(obj, peers, group, "target"); err != nil {
+		return nil, err
+	}
+	log.Debug("Built ClusterSecurityPolicy target group", "group", group)
+	return group, nil
+}
+
+func (service *SecurityPolicyService) buildClusterRulePeerGroup(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule, ruleIdx int,
+	ruleBaseID string, isSource bool, vpcInfo *common.VPCResourceInfo, isDefaultProject bool,
+) (*model.Group, error) {
+	suffix, peers, groupType := common.DstGroupSuffix, rule.Destinations, "destination"
+	if isSource {
+		suffix, peers, groupType = common.SrcGroupSuffix, rule.Sources, "source"
+	}
+	groupScope := getClusterSecurityPolicyGroupScope(isDefaultProject)
+	groupID := util.GenerateID(ruleBaseID, "", suffix, "")
+	groupPath, err := service.buildRulePeerGroupPath(groupID, groupScope, vpcInfo)
+	if err != nil {
+		return nil, err
+	}
+	group := &model.Group{
+		Id:          String(groupID),
+		DisplayName: String(service.buildRulePeerGroupName(obj, ruleIdx, isSource)),
+		Path:        String(groupPath),
+		Tags:        service.buildPeerTags(obj, rule, ruleBaseID, isSource, groupScope, common.ResourceTypeClusterSecurityPolicy),
+	}
+	if err = service.updateClusterGroupExpressions(obj, service.dedupBlocks(peers), group, groupType); err != nil {
+		return nil, err
+	}
+	log.Debug("Built ClusterSecurityPolicy rule peer group", "group", group)
+	return group, nil
+}
+
+// updateClusterGroupExpressions adds the expressions of the peers to the group. The groups are in the infra of the
+// Project, so they can use the mixed criteria of the Namespace and Pod or VM labels like the shared groups.
+func (service *SecurityPolicyService) updateClusterGroupExpressions(obj *v1alpha1.SecurityPolicy, peers []v1alpha1.SecurityPolicyPeer, group *model.Group, groupType string) error {
+	groupCriteriaCount, groupTotalExprCount := 0, 0
+	for i := range peers {
+		criteriaCount, totalExprCount, err := service.updatePeerExpressions(obj, &peers[i], group, i, true)
+		if err != nil {
+			return err
+		}
+		groupCriteriaCount += criteriaCount
+		groupTotalExprCount += totalExprCount
+	}
+	if groupCriteriaCount > MaxCriteria {
+		return &nsxutil.ValidationError{Desc: fmt.Sprintf("total counts of %s group criteria %d exceed NSX limit of %d",
+			groupType, groupCriteriaCount, MaxCriteria)}
+	}
+	if groupTotalExprCount > MaxTotalCriteriaExpressions {
+		return &nsxutil.ValidationError{Desc: fmt.Sprintf("total expression counts in %s group criteria %d exceed NSX limit of %d",
+			groupType, groupTotalExprCount, MaxTotalCriteriaExpressions)}
+	}
+	return nil
+}
+
+func getClusterSecurityPolicyGroupScope(isDefaultProject bool) GroupScope {
+	if isDefaultProject {
+		return InfraScopeGroup
+	}
+	return ProjectInfraScopeGroup
+}
+
+// getClusterSecurityPolicyGroups returns the groups of the ClusterSecurityPolicy in /infra for the Default Project,
+// otherwise the ones in the infra of the Projects.
+func (service *SecurityPolicyService) getClusterSecurityPolicyGroups(uid string, isDefaultProject bool) []*model.Group {
+	var groups []*model.Group
+	for _, group := range service.clusterGroupStore.GetByIndex(common.TagScopeClusterSecurityPolicyUID, uid) {
+		if group.Path != nil && strings.HasPrefix(*group.Path, "/infra/") == isDefaultProject {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// CreateOrUpdateClusterSecurityPolicy creates or updates the NSX SecurityPolicy, rules, groups and context profiles of
+// the ClusterSecurityPolicy.
+func (service *SecurityPolicyService) CreateOrUpdateClusterSecurityPolicy(ctx context.Context, csp *crdv1alpha1.ClusterSecurityPolicy) error {
+	if !nsxutil.GetDFWLicense() {
+		log.Warn("No DFW license, skip creating ClusterSecurityPolicy.")
+		return nsxutil.RestrictionError{Desc: "no DFW license"}
+	}
+	if !IsVPCEnabled(service) {
+		return nsxutil.RestrictionError{Desc: "ClusterSecurityPolicy is only supported in VPC network"}
+	}
+	vpcInfo, isDefaultProject, err := service.getClusterSecurityPolicyProject()
+	if err != nil {
+		log.Error(err, "Failed to get the NSX Project of ClusterSecurityPolicy", "clusterSecurityPolicy", csp.Name)
+		return err
+	}
+	nsxSecurityPolicy, nsxGroups, nsxContextProfiles, err := service.buildClusterSecurityPolicy(csp, vpcInfo, isDefaultProject)
+	if err != nil {
+		log.Error(err, "Failed to build SecurityPolicy from CR", "clusterSecurityPolicyUID", csp.UID)
+		return err
+	}
+
+	indexScope := common.TagScopeClusterSecurityPolicyUID
+	uid := string(csp.UID)
+	isChanged := true
+	finalSecurityPolicy := nsxSecurityPolicy
+	if existingSecurityPolicies := service.securityPolicyStore.GetByIndex(indexScope, uid); len(existingSecurityPolicies) > 0 {
+		existingSecurityPolicy := existingSecurityPolicies[0]
+		isChanged = common.CompareResource(SecurityPolicyPtrToComparable(existingSecurityPolicy), SecurityPolicyPtrToComparable(nsxSecurityPolicy))
+		if !isChanged {
+			finalSecurityPolicy = existingSecurityPolicy
+		}
+	}
+	finalRules := service.getUpdateRules(service.ruleStore.GetByIndex(indexScope, uid), nsxSecurityPolicy.Rules)
+	finalSecurityPolicy.Rules = finalRules
+	finalGroups := service.getUpdateGroups(service.getClusterSecurityPolicyGroups(uid, isDefaultProject), nsxGroups)
+	finalContextProfiles := service.getUpdateContextProfiles(service.contextProfileStore.GetByIndex(indexScope, uid), nsxContextProfiles)
+
+	if !isChanged && len(finalRules) == 0 && len(finalGroups) == 0 && len(finalContextProfiles) == 0 {
+		log.Info("ClusterSecurityPolicy, rules and groups are not changed, skip updating them", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
+		return nil
+	}
+	if service.PlanModeEnabled(ctx, csp) {
+		return service.planSecurityPolicy(finalSecurityPolicy, nil, nil, finalGroups, finalContextProfiles, isDefaultProject)
+	}
+
+	// The context profiles need to be created/updated before they are referred by the rules, and deleted after the
+	// rules referring them are deleted.
+	staleContextProfiles, changedContextProfiles := service.getStaleUpdateContextProfiles(finalContextProfiles)
+	if err = service.updateNSXContextProfiles(ctx, changedContextProfiles); err != nil {
+		log.Error(err, "Failed to create or update NSX context profiles", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
+		return err
+	}
+	if err = service.patchClusterSecurityPolicy(ctx, finalSecurityPolicy, finalGroups, vpcInfo, isDefaultProject); err != nil {
+		log.Error(err, "Failed to create or update NSX SecurityPolicy of ClusterSecurityPolicy", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
+		return err
+	}
+	if err = service.checkSecurityPolicyRealizationState(ctx, finalSecurityPolicy, *finalSecurityPolicy.Path); err != nil {
+		return err
+	}
+
+	if err = service.applySecurityPolicyStore(finalSecurityPolicy, finalRules, isChanged); err != nil {
+		return err
+	}
+	if err = service.clusterGroupStore.Apply(&finalGroups); err != nil {
+		log.Error(err, "Failed to apply store", "nsxGroups", finalGroups)
+		return err
+	}
+	if err = service.updateNSXContextProfiles(ctx, staleContextProfiles); err != nil {
+		log.Error(err, "Failed to delete NSX context profiles", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
+		return err
+	}
+	if err = service.contextProfileStore.Apply(&finalContextProfiles); err != nil {
+		log.Error(err, "Failed to apply store", "nsxContextProfiles", finalContextProfiles)
+		return err
+	}
+	log.Info("Successfully created or updated NSX SecurityPolicy of ClusterSecurityPolicy", "nsxSecurityPolicy", finalSecurityPolicy)
+	return nil
+}
+
+// patchClusterSecurityPolicy uses one hierarchy API call to create, update or delete the SecurityPolicy with its rules
+// and the groups in the infra of the Project, or in /infra for the Default Project.
+func (service *SecurityPolicyService) patchClusterSecurityPolicy(ctx context.Context, nsxSecurityPolicy *model.SecurityPolicy, nsxGroups []model.Group,
+	vpcInfo *common.VPCResourceInfo, isDefaultProject bool,
+) error {
+	var domainChildren []*data.StructValue
+	if nsxSecurityPolicy != nil {
+		rulesChildren, err := service.wrapRules(nsxSecurityPolicy.Rules)
+		if err != nil {
+			return err
+		}
+		// Wrap a copy of the SecurityPolicy since the rules are moved to the children.
+		securityPolicy := *nsxSecurityPolicy
+		securityPolicy.Rules = nil
+		securityPolicy.Children = rulesChildren
+		securityPolicy.ResourceType = &common.ResourceTypeSecurityPolicy
+		securityPolicyChildren, err := service.wrapSecurityPolicy(&securityPolicy)
+		if err != nil {
+			return err
+		}
+		domainChildren = append(domainChildren, securityPolicyChildren...)
+	}
+	groupsChildren, err := service.wrapGroups(nsxGroups)
+	if err != nil {
+		return err
+	}
+	domainChildren = append(domainChildren, groupsChildren...)
+
+	nsxClient := service.NSXClientWithContext(ctx)
+	if isDefaultProject {
+		infraChildren, err := service.wrapDomainResource(domainChildren, getDefaultProjectDomain())
+		if err != nil {
+			return err
+		}
+		infra, err := service.wrapInfra(infraChildren)
+		if err != nil {
+			return err
+		}
+		err = nsxClient.InfraClient.Patch(*infra, &EnforceRevisionCheckParam)
+		return nsxutil.TransNSXApiError(err)
+	}
+
+	projectInfraChildren, err := service.wrapDomainResource(domainChildren, getVPCProjectDomain())
+	if err != nil {
+		return err
+	}
+	projectInfraChildren, err = service.wrapChildTargetInfra(projectInfraChildren)
+	if err != nil {
+		return err
+	}
+	orgRoot, err := service.wrapOrgRoot(nil, nil, projectInfraChildren, vpcInfo.OrgID, vpcInfo.ProjectID, "")
+	if err != nil {
+		return err
+	}
+	err = nsxClient.OrgRootClient.Patch(*orgRoot, &EnforceRevisionCheckParam)
+	return nsxutil.TransNSXApiError(err)
+}
+
+// DeleteClusterSecurityPolicy deletes the NSX SecurityPolicy, rules, groups and context profiles of the
+// ClusterSecurityPolicy, the NSX Project is got from the paths of the resources in the stores in case the default
+// VPCNetworkConfiguration is changed or deleted.
+func (service *SecurityPolicyService) DeleteClusterSecurityPolicy(ctx context.Context, uid types.UID) error {
+	indexScope := common.TagScopeClusterSecurityPolicyUID
+	if service.PlanModeEnabled(ctx, nil) {
+		return service.planDeleteSecurityPolicy(indexScope, uid)
+	}
+	var nsxSecurityPolicy *model.SecurityPolicy
+	if existingSecurityPolicies := service.securityPolicyStore.GetByIndex(indexScope, string(uid)); len(existingSecurityPolicies) > 0 {
+		nsxSecurityPolicy = existingSecurityPolicies[0]
+		nsxSecurityPolicy.MarkedForDelete = &MarkedForDelete
+		nsxSecurityPolicy.Rules = service.getMarkDeleteRules(service.ruleStore.GetByIndex(indexScope, string(uid)), uid)
+	}
+
+	// The SecurityPolicy is deleted together with the groups in the same infra, the project infra is handled first
+	// since the policy can only refer the groups in its own infra.
+	for _, isDefaultProject := range []bool{false, true} {
+		nsxGroups := service.getMarkDeleteGroups(service.getClusterSecurityPolicyGroups(string(uid), isDefaultProject), uid)
+		var securityPolicy *model.SecurityPolicy
+		if nsxSecurityPolicy != nil && strings.HasPrefix(*nsxSecurityPolicy.Path, "/infra/") == isDefaultProject {
+			securityPolicy = nsxSecurityPolicy
+		}
+		if securityPolicy == nil && len(nsxGroups) == 0 {
+			continue
+		}
+
+		vpcInfo := &common.VPCResourceInfo{}
+		if !isDefaultProject {
+			path := ""
+			if securityPolicy != nil {
+				path = *securityPolicy.Path
+			} else if nsxGroups[0].Path != nil {
+				path = *nsxGroups[0].Path
+			}
+			orgID, projectID, err := common.NSXProjectPathToId(path)
+			if err != nil {
+				log.Error(err, "Failed to get the NSX Project of ClusterSecurityPolicy", "clusterSecurityPolicyUID", uid, "path", path)
+				return err
+			}
+			vpcInfo.OrgID, vpcInfo.ProjectID = orgID, projectID
+		}
+		if err := service.patchClusterSecurityPolicy(ctx, securityPolicy, nsxGroups, vpcInfo, isDefaultProject); err != nil {
+			log.Error(err, "Failed to delete NSX SecurityPolicy of ClusterSecurityPolicy", "clusterSecurityPolicyUID", uid)
+			return err
+		}
+		if securityPolicy != nil {
+			if err := service.applySecurityPolicyStore(securityPolicy, securityPolicy.Rules, true); err != nil {
+				return err
+			}
+		}
+		if err := service.clusterGroupStore.Apply(&nsxGroups); err != nil {
+			log.Error(err, "Failed to apply store", "nsxGroups", nsxGroups)
+			return err
+		}
+	}
+
+	// The context profiles can be deleted once no rules refer them.
+	if err := service.deleteNSXContextProfiles(ctx, indexScope, uid); err != nil {
+		return err
+	}
+	log.Info("Successfully deleted NSX SecurityPolicy, rules and groups of ClusterSecurityPolicy", "clusterSecurityPolicyUID", uid)
+	return nil
+}
+
+// ListClusterSecurityPolicyID returns the UIDs of the ClusterSecurityPolicies which have NSX resources.
+func (service *SecurityPolicyService) ListClusterSecurityPolicyID() sets.Set[string] {
+	return service.getGCSecurityPolicyIDSet(common.TagScopeClusterSecurityPolicyUID)
+}
+
+func (service *SecurityPolicyService) ListClusterSecurityPolicyByName(name string) []*model.SecurityPolicy {
+	var result []*model.SecurityPolicy
+	for uid := range service.securityPolicyStore.ListIndexFuncValues(common.TagScopeClusterSecurityPolicyUID) {
+		for _, securityPolicy := range service.securityPolicyStore.GetByIndex(common.TagScopeClusterSecurityPolicyUID, uid) {
+			if nsxutil.FindTag(securityPolicy.Tags, common.TagScopeClusterSecurityPolicyName) == name {
+				result = append(result, securityPolicy)
+			}
+		}
+	}
+	return result
+}
+
+// cleanupClusterSecurityPolicies deletes the NSX resources of all the ClusterSecurityPolicies on NSX and in local cache.
+func (service *SecurityPolicyService) cleanupClusterSecurityPolicies(ctx context.Context) error {
+	for uid := range service.ListClusterSecurityPolicyID() {
+		if err := service.DeleteClusterSecurityPolicy(ctx, types.UID(uid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+func fakeClusterSecurityPolicy() *crdv1alpha1.ClusterSecurityPolicy {
+	allow := crdv1alpha1.RuleActionAllow
+	drop := crdv1alpha1.RuleActionDrop
+	ingress := crdv1alpha1.RuleDirectionIn
+	egress := crdv1alpha1.RuleDirectionOut
+	return &crdv1alpha1.ClusterSecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "csp-1", UID: "csp-uid"},
+		Spec: crdv1alpha1.ClusterSecurityPolicySpec{
+			Priority: 3,
+			AppliedTo: []crdv1alpha1.ClusterSecurityPolicyTarget{{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			}},
+			Rules: []crdv1alpha1.ClusterSecurityPolicyRule{
+				{
+					Action:    &allow,
+					Direction: &ingress,
+					Sources: []crdv1alpha1.SecurityPolicyPeer{{
+						PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "monitor"}},
+					}},
+					Ports: []crdv1alpha1.SecurityPolicyPort{{Protocol: "TCP", Port: intstr.FromInt32(9090)}},
+				},
+				{
+					Action:    &drop,
+					Direction: &egress,
+					AppliedTo: []crdv1alpha1.ClusterSecurityPolicyTarget{{
+						VMSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "db"}},
+					}},
+					Destinations: []crdv1alpha1.SecurityPolicyPeer{{
+						IPBlocks: []crdv1alpha1.IPBlock{{CIDR: "10.0.0.0/8"}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func fakeClusterSecurityPolicyService(t *testing.T, isDefaultProject bool) (*SecurityPolicyService, *gomonkey.Patches) {
+	s := fakeSecurityPolicyService()
+	s.NSXConfig.EnableVPCNetwork = true
+	s.vpcService = &mock.MockVPCServiceProvider{}
+	s.setUpStore(common.TagScopeSecurityPolicyUID, false)
+
+	patches := gomonkey.ApplyFunc(nsxutil.GetDFWLicense, func() bool { return true })
+	patches.ApplyMethod(reflect.TypeOf(s.vpcService), "GetDefaultNetworkConfig", func(_ *mock.MockVPCServiceProvider) (*crdv1alpha1.VPCNetworkConfiguration, error) {
+		return &crdv1alpha1.VPCNetworkConfiguration{Spec: crdv1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/project-1"}}, nil
+	})
+	patches.ApplyMethod(reflect.TypeOf(s.vpcService), "IsDefaultNSXProject", func(_ *mock.MockVPCServiceProvider, orgID, projectID string) (bool, error) {
+		assert.Equal(t, "default", orgID)
+		assert.Equal(t, "project-1", projectID)
+		return isDefaultProject, nil
+	})
+	patches.ApplyPrivateMethod(reflect.TypeOf(s), "checkSecurityPolicyRealizationState", func(_ *SecurityPolicyService, _ context.Context, _ *model.SecurityPolicy, _ string) error {
+		return nil
+	})
+	return s, patches
+}
+
+func TestBuildClusterSecurityPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		isDefaultProject bool
+		domainPath       string
+	}{
+		{"project", false, "/orgs/default/projects/project-1/infra/domains/default"},
+		{"default project", true, "/infra/domains/default"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, patches := fakeClusterSecurityPolicyService(t, tc.isDefaultProject)
+			defer patches.Reset()
+
+			vpcInfo := &common.VPCResourceInfo{OrgID: "default", ProjectID: "project-1"}
+			policy, groups, profiles, err := s.buildClusterSecurityPolicy(fakeClusterSecurityPolicy(), vpcInfo, tc.isDefaultProject)
+			require.NoError(t, err)
+			assert.Empty(t, profiles)
+			assert.Equal(t, "csp_csp-uid", *policy.Id)
+			assert.Equal(t, tc.domainPath+"/security-policies/csp_csp-uid", *policy.Path)
+			assert.Equal(t, int64(3), *policy.SequenceNumber)
+			assert.Equal(t, []string{tc.domainPath + "/groups/csp_csp-uid_scope"}, policy.Scope)
+			assert.Equal(t, "csp-uid", nsxutil.FindTag(policy.Tags, common.TagScopeClusterSecurityPolicyUID))
+			assert.Equal(t, "", nsxutil.FindTag(policy.Tags, common.TagScopeNamespace))
+
+			// The policy target group, the source group of the ingress rule, the target and destination groups of the
+			// egress rule.
+			require.Len(t, groups, 4)
+			for _, group := range groups {
+				assert.Contains(t, *group.Path, tc.domainPath+"/groups/")
+				// The groups aren't shared, they are loaded by the ClusterSecurityPolicy tag.
+				assert.Equal(t, "", nsxutil.FindTag(group.Tags, common.TagScopeNSXShareCreatedFor))
+				assert.Equal(t, "csp-uid", nsxutil.FindTag(group.Tags, common.TagScopeClusterSecurityPolicyUID))
+			}
+
+			require.Len(t, policy.Rules, 2)
+			ingressRule, egressRule := policy.Rules[0], policy.Rules[1]
+			assert.Equal(t, []string{*groups[1].Path}, ingressRule.SourceGroups)
+			assert.Equal(t, []string{"ANY"}, ingressRule.DestinationGroups)
+			assert.Equal(t, []string{"ANY"}, ingressRule.Scope)
+			assert.Len(t, ingressRule.ServiceEntries, 1)
+			assert.Equal(t, []string{"ANY"}, egressRule.SourceGroups)
+			assert.Equal(t, []string{*groups[2].Path}, egressRule.DestinationGroups)
+			assert.Equal(t, []string{*groups[3].Path}, egressRule.Scope)
+		})
+	}
+}
+
+func TestBuildClusterSecurityPolicy_Invalid(t *testing.T) {
+	s, patches := fakeClusterSecurityPolicyService(t, false)
+	defer patches.Reset()
+	vpcInfo := &common.VPCResourceInfo{OrgID: "default", ProjectID: "project-1"}
+
+	noAppliedTo := fakeClusterSecurityPolicy()
+	noAppliedTo.Spec.AppliedTo = nil
+	_, _, _, err := s.buildClusterSecurityPolicy(noAppliedTo, vpcInfo, false)
+	assert.ErrorContains(t, err, "appliedTo needs to be set in either spec or rules")
+	assert.ErrorAs(t, err, new(*nsxutil.ValidationError))
+
+	emptyTarget := fakeClusterSecurityPolicy()
+	emptyTarget.Spec.AppliedTo = []crdv1alpha1.ClusterSecurityPolicyTarget{{}}
+	_, _, _, err = s.buildClusterSecurityPolicy(emptyTarget, vpcInfo, false)
+	assert.ErrorAs(t, err, new(*nsxutil.ValidationError))
+
+	namedPort := fakeClusterSecurityPolicy()
+	namedPort.Spec.Rules[0].Ports[0].Port = intstr.FromString("http")
+	_, _, _, err = s.buildClusterSecurityPolicy(namedPort, vpcInfo, false)
+	assert.ErrorAs(t, err, &nsxutil.RestrictionError{})
+}
+
+func TestCreateOrUpdateAndDeleteClusterSecurityPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		isDefaultProject bool
+	}{
+		{"project", false},
+		{"default project", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, patches := fakeClusterSecurityPolicyService(t, tc.isDefaultProject)
+			defer patches.Reset()
+			groupStore := s.clusterGroupStore
+
+			csp := fakeClusterSecurityPolicy()
+			require.NoError(t, s.CreateOrUpdateClusterSecurityPolicy(context.TODO(), csp))
+			assert.Len(t, s.securityPolicyStore.ListKeys(), 1)
+			assert.Len(t, s.ruleStore.ListKeys(), 2)
+			assert.Len(t, groupStore.ListKeys(), 4)
+			assert.Len(t, s.getClusterSecurityPolicyGroups("csp-uid", tc.isDefaultProject), 4)
+			assert.Empty(t, s.getClusterSecurityPolicyGroups("csp-uid", !tc.isDefaultProject))
+			assert.Empty(t, s.projectGroupStore.ListKeys())
+			assert.Empty(t, s.infraGroupStore.ListKeys())
+			assert.Empty(t, s.groupStore.ListKeys())
+			assert.Equal(t, []string{"csp-uid"}, s.ListClusterSecurityPolicyID().UnsortedList())
+			assert.Len(t, s.ListClusterSecurityPolicyByName("csp-1"), 1)
+
+			// The rule removed from the spec is deleted with its groups.
+			csp.Spec.Rules = csp.Spec.Rules[:1]
+			require.NoError(t, s.CreateOrUpdateClusterSecurityPolicy(context.TODO(), csp))
+			assert.Len(t, s.ruleStore.ListKeys(), 1)
+			assert.Len(t, groupStore.ListKeys(), 2)
+
+			require.NoError(t, s.cleanupClusterSecurityPolicies(context.TODO()))
+			assert.Empty(t, s.securityPolicyStore.ListKeys())
+			assert.Empty(t, s.ruleStore.ListKeys())
+			assert.Empty(t, groupStore.ListKeys())
+			assert.Empty(t, s.ListClusterSecurityPolicyID())
+		})
+	}
+}
+
+func TestClusterSecurityPolicyDriftSpecs(t *testing.T) {
+	s, patches := fakeClusterSecurityPolicyService(t, false)
+	defer patches.Reset()
+	require.NoError(t, s.CreateOrUpdateClusterSecurityPolicy(context.TODO(), fakeClusterSecurityPolicy()))
+
+	countDrifted := func(spec common.DriftSpec) int {
+		count := 0
+		for _, store := range spec.Stores {
+			for _, obj := range store.List() {
+				if spec.Filter(obj) {
+					count++
+				}
+			}
+		}
+		return count
+	}
+	specs := s.DriftSpecs(common.ResourceTypeClusterSecurityPolicy)
+	require.Len(t, specs, 2)
+	assert.Equal(t, common.ResourceTypeRule, specs[0].ResourceType)
+	assert.Equal(t, 2, countDrifted(specs[0]))
+	assert.Equal(t, common.ResourceTypeGroup, specs[1].ResourceType)
+	assert.Equal(t, 4, countDrifted(specs[1]))
+
+	// The resources of the ClusterSecurityPolicy aren't checked for the SecurityPolicies.
+	for _, spec := range s.DriftSpecs(common.ResourceTypeSecurityPolicy) {
+		assert.Zero(t, countDrifted(spec))
+	}
+}
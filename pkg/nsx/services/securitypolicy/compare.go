@@ -7,7 +7,9 @@ import (
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
 
 type (
@@ -15,6 +17,7 @@ type (
 	Rule           model.Rule
 	Group          model.Group
 	Share          model.Share
+	ContextProfile model.PolicyContextProfile
 )
 
 type Comparable = common.Comparable
@@ -35,14 +38,24 @@ func (share *Share) Key() string {
 	return *share.Id
 }
 
+func (profile *ContextProfile) Key() string {
+	return *profile.Id
+}
+
 func (sp *SecurityPolicy) Value() data.DataValue {
 	s := &SecurityPolicy{
 		Id:             sp.Id,
 		DisplayName:    sp.DisplayName,
 		SequenceNumber: sp.SequenceNumber,
+		Category:       sp.Category,
 		Scope:          sp.Scope,
 		Tags:           sp.Tags,
 	}
+	// The policies created before the category is set are in the NSX default category Application, they are the
+	// same as the policies built with Application so they are not patched once more.
+	if s.Category == nil || *s.Category == "" {
+		s.Category = common.String(string(v1alpha1.SecurityPolicyCategoryApplication))
+	}
 	dataValue, _ := ComparableToSecurityPolicy(s).GetDataValue__()
 	return dataValue
 }
@@ -60,6 +73,15 @@ func (rule *Rule) Value() data.DataValue {
 		ServiceEntries:    rule.ServiceEntries,
 		DestinationGroups: rule.DestinationGroups,
 		SourceGroups:      rule.SourceGroups,
+		Profiles:          rule.Profiles,
+	}
+	// NSX returns the rules without logging as logged false and no tag, they are the same as the rules built without
+	// Logged and Tag.
+	if rule.Logged != nil && *rule.Logged {
+		r.Logged = rule.Logged
+	}
+	if rule.Tag != nil && *rule.Tag != "" {
+		r.Tag = rule.Tag
 	}
 	dataValue, _ := ComparableToRule(r).GetDataValue__()
 	return dataValue
@@ -88,6 +110,17 @@ func (share *Share) Value() data.DataValue {
 	return dataValue
 }
 
+func (profile *ContextProfile) Value() data.DataValue {
+	p := &ContextProfile{
+		Id:          profile.Id,
+		DisplayName: profile.DisplayName,
+		Tags:        profile.Tags,
+		Attributes:  profile.Attributes,
+	}
+	dataValue, _ := ComparableToContextProfile(p).GetDataValue__()
+	return dataValue
+}
+
 func SecurityPolicyPtrToComparable(sp *model.SecurityPolicy) Comparable {
 	return (*SecurityPolicy)(sp)
 }
@@ -144,6 +177,22 @@ func SharesToComparable(shares []model.Share) []Comparable {
 	return res
 }
 
+func ContextProfilesPtrToComparable(profiles []*model.PolicyContextProfile) []Comparable {
+	res := make([]Comparable, 0, len(profiles))
+	for i := range profiles {
+		res = append(res, (*ContextProfile)(profiles[i]))
+	}
+	return res
+}
+
+func ContextProfilesToComparable(profiles []model.PolicyContextProfile) []Comparable {
+	res := make([]Comparable, 0, len(profiles))
+	for i := range profiles {
+		res = append(res, (*ContextProfile)(&(profiles[i])))
+	}
+	return res
+}
+
 func ComparableToSecurityPolicy(sp Comparable) *model.SecurityPolicy {
 	return (*model.SecurityPolicy)(sp.(*SecurityPolicy))
 }
@@ -183,3 +232,60 @@ func ComparableToShares(shares []Comparable) []model.Share {
 func ComparableToShare(share Comparable) *model.Share {
 	return (*model.Share)(share.(*Share))
 }
+
+func ComparableToContextProfiles(profiles []Comparable) []model.PolicyContextProfile {
+	res := make([]model.PolicyContextProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		res = append(res, (model.PolicyContextProfile)(*(profile.(*ContextProfile))))
+	}
+	return res
+}
+
+func ComparableToContextProfile(profile Comparable) *model.PolicyContextProfile {
+	return (*model.PolicyContextProfile)(profile.(*ContextProfile))
+}
+
+// DriftSpecs returns the Rules and Groups created for createdFor, SecurityPolicy, NetworkPolicy or
+// ClusterSecurityPolicy, which are checked for out-of-band changes.
+func (service *SecurityPolicyService) DriftSpecs(createdFor string) []common.DriftSpec {
+	indexScope := common.TagValueScopeSecurityPolicyUID
+	switch createdFor {
+	case common.ResourceTypeNetworkPolicy:
+		indexScope = common.TagScopeNetworkPolicyUID
+	case common.ResourceTypeClusterSecurityPolicy:
+		indexScope = common.TagScopeClusterSecurityPolicyUID
+	}
+	filter := func(obj interface{}) bool {
+		switch o := obj.(type) {
+		case *model.Rule:
+			return nsxutil.FindTag(o.Tags, indexScope) != ""
+		case *model.Group:
+			return nsxutil.FindTag(o.Tags, indexScope) != ""
+		}
+		return false
+	}
+	groupStores := []*common.ResourceStore{&service.groupStore.ResourceStore}
+	for _, store := range []*GroupStore{service.infraGroupStore, service.projectGroupStore, service.clusterGroupStore} {
+		if store != nil {
+			groupStores = append(groupStores, &store.ResourceStore)
+		}
+	}
+	return []common.DriftSpec{
+		{
+			ResourceType: common.ResourceTypeRule,
+			Stores:       []*common.ResourceStore{&service.ruleStore.ResourceStore},
+			ToComparable: func(obj interface{}) Comparable {
+				return (*Rule)(obj.(*model.Rule))
+			},
+			Filter: filter,
+		},
+		{
+			ResourceType: common.ResourceTypeGroup,
+			Stores:       groupStores,
+			ToComparable: func(obj interface{}) Comparable {
+				return (*Group)(obj.(*model.Group))
+			},
+			Filter: filter,
+		},
+	}
+}
@@ -149,6 +149,43 @@ func Test_RulesEqual(t *testing.T) {
 			expectedResult1: []model.Rule{},
 			expectedResult2: []model.Rule{},
 		},
+		{
+			name: "rule-with-logging-disabled",
+			inputRule1: []model.Rule{
+				{
+					Id:     &ruleID0,
+					Logged: common.Bool(false),
+				},
+			},
+			inputRule2: []model.Rule{
+				{
+					Id: &ruleID0,
+				},
+			},
+			expectedResult1: []model.Rule{},
+			expectedResult2: []model.Rule{},
+		},
+		{
+			name: "rule-with-logging-changed",
+			inputRule1: []model.Rule{
+				{
+					Id:     &ruleID0,
+					Logged: common.Bool(true),
+					Tag:    common.String("web"),
+				},
+			},
+			inputRule2: []model.Rule{
+				{
+					Id: &ruleID0,
+				},
+			},
+			expectedResult1: []model.Rule{
+				{
+					Id: &ruleID0,
+				},
+			},
+			expectedResult2: []model.Rule{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -209,6 +246,36 @@ func Test_SecurityPolicyEqual(t *testing.T) {
 			},
 			expectedResult2: false,
 		},
+		{
+			name: "security-policy-without-category-in-default-category",
+			inputPolicy1: &model.SecurityPolicy{
+				Id: &spID,
+			},
+			inputPolicy2: &model.SecurityPolicy{
+				Id:       &spID,
+				Category: String("Application"),
+			},
+			expectedResult: &model.SecurityPolicy{
+				Id:       &spID,
+				Category: String("Application"),
+			},
+			expectedResult2: false,
+		},
+		{
+			name: "security-policy-category-changed",
+			inputPolicy1: &model.SecurityPolicy{
+				Id: &spID,
+			},
+			inputPolicy2: &model.SecurityPolicy{
+				Id:       &spID,
+				Category: String("Environment"),
+			},
+			expectedResult: &model.SecurityPolicy{
+				Id:       &spID,
+				Category: String("Environment"),
+			},
+			expectedResult2: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
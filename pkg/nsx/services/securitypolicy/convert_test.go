@@ -8,6 +8,7 @@ import (
 	"unsafe"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
@@ -40,6 +41,9 @@ func Test_VPCToT1(t *testing.T) {
 			Rules: []crdv1alpha1.SecurityPolicyRule{
 				{
 					Name: "egress_isolation",
+					Ports: []crdv1alpha1.SecurityPolicyPort{
+						{Protocol: crdv1alpha1.ProtocolICMPv6, ICMPType: ptr.To[int32](1), ICMPCode: ptr.To[int32](4)},
+					},
 				},
 			},
 		},
@@ -51,4 +55,5 @@ func Test_VPCToT1(t *testing.T) {
 	assert.Equal(t, "nsx.vmware.com/v1alpha1", output.APIVersion, "APIVersion should be set correctly")
 	assert.Equal(t, (*v1alpha1.SecurityPolicy)(unsafe.Pointer(input)), output, "Conversion should produce the correct type")
 	assert.Equal(t, input.Spec.Rules[0].Name, output.Spec.Rules[0].Name, "Field values should match after conversion")
+	assert.Equal(t, int32(4), *output.Spec.Rules[0].Ports[0].ICMPCode, "ICMP fields should match after conversion")
 }
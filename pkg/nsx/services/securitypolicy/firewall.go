@@ -4,13 +4,16 @@
 package securitypolicy
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -47,6 +50,10 @@ type SecurityPolicyService struct {
 	infraShareStore     *ShareStore
 	projectGroupStore   *GroupStore
 	projectShareStore   *ShareStore
+	// clusterGroupStore is the groups of the ClusterSecurityPolicies, in /infra for the Default Project or in the
+	// infra of the other Projects.
+	clusterGroupStore   *GroupStore
+	contextProfileStore *ContextProfileStore
 	vpcService          common.VPCServiceProvider
 
 	securityPolicyBuilder *common.PolicyTreeBuilder[*model.SecurityPolicy]
@@ -56,6 +63,10 @@ type SecurityPolicyService struct {
 	projectGroupBuilder   *common.PolicyTreeBuilder[*model.Group]
 	infraShareBuilder     *common.PolicyTreeBuilder[*model.Share]
 	projectShareBuilder   *common.PolicyTreeBuilder[*model.Share]
+	// The context profiles are created by the policy tree builders in both reconciliation and cleanup, they are
+	// either in /infra or in the infra of the VPC Project.
+	infraContextProfileBuilder   *common.PolicyTreeBuilder[*model.PolicyContextProfile]
+	projectContextProfileBuilder *common.PolicyTreeBuilder[*model.PolicyContextProfile]
 }
 
 type GroupShare struct {
@@ -90,11 +101,13 @@ func InitializeSecurityPolicy(service common.Service, vpcService common.VPCServi
 	wgDone := make(chan bool)
 	fatalErrors := make(chan error)
 
-	wg.Add(7)
+	wg.Add(9)
 
 	securityPolicyService := &SecurityPolicyService{
 		Service: service,
 	}
+	securityPolicyService.infraContextProfileBuilder, _ = common.PolicyPathInfraContextProfile.NewPolicyTreeBuilder()
+	securityPolicyService.projectContextProfileBuilder, _ = common.PolicyPathProjectContextProfile.NewPolicyTreeBuilder()
 
 	if forCleanUp {
 		securityPolicyService.securityPolicyBuilder, _ = common.PolicyPathVpcSecurityPolicy.NewPolicyTreeBuilder()
@@ -132,11 +145,17 @@ func InitializeSecurityPolicy(service common.Service, vpcService common.VPCServi
 			Tag:   String(common.TagValueShareNotCreated),
 		},
 	}
+	clusterSecurityPolicyTag := []model.Tag{
+		{
+			Scope: String(common.TagScopeClusterSecurityPolicyUID),
+		},
+	}
 
 	go securityPolicyService.InitializeResourceStore(&wg, fatalErrors, ResourceTypeGroup, infraShareTag, securityPolicyService.infraGroupStore)
 	go securityPolicyService.InitializeResourceStore(&wg, fatalErrors, ResourceTypeShare, infraShareTag, securityPolicyService.infraShareStore)
 	go securityPolicyService.InitializeResourceStore(&wg, fatalErrors, ResourceTypeGroup, projectShareTag, securityPolicyService.projectGroupStore)
 	go securityPolicyService.InitializeResourceStore(&wg, fatalErrors, ResourceTypeShare, projectShareTag, securityPolicyService.projectShareStore)
+	go securityPolicyService.InitializeResourceStore(&wg, fatalErrors, ResourceTypeGroup, clusterSecurityPolicyTag, securityPolicyService.clusterGroupStore)
 
 	if IsVPCEnabled(securityPolicyService) {
 		go securityPolicyService.InitializeResourceStore(&wg, fatalErrors, ResourceTypeGroup, notShareTag, securityPolicyService.groupStore)
@@ -145,6 +164,7 @@ func InitializeSecurityPolicy(service common.Service, vpcService common.VPCServi
 	}
 	go securityPolicyService.InitializeResourceStore(&wg, fatalErrors, ResourceTypeSecurityPolicy, nil, securityPolicyService.securityPolicyStore)
 	go securityPolicyService.InitializeResourceStore(&wg, fatalErrors, ResourceTypeRule, nil, securityPolicyService.ruleStore)
+	go securityPolicyService.InitializeResourceStore(&wg, fatalErrors, common.ResourceTypeContextProfile, nil, securityPolicyService.contextProfileStore)
 
 	go func() {
 		wg.Wait()
@@ -161,74 +181,111 @@ func InitializeSecurityPolicy(service common.Service, vpcService common.VPCServi
 	return securityPolicyService, nil
 }
 
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (s *SecurityPolicyService) ResourceStores() map[string]*common.ResourceStore {
+	return map[string]*common.ResourceStore{
+		"securitypolicies": &s.securityPolicyStore.ResourceStore,
+		"rules":            &s.ruleStore.ResourceStore,
+		"groups":           &s.groupStore.ResourceStore,
+		"infragroups":      &s.infraGroupStore.ResourceStore,
+		"infrashares":      &s.infraShareStore.ResourceStore,
+		"projectgroups":    &s.projectGroupStore.ResourceStore,
+		"projectshares":    &s.projectShareStore.ResourceStore,
+		"clustergroups":    &s.clusterGroupStore.ResourceStore,
+		"contextprofiles":  &s.contextProfileStore.ResourceStore,
+	}
+}
+
 func (s *SecurityPolicyService) setUpStore(indexScope string, indexWithVPCPath bool) {
 	vpcResourceIndexWrapper := func(indexers cache.Indexers) cache.Indexers {
 		indexers[indexScope] = indexBySecurityPolicyUID
 		indexers[common.TagScopeNetworkPolicyUID] = indexByNetworkPolicyUID
+		indexers[common.TagScopeClusterSecurityPolicyUID] = indexByClusterSecurityPolicyUID
 		// Note: we can't use indexer `common.IndexByVPCPathFuncKey` with group/rule stores by default because the
 		// caller may not use the object read from NSX to apply on the store which is possibly not set with path or
 		// the parent path. But for cleanup logic, indexWithVPCPath is always set true and the store is re-built from
 		// the NSX resources but not from nsx-operator local calculation.
 		if indexWithVPCPath {
-			indexers[common.IndexByVPCPathFuncKey] = common.IndexByVPCFunc
+			indexers[common.IndexByVPCPathFuncKey] = indexByVPCPath
 		}
 		return indexers
 	}
 
-	s.securityPolicyStore = &SecurityPolicyStore{ResourceStore: common.ResourceStore{
+	s.securityPolicyStore = &SecurityPolicyStore{TypedStore: common.TypedStore[model.SecurityPolicy]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(
 			keyFunc, vpcResourceIndexWrapper(cache.Indexers{
 				common.TagScopeNamespace: indexBySecurityPolicyNamespace,
 			})),
 		BindingType: model.SecurityPolicyBindingType(),
-	}}
-	s.groupStore = &GroupStore{ResourceStore: common.ResourceStore{
+	}}}
+	s.groupStore = &GroupStore{TypedStore: common.TypedStore[model.Group]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, vpcResourceIndexWrapper(cache.Indexers{
 			common.TagScopeRuleID: indexGroupFunc,
 		})),
 		BindingType: model.GroupBindingType(),
-	}}
-	s.ruleStore = &RuleStore{ResourceStore: common.ResourceStore{
+	}}}
+	s.ruleStore = &RuleStore{TypedStore: common.TypedStore[model.Rule]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, vpcResourceIndexWrapper(cache.Indexers{
 			SPIndexByUUIDAndRuleHashFuncKey: indexSPByUUIDAndRuleHash,
 			NPIndexByUUIDAndRuleHashFuncKey: indexNPByUUIDAndRuleHash,
 			common.TagScopeRuleID:           indexRuleFunc,
 		})),
 		BindingType: model.RuleBindingType(),
-	}}
-	s.infraGroupStore = &GroupStore{ResourceStore: common.ResourceStore{
+	}}}
+	s.infraGroupStore = &GroupStore{TypedStore: common.TypedStore[model.Group]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
-			indexScope:                      indexBySecurityPolicyUID,
-			common.TagScopeNetworkPolicyUID: indexByNetworkPolicyUID,
-			common.TagScopeRuleID:           indexGroupFunc,
+			indexScope:                              indexBySecurityPolicyUID,
+			common.TagScopeNetworkPolicyUID:         indexByNetworkPolicyUID,
+			common.TagScopeClusterSecurityPolicyUID: indexByClusterSecurityPolicyUID,
+			common.TagScopeRuleID:                   indexGroupFunc,
 		}),
 		BindingType: model.GroupBindingType(),
-	}}
-	s.infraShareStore = &ShareStore{ResourceStore: common.ResourceStore{
+	}}}
+	s.infraShareStore = &ShareStore{TypedStore: common.TypedStore[model.Share]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
-			indexScope:                      indexBySecurityPolicyUID,
-			common.TagScopeNetworkPolicyUID: indexByNetworkPolicyUID,
+			indexScope:                              indexBySecurityPolicyUID,
+			common.TagScopeNetworkPolicyUID:         indexByNetworkPolicyUID,
+			common.TagScopeClusterSecurityPolicyUID: indexByClusterSecurityPolicyUID,
 		}),
 		BindingType: model.ShareBindingType(),
-	}}
-	s.projectGroupStore = &GroupStore{ResourceStore: common.ResourceStore{
+	}}}
+	s.projectGroupStore = &GroupStore{TypedStore: common.TypedStore[model.Group]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
-			indexScope:                      indexBySecurityPolicyUID,
-			common.TagScopeNetworkPolicyUID: indexByNetworkPolicyUID,
-			common.TagScopeRuleID:           indexGroupFunc,
+			indexScope:                              indexBySecurityPolicyUID,
+			common.TagScopeNetworkPolicyUID:         indexByNetworkPolicyUID,
+			common.TagScopeClusterSecurityPolicyUID: indexByClusterSecurityPolicyUID,
+			common.TagScopeRuleID:                   indexGroupFunc,
 		}),
 		BindingType: model.GroupBindingType(),
-	}}
-	s.projectShareStore = &ShareStore{ResourceStore: common.ResourceStore{
+	}}}
+	s.projectShareStore = &ShareStore{TypedStore: common.TypedStore[model.Share]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
-			indexScope:                      indexBySecurityPolicyUID,
-			common.TagScopeNetworkPolicyUID: indexByNetworkPolicyUID,
+			indexScope:                              indexBySecurityPolicyUID,
+			common.TagScopeNetworkPolicyUID:         indexByNetworkPolicyUID,
+			common.TagScopeClusterSecurityPolicyUID: indexByClusterSecurityPolicyUID,
 		}),
 		BindingType: model.ShareBindingType(),
-	}}
+	}}}
+	s.clusterGroupStore = &GroupStore{TypedStore: common.TypedStore[model.Group]{ResourceStore: common.ResourceStore{
+		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
+			indexScope:                              indexBySecurityPolicyUID,
+			common.TagScopeNetworkPolicyUID:         indexByNetworkPolicyUID,
+			common.TagScopeClusterSecurityPolicyUID: indexByClusterSecurityPolicyUID,
+			common.TagScopeRuleID:                   indexGroupFunc,
+		}),
+		BindingType: model.GroupBindingType(),
+	}}}
+	s.contextProfileStore = &ContextProfileStore{TypedStore: common.TypedStore[model.PolicyContextProfile]{ResourceStore: common.ResourceStore{
+		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
+			indexScope:                              indexBySecurityPolicyUID,
+			common.TagScopeNetworkPolicyUID:         indexByNetworkPolicyUID,
+			common.TagScopeClusterSecurityPolicyUID: indexByClusterSecurityPolicyUID,
+		}),
+		BindingType: model.PolicyContextProfileBindingType(),
+	}}}
 }
 
-func (service *SecurityPolicyService) CreateOrUpdateSecurityPolicy(obj interface{}) error {
+func (service *SecurityPolicyService) CreateOrUpdateSecurityPolicy(ctx context.Context, obj interface{}) error {
 	if !nsxutil.GetDFWLicense() {
 		log.Warn("No DFW license, skip creating SecurityPolicy.")
 		return nsxutil.RestrictionError{Desc: "no DFW license"}
@@ -242,17 +299,17 @@ func (service *SecurityPolicyService) CreateOrUpdateSecurityPolicy(obj interface
 			return err
 		}
 		for _, internalSecurityPolicy := range internalSecurityPolicies {
-			err = service.createOrUpdateVPCSecurityPolicy(internalSecurityPolicy, common.ResourceTypeNetworkPolicy)
+			err = service.createOrUpdateVPCSecurityPolicy(ctx, internalSecurityPolicy, common.ResourceTypeNetworkPolicy)
 			if err != nil {
 				return err
 			}
 		}
 	case *v1alpha1.SecurityPolicy:
 		if IsVPCEnabled(service) {
-			err = service.createOrUpdateVPCSecurityPolicy(obj.(*v1alpha1.SecurityPolicy), common.ResourceTypeSecurityPolicy)
+			err = service.createOrUpdateVPCSecurityPolicy(ctx, obj.(*v1alpha1.SecurityPolicy), common.ResourceTypeSecurityPolicy)
 		} else {
 			// For T1 network SecurityPolicy create/update
-			err = service.createOrUpdateT1SecurityPolicy(obj.(*v1alpha1.SecurityPolicy), common.ResourceTypeSecurityPolicy)
+			err = service.createOrUpdateT1SecurityPolicy(ctx, obj.(*v1alpha1.SecurityPolicy), common.ResourceTypeSecurityPolicy)
 		}
 	}
 	return err
@@ -349,6 +406,10 @@ func (service *SecurityPolicyService) generateSectionForNetworkPolicy(networkPol
 		uid = types.UID(service.BuildNetworkPolicyIsolationPolicyID(string(networkPolicy.UID)))
 		priority = common.PriorityNetworkPolicyIsolationRule
 	}
+	logging, err := service.getNetworkPolicyLogging(networkPolicy)
+	if err != nil {
+		return nil, err
+	}
 	section := &v1alpha1.SecurityPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: networkPolicy.Namespace,
@@ -362,11 +423,34 @@ func (service *SecurityPolicyService) generateSectionForNetworkPolicy(networkPol
 					PodSelector: &networkPolicy.Spec.PodSelector,
 				},
 			},
+			Logging: logging,
 		},
 	}
 	return section, nil
 }
 
+// getNetworkPolicyLogging returns the packet logging of the rules generated from networkPolicy. It is enabled by the
+// annotation AnnotationNetworkPolicyLogging "true" on the NetworkPolicy, or on its Namespace if the NetworkPolicy
+// doesn't have the annotation. nil is returned if neither of them has the annotation.
+func (service *SecurityPolicyService) getNetworkPolicyLogging(networkPolicy *networkingv1.NetworkPolicy) (*bool, error) {
+	value, ok := networkPolicy.Annotations[common.AnnotationNetworkPolicyLogging]
+	if !ok {
+		namespace := &corev1.Namespace{}
+		if err := service.Client.Get(context.TODO(), types.NamespacedName{Name: networkPolicy.Namespace}, namespace); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			log.Error(err, "Failed to get Namespace of NetworkPolicy", "namespace", networkPolicy.Namespace)
+			return nil, err
+		}
+		if value, ok = namespace.Annotations[common.AnnotationNetworkPolicyLogging]; !ok {
+			return nil, nil
+		}
+	}
+	logging := value == "true"
+	return &logging, nil
+}
+
 func (service *SecurityPolicyService) convertNetworkPolicyToInternalSecurityPolicies(networkPolicy *networkingv1.NetworkPolicy) ([]*v1alpha1.SecurityPolicy, error) {
 	securityPolicies := []*v1alpha1.SecurityPolicy{}
 
@@ -487,13 +571,13 @@ func (service *SecurityPolicyService) getFinalVPCShareResources(obj *v1alpha1.Se
 	return finalShares, finalShareGroups
 }
 
-func (service *SecurityPolicyService) getFinalSecurityPolicyResource(obj *v1alpha1.SecurityPolicy, createdFor string, vpcInfo *common.VPCResourceInfo, isDefaultProject bool) (*model.SecurityPolicy, []model.Group, []model.Share, []model.Group, bool, error) {
+func (service *SecurityPolicyService) getFinalSecurityPolicyResource(obj *v1alpha1.SecurityPolicy, createdFor string, vpcInfo *common.VPCResourceInfo, isDefaultProject bool) (*model.SecurityPolicy, []model.Group, []model.Share, []model.Group, []model.PolicyContextProfile, bool, error) {
 	securityPolicyStore, ruleStore, groupStore := service.getSecurityPolicyResourceStores()
 
-	nsxSecurityPolicy, nsxGroups, nsxGroupShares, err := service.buildSecurityPolicy(obj, createdFor, vpcInfo, isDefaultProject)
+	nsxSecurityPolicy, nsxGroups, nsxGroupShares, nsxContextProfiles, err := service.buildSecurityPolicy(obj, createdFor, vpcInfo, isDefaultProject)
 	if err != nil {
 		log.Error(err, "Failed to build SecurityPolicy from CR", "securityPolicyUID", obj.UID)
-		return nil, nil, nil, nil, false, err
+		return nil, nil, nil, nil, nil, false, err
 	}
 
 	if len(nsxSecurityPolicy.Scope) == 0 {
@@ -522,16 +606,19 @@ func (service *SecurityPolicyService) getFinalSecurityPolicyResource(obj *v1alph
 	existingGroups := groupStore.GetByIndex(indexScope, string(obj.UID))
 	finalGroups := service.getUpdateGroups(existingGroups, *nsxGroups)
 
+	existingContextProfiles := service.contextProfileStore.GetByIndex(indexScope, string(obj.UID))
+	finalContextProfiles := service.getUpdateContextProfiles(existingContextProfiles, *nsxContextProfiles)
+
 	if IsVPCEnabled(service) {
 		finalShares, finalShareGroups := service.getFinalVPCShareResources(obj, indexScope, nsxGroupShares, isDefaultProject)
-		return finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, isChanged, nil
+		return finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, finalContextProfiles, isChanged, nil
 	} else {
-		return finalSecurityPolicy, finalGroups, nil, nil, isChanged, nil
+		return finalSecurityPolicy, finalGroups, nil, nil, finalContextProfiles, isChanged, nil
 	}
 }
 
-func (service *SecurityPolicyService) createOrUpdateT1SecurityPolicy(obj *v1alpha1.SecurityPolicy, createdFor string) error {
-	finalSecurityPolicy, finalGroups, _, _, isChanged, err := service.getFinalSecurityPolicyResource(obj, createdFor, nil, false)
+func (service *SecurityPolicyService) createOrUpdateT1SecurityPolicy(ctx context.Context, obj *v1alpha1.SecurityPolicy, createdFor string) error {
+	finalSecurityPolicy, finalGroups, _, _, finalContextProfiles, isChanged, err := service.getFinalSecurityPolicyResource(obj, createdFor, nil, false)
 	if err != nil {
 		log.Error(err, "Failed to get SecurityPolicy resources from CR", "securityPolicyUID", obj.UID)
 		return err
@@ -541,24 +628,36 @@ func (service *SecurityPolicyService) createOrUpdateT1SecurityPolicy(obj *v1alph
 	// so we need to make a copy for the rules store update.
 	finalRules := finalSecurityPolicy.Rules
 
-	if !isChanged && len(finalSecurityPolicy.Rules) == 0 && len(finalGroups) == 0 {
+	if !isChanged && len(finalSecurityPolicy.Rules) == 0 && len(finalGroups) == 0 && len(finalContextProfiles) == 0 {
 		log.Info("SecurityPolicy, rules, groups are not changed, skip updating them", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
 		return nil
 	}
+	if service.PlanModeEnabled(ctx, obj) {
+		return service.planSecurityPolicy(finalSecurityPolicy, finalGroups, nil, nil, finalContextProfiles, false)
+	}
+
+	// The context profiles need to be created/updated before they are referred by the rules, and deleted after the
+	// rules referring them are deleted.
+	staleContextProfiles, changedContextProfiles := service.getStaleUpdateContextProfiles(finalContextProfiles)
+	if err = service.updateNSXContextProfiles(ctx, changedContextProfiles); err != nil {
+		log.Error(err, "Failed to create or update NSX context profiles", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
+		return err
+	}
 
 	infraSecurityPolicy, err := service.WrapHierarchySecurityPolicy(finalSecurityPolicy, finalGroups)
 	if err != nil {
 		log.Error(err, "Failed to wrap SecurityPolicy", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
 		return err
 	}
-	err = service.NSXClient.InfraClient.Patch(*infraSecurityPolicy, &EnforceRevisionCheckParam)
+	nsxClient := service.NSXClientWithContext(ctx)
+	err = nsxClient.InfraClient.Patch(*infraSecurityPolicy, &EnforceRevisionCheckParam)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to create or update SecurityPolicy", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
 		return err
 	}
 	// Get SecurityPolicy from NSX after HAPI call as NSX renders several fields like `path`/`parent_path`.
-	finalGetNSXSecurityPolicy, err := service.NSXClient.SecurityClient.Get(getDomain(service), *finalSecurityPolicy.Id)
+	finalGetNSXSecurityPolicy, err := nsxClient.SecurityClient.Get(getDomain(service), *finalSecurityPolicy.Id)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to get SecurityPolicy", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
@@ -585,11 +684,20 @@ func (service *SecurityPolicyService) createOrUpdateT1SecurityPolicy(obj *v1alph
 		log.Error(err, "Failed to apply store", "nsxGroups", finalGroups)
 		return err
 	}
+	if err = service.updateNSXContextProfiles(ctx, staleContextProfiles); err != nil {
+		log.Error(err, "Failed to delete NSX context profiles", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
+		return err
+	}
+	err = service.contextProfileStore.Apply(&finalContextProfiles)
+	if err != nil {
+		log.Error(err, "Failed to apply store", "nsxContextProfiles", finalContextProfiles)
+		return err
+	}
 	log.Info("Successfully created or updated NSX SecurityPolicy", "nsxSecurityPolicy", finalGetNSXSecurityPolicy)
 	return nil
 }
 
-func (service *SecurityPolicyService) createOrUpdateVPCSecurityPolicy(obj *v1alpha1.SecurityPolicy, createdFor string) error {
+func (service *SecurityPolicyService) createOrUpdateVPCSecurityPolicy(ctx context.Context, obj *v1alpha1.SecurityPolicy, createdFor string) error {
 	var err error
 	var finalGetNSXSecurityPolicy *model.SecurityPolicy
 
@@ -603,7 +711,7 @@ func (service *SecurityPolicyService) createOrUpdateVPCSecurityPolicy(obj *v1alp
 		return err
 	}
 
-	finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, isChanged, err := service.getFinalSecurityPolicyResource(obj, createdFor, vpcInfo, isDefaultProject)
+	finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, finalContextProfiles, isChanged, err := service.getFinalSecurityPolicyResource(obj, createdFor, vpcInfo, isDefaultProject)
 	if err != nil {
 		log.Error(err, "Failed to get SecurityPolicy resources from CR", "securityPolicyUID", obj.UID)
 		return err
@@ -613,14 +721,25 @@ func (service *SecurityPolicyService) createOrUpdateVPCSecurityPolicy(obj *v1alp
 	// so we need to make a copy for the rules store update.
 	finalRules := finalSecurityPolicy.Rules
 
-	if !isChanged && len(finalSecurityPolicy.Rules) == 0 && len(finalGroups) == 0 && len(finalShares) == 0 {
+	if !isChanged && len(finalSecurityPolicy.Rules) == 0 && len(finalGroups) == 0 && len(finalShares) == 0 && len(finalContextProfiles) == 0 {
 		log.Info("SecurityPolicy, rules, groups and shares are not changed, skip updating them", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
 		return nil
 	}
+	if service.PlanModeEnabled(ctx, obj) {
+		return service.planSecurityPolicy(finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, finalContextProfiles, isDefaultProject)
+	}
+
+	// The context profiles need to be created/updated before they are referred by the rules, and deleted after the
+	// rules referring them are deleted.
+	staleContextProfiles, changedContextProfiles := service.getStaleUpdateContextProfiles(finalContextProfiles)
+	if err = service.updateNSXContextProfiles(ctx, changedContextProfiles); err != nil {
+		log.Error(err, "Failed to create or update NSX context profiles", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
+		return err
+	}
 	if !isDefaultProject {
-		finalGetNSXSecurityPolicy, err = service.createOrUpdateNSXSecurityPolicy(finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, vpcInfo)
+		finalGetNSXSecurityPolicy, err = service.createOrUpdateNSXSecurityPolicy(ctx, finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, vpcInfo)
 	} else {
-		finalGetNSXSecurityPolicy, err = service.createOrUpdateNSXSecurityPolicyForDefaultProject(finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, vpcInfo)
+		finalGetNSXSecurityPolicy, err = service.createOrUpdateNSXSecurityPolicyForDefaultProject(ctx, finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, vpcInfo)
 	}
 	if err != nil {
 		return err
@@ -634,24 +753,40 @@ func (service *SecurityPolicyService) createOrUpdateVPCSecurityPolicy(obj *v1alp
 	if err != nil {
 		return err
 	}
+	if err = service.updateNSXContextProfiles(ctx, staleContextProfiles); err != nil {
+		log.Error(err, "Failed to delete NSX context profiles", "nsxSecurityPolicyId", finalSecurityPolicy.Id)
+		return err
+	}
+	err = service.contextProfileStore.Apply(&finalContextProfiles)
+	if err != nil {
+		log.Error(err, "Failed to apply store", "nsxContextProfiles", finalContextProfiles)
+		return err
+	}
 
 	log.Info("Successfully created or updated NSX SecurityPolicy resources in VPC", "nsxSecurityPolicy", *finalGetNSXSecurityPolicy)
 	return nil
 }
 
-func (service *SecurityPolicyService) DeleteSecurityPolicy(spUid types.UID, isGC bool, createdFor string) error {
+func (service *SecurityPolicyService) DeleteSecurityPolicy(ctx context.Context, spUid types.UID, isGC bool, createdFor string) error {
+	if service.PlanModeEnabled(ctx, nil) {
+		indexScope := common.TagValueScopeSecurityPolicyUID
+		if IsVPCEnabled(service) && createdFor == common.ResourceTypeNetworkPolicy {
+			indexScope = common.TagScopeNetworkPolicyUID
+		}
+		return service.planDeleteSecurityPolicy(indexScope, spUid)
+	}
 	var err error
 	// For VPC network, SecurityPolicy normal deletion, GC deletion and cleanup
 	if IsVPCEnabled(service) {
-		err = service.deleteVPCSecurityPolicy(spUid, isGC, createdFor)
+		err = service.deleteVPCSecurityPolicy(ctx, spUid, isGC, createdFor)
 	} else {
 		// For T1 network, SecurityPolicy normal deletion and GC deletion
-		err = service.deleteT1SecurityPolicy(spUid)
+		err = service.deleteT1SecurityPolicy(ctx, spUid)
 	}
 	return err
 }
 
-func (service *SecurityPolicyService) deleteT1SecurityPolicy(spUid types.UID) error {
+func (service *SecurityPolicyService) deleteT1SecurityPolicy(ctx context.Context, spUid types.UID) error {
 	var nsxSecurityPolicy *model.SecurityPolicy
 	var err error
 
@@ -665,7 +800,7 @@ func (service *SecurityPolicyService) deleteT1SecurityPolicy(spUid types.UID) er
 	existingSecurityPolices := securityPolicyStore.GetByIndex(indexScope, string(spUid))
 	if len(existingSecurityPolices) == 0 {
 		log.Info("NSX SecurityPolicy is not found in store, skip deleting it", "nsxSecurityPolicyUID", spUid)
-		return nil
+		return service.deleteNSXContextProfiles(ctx, indexScope, spUid)
 	}
 	nsxSecurityPolicy = existingSecurityPolices[0]
 	if nsxSecurityPolicy.Path == nil {
@@ -693,7 +828,7 @@ func (service *SecurityPolicyService) deleteT1SecurityPolicy(spUid types.UID) er
 		log.Error(err, "Failed to wrap SecurityPolicy", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
 		return err
 	}
-	err = service.NSXClient.InfraClient.Patch(*infraSecurityPolicy, &EnforceRevisionCheckParam)
+	err = service.NSXClientWithContext(ctx).InfraClient.Patch(*infraSecurityPolicy, &EnforceRevisionCheckParam)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to delete SecurityPolicy", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
@@ -715,12 +850,15 @@ func (service *SecurityPolicyService) deleteT1SecurityPolicy(spUid types.UID) er
 		log.Error(err, "Failed to apply store", "nsxGroups", nsxGroups)
 		return err
 	}
+	if err = service.deleteNSXContextProfiles(ctx, indexScope, spUid); err != nil {
+		return err
+	}
 
 	log.Info("Successfully deleted NSX SecurityPolicy", "nsxSecurityPolicy", finalSecurityPolicyCopy)
 	return nil
 }
 
-func (service *SecurityPolicyService) deleteVPCSecurityPolicy(spUID types.UID, isGC bool, createdFor string) error {
+func (service *SecurityPolicyService) deleteVPCSecurityPolicy(ctx context.Context, spUID types.UID, isGC bool, createdFor string) error {
 	indexScope := common.TagValueScopeSecurityPolicyUID
 	if createdFor == common.ResourceTypeNetworkPolicy {
 		indexScope = common.TagScopeNetworkPolicyUID
@@ -737,6 +875,13 @@ func (service *SecurityPolicyService) deleteVPCSecurityPolicy(spUID types.UID, i
 		return err
 	}
 
+	// The context profiles aren't in VPC, they can be deleted without vpcInfo once no rules refer them.
+	if nsxSecurityPolicy == nil {
+		if err = service.deleteNSXContextProfiles(ctx, indexScope, spUID); err != nil {
+			return err
+		}
+	}
+
 	isDefaultProject := false
 	// For GC case, it usually will follow the normal deletion process.
 	// Infra shares and groups also could be GC with NSX security policy together if security policy is found in store.
@@ -764,7 +909,7 @@ func (service *SecurityPolicyService) deleteVPCSecurityPolicy(spUID types.UID, i
 	}
 
 	if nsxSecurityPolicy != nil {
-		err = service.deleteNSXSecurityPolicy(nsxSecurityPolicy, &vpcInfo)
+		err = service.deleteNSXSecurityPolicy(ctx, nsxSecurityPolicy, &vpcInfo)
 		if err != nil {
 			log.Error(err, "Failed to delete NSX SecurityPolicy and rules in VPC", "nsxSecurityPolicyUID", spUID)
 			return err
@@ -774,12 +919,15 @@ func (service *SecurityPolicyService) deleteVPCSecurityPolicy(spUID types.UID, i
 			return err
 		}
 		log.Info("Successfully deleted NSX SecurityPolicy and rules only", "nsxSecurityPolicyUID", spUID)
+		if err = service.deleteNSXContextProfiles(ctx, indexScope, spUID); err != nil {
+			return err
+		}
 	}
 
 	if !isDefaultProject {
-		err = service.deleteNSXSecurityPolicyGroupShare(nsxGroups, nsxProjectShares, nsxProjectShareGroups, &vpcInfo)
+		err = service.deleteNSXSecurityPolicyGroupShare(ctx, nsxGroups, nsxProjectShares, nsxProjectShareGroups, &vpcInfo)
 	} else {
-		err = service.deleteNSXSecurityPolicyGroupShareForDefaultProject(nsxGroups, nsxInfraShares, nsxInfraShareGroups, &vpcInfo)
+		err = service.deleteNSXSecurityPolicyGroupShareForDefaultProject(ctx, nsxGroups, nsxInfraShares, nsxInfraShareGroups, &vpcInfo)
 	}
 	// Ignore error here to make groups/shares to be deleted in GC.
 	// Because NSX SecurityPolicy is deleted, it's unable to get SecurityPolicyUID from SecurityPolicyStore for fetching groups/shares even by requeuing the error.
@@ -878,6 +1026,18 @@ func (service *SecurityPolicyService) getUpdateShares(existingShares []*model.Sh
 	return finalShares
 }
 
+func (service *SecurityPolicyService) getUpdateContextProfiles(existingProfiles []*model.PolicyContextProfile, expectedProfiles []model.PolicyContextProfile) []model.PolicyContextProfile {
+	changed, stale := common.CompareResources(ContextProfilesPtrToComparable(existingProfiles), ContextProfilesToComparable(expectedProfiles))
+	changedProfiles, staleProfiles := ComparableToContextProfiles(changed), ComparableToContextProfiles(stale)
+	for i := len(staleProfiles) - 1; i >= 0; i-- {
+		staleProfiles[i].MarkedForDelete = &MarkedForDelete
+	}
+	finalProfiles := make([]model.PolicyContextProfile, 0)
+	finalProfiles = append(finalProfiles, staleProfiles...)
+	finalProfiles = append(finalProfiles, changedProfiles...)
+	return finalProfiles
+}
+
 func (service *SecurityPolicyService) getMarkDeleteGroups(existingGroups []*model.Group, sp types.UID) []model.Group {
 	deleteGroups := make([]model.Group, 0)
 
@@ -953,6 +1113,17 @@ func (service *SecurityPolicyService) getStaleUpdateGroups(nsxGroups []model.Gro
 	return finalStaleGroups, finalChangedGroups
 }
 
+func (service *SecurityPolicyService) getStaleUpdateContextProfiles(nsxProfiles []model.PolicyContextProfile) (staleProfiles []model.PolicyContextProfile, updatedProfiles []model.PolicyContextProfile) {
+	for i := range nsxProfiles {
+		if nsxProfiles[i].MarkedForDelete != nil && *nsxProfiles[i].MarkedForDelete {
+			staleProfiles = append(staleProfiles, nsxProfiles[i])
+		} else {
+			updatedProfiles = append(updatedProfiles, nsxProfiles[i])
+		}
+	}
+	return staleProfiles, updatedProfiles
+}
+
 func (service *SecurityPolicyService) markSecurityPolicyResourcesDelete(indexScope string, spUID types.UID) (
 	*model.SecurityPolicy, []model.Group, []model.Share, []model.Group, []model.Share, []model.Group, common.VPCResourceInfo, error,
 ) {
@@ -1010,7 +1181,7 @@ func (service *SecurityPolicyService) markSecurityPolicyResourcesDelete(indexSco
 }
 
 // createOrUpdateNSXSecurityPolicy uses hierarchy API call to create/update SecurityPolicy on the whole resource tree for non-Default Project.
-func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicy(nsxSecurityPolicy *model.SecurityPolicy, nsxGroups []model.Group,
+func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicy(ctx context.Context, nsxSecurityPolicy *model.SecurityPolicy, nsxGroups []model.Group,
 	nsxShares []model.Share, nsxShareGroups []model.Group, vpcInfo *common.VPCResourceInfo,
 ) (*model.SecurityPolicy, error) {
 	var err error
@@ -1032,7 +1203,8 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicy(nsxSecurit
 		return nil, err
 	}
 	// Create/update SecurityPolicy together with groups, rules under VPC level and project groups, shares.
-	err = service.NSXClient.OrgRootClient.Patch(*orgRoot, &EnforceRevisionCheckParam)
+	nsxClient := service.NSXClientWithContext(ctx)
+	err = nsxClient.OrgRootClient.Patch(*orgRoot, &EnforceRevisionCheckParam)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to create or update NSX SecurityPolicy in VPC", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
@@ -1040,7 +1212,7 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicy(nsxSecurit
 	}
 
 	// Get SecurityPolicy from NSX after HAPI call as NSX renders several fields like `path`/`parent_path`.
-	nsxGetSecurityPolicy, err := service.NSXClient.VPCSecurityClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSecurityPolicy.Id)
+	nsxGetSecurityPolicy, err := nsxClient.VPCSecurityClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSecurityPolicy.Id)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to get NSX SecurityPolicy in VPC", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
@@ -1048,7 +1220,7 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicy(nsxSecurit
 	}
 
 	// Check SecurityPolicy realization state
-	err = service.checkSecurityPolicyRealizationState(&nsxGetSecurityPolicy, *(nsxGetSecurityPolicy.Path))
+	err = service.checkSecurityPolicyRealizationState(ctx, &nsxGetSecurityPolicy, *(nsxGetSecurityPolicy.Path))
 	if err != nil {
 		return nil, err
 	}
@@ -1057,13 +1229,14 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicy(nsxSecurit
 }
 
 // createOrUpdateNSXSecurityPolicyForDefaultProject uses hierarchy API call to create/update SecurityPolicy on the whole resource tree for Default Project.
-func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicyForDefaultProject(nsxSecurityPolicy *model.SecurityPolicy, nsxGroups []model.Group,
+func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicyForDefaultProject(ctx context.Context, nsxSecurityPolicy *model.SecurityPolicy, nsxGroups []model.Group,
 	nsxShares []model.Share, nsxShareGroups []model.Group, vpcInfo *common.VPCResourceInfo,
 ) (*model.SecurityPolicy, error) {
 	var err error
 	var infraResource *model.Infra
 	var projectInfraResource []*data.StructValue
 	nsxGetSecurityPolicy := model.SecurityPolicy{}
+	nsxClient := service.NSXClientWithContext(ctx)
 
 	finalStaleShares, finalChangedShares := service.getStaleUpdateShares(nsxShares)
 	finalStaleShareGroups, finalChangedShareGroups := service.getStaleUpdateGroups(nsxShareGroups)
@@ -1077,7 +1250,7 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicyForDefaultP
 			return nil, err
 		}
 
-		err = service.NSXClient.InfraClient.Patch(*infraResource, &EnforceRevisionCheckParam)
+		err = nsxClient.InfraClient.Patch(*infraResource, &EnforceRevisionCheckParam)
 		err = nsxutil.TransNSXApiError(err)
 		if err != nil {
 			log.Error(err, "Failed to create or update NSX infra resource", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
@@ -1093,7 +1266,7 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicyForDefaultP
 	}
 
 	// Create/update SecurityPolicy together with groups, rules under VPC level.
-	err = service.NSXClient.OrgRootClient.Patch(*orgRoot, &EnforceRevisionCheckParam)
+	err = nsxClient.OrgRootClient.Patch(*orgRoot, &EnforceRevisionCheckParam)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to create or update SecurityPolicy in VPC", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
@@ -1108,7 +1281,7 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicyForDefaultP
 			log.Error(err, "Failed to wrap NSX infra stale groups and shares", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
 			return nil, err
 		}
-		err = service.NSXClient.InfraClient.Patch(*infraResource, &EnforceRevisionCheckParam)
+		err = nsxClient.InfraClient.Patch(*infraResource, &EnforceRevisionCheckParam)
 		err = nsxutil.TransNSXApiError(err)
 		if err != nil {
 			log.Error(err, "Failed to delete NSX infra Resource", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
@@ -1117,7 +1290,7 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicyForDefaultP
 	}
 
 	// Get SecurityPolicy from NSX after HAPI call as NSX renders several fields like `path`/`parent_path`.
-	nsxGetSecurityPolicy, err = service.NSXClient.VPCSecurityClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSecurityPolicy.Id)
+	nsxGetSecurityPolicy, err = nsxClient.VPCSecurityClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSecurityPolicy.Id)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to get SecurityPolicy in VPC", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
@@ -1125,7 +1298,7 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicyForDefaultP
 	}
 
 	// Check SecurityPolicy realization state
-	err = service.checkSecurityPolicyRealizationState(&nsxGetSecurityPolicy, *(nsxGetSecurityPolicy.Path))
+	err = service.checkSecurityPolicyRealizationState(ctx, &nsxGetSecurityPolicy, *(nsxGetSecurityPolicy.Path))
 	if err != nil {
 		return nil, err
 	}
@@ -1134,11 +1307,11 @@ func (service *SecurityPolicyService) createOrUpdateNSXSecurityPolicyForDefaultP
 }
 
 // deleteNSXSecurityPolicy deletes NSX SecurityPolicy, rules and the groups/shares for both NSX Default Project and non-Default Project.
-func (service *SecurityPolicyService) deleteNSXSecurityPolicy(nsxSecurityPolicy *model.SecurityPolicy, vpcInfo *common.VPCResourceInfo) error {
+func (service *SecurityPolicyService) deleteNSXSecurityPolicy(ctx context.Context, nsxSecurityPolicy *model.SecurityPolicy, vpcInfo *common.VPCResourceInfo) error {
 	var err error
 
 	// Delete NSX SecurityPolicy and rules only.
-	err = service.NSXClient.VPCSecurityClient.Delete(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSecurityPolicy.Id)
+	err = service.NSXClientWithContext(ctx).VPCSecurityClient.Delete(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSecurityPolicy.Id)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to delete NSX SecurityPolicy in VPC", "nsxSecurityPolicyId", nsxSecurityPolicy.Id)
@@ -1149,7 +1322,7 @@ func (service *SecurityPolicyService) deleteNSXSecurityPolicy(nsxSecurityPolicy
 }
 
 // deleteNSXSecurityPolicyGroupShare deletes NSX SecurityPolicy associated the groups/shares for non-Default Project.
-func (service *SecurityPolicyService) deleteNSXSecurityPolicyGroupShare(nsxGroups []model.Group,
+func (service *SecurityPolicyService) deleteNSXSecurityPolicyGroupShare(ctx context.Context, nsxGroups []model.Group,
 	nsxShares []model.Share, nsxShareGroups []model.Group, vpcInfo *common.VPCResourceInfo,
 ) error {
 	var err error
@@ -1171,7 +1344,7 @@ func (service *SecurityPolicyService) deleteNSXSecurityPolicyGroupShare(nsxGroup
 		return err
 	}
 	// Delete groups under VPC level together with project groups, shares.
-	err = service.NSXClient.OrgRootClient.Patch(*orgRoot, &EnforceRevisionCheckParam)
+	err = service.NSXClientWithContext(ctx).OrgRootClient.Patch(*orgRoot, &EnforceRevisionCheckParam)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to delete NSX groups and shares in VPC")
@@ -1182,10 +1355,11 @@ func (service *SecurityPolicyService) deleteNSXSecurityPolicyGroupShare(nsxGroup
 }
 
 // deleteNSXSecurityPolicyGroupShareForDefaultProject deletes NSX SecurityPolicy associated the groups/shares for Default Project.
-func (service *SecurityPolicyService) deleteNSXSecurityPolicyGroupShareForDefaultProject(nsxGroups []model.Group,
+func (service *SecurityPolicyService) deleteNSXSecurityPolicyGroupShareForDefaultProject(ctx context.Context, nsxGroups []model.Group,
 	nsxShares []model.Share, nsxShareGroups []model.Group, vpcInfo *common.VPCResourceInfo,
 ) error {
 	var projectInfraResource []*data.StructValue
+	nsxClient := service.NSXClientWithContext(ctx)
 
 	if len(nsxGroups) != 0 {
 		// Wrap VPC level groups into project child infra.
@@ -1196,7 +1370,7 @@ func (service *SecurityPolicyService) deleteNSXSecurityPolicyGroupShareForDefaul
 		}
 
 		// Delete groups under VPC level.
-		err = service.NSXClient.OrgRootClient.Patch(*orgRoot, &EnforceRevisionCheckParam)
+		err = nsxClient.OrgRootClient.Patch(*orgRoot, &EnforceRevisionCheckParam)
 		err = nsxutil.TransNSXApiError(err)
 		if err != nil {
 			log.Error(err, "Failed to delete NSX groups in VPC")
@@ -1212,7 +1386,7 @@ func (service *SecurityPolicyService) deleteNSXSecurityPolicyGroupShareForDefaul
 			return err
 		}
 		// Delete infra groups and shares.
-		err = service.NSXClient.InfraClient.Patch(*infraResource, &EnforceRevisionCheckParam)
+		err = nsxClient.InfraClient.Patch(*infraResource, &EnforceRevisionCheckParam)
 		err = nsxutil.TransNSXApiError(err)
 		if err != nil {
 			log.Error(err, "Failed to delete NSX infra groups and shares")
@@ -1222,6 +1396,147 @@ func (service *SecurityPolicyService) deleteNSXSecurityPolicyGroupShareForDefaul
 	return nil
 }
 
+// splitContextProfiles splits the context profiles into the ones in the infra of the VPC Projects and the ones in /infra,
+// which are created by the different policy tree builders.
+func splitContextProfiles(profiles []*model.PolicyContextProfile) (projectProfiles []*model.PolicyContextProfile, infraProfiles []*model.PolicyContextProfile) {
+	for _, profile := range profiles {
+		if profile.Path != nil && strings.HasPrefix(*profile.Path, "/orgs/") {
+			projectProfiles = append(projectProfiles, profile)
+		} else {
+			infraProfiles = append(infraProfiles, profile)
+		}
+	}
+	return projectProfiles, infraProfiles
+}
+
+// updateNSXContextProfiles creates, updates or deletes the context profiles of the FQDN rules, they are in /infra for
+// T1 network and the VPC Default Project, or in the infra of the VPC Project.
+func (service *SecurityPolicyService) updateNSXContextProfiles(ctx context.Context, nsxProfiles []model.PolicyContextProfile) error {
+	profiles := make([]*model.PolicyContextProfile, 0, len(nsxProfiles))
+	for i := range nsxProfiles {
+		profiles = append(profiles, &nsxProfiles[i])
+	}
+	projectProfiles, infraProfiles := splitContextProfiles(profiles)
+	if err := service.projectContextProfileBuilder.UpdateMultipleResourcesOnNSX(ctx, projectProfiles, service.NSXClient); err != nil {
+		return err
+	}
+	return service.infraContextProfileBuilder.UpdateMultipleResourcesOnNSX(ctx, infraProfiles, service.NSXClient)
+}
+
+// deleteNSXContextProfiles deletes the context profiles created for the SecurityPolicy or NetworkPolicy of uid, it must
+// be called after the rules referring them are deleted.
+func (service *SecurityPolicyService) deleteNSXContextProfiles(ctx context.Context, indexScope string, uid types.UID) error {
+	existingProfiles := service.contextProfileStore.GetByIndex(indexScope, string(uid))
+	if len(existingProfiles) == 0 {
+		return nil
+	}
+	nsxProfiles := make([]model.PolicyContextProfile, 0, len(existingProfiles))
+	for _, profile := range existingProfiles {
+		nsxProfile := *profile
+		nsxProfile.MarkedForDelete = &MarkedForDelete
+		nsxProfiles = append(nsxProfiles, nsxProfile)
+	}
+	if err := service.updateNSXContextProfiles(ctx, nsxProfiles); err != nil {
+		log.Error(err, "Failed to delete NSX context profiles", "securityPolicyUID", uid)
+		return err
+	}
+	if err := service.contextProfileStore.Apply(&nsxProfiles); err != nil {
+		log.Error(err, "Failed to apply store", "nsxContextProfiles", nsxProfiles)
+		return err
+	}
+	return nil
+}
+
+// planSecurityPolicy renders the changes of the SecurityPolicy and its rules, groups, shares and context profiles
+// against the stores instead of applying them to NSX in plan mode.
+func (service *SecurityPolicyService) planSecurityPolicy(nsxSecurityPolicy *model.SecurityPolicy, nsxGroups []model.Group, nsxShares []model.Share, nsxShareGroups []model.Group,
+	nsxContextProfiles []model.PolicyContextProfile, isDefaultProject bool,
+) error {
+	securityPolicyStore, ruleStore, groupStore := service.getSecurityPolicyResourceStores()
+	infraGroupStore, infraShareStore, projectGroupStore, projectShareStore := service.getVPCShareResourceStores()
+	shareGroupStore, shareStore := projectGroupStore, projectShareStore
+	if isDefaultProject {
+		shareGroupStore, shareStore = infraGroupStore, infraShareStore
+	}
+	// The groups of the ClusterSecurityPolicy are in the infra like the shared groups, but they have their own store.
+	if nsxutil.FindTag(nsxSecurityPolicy.Tags, common.TagScopeClusterSecurityPolicyUID) != "" {
+		shareGroupStore = service.clusterGroupStore
+	}
+
+	plan := &common.Plan{}
+	// The rules are planned against the rule store, the SecurityPolicy store has no rules.
+	securityPolicy := *nsxSecurityPolicy
+	securityPolicy.Rules = nil
+	if err := plan.Add(&securityPolicyStore.ResourceStore, &securityPolicy); err != nil {
+		return err
+	}
+	for i := range nsxSecurityPolicy.Rules {
+		if err := plan.Add(&ruleStore.ResourceStore, &nsxSecurityPolicy.Rules[i]); err != nil {
+			return err
+		}
+	}
+	for i := range nsxGroups {
+		if err := plan.Add(&groupStore.ResourceStore, &nsxGroups[i]); err != nil {
+			return err
+		}
+	}
+	for i := range nsxShareGroups {
+		if err := plan.Add(&shareGroupStore.ResourceStore, &nsxShareGroups[i]); err != nil {
+			return err
+		}
+	}
+	for i := range nsxShares {
+		if err := plan.Add(&shareStore.ResourceStore, &nsxShares[i]); err != nil {
+			return err
+		}
+	}
+	for i := range nsxContextProfiles {
+		if err := plan.Add(&service.contextProfileStore.ResourceStore, &nsxContextProfiles[i]); err != nil {
+			return err
+		}
+	}
+	return plan.Err()
+}
+
+// planDeleteSecurityPolicy renders the deletion of the SecurityPolicy and its rules, groups, shares and context
+// profiles found by indexScope in the stores in plan mode, the resources in the stores aren't changed.
+func (service *SecurityPolicyService) planDeleteSecurityPolicy(indexScope string, uid types.UID) error {
+	securityPolicyStore, ruleStore, groupStore := service.getSecurityPolicyResourceStores()
+	infraGroupStore, infraShareStore, projectGroupStore, projectShareStore := service.getVPCShareResourceStores()
+
+	plan := &common.Plan{}
+	for _, securityPolicy := range securityPolicyStore.GetByIndex(indexScope, string(uid)) {
+		if err := plan.Delete(&securityPolicyStore.ResourceStore, securityPolicy); err != nil {
+			return err
+		}
+	}
+	for _, rule := range ruleStore.GetByIndex(indexScope, string(uid)) {
+		if err := plan.Delete(&ruleStore.ResourceStore, rule); err != nil {
+			return err
+		}
+	}
+	for _, store := range []*GroupStore{groupStore, projectGroupStore, infraGroupStore, service.clusterGroupStore} {
+		for _, group := range store.GetByIndex(indexScope, string(uid)) {
+			if err := plan.Delete(&store.ResourceStore, group); err != nil {
+				return err
+			}
+		}
+	}
+	for _, store := range []*ShareStore{projectShareStore, infraShareStore} {
+		for _, share := range store.GetByIndex(indexScope, string(uid)) {
+			if err := plan.Delete(&store.ResourceStore, share); err != nil {
+				return err
+			}
+		}
+	}
+	for _, profile := range service.contextProfileStore.GetByIndex(indexScope, string(uid)) {
+		if err := plan.Delete(&service.contextProfileStore.ResourceStore, profile); err != nil {
+			return err
+		}
+	}
+	return plan.Err()
+}
+
 func (service *SecurityPolicyService) applySecurityPolicyStore(nsxSecurityPolicy *model.SecurityPolicy, nsxRules []model.Rule, isChanged bool) error {
 	var err error
 	securityPolicyStore, ruleStore, _ := service.getSecurityPolicyResourceStores()
@@ -1325,8 +1640,12 @@ func (service *SecurityPolicyService) getGCSecurityPolicyIDSet(indexScope string
 	// List SecurityPolicyID to which share resources are associated in infra share/group store
 	infraShareSet := service.infraShareStore.ListIndexFuncValues(indexScope)
 	infraGroupSet := service.infraGroupStore.ListIndexFuncValues(indexScope)
+	// List ClusterSecurityPolicyID to which groups are associated in cluster group store
+	clusterGroupSet := service.clusterGroupStore.ListIndexFuncValues(indexScope)
+	// List SecurityPolicyID to which context profiles are associated in context profile store
+	contextProfileSet := service.contextProfileStore.ListIndexFuncValues(indexScope)
 
-	return groupSet.Union(policySet).Union(projectShareSet).Union(projectGroupSet).Union(infraShareSet).Union(infraGroupSet)
+	return groupSet.Union(policySet).Union(projectShareSet).Union(projectGroupSet).Union(infraShareSet).Union(infraGroupSet).Union(clusterGroupSet).Union(contextProfileSet)
 }
 
 func (service *SecurityPolicyService) getVPCInfo(spNameSpace string) (*common.VPCResourceInfo, error) {
@@ -1339,10 +1658,10 @@ func (service *SecurityPolicyService) getVPCInfo(spNameSpace string) (*common.VP
 	return &vpcInfo[0], nil
 }
 
-func (service *SecurityPolicyService) checkSecurityPolicyRealizationState(sp *model.SecurityPolicy, spPath string) error {
+func (service *SecurityPolicyService) checkSecurityPolicyRealizationState(ctx context.Context, sp *model.SecurityPolicy, spPath string) error {
 	log.Trace("Check NSX SecurityPolicy realization state", "nsxSecurityPolicyId", *sp.Id)
 	realizeService := realizestate.InitializeRealizeState(service.Service)
-	if err := realizeService.CheckRealizeState(util.NSXTRealizeRetry, spPath, []string{}); err != nil {
+	if err := realizeService.CheckRealizeState(ctx, util.NSXTRealizeRetry, spPath, []string{}); err != nil {
 		log.Error(err, "Failed to check NSX SecurityPolicy realization state", "nsxSecurityPolicyId", *sp.Id)
 		if nsxutil.IsRealizeStateError(err) {
 			log.Error(err, "The created SecurityPolicy is in error realization state", "nsxSecurityPolicyId", *sp.Id)
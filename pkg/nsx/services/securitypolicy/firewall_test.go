@@ -4,6 +4,7 @@
 package securitypolicy
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/openlyinc/pointy"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	corev1 "k8s.io/api/core/v1"
@@ -22,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
@@ -931,6 +934,40 @@ func Test_GetUpdateShares(t *testing.T) {
 	}
 }
 
+func Test_GetUpdateContextProfiles(t *testing.T) {
+	newProfile := func(id, path string, fqdns ...string) model.PolicyContextProfile {
+		return model.PolicyContextProfile{
+			Id:   String(id),
+			Path: String(path),
+			Tags: []model.Tag{{Scope: String(tagScopeSecurityPolicyUID), Tag: String("11111")}},
+			Attributes: []model.PolicyAttributes{{
+				Key:      String(model.PolicyAttributes_KEY_DOMAIN_NAME),
+				Datatype: String(model.PolicyAttributes_DATATYPE_STRING),
+				Value:    fqdns,
+			}},
+		}
+	}
+	p1 := newProfile("rule1_fqdn", "/infra/context-profiles/rule1_fqdn", "www.example.com")
+	p2 := newProfile("rule2_fqdn", "/orgs/default/projects/project-1/infra/context-profiles/rule2_fqdn", "www.example.com")
+
+	// no change
+	finalProfiles := service.getUpdateContextProfiles([]*model.PolicyContextProfile{&p1, &p2}, []model.PolicyContextProfile{p1, p2})
+	assert.Empty(t, finalProfiles)
+
+	// p1 is changed and p2 is stale
+	changedP1 := newProfile("rule1_fqdn", "/infra/context-profiles/rule1_fqdn", "www.example.com", "*.example.org")
+	finalProfiles = service.getUpdateContextProfiles([]*model.PolicyContextProfile{&p1, &p2}, []model.PolicyContextProfile{changedP1})
+	staleProfiles, updatedProfiles := service.getStaleUpdateContextProfiles(finalProfiles)
+	assert.Equal(t, []model.PolicyContextProfile{changedP1}, updatedProfiles)
+	assert.Len(t, staleProfiles, 1)
+	assert.Equal(t, "rule2_fqdn", *staleProfiles[0].Id)
+	assert.True(t, *staleProfiles[0].MarkedForDelete)
+
+	projectProfiles, infraProfiles := splitContextProfiles([]*model.PolicyContextProfile{&p1, &p2})
+	assert.Equal(t, []*model.PolicyContextProfile{&p2}, projectProfiles)
+	assert.Equal(t, []*model.PolicyContextProfile{&p1}, infraProfiles)
+}
+
 func Test_GetMarkDeleteRules(t *testing.T) {
 	var sp types.UID
 	sp = "sp_test"
@@ -1256,7 +1293,7 @@ func Test_DeleteSecurityPolicy(t *testing.T) {
 				})
 			defer patches.Reset()
 
-			if err := fakeService.DeleteSecurityPolicy(tt.args.uid, false, tt.args.createdFor); (err != nil) != tt.wantErr {
+			if err := fakeService.DeleteSecurityPolicy(context.TODO(), tt.args.uid, false, tt.args.createdFor); (err != nil) != tt.wantErr {
 				t.Errorf("DeleteSecurityPolicy error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.Equal(t, tt.wantSecurityPolicyStoreCount, len(fakeService.securityPolicyStore.ListKeys()))
@@ -1440,7 +1477,7 @@ func Test_deleteT1SecurityPolicy(t *testing.T) {
 			patches := tt.prepareFunc(t, fakeService)
 			defer patches.Reset()
 
-			if err := fakeService.deleteT1SecurityPolicy(tt.args.uid); (err != nil) != tt.wantErr {
+			if err := fakeService.deleteT1SecurityPolicy(context.TODO(), tt.args.uid); (err != nil) != tt.wantErr {
 				t.Errorf("deleteT1SecurityPolicy error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.Equal(t, tt.wantSecurityPolicyStoreCount, len(fakeService.securityPolicyStore.ListKeys()))
@@ -1708,7 +1745,7 @@ func Test_deleteVPCSecurityPolicy(t *testing.T) {
 				})
 			defer patches.Reset()
 
-			if err := fakeService.deleteVPCSecurityPolicy(tt.args.uid, false, tt.args.createdFor); (err != nil) != tt.wantErr {
+			if err := fakeService.deleteVPCSecurityPolicy(context.TODO(), tt.args.uid, false, tt.args.createdFor); (err != nil) != tt.wantErr {
 				t.Errorf("deleteVPCSecurityPolicy error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.Equal(t, tt.wantSecurityPolicyStoreCount, len(fakeService.securityPolicyStore.ListKeys()))
@@ -1722,6 +1759,48 @@ func Test_deleteVPCSecurityPolicy(t *testing.T) {
 	}
 }
 
+func Test_DeleteSecurityPolicyInPlanMode(t *testing.T) {
+	common.TagValueScopeSecurityPolicyName = common.TagScopeSecurityPolicyName
+	common.TagValueScopeSecurityPolicyUID = common.TagScopeSecurityPolicyUID
+	fakeService := fakeSecurityPolicyService()
+	fakeService.NSXConfig.EnableVPCNetwork = true
+	fakeService.setUpStore(common.TagValueScopeSecurityPolicyUID, false)
+
+	mTag, mScope := tagValuePolicyCRUID, tagScopeSecurityPolicyUID
+	spPath := "/orgs/default/projects/projectQuality/vpcs/vpc1/security-policies/spA_uidA"
+	sp := &model.SecurityPolicy{
+		Id:   common.String("spA_uidA"),
+		Path: &spPath,
+		Tags: vpcBasicTags,
+		Rules: []model.Rule{
+			{Id: &ruleID0, Action: &nsxRuleActionAllow, Tags: vpcBasicTags},
+		},
+	}
+	assert.NoError(t, fakeService.securityPolicyStore.Apply(sp))
+	assert.NoError(t, fakeService.ruleStore.Apply(&sp.Rules))
+	assert.NoError(t, fakeService.groupStore.Apply(&[]model.Group{{Id: common.String("spA_uidA_scope"), Tags: []model.Tag{{Tag: &mTag, Scope: &mScope}}}}))
+	assert.NoError(t, fakeService.projectGroupStore.Apply(&[]model.Group{{Id: common.String("spA_uidA_2c822e90_src"), Tags: []model.Tag{{Tag: &mTag, Scope: &mScope}}}}))
+	assert.NoError(t, fakeService.projectShareStore.Apply(&[]model.Share{{Id: common.String("share_projectQuality_group_spA_uidA_2c822e90_src"), Tags: []model.Tag{{Tag: &mTag, Scope: &mScope}}}}))
+
+	err := fakeService.DeleteSecurityPolicy(common.WithPlanMode(context.TODO()), types.UID(tagValuePolicyCRUID), false, common.ResourceTypeSecurityPolicy)
+	var planErr *common.PlanError
+	require.ErrorAs(t, err, &planErr)
+	var paths []string
+	for _, change := range planErr.Changes {
+		assert.Equal(t, common.PlanActionDelete, change.Action)
+		paths = append(paths, change.Path)
+	}
+	assert.ElementsMatch(t, []string{spPath, ruleID0, "spA_uidA_scope", "spA_uidA_2c822e90_src", "share_projectQuality_group_spA_uidA_2c822e90_src"}, paths)
+
+	// nothing is deleted from the stores, and the resources in the stores aren't marked for delete
+	assert.Len(t, fakeService.securityPolicyStore.ListKeys(), 1)
+	assert.Nil(t, fakeService.securityPolicyStore.GetByKey("spA_uidA").MarkedForDelete)
+	assert.Len(t, fakeService.ruleStore.ListKeys(), 1)
+	assert.Len(t, fakeService.groupStore.ListKeys(), 1)
+	assert.Len(t, fakeService.projectGroupStore.ListKeys(), 1)
+	assert.Len(t, fakeService.projectShareStore.ListKeys(), 1)
+}
+
 func Test_deleteVPCSecurityPolicyInDefaultProject(t *testing.T) {
 	spPath := "/orgs/default/projects/default/vpcs/vpc1"
 
@@ -2107,7 +2186,7 @@ func Test_deleteVPCSecurityPolicyInDefaultProject(t *testing.T) {
 				})
 			defer patches.Reset()
 
-			if err := fakeService.deleteVPCSecurityPolicy(tt.args.uid, false, tt.args.createdFor); (err != nil) != tt.wantErr {
+			if err := fakeService.deleteVPCSecurityPolicy(context.TODO(), tt.args.uid, false, tt.args.createdFor); (err != nil) != tt.wantErr {
 				t.Errorf("deleteVPCSecurityPolicy error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.Equal(t, tt.wantSecurityPolicyStoreCount, len(fakeService.securityPolicyStore.ListKeys()))
@@ -2243,12 +2322,12 @@ func Test_CreateOrUpdateSecurityPolicy(t *testing.T) {
 				Times:  1,
 			}})
 			patches.ApplyPrivateMethod(reflect.TypeOf(fakeService), "checkSecurityPolicyRealizationState",
-				func(s *SecurityPolicyService, sp *model.SecurityPolicy, spPath string) error {
+				func(s *SecurityPolicyService, ctx context.Context, sp *model.SecurityPolicy, spPath string) error {
 					return nil
 				})
 			defer patches.Reset()
 
-			if err := fakeService.CreateOrUpdateSecurityPolicy(tt.args.spObj); (err != nil) != tt.wantErr {
+			if err := fakeService.CreateOrUpdateSecurityPolicy(context.TODO(), tt.args.spObj); (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateSecurityPolicy error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -2370,7 +2449,7 @@ func Test_CreateOrUpdateSecurityPolicyFromNetworkPolicy(t *testing.T) {
 				},
 			})
 			patches.ApplyPrivateMethod(reflect.TypeOf(fakeService), "checkSecurityPolicyRealizationState",
-				func(s *SecurityPolicyService, sp *model.SecurityPolicy, spPath string) error {
+				func(s *SecurityPolicyService, ctx context.Context, sp *model.SecurityPolicy, spPath string) error {
 					return nil
 				})
 			defer patches.Reset()
@@ -2378,7 +2457,7 @@ func Test_CreateOrUpdateSecurityPolicyFromNetworkPolicy(t *testing.T) {
 			assert.Equal(t, tt.wantSPStoreCountBeforeCreate, len(fakeService.securityPolicyStore.ListKeys()))
 			assert.Equal(t, tt.wantRuleStoreCountBeforeCreate, len(fakeService.ruleStore.ListKeys()))
 
-			if err := fakeService.CreateOrUpdateSecurityPolicy(tt.npObj); (err != nil) != tt.wantErr {
+			if err := fakeService.CreateOrUpdateSecurityPolicy(context.TODO(), tt.npObj); (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateSecurityPolicy error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -2522,7 +2601,7 @@ func Test_createOrUpdateT1SecurityPolicy(t *testing.T) {
 			}})
 			defer patches.Reset()
 
-			if err := fakeService.createOrUpdateT1SecurityPolicy(tt.args.spObj, tt.args.createdFor); (err != nil) != tt.wantErr {
+			if err := fakeService.createOrUpdateT1SecurityPolicy(context.TODO(), tt.args.spObj, tt.args.createdFor); (err != nil) != tt.wantErr {
 				t.Errorf("createOrUpdateT1SecurityPolicy error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -2688,12 +2767,12 @@ func Test_createOrUpdateVPCSecurityPolicy(t *testing.T) {
 				Times:  1,
 			}})
 			patches.ApplyPrivateMethod(reflect.TypeOf(fakeService), "checkSecurityPolicyRealizationState",
-				func(s *SecurityPolicyService, sp *model.SecurityPolicy, spPath string) error {
+				func(s *SecurityPolicyService, ctx context.Context, sp *model.SecurityPolicy, spPath string) error {
 					return nil
 				})
 			defer patches.Reset()
 
-			if err := fakeService.createOrUpdateVPCSecurityPolicy(tt.args.spObj, tt.args.createdFor); (err != nil) != tt.wantErr {
+			if err := fakeService.createOrUpdateVPCSecurityPolicy(context.TODO(), tt.args.spObj, tt.args.createdFor); (err != nil) != tt.wantErr {
 				t.Errorf("createOrUpdateVPCSecurityPolicy error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -2868,12 +2947,12 @@ func Test_createOrUpdateVPCSecurityPolicyInDefaultProject(t *testing.T) {
 				Values: gomonkey.Params{*(tt.expectedPolicy), nil},
 				Times:  1,
 			}})
-			patches.ApplyPrivateMethod(reflect.TypeOf(fakeService), "checkSecurityPolicyRealizationState", func(_ *SecurityPolicyService, sp *model.SecurityPolicy, spPath string) error {
+			patches.ApplyPrivateMethod(reflect.TypeOf(fakeService), "checkSecurityPolicyRealizationState", func(_ *SecurityPolicyService, _ context.Context, sp *model.SecurityPolicy, spPath string) error {
 				return nil
 			})
 			defer patches.Reset()
 
-			if err := fakeService.createOrUpdateVPCSecurityPolicy(tt.args.spObj, tt.args.createdFor); (err != nil) != tt.wantErr {
+			if err := fakeService.createOrUpdateVPCSecurityPolicy(context.TODO(), tt.args.spObj, tt.args.createdFor); (err != nil) != tt.wantErr {
 				t.Errorf("createOrUpdateVPCSecurityPolicy error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -2957,7 +3036,7 @@ func Test_GetFinalSecurityPolicyResourceForT1(t *testing.T) {
 			var isChanged bool
 			var err error
 
-			if finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, isChanged, err = fakeService.getFinalSecurityPolicyResource(tt.args.spObj, tt.args.createdFor, nil, false); (err != nil) != tt.wantErr {
+			if finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, _, isChanged, err = fakeService.getFinalSecurityPolicyResource(tt.args.spObj, tt.args.createdFor, nil, false); (err != nil) != tt.wantErr {
 				t.Errorf("getFinalSecurityPolicyResource error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -3087,7 +3166,7 @@ func Test_GetFinalSecurityPolicyResourceForVPC(t *testing.T) {
 			var isChanged bool
 			var err error
 
-			if finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, isChanged, err = fakeService.getFinalSecurityPolicyResource(tt.args.spObj, tt.args.createdFor, &VPCInfo[0], false); (err != nil) != tt.wantErr {
+			if finalSecurityPolicy, finalGroups, finalShares, finalShareGroups, _, isChanged, err = fakeService.getFinalSecurityPolicyResource(tt.args.spObj, tt.args.createdFor, &VPCInfo[0], false); (err != nil) != tt.wantErr {
 				t.Errorf("getFinalSecurityPolicyResource error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -3373,7 +3452,7 @@ func Test_GetFinalSecurityPolicyResourceFromNetworkPolicy(t *testing.T) {
 			convertSecurityPolicy, err := fakeService.convertNetworkPolicyToInternalSecurityPolicies(tt.npObj)
 			assert.Equal(t, nil, err)
 
-			if finalAllowSecurityPolicy, finalGroups, finalShares, finalShareGroups, isChanged, err = fakeService.getFinalSecurityPolicyResource(convertSecurityPolicy[0], common.ResourceTypeNetworkPolicy, &VPCInfo[0], false); (err != nil) != tt.wantErr {
+			if finalAllowSecurityPolicy, finalGroups, finalShares, finalShareGroups, _, isChanged, err = fakeService.getFinalSecurityPolicyResource(convertSecurityPolicy[0], common.ResourceTypeNetworkPolicy, &VPCInfo[0], false); (err != nil) != tt.wantErr {
 				t.Errorf("getFinalSecurityPolicyResource error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.Equal(t, *tt.expAllowPolicy.Id, *finalAllowSecurityPolicy.Id)
@@ -3385,7 +3464,7 @@ func Test_GetFinalSecurityPolicyResourceFromNetworkPolicy(t *testing.T) {
 			assert.Equal(t, tt.wantAllowPolicyShareGroupStoreCount, len(finalShareGroups))
 			assert.ElementsMatch(t, tt.expAllowPolicy.Rules, finalAllowSecurityPolicy.Rules)
 
-			if finalIsolationSecurityPolicy, finalGroups, finalShares, finalShareGroups, isChanged, err = fakeService.getFinalSecurityPolicyResource(convertSecurityPolicy[1], common.ResourceTypeNetworkPolicy, &VPCInfo[0], false); (err != nil) != tt.wantErr {
+			if finalIsolationSecurityPolicy, finalGroups, finalShares, finalShareGroups, _, isChanged, err = fakeService.getFinalSecurityPolicyResource(convertSecurityPolicy[1], common.ResourceTypeNetworkPolicy, &VPCInfo[0], false); (err != nil) != tt.wantErr {
 				t.Errorf("getFinalSecurityPolicyResource error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.Equal(t, *tt.expIsolationPolicy.Id, *finalIsolationSecurityPolicy.Id)
@@ -3442,6 +3521,33 @@ func Test_ListSecurityPolicyByName(t *testing.T) {
 	assert.Len(t, result, 0)
 }
 
+func Test_getNetworkPolicyLogging(t *testing.T) {
+	s := fakeSecurityPolicyService()
+	s.Client = fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-logged", Annotations: map[string]string{common.AnnotationNetworkPolicyLogging: "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1"}},
+	).Build()
+	for _, tc := range []struct {
+		name        string
+		namespace   string
+		annotations map[string]string
+		expected    *bool
+	}{
+		{"not configured", "ns-1", nil, nil},
+		{"namespace not found", "ns-2", nil, nil},
+		{"namespace annotation", "ns-logged", nil, ptr.To(true)},
+		{"network policy annotation", "ns-1", map[string]string{common.AnnotationNetworkPolicyLogging: "true"}, ptr.To(true)},
+		{"network policy annotation takes precedence", "ns-logged", map[string]string{common.AnnotationNetworkPolicyLogging: "false"}, ptr.To(false)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			np := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "np-1", Namespace: tc.namespace, Annotations: tc.annotations}}
+			logging, err := s.getNetworkPolicyLogging(np)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, logging)
+		})
+	}
+}
+
 func Test_ListNetworkPolicyByName(t *testing.T) {
 	common.TagValueScopeSecurityPolicyName = common.TagScopeSecurityPolicyName
 	common.TagValueScopeSecurityPolicyUID = common.TagScopeSecurityPolicyUID
@@ -3715,7 +3821,7 @@ func Test_deleteVPCSecurityPolicyGC(t *testing.T) {
 			patches := tt.prepareFunc(t, fakeService)
 			defer patches.Reset()
 
-			if err := fakeService.deleteVPCSecurityPolicy(tt.args.uid, true, tt.args.createdFor); (err != nil) != tt.wantErr {
+			if err := fakeService.deleteVPCSecurityPolicy(context.TODO(), tt.args.uid, true, tt.args.createdFor); (err != nil) != tt.wantErr {
 				t.Errorf("deleteVPCSecurityPolicyGC error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -6,6 +6,8 @@ package securitypolicy
 import (
 	"errors"
 
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
@@ -17,6 +19,8 @@ var validRuleActions = []string{
 	util.ToUpper(v1alpha1.RuleActionReject),
 }
 
+var ruleActionPass = util.ToUpper(v1alpha1.RuleActionPass)
+
 var (
 	ruleDirectionIngress = util.ToUpper(v1alpha1.RuleDirectionIngress)
 	ruleDirectionIn      = util.ToUpper(v1alpha1.RuleDirectionIn)
@@ -26,6 +30,9 @@ var (
 
 func getRuleAction(rule *v1alpha1.SecurityPolicyRule) (string, error) {
 	ruleAction := util.ToUpper(*rule.Action)
+	if ruleAction == ruleActionPass {
+		return model.Rule_ACTION_JUMP_TO_APPLICATION, nil
+	}
 	for _, validRuleAction := range validRuleActions {
 		if ruleAction == validRuleAction {
 			return ruleAction, nil
@@ -34,6 +41,14 @@ func getRuleAction(rule *v1alpha1.SecurityPolicyRule) (string, error) {
 	return "", errors.New("invalid rule action")
 }
 
+// getPolicyCategory returns the NSX category of the SecurityPolicy, it is Application by default.
+func getPolicyCategory(obj *v1alpha1.SecurityPolicy) v1alpha1.SecurityPolicyCategory {
+	if obj.Spec.Category == "" {
+		return v1alpha1.SecurityPolicyCategoryApplication
+	}
+	return obj.Spec.Category
+}
+
 func getRuleDirection(rule *v1alpha1.SecurityPolicyRule) (string, error) {
 	ruleDirection := util.ToUpper(*rule.Direction)
 	if ruleDirection == ruleDirectionIngress || ruleDirection == ruleDirectionIn {
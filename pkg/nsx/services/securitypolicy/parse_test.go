@@ -7,8 +7,69 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
 
 func Test_GetCluster(t *testing.T) {
 	assert.Equal(t, "k8scl-one", getCluster(service))
 }
+
+func Test_GetRuleAction(t *testing.T) {
+	for action, expected := range map[v1alpha1.RuleAction]string{
+		v1alpha1.RuleActionAllow:  model.Rule_ACTION_ALLOW,
+		v1alpha1.RuleActionDrop:   model.Rule_ACTION_DROP,
+		v1alpha1.RuleActionReject: model.Rule_ACTION_REJECT,
+		v1alpha1.RuleActionPass:   model.Rule_ACTION_JUMP_TO_APPLICATION,
+	} {
+		ruleAction, err := getRuleAction(&v1alpha1.SecurityPolicyRule{Action: &action})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ruleAction)
+	}
+	invalid := v1alpha1.RuleAction("Skip")
+	_, err := getRuleAction(&v1alpha1.SecurityPolicyRule{Action: &invalid})
+	assert.Error(t, err)
+}
+
+func Test_ValidateRuleAction(t *testing.T) {
+	pass := v1alpha1.RuleActionPass
+	obj := &v1alpha1.SecurityPolicy{}
+	assert.Equal(t, v1alpha1.SecurityPolicyCategoryApplication, getPolicyCategory(obj))
+	assert.NoError(t, validateRuleAction(obj, &v1alpha1.SecurityPolicyRule{Action: &allowAction}))
+	err := validateRuleAction(obj, &v1alpha1.SecurityPolicyRule{Action: &pass})
+	assert.ErrorAs(t, err, new(*nsxutil.ValidationError))
+
+	obj.Spec.Category = v1alpha1.SecurityPolicyCategoryEnvironment
+	assert.NoError(t, validateRuleAction(obj, &v1alpha1.SecurityPolicyRule{Action: &pass}))
+}
+
+func Test_ValidatePolicyCategory(t *testing.T) {
+	svc := &SecurityPolicyService{
+		Service: common.Service{
+			NSXConfig: &config.NSXOperatorConfig{
+				K8sConfig: &config.K8sConfig{SecurityPolicyCategoryAllowList: []string{"kube-system:*", "platform-ns:Environment"}},
+			},
+		},
+	}
+	obj := &v1alpha1.SecurityPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "app-ns"}}
+	assert.NoError(t, svc.validatePolicyCategory(obj))
+	obj.Spec.Category = v1alpha1.SecurityPolicyCategoryEnvironment
+	assert.ErrorAs(t, svc.validatePolicyCategory(obj), new(*nsxutil.ValidationError))
+
+	obj.Namespace = "platform-ns"
+	assert.NoError(t, svc.validatePolicyCategory(obj))
+	obj.Spec.Category = v1alpha1.SecurityPolicyCategoryEmergency
+	assert.ErrorAs(t, svc.validatePolicyCategory(obj), new(*nsxutil.ValidationError))
+
+	obj.Namespace = "kube-system"
+	assert.NoError(t, svc.validatePolicyCategory(obj))
+
+	// all the categories other than Application are denied without the allow list
+	svc.NSXConfig.K8sConfig = nil
+	assert.ErrorAs(t, svc.validatePolicyCategory(obj), new(*nsxutil.ValidationError))
+}
@@ -27,6 +27,8 @@ func keyFunc(obj interface{}) (string, error) {
 		return *v.Id, nil
 	case *model.Share:
 		return *v.Id, nil
+	case *model.PolicyContextProfile:
+		return *v.Id, nil
 	default:
 		return "", errors.New("keyFunc doesn't support unknown type")
 	}
@@ -54,6 +56,8 @@ func indexBySecurityPolicyUID(obj interface{}) ([]string, error) {
 		return filterTag(o.Tags, common.TagValueScopeSecurityPolicyUID), nil
 	case *model.Share:
 		return filterTag(o.Tags, common.TagValueScopeSecurityPolicyUID), nil
+	case *model.PolicyContextProfile:
+		return filterTag(o.Tags, common.TagValueScopeSecurityPolicyUID), nil
 	default:
 		return nil, errors.New("indexBySecurityPolicyUID doesn't support unknown type")
 	}
@@ -69,11 +73,39 @@ func indexByNetworkPolicyUID(obj interface{}) ([]string, error) {
 		return filterTag(o.Tags, common.TagScopeNetworkPolicyUID), nil
 	case *model.Share:
 		return filterTag(o.Tags, common.TagScopeNetworkPolicyUID), nil
+	case *model.PolicyContextProfile:
+		return filterTag(o.Tags, common.TagScopeNetworkPolicyUID), nil
 	default:
 		return nil, errors.New("indexByNetworkPolicyUID doesn't support unknown type")
 	}
 }
 
+func indexByClusterSecurityPolicyUID(obj interface{}) ([]string, error) {
+	switch o := obj.(type) {
+	case *model.SecurityPolicy:
+		return filterTag(o.Tags, common.TagScopeClusterSecurityPolicyUID), nil
+	case *model.Group:
+		return filterTag(o.Tags, common.TagScopeClusterSecurityPolicyUID), nil
+	case *model.Rule:
+		return filterTag(o.Tags, common.TagScopeClusterSecurityPolicyUID), nil
+	case *model.Share:
+		return filterTag(o.Tags, common.TagScopeClusterSecurityPolicyUID), nil
+	case *model.PolicyContextProfile:
+		return filterTag(o.Tags, common.TagScopeClusterSecurityPolicyUID), nil
+	default:
+		return nil, errors.New("indexByClusterSecurityPolicyUID doesn't support unknown type")
+	}
+}
+
+// indexByVPCPath indexes the SecurityPolicy resources by the VPC path, the ClusterSecurityPolicy resources are in the
+// infra of the Project instead of the VPCs, so they are not indexed.
+func indexByVPCPath(obj interface{}) ([]string, error) {
+	if uids, err := indexByClusterSecurityPolicyUID(obj); err == nil && len(uids) > 0 {
+		return []string{}, nil
+	}
+	return common.IndexByVPCFunc(obj)
+}
+
 func indexGroupFunc(obj interface{}) ([]string, error) {
 	res := make([]string, 0, 5)
 	switch o := obj.(type) {
@@ -154,205 +186,30 @@ func indexNPByUUIDAndRuleHash(obj interface{}) ([]string, error) {
 }
 
 func (ruleStore *RuleStore) GetByIndexUUIDAndHash(key string, uuid, hash string) []*model.Rule {
-	value := uuid + ":" + hash
-	rules := make([]*model.Rule, 0)
-	objs := ruleStore.ResourceStore.GetByIndex(key, value)
-	for _, rule := range objs {
-		rules = append(rules, rule.(*model.Rule))
-	}
-	return rules
+	return ruleStore.GetByIndex(key, uuid+":"+hash)
 }
 
 // SecurityPolicyStore is a store for security policy
 type SecurityPolicyStore struct {
-	common.ResourceStore
+	common.TypedStore[model.SecurityPolicy]
 }
 
 // RuleStore is a store for rules of security policy
 type RuleStore struct {
-	common.ResourceStore
+	common.TypedStore[model.Rule]
 }
 
 // GroupStore is a store for groups referenced by security policy or rule
 type GroupStore struct {
-	common.ResourceStore
+	common.TypedStore[model.Group]
 }
 
 // ShareStore is a store for project shares referenced by security policy rule
 type ShareStore struct {
-	common.ResourceStore
-}
-
-func (securityPolicyStore *SecurityPolicyStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	sp, ok := i.(*model.SecurityPolicy)
-	if !ok || sp == nil {
-		return nil
-	}
-	if sp.MarkedForDelete != nil && *sp.MarkedForDelete {
-		err := securityPolicyStore.Delete(sp)
-		log.Debug("Delete security policy from store", "securitypolicy", sp)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := securityPolicyStore.Add(sp)
-		log.Debug("Add security policy to store", "securitypolicy", sp)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (securityPolicyStore *SecurityPolicyStore) GetByKey(key string) *model.SecurityPolicy {
-	var securityPolicy *model.SecurityPolicy
-	obj := securityPolicyStore.ResourceStore.GetByKey(key)
-	if obj != nil {
-		securityPolicy = obj.(*model.SecurityPolicy)
-	}
-	return securityPolicy
-}
-
-func (securityPolicyStore *SecurityPolicyStore) GetByIndex(key string, value string) []*model.SecurityPolicy {
-	securityPolicies := make([]*model.SecurityPolicy, 0)
-	objs := securityPolicyStore.ResourceStore.GetByIndex(key, value)
-	for _, securityPolicy := range objs {
-		securityPolicies = append(securityPolicies, securityPolicy.(*model.SecurityPolicy))
-	}
-	return securityPolicies
-}
-
-func (securityPolicyStore *SecurityPolicyStore) DeleteMultipleObjects(securityPolicies []*model.SecurityPolicy) {
-	for _, securityPolicy := range securityPolicies {
-		securityPolicyStore.Delete(securityPolicy)
-	}
-}
-
-func (ruleStore *RuleStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	rules, ok := i.(*[]model.Rule)
-	if !ok || rules == nil {
-		return nil
-	}
-	for _, rule := range *rules {
-		tempRule := rule
-		if rule.MarkedForDelete != nil && *rule.MarkedForDelete {
-			err := ruleStore.Delete(&tempRule)
-			log.Debug("Delete rule from store", "rule", tempRule)
-			if err != nil {
-				return err
-			}
-		} else {
-			err := ruleStore.Add(&tempRule)
-			log.Debug("Add rule to store", "rule", tempRule)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
-func (ruleStore *RuleStore) GetByIndex(key string, value string) []*model.Rule {
-	rules := make([]*model.Rule, 0)
-	objs := ruleStore.ResourceStore.GetByIndex(key, value)
-	for _, rule := range objs {
-		rules = append(rules, rule.(*model.Rule))
-	}
-	return rules
-}
-
-func (ruleStore *RuleStore) DeleteMultipleObjects(rules []*model.Rule) {
-	for _, rule := range rules {
-		ruleStore.Delete(rule)
-	}
-}
-
-func (groupStore *GroupStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	gs, ok := i.(*[]model.Group)
-	if !ok || gs == nil {
-		return nil
-	}
-	for _, group := range *gs {
-		tempGroup := group
-		if group.MarkedForDelete != nil && *group.MarkedForDelete {
-			err := groupStore.Delete(&tempGroup)
-			log.Debug("Delete group from store", "group", tempGroup)
-			if err != nil {
-				return err
-			}
-		} else {
-			err := groupStore.Add(&tempGroup)
-			log.Debug("Add group to store", "group", tempGroup)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
+	common.TypedStore[model.Share]
 }
 
-func (groupStore *GroupStore) GetByIndex(key string, value string) []*model.Group {
-	groups := make([]*model.Group, 0)
-	objs := groupStore.ResourceStore.GetByIndex(key, value)
-	for _, group := range objs {
-		groups = append(groups, group.(*model.Group))
-	}
-	return groups
-}
-
-func (groupStore *GroupStore) DeleteMultipleObjects(groups []*model.Group) {
-	for _, group := range groups {
-		groupStore.Delete(group)
-	}
-}
-
-func (shareStore *ShareStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	shares, ok := i.(*[]model.Share)
-	if !ok || shares == nil {
-		return nil
-	}
-	for _, share := range *shares {
-		tempShare := share
-		if share.MarkedForDelete != nil && *share.MarkedForDelete {
-			err := shareStore.Delete(&tempShare)
-			log.Debug("Delete share from store", "share", tempShare)
-			if err != nil {
-				return err
-			}
-		} else {
-			err := shareStore.Add(&tempShare)
-			log.Debug("Add share to store", "share", tempShare)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
-func (shareStore *ShareStore) GetByIndex(key string, value string) []*model.Share {
-	shares := make([]*model.Share, 0)
-	objs := shareStore.ResourceStore.GetByIndex(key, value)
-	for _, share := range objs {
-		shares = append(shares, share.(*model.Share))
-	}
-	return shares
-}
-
-func (shareStore *ShareStore) DeleteMultipleObjects(shares []*model.Share) {
-	for _, share := range shares {
-		shareStore.Delete(share)
-	}
+// ContextProfileStore is a store for the context profiles of the FQDNs referenced by security policy rule
+type ContextProfileStore struct {
+	common.TypedStore[model.PolicyContextProfile]
 }
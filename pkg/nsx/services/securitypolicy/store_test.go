@@ -97,6 +97,28 @@ func Test_indexBySecurityPolicyUIDForVPC(t *testing.T) {
 	})
 }
 
+func Test_indexByVPCPath(t *testing.T) {
+	vpcRule := &model.Rule{
+		Id:   String("rule-1"),
+		Path: String("/orgs/default/projects/p1/vpcs/vpc1/security-policies/sp1/rules/rule-1"),
+	}
+	got, err := indexByVPCPath(vpcRule)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/orgs/default/projects/p1/vpcs/vpc1"}, got)
+
+	cspRule := &model.Rule{
+		Id:   String("rule-2"),
+		Path: String("/orgs/default/projects/p1/infra/domains/default/security-policies/csp1/rules/rule-2"),
+		Tags: []model.Tag{{Scope: String(common.TagScopeClusterSecurityPolicyUID), Tag: String("csp-uid")}},
+	}
+	got, err = indexByVPCPath(cspRule)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	got, err = indexByClusterSecurityPolicyUID(cspRule)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"csp-uid"}, got)
+}
+
 func Test_filterTag(t *testing.T) {
 	tagScope := common.TagValueScopeSecurityPolicyUID
 	tags1 := []model.Tag{{Tag: common.String("sp-uid"), Scope: common.String(common.TagValueScopeSecurityPolicyUID)}}
@@ -172,10 +194,10 @@ func Test_InitializeRuleStore(t *testing.T) {
 		},
 	}
 	ruleCacheIndexer := cache.NewIndexer(keyFunc, cache.Indexers{common.TagValueScopeSecurityPolicyUID: indexBySecurityPolicyUID})
-	ruleStore := &RuleStore{ResourceStore: common.ResourceStore{
+	ruleStore := &RuleStore{TypedStore: common.TypedStore[model.Rule]{ResourceStore: common.ResourceStore{
 		Indexer:     ruleCacheIndexer,
 		BindingType: model.RuleBindingType(),
-	}}
+	}}}
 
 	wg := sync.WaitGroup{}
 	fatalErrors := make(chan error)
@@ -223,10 +245,10 @@ func Test_InitializeGroupStore(t *testing.T) {
 		},
 	}
 	groupCacheIndexer := cache.NewIndexer(keyFunc, cache.Indexers{common.TagValueScopeSecurityPolicyUID: indexBySecurityPolicyUID})
-	groupStore := &GroupStore{ResourceStore: common.ResourceStore{
+	groupStore := &GroupStore{TypedStore: common.TypedStore[model.Group]{ResourceStore: common.ResourceStore{
 		Indexer:     groupCacheIndexer,
 		BindingType: model.GroupBindingType(),
-	}}
+	}}}
 
 	wg := sync.WaitGroup{}
 	fatalErrors := make(chan error)
@@ -274,10 +296,10 @@ func Test_InitializeSecurityPolicyStore(t *testing.T) {
 		},
 	}
 	securityPolicyCacheIndexer := cache.NewIndexer(keyFunc, cache.Indexers{common.TagValueScopeSecurityPolicyUID: indexBySecurityPolicyUID})
-	securityPolicyStore := &SecurityPolicyStore{ResourceStore: common.ResourceStore{
+	securityPolicyStore := &SecurityPolicyStore{TypedStore: common.TypedStore[model.SecurityPolicy]{ResourceStore: common.ResourceStore{
 		Indexer:     securityPolicyCacheIndexer,
 		BindingType: model.SecurityPolicyBindingType(),
-	}}
+	}}}
 
 	wg := sync.WaitGroup{}
 	fatalErrors := make(chan error)
@@ -307,7 +329,7 @@ func Test_SecurityPolicyStore_Apply(t *testing.T) {
 		Indexer:     securityPolicyCacheIndexer,
 		BindingType: model.SecurityPolicyBindingType(),
 	}
-	securityPolicyStore := &SecurityPolicyStore{ResourceStore: resourceStore}
+	securityPolicyStore := &SecurityPolicyStore{TypedStore: common.TypedStore[model.SecurityPolicy]{ResourceStore: resourceStore}}
 	type args struct {
 		i interface{}
 	}
@@ -333,7 +355,7 @@ func Test_RuleStore_Apply(t *testing.T) {
 		Indexer:     ruleCacheIndexer,
 		BindingType: model.RuleBindingType(),
 	}
-	ruleStore := &RuleStore{ResourceStore: resourceStore}
+	ruleStore := &RuleStore{TypedStore: common.TypedStore[model.Rule]{ResourceStore: resourceStore}}
 	type args struct {
 		i interface{}
 	}
@@ -391,7 +413,7 @@ func Test_GroupStore_Apply(t *testing.T) {
 		Indexer:     groupCacheIndexer,
 		BindingType: model.GroupBindingType(),
 	}
-	groupStore := &GroupStore{ResourceStore: resourceStore}
+	groupStore := &GroupStore{TypedStore: common.TypedStore[model.Group]{ResourceStore: resourceStore}}
 	type args struct {
 		i interface{}
 	}
@@ -418,7 +440,7 @@ func Test_ShareStore_Apply(t *testing.T) {
 		Indexer:     shareCacheIndexer,
 		BindingType: model.ShareBindingType(),
 	}
-	shareStore := &ShareStore{ResourceStore: resourceStore}
+	shareStore := &ShareStore{TypedStore: common.TypedStore[model.Share]{ResourceStore: resourceStore}}
 	type args struct {
 		i interface{}
 	}
@@ -659,7 +681,7 @@ func TestRuleStore_getByIndexUUIDAndHash(t *testing.T) {
 		Indexer:     ruleCacheIndexer,
 		BindingType: model.RuleBindingType(),
 	}
-	ruleStore := &RuleStore{ResourceStore: resourceStore}
+	ruleStore := &RuleStore{TypedStore: common.TypedStore[model.Rule]{ResourceStore: resourceStore}}
 
 	spRuleTags := appendRuleIDAndHashTags(vpcBasicTags, "2c822e90", "spA-2c822e90_re0bz")
 	npRuleTags := appendRuleIDAndHashTags(npAllowBasicTags, "67410606", "npB-67410606_9u8w9")
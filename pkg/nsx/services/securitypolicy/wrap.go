@@ -201,14 +201,16 @@ func (service *SecurityPolicyService) wrapOrg(sp *model.SecurityPolicy, vpcGroup
 func (service *SecurityPolicyService) wrapProject(sp *model.SecurityPolicy, vpcGroups []model.Group, projectInfraChildren []*data.StructValue,
 	projectID, vpcID string,
 ) ([]*data.StructValue, error) {
-	vpcChildren, err := service.wrapVPC(sp, vpcGroups, vpcID)
-	if err != nil {
-		return nil, err
-	}
-
 	var resourceReferenceChildren []*data.StructValue
 	resourceReferenceChildren = append(resourceReferenceChildren, projectInfraChildren...)
-	resourceReferenceChildren = append(resourceReferenceChildren, vpcChildren...)
+	// The ClusterSecurityPolicy resources are only in the project infra without any VPC.
+	if vpcID != "" {
+		vpcChildren, err := service.wrapVPC(sp, vpcGroups, vpcID)
+		if err != nil {
+			return nil, err
+		}
+		resourceReferenceChildren = append(resourceReferenceChildren, vpcChildren...)
+	}
 
 	targetType := common.ResourceTypeProject
 	resourceType := common.ResourceTypeChildResourceReference
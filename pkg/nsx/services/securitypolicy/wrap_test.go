@@ -10,6 +10,7 @@ import (
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
@@ -137,6 +138,7 @@ func fakeSecurityPolicyService() *SecurityPolicyService {
 	rc := cluster.NewRestConnector()
 	fakeService := &SecurityPolicyService{
 		Service: common.Service{
+			Client: fake.NewClientBuilder().Build(),
 			NSXClient: &nsx.Client{
 				QueryClient:       &fakeQueryClient{},
 				InfraClient:       &fakeInfraClient{},
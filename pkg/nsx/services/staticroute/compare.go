@@ -1,10 +1,54 @@
 package staticroute
 
 import (
+	"sort"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 )
 
+type StaticRoutes model.StaticRoutes
+
+func (route *StaticRoutes) Key() string {
+	return *route.Id
+}
+
+// Value compares the network, the IP addresses of the next hops regardless of their order and the tags.
+func (route *StaticRoutes) Value() data.DataValue {
+	nextHops := make([]model.RouterNexthop, 0, len(route.NextHops))
+	for _, hop := range route.NextHops {
+		nextHops = append(nextHops, model.RouterNexthop{IpAddress: hop.IpAddress})
+	}
+	sort.Slice(nextHops, func(i, j int) bool {
+		return *nextHops[i].IpAddress < *nextHops[j].IpAddress
+	})
+	r := &model.StaticRoutes{
+		Network:  route.Network,
+		NextHops: nextHops,
+		Tags:     route.Tags,
+	}
+	dataValue, _ := r.GetDataValue__()
+	return dataValue
+}
+
+func StaticRoutesToComparable(route *model.StaticRoutes) common.Comparable {
+	return (*StaticRoutes)(route)
+}
+
+// DriftSpec returns the StaticRoutes which are checked for out-of-band changes.
+func (service *StaticRouteService) DriftSpec() common.DriftSpec {
+	return common.DriftSpec{
+		ResourceType: common.ResourceTypeStaticRoutes,
+		Stores:       []*common.ResourceStore{&service.StaticRouteStore.ResourceStore},
+		ToComparable: func(obj interface{}) common.Comparable {
+			return StaticRoutesToComparable(obj.(*model.StaticRoutes))
+		},
+	}
+}
+
 // assume that staticroute doesn't have the same ipaddress, return true if equal
 func (service *StaticRouteService) compareStaticRoute(oldStaticRoute *model.StaticRoutes, newStaticRoute *model.StaticRoutes) bool {
 	if *oldStaticRoute.Network != *newStaticRoute.Network {
@@ -1,6 +1,7 @@
 package staticroute
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -67,7 +68,14 @@ func isStaticRouteReady(staticRoute *v1alpha1.StaticRoute) bool {
 	return false
 }
 
-func (service *StaticRouteService) CreateOrUpdateStaticRoute(namespace string, obj *v1alpha1.StaticRoute) error {
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (service *StaticRouteService) ResourceStores() map[string]*common.ResourceStore {
+	return map[string]*common.ResourceStore{
+		"staticroutes": &service.StaticRouteStore.ResourceStore,
+	}
+}
+
+func (service *StaticRouteService) CreateOrUpdateStaticRoute(ctx context.Context, namespace string, obj *v1alpha1.StaticRoute) error {
 	nsxStaticRoute, err := service.buildStaticRoute(obj)
 	if err != nil {
 		return err
@@ -89,6 +97,14 @@ func (service *StaticRouteService) CreateOrUpdateStaticRoute(namespace string, o
 		}
 	}
 
+	if service.PlanModeEnabled(ctx, obj) {
+		plan := &common.Plan{}
+		if err := plan.Add(&service.StaticRouteStore.ResourceStore, nsxStaticRoute); err != nil {
+			return err
+		}
+		return plan.Err()
+	}
+
 	vpc := service.VPCService.ListVPCInfo(namespace)
 	if len(vpc) == 0 {
 		return fmt.Errorf("no vpc found for ns %s", namespace)
@@ -115,9 +131,9 @@ func (service *StaticRouteService) CreateOrUpdateStaticRoute(namespace string, o
 
 func (service *StaticRouteService) checkStaticRouteRealizeState(staticRoute *model.StaticRoutes) error {
 	realizeService := realizestate.InitializeRealizeState(service.Service)
-	if err := realizeService.CheckRealizeState(util.NSXTRealizeRetry, *staticRoute.Path, []string{}); err != nil {
+	if err := realizeService.CheckRealizeState(context.TODO(), util.NSXTRealizeRetry, *staticRoute.Path, []string{}); err != nil {
 		log.Error(err, "Failed to check static route realization state", "ID", *staticRoute.Id)
-		deleteErr := service.DeleteStaticRoute(staticRoute)
+		deleteErr := service.DeleteStaticRoute(context.TODO(), staticRoute)
 		if deleteErr != nil {
 			log.Error(deleteErr, "Failed to delete static route after realization check failure", "ID", *staticRoute.Id)
 			return fmt.Errorf("realization check failed: %v; deletion failed: %v", err, deleteErr)
@@ -136,8 +152,15 @@ func (service *StaticRouteService) patch(orgId string, projectId string, vpcId s
 	return nil
 }
 
-func (service *StaticRouteService) DeleteStaticRoute(nsxStaticRoute *model.StaticRoutes) error {
-	staticRouteClient := service.NSXClient.StaticRouteClient
+func (service *StaticRouteService) DeleteStaticRoute(ctx context.Context, nsxStaticRoute *model.StaticRoutes) error {
+	if service.PlanModeEnabled(ctx, nil) {
+		plan := &common.Plan{}
+		if err := plan.Delete(&service.StaticRouteStore.ResourceStore, nsxStaticRoute); err != nil {
+			return err
+		}
+		return plan.Err()
+	}
+	staticRouteClient := service.NSXClientWithContext(ctx).StaticRouteClient
 	vpcInfo, err := common.ParseVPCResourcePath(*nsxStaticRoute.Path)
 	if err != nil {
 		log.Error(err, "Failed to parse NSX VPC path for StaticRoute", "path", *nsxStaticRoute.Path)
@@ -168,7 +191,7 @@ func (service *StaticRouteService) GetUID(staticroute *model.StaticRoutes) *stri
 
 }
 
-func (service *StaticRouteService) DeleteStaticRouteByCR(obj *v1alpha1.StaticRoute) error {
+func (service *StaticRouteService) DeleteStaticRouteByCR(ctx context.Context, obj *v1alpha1.StaticRoute) error {
 	// Use obj.UID as the index to search the NSX StaticRoute from the local cache. Since this function is called
 	// when the "StaticRoute" is got from the kube-apiserver and its DeletionTimestamp is not Zero, the UID field
 	// must be set in the CR.
@@ -176,28 +199,21 @@ func (service *StaticRouteService) DeleteStaticRouteByCR(obj *v1alpha1.StaticRou
 	if len(staticroutes) == 0 {
 		return nil
 	}
-	staticroute := staticroutes[0].(*model.StaticRoutes)
-	return service.DeleteStaticRoute(staticroute)
+	return service.DeleteStaticRoute(ctx, staticroutes[0])
 }
 
 func (service *StaticRouteService) ListStaticRouteByName(ns, name string) []*model.StaticRoutes {
 	var result []*model.StaticRoutes
 	staticroutes := service.StaticRouteStore.GetByIndex(common.TagScopeNamespace, ns)
 	for _, staticroute := range staticroutes {
-		sr := staticroute.(*model.StaticRoutes)
-		tagname := nsxutil.FindTag(sr.Tags, common.TagScopeStaticRouteCRName)
+		tagname := nsxutil.FindTag(staticroute.Tags, common.TagScopeStaticRouteCRName)
 		if tagname == name {
-			result = append(result, staticroute.(*model.StaticRoutes))
+			result = append(result, staticroute)
 		}
 	}
 	return result
 }
 
 func (service *StaticRouteService) ListStaticRoute() []*model.StaticRoutes {
-	staticRoutes := service.StaticRouteStore.List()
-	staticRouteSet := []*model.StaticRoutes{}
-	for _, staticroute := range staticRoutes {
-		staticRouteSet = append(staticRouteSet, staticroute.(*model.StaticRoutes))
-	}
-	return staticRouteSet
+	return service.StaticRouteStore.Snapshot()
 }
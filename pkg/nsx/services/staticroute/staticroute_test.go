@@ -3,7 +3,10 @@ package staticroute
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 
@@ -12,8 +15,10 @@ import (
 	"github.com/openlyinc/pointy"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	policyclient "github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -149,14 +154,14 @@ func TestStaticRouteService_DeleteStaticRouteByCR(t *testing.T) {
 
 	// no record found
 	mockStaticRouteclient.EXPECT().Delete(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Times(0)
-	err = returnservice.DeleteStaticRouteByCR(srObj)
+	err = returnservice.DeleteStaticRouteByCR(context.TODO(), srObj)
 	assert.Equal(t, err, nil)
 
 	returnservice.StaticRouteStore.Add(sr1)
 
 	// delete record
 	mockStaticRouteclient.EXPECT().Delete("default", "project-1", "vpc-1", id).Return(nil).Times(1)
-	err = returnservice.DeleteStaticRouteByCR(srObj)
+	err = returnservice.DeleteStaticRouteByCR(context.TODO(), srObj)
 	assert.Equal(t, err, nil)
 	srs := returnservice.StaticRouteStore.List()
 	assert.Equal(t, len(srs), 0)
@@ -340,7 +345,7 @@ func TestStaticRouteService_DeleteStaticRoute(t *testing.T) {
 	t.Run("Error parsing path", func(t *testing.T) {
 		staticRouteID := "nonexistent-id"
 
-		err := service.DeleteStaticRoute(&model.StaticRoutes{
+		err := service.DeleteStaticRoute(context.TODO(), &model.StaticRoutes{
 			Path: &staticRouteID,
 			Id:   &staticRouteID,
 		})
@@ -354,7 +359,7 @@ func TestStaticRouteService_DeleteStaticRoute(t *testing.T) {
 		service.StaticRouteStore.Add(staticRoute)
 
 		mockStaticRouteclient.EXPECT().Delete("org1", "project1", "vpc1", staticRouteID).Return(nil).Times(1)
-		err := service.DeleteStaticRoute(&model.StaticRoutes{
+		err := service.DeleteStaticRoute(context.TODO(), &model.StaticRoutes{
 			Path: common.String(fmt.Sprintf("/orgs/org1/projects/project1/vpcs/vpc1/static-routes/%s", staticRouteID)),
 			Id:   &staticRouteID,
 		})
@@ -368,7 +373,7 @@ func TestStaticRouteService_DeleteStaticRoute(t *testing.T) {
 
 		mockStaticRouteclient.EXPECT().Delete("org1", "project1", "vpc1", staticRouteID).Return(fmt.Errorf("delete error")).Times(1)
 
-		err := service.DeleteStaticRoute(&model.StaticRoutes{
+		err := service.DeleteStaticRoute(context.TODO(), &model.StaticRoutes{
 			Path: common.String(fmt.Sprintf("/orgs/org1/projects/project1/vpcs/vpc1/static-routes/%s", staticRouteID)),
 			Id:   &staticRouteID,
 		})
@@ -377,6 +382,66 @@ func TestStaticRouteService_DeleteStaticRoute(t *testing.T) {
 	})
 }
 
+type priorityRecorder struct {
+	method     string
+	priorities []ratelimiter.Priority
+}
+
+func (r *priorityRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.method = req.Method
+	r.priorities = append(r.priorities, ratelimiter.PriorityFromContext(req.Context()))
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+}
+
+func TestStaticRouteService_DeleteStaticRouteWithPriority(t *testing.T) {
+	service, mockController, _ := createService(t)
+	defer mockController.Finish()
+	recorder := &priorityRecorder{}
+	service.NSXClient.RestConnector = policyclient.NewConnector("http://localhost", policyclient.UsingRest(nil), policyclient.WithHttpClient(&http.Client{Transport: recorder}))
+
+	staticRouteID := "staticroute-gc"
+	staticRoute := &model.StaticRoutes{
+		Id:         &staticRouteID,
+		Path:       common.String(fmt.Sprintf("/orgs/org1/projects/project1/vpcs/vpc1/static-routes/%s", staticRouteID)),
+		ParentPath: String("/orgs/org1/projects/project1/vpcs/vpc1"),
+	}
+	service.StaticRouteStore.Add(staticRoute)
+
+	// the garbage collector deletes with the background priority, the request is sent by the SDK client created with it
+	gcCtx := ratelimiter.WithPriority(context.Background(), ratelimiter.PriorityBackground)
+	err := service.DeleteStaticRoute(gcCtx, staticRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, recorder.method)
+	assert.Equal(t, []ratelimiter.Priority{ratelimiter.PriorityBackground}, recorder.priorities)
+	assert.Nil(t, service.StaticRouteStore.GetByKey(staticRouteID))
+}
+
+func TestStaticRouteService_DeleteStaticRouteInPlanMode(t *testing.T) {
+	service, mockController, _ := createService(t)
+	defer mockController.Finish()
+	recorder := &priorityRecorder{}
+	service.NSXClient.RestConnector = policyclient.NewConnector("http://localhost", policyclient.UsingRest(nil), policyclient.WithHttpClient(&http.Client{Transport: recorder}))
+
+	staticRouteID := "staticroute-plan"
+	staticRoutePath := fmt.Sprintf("/orgs/org1/projects/project1/vpcs/vpc1/static-routes/%s", staticRouteID)
+	staticRoute := &model.StaticRoutes{
+		Id:         &staticRouteID,
+		Path:       &staticRoutePath,
+		ParentPath: String("/orgs/org1/projects/project1/vpcs/vpc1"),
+	}
+	service.StaticRouteStore.Add(staticRoute)
+
+	err := service.DeleteStaticRoute(common.WithPlanMode(context.Background()), staticRoute)
+	var planErr *common.PlanError
+	require.ErrorAs(t, err, &planErr)
+	assert.Len(t, planErr.Changes, 1)
+	assert.Equal(t, common.PlanActionDelete, planErr.Changes[0].Action)
+	assert.Equal(t, staticRoutePath, planErr.Changes[0].Path)
+	// no request is sent to NSX and the static route is kept in the store
+	assert.Empty(t, recorder.method)
+	assert.NotNil(t, service.StaticRouteStore.GetByKey(staticRouteID))
+}
+
 func TestStaticRouteService_CreateOrUpdateStaticRoute(t *testing.T) {
 	service, mockController, mockStaticRouteclient := createService(t)
 	defer mockController.Finish()
@@ -399,7 +464,7 @@ func TestStaticRouteService_CreateOrUpdateStaticRoute(t *testing.T) {
 		})
 		defer patchBuild.Reset()
 
-		err := service.CreateOrUpdateStaticRoute("ns", &v1alpha1.StaticRoute{})
+		err := service.CreateOrUpdateStaticRoute(context.TODO(), "ns", &v1alpha1.StaticRoute{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "build error")
 	})
@@ -425,7 +490,7 @@ func TestStaticRouteService_CreateOrUpdateStaticRoute(t *testing.T) {
 		defer patchCompare.Reset()
 		// Add existing static route to store
 		service.StaticRouteStore.Add(nsxStaticRoute)
-		err := service.CreateOrUpdateStaticRoute("ns", &v1alpha1.StaticRoute{
+		err := service.CreateOrUpdateStaticRoute(context.TODO(), "ns", &v1alpha1.StaticRoute{
 			Status: v1alpha1.StaticRouteStatus{
 				Conditions: []v1alpha1.StaticRouteCondition{
 					{
@@ -462,7 +527,7 @@ func TestStaticRouteService_CreateOrUpdateStaticRoute(t *testing.T) {
 		})
 		defer patchVPC.Reset()
 
-		err := service.CreateOrUpdateStaticRoute("ns", &v1alpha1.StaticRoute{})
+		err := service.CreateOrUpdateStaticRoute(context.TODO(), "ns", &v1alpha1.StaticRoute{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no vpc found for ns ns")
 	})
@@ -497,7 +562,7 @@ func TestStaticRouteService_CreateOrUpdateStaticRoute(t *testing.T) {
 		})
 		defer patchPatch.Reset()
 
-		err := service.CreateOrUpdateStaticRoute("ns", &v1alpha1.StaticRoute{})
+		err := service.CreateOrUpdateStaticRoute(context.TODO(), "ns", &v1alpha1.StaticRoute{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "patch error")
 	})
@@ -533,7 +598,7 @@ func TestStaticRouteService_CreateOrUpdateStaticRoute(t *testing.T) {
 		defer patchPatch.Reset()
 
 		mockStaticRouteclient.EXPECT().Get("org1", "proj1", "vpc1", staticRouteID).Return(model.StaticRoutes{}, fmt.Errorf("get error")).Times(1)
-		err := service.CreateOrUpdateStaticRoute("ns", &v1alpha1.StaticRoute{})
+		err := service.CreateOrUpdateStaticRoute(context.TODO(), "ns", &v1alpha1.StaticRoute{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "get error")
 	})
@@ -570,16 +635,16 @@ func TestStaticRouteService_CreateOrUpdateStaticRoute(t *testing.T) {
 		// Patch StaticRouteClient.Get to succeed, but realization check fails and delete fails
 		mockStaticRouteclient.EXPECT().Get("org1", "proj1", "vpc1", staticRouteID).Return(*nsxStaticRoute, nil).Times(1)
 		patchRealize := gomonkey.ApplyFunc((*realizestate.RealizeStateService).CheckRealizeState,
-			func(_ *realizestate.RealizeStateService, _ wait.Backoff, _ string, _ []string) error {
+			func(_ *realizestate.RealizeStateService, _ context.Context, _ wait.Backoff, _ string, _ []string) error {
 				return nsxutil.NewRealizeStateError("mocked realized error", 0)
 			})
 		defer patchRealize.Reset()
-		patchDelete := gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRoute", func(_ *StaticRouteService, _ *model.StaticRoutes) error {
+		patchDelete := gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRoute", func(_ *StaticRouteService, _ context.Context, _ *model.StaticRoutes) error {
 			return fmt.Errorf("delete error")
 		})
 		defer patchDelete.Reset()
 
-		err := service.CreateOrUpdateStaticRoute("ns", &v1alpha1.StaticRoute{})
+		err := service.CreateOrUpdateStaticRoute(context.TODO(), "ns", &v1alpha1.StaticRoute{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "realization check failed")
 		assert.Contains(t, err.Error(), "deletion failed")
@@ -617,16 +682,16 @@ func TestStaticRouteService_CreateOrUpdateStaticRoute(t *testing.T) {
 		// Patch StaticRouteClient.Get to succeed, but realization check fails and delete fails
 		mockStaticRouteclient.EXPECT().Get("org1", "proj1", "vpc1", staticRouteID).Return(*nsxStaticRoute, nil).Times(1)
 		patchRealize := gomonkey.ApplyFunc((*realizestate.RealizeStateService).CheckRealizeState,
-			func(_ *realizestate.RealizeStateService, _ wait.Backoff, _ string, _ []string) error {
+			func(_ *realizestate.RealizeStateService, _ context.Context, _ wait.Backoff, _ string, _ []string) error {
 				return nsxutil.NewRealizeStateError("mocked realized error", 0)
 			})
 		defer patchRealize.Reset()
 		// Patch DeleteStaticRoute to succeed
-		patchDelete := gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRoute", func(_ *StaticRouteService, _ *model.StaticRoutes) error {
+		patchDelete := gomonkey.ApplyMethod(reflect.TypeOf(service), "DeleteStaticRoute", func(_ *StaticRouteService, _ context.Context, _ *model.StaticRoutes) error {
 			return nil
 		})
 		defer patchDelete.Reset()
-		err := service.CreateOrUpdateStaticRoute("ns", &v1alpha1.StaticRoute{})
+		err := service.CreateOrUpdateStaticRoute(context.TODO(), "ns", &v1alpha1.StaticRoute{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "realized error")
 	})
@@ -661,12 +726,12 @@ func TestStaticRouteService_CreateOrUpdateStaticRoute(t *testing.T) {
 		defer patchPatch.Reset()
 		// Patch Add to succeed, should return nil
 		patchRealize := gomonkey.ApplyFunc((*realizestate.RealizeStateService).CheckRealizeState,
-			func(_ *realizestate.RealizeStateService, _ wait.Backoff, _ string, _ []string) error {
+			func(_ *realizestate.RealizeStateService, _ context.Context, _ wait.Backoff, _ string, _ []string) error {
 				return nil
 			})
 		defer patchRealize.Reset()
 		mockStaticRouteclient.EXPECT().Get("org1", "proj1", "vpc1", staticRouteID).Return(*nsxStaticRoute, nil).Times(1)
-		err := service.CreateOrUpdateStaticRoute("ns", &v1alpha1.StaticRoute{})
+		err := service.CreateOrUpdateStaticRoute(context.TODO(), "ns", &v1alpha1.StaticRoute{})
 		assert.NoError(t, err)
 	})
 }
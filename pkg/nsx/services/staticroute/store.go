@@ -12,7 +12,7 @@ import (
 
 // StaticRouteStore is a store for static route
 type StaticRouteStore struct {
-	common.ResourceStore
+	common.TypedStore[model.StaticRoutes]
 }
 
 // keyFunc is used to get the key of a resource, usually, which is the ID of the resource
@@ -57,20 +57,6 @@ var filterTag = func(v []model.Tag, tagScope string) []string {
 	return res
 }
 
-func (StaticRouteStore *StaticRouteStore) Apply(i interface{}) error {
-	// not used by staticroute since staticroute doesn't use hierarchy API
-	return nil
-}
-
-func (StaticRouteStore *StaticRouteStore) GetByKey(key string) *model.StaticRoutes {
-	obj := StaticRouteStore.ResourceStore.GetByKey(key)
-	if obj != nil {
-		staticRoute := obj.(*model.StaticRoutes)
-		return staticRoute
-	}
-	return nil
-}
-
 func (StaticRouteStore *StaticRouteStore) GetByVPCPath(vpcPath string) ([]*model.StaticRoutes, error) {
 	objs, err := StaticRouteStore.ResourceStore.ByIndex(common.IndexByVPCPathFuncKey, vpcPath)
 	if err != nil {
@@ -84,29 +70,23 @@ func (StaticRouteStore *StaticRouteStore) GetByVPCPath(vpcPath string) ([]*model
 	return routes, nil
 }
 
-func (StaticRouteStore *StaticRouteStore) DeleteMultipleObjects(routes []*model.StaticRoutes) {
-	for _, route := range routes {
-		StaticRouteStore.Delete(route)
-	}
-}
-
 func (StaticRouteStore *StaticRouteStore) GetStaticRoutesByCRUID(uid types.UID) *model.StaticRoutes {
-	staticRoutes := StaticRouteStore.ResourceStore.GetByIndex(common.TagScopeStaticRouteCRUID, string(uid))
+	staticRoutes := StaticRouteStore.GetByIndex(common.TagScopeStaticRouteCRUID, string(uid))
 	if len(staticRoutes) == 0 {
 		return nil
 	}
-	return staticRoutes[0].(*model.StaticRoutes)
+	return staticRoutes[0]
 }
 
 func buildStaticRouteStore() *StaticRouteStore {
 	return &StaticRouteStore{
-		ResourceStore: common.ResourceStore{
+		TypedStore: common.TypedStore[model.StaticRoutes]{ResourceStore: common.ResourceStore{
 			Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 				common.TagScopeStaticRouteCRUID: indexFunc,
 				common.TagScopeNamespace:        indexStaticRouteNamespace,
 				common.IndexByVPCPathFuncKey:    common.IndexByVPCFunc,
 			}),
 			BindingType: model.StaticRoutesBindingType(),
-		},
+		}},
 	}
 }
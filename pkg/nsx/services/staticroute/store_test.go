@@ -43,7 +43,7 @@ func TestStaticRouteStore_CRUDResource(t *testing.T) {
 		Indexer:     staticRouteCacheIndexer,
 		BindingType: model.StaticRoutesBindingType(),
 	}
-	staticRouteStore := &StaticRouteStore{ResourceStore: resourceStore}
+	staticRouteStore := &StaticRouteStore{TypedStore: common.TypedStore[model.StaticRoutes]{ResourceStore: resourceStore}}
 	type args struct {
 		i interface{}
 	}
@@ -69,7 +69,7 @@ func TestStaticRouteStore_CRUDResource_List(t *testing.T) {
 		Indexer:     staticRouteCacheIndexer,
 		BindingType: model.StaticRoutesBindingType(),
 	}
-	staticRouteStore := &StaticRouteStore{ResourceStore: resourceStore}
+	staticRouteStore := &StaticRouteStore{TypedStore: common.TypedStore[model.StaticRoutes]{ResourceStore: resourceStore}}
 	type args struct {
 		i interface{}
 		j interface{}
@@ -150,7 +150,7 @@ func TestStaticRouteStore_GetByKey(t *testing.T) {
 		BindingType: model.StaticRoutesBindingType(),
 	}
 
-	staticRouteStore := &StaticRouteStore{ResourceStore: resourceStore}
+	staticRouteStore := &StaticRouteStore{TypedStore: common.TypedStore[model.StaticRoutes]{ResourceStore: resourceStore}}
 	ns1 := "test-ns-1"
 	tag1 := []model.Tag{
 		{
@@ -223,7 +223,7 @@ func TestStaticRouteStore_GetByIndex(t *testing.T) {
 		BindingType: model.StaticRoutesBindingType(),
 	}
 
-	staticRouteStore := &StaticRouteStore{ResourceStore: resourceStore}
+	staticRouteStore := &StaticRouteStore{TypedStore: common.TypedStore[model.StaticRoutes]{ResourceStore: resourceStore}}
 	type args struct {
 		i interface{}
 		j interface{}
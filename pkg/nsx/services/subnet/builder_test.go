@@ -157,7 +157,7 @@ func TestBuildSubnetForSubnet(t *testing.T) {
 			},
 		},
 		SubnetStore: &SubnetStore{
-			ResourceStore: common.ResourceStore{
+			TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 					common.TagScopeSubnetCRUID:    subnetIndexFunc,
 					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -165,7 +165,7 @@ func TestBuildSubnetForSubnet(t *testing.T) {
 					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 				}),
 				BindingType: model.VpcSubnetBindingType(),
-			},
+			}},
 		},
 	}
 	tags := []model.Tag{
@@ -274,7 +274,7 @@ func TestBuildSubnetWithConnectivityState(t *testing.T) {
 			},
 		},
 		SubnetStore: &SubnetStore{
-			ResourceStore: common.ResourceStore{
+			TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 					common.TagScopeSubnetCRUID:    subnetIndexFunc,
 					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -282,7 +282,7 @@ func TestBuildSubnetWithConnectivityState(t *testing.T) {
 					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 				}),
 				BindingType: model.VpcSubnetBindingType(),
-			},
+			}},
 		},
 	}
 	tags := []model.Tag{
@@ -382,7 +382,7 @@ func TestBuildSubnetWithCustomGatewayAddresses(t *testing.T) {
 			},
 		},
 		SubnetStore: &SubnetStore{
-			ResourceStore: common.ResourceStore{
+			TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 					common.TagScopeSubnetCRUID:    subnetIndexFunc,
 					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -390,7 +390,7 @@ func TestBuildSubnetWithCustomGatewayAddresses(t *testing.T) {
 					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 				}),
 				BindingType: model.VpcSubnetBindingType(),
-			},
+			}},
 		},
 	}
 	tags := []model.Tag{
@@ -465,7 +465,7 @@ func TestBuildSubnetWithCustomDHCPServerAddresses(t *testing.T) {
 			},
 		},
 		SubnetStore: &SubnetStore{
-			ResourceStore: common.ResourceStore{
+			TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 					common.TagScopeSubnetCRUID:    subnetIndexFunc,
 					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -473,7 +473,7 @@ func TestBuildSubnetWithCustomDHCPServerAddresses(t *testing.T) {
 					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 				}),
 				BindingType: model.VpcSubnetBindingType(),
-			},
+			}},
 		},
 	}
 	tags := []model.Tag{
@@ -567,7 +567,7 @@ func TestBuildSubnetWithExceedTagsLimit(t *testing.T) {
 			},
 		},
 		SubnetStore: &SubnetStore{
-			ResourceStore: common.ResourceStore{
+			TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 					common.TagScopeSubnetCRUID:    subnetIndexFunc,
 					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -575,7 +575,7 @@ func TestBuildSubnetWithExceedTagsLimit(t *testing.T) {
 					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 				}),
 				BindingType: model.VpcSubnetBindingType(),
-			},
+			}},
 		},
 	}
 
@@ -627,7 +627,7 @@ func TestBuildSubnetTags(t *testing.T) {
 			},
 		},
 		SubnetStore: &SubnetStore{
-			ResourceStore: common.ResourceStore{
+			TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 					common.TagScopeSubnetCRUID:    subnetIndexFunc,
 					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -635,7 +635,7 @@ func TestBuildSubnetTags(t *testing.T) {
 					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 				}),
 				BindingType: model.VpcSubnetBindingType(),
-			},
+			}},
 		},
 	}
 
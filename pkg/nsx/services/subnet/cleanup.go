@@ -26,12 +26,10 @@ func (service *SubnetService) CleanupVPCChildResources(ctx context.Context, vpcP
 		return nil
 	}
 
-	subnets := make([]*model.VpcSubnet, 0)
+	subnets := service.SubnetStore.Snapshot()
 	// Mark the resources for delete.
-	for _, obj := range service.SubnetStore.List() {
-		subnet := obj.(*model.VpcSubnet)
+	for _, subnet := range subnets {
 		subnet.MarkedForDelete = &MarkedForDelete
-		subnets = append(subnets, subnet)
 	}
 
 	log.Info("Cleaning up VpcSubnets from pre-created VPC", "count", len(subnets))
@@ -5,6 +5,7 @@ import (
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
 
 type (
@@ -57,3 +58,18 @@ func (subnet *Subnet) Value() data.DataValue {
 func SubnetToComparable(subnet *model.VpcSubnet) Comparable {
 	return (*Subnet)(subnet)
 }
+
+// DriftSpec returns the VpcSubnets created for the Subnet CRs which are checked for out-of-band changes.
+func (service *SubnetService) DriftSpec() common.DriftSpec {
+	return common.DriftSpec{
+		ResourceType: common.ResourceTypeSubnet,
+		Stores:       []*common.ResourceStore{&service.SubnetStore.ResourceStore},
+		ToComparable: func(obj interface{}) Comparable {
+			return SubnetToComparable(obj.(*model.VpcSubnet))
+		},
+		Filter: func(obj interface{}) bool {
+			subnet, ok := obj.(*model.VpcSubnet)
+			return ok && nsxutil.FindTag(subnet.Tags, common.TagScopeSubnetCRUID) != ""
+		},
+	}
+}
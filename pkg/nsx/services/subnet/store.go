@@ -82,55 +82,12 @@ func subnetIndexNameFunc(obj interface{}) ([]string, error) {
 
 // SubnetStore is a store for subnet.
 type SubnetStore struct {
-	common.ResourceStore
-}
-
-func (subnetStore *SubnetStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	subnet := i.(*model.VpcSubnet)
-	if subnet.MarkedForDelete != nil && *subnet.MarkedForDelete {
-		if err := subnetStore.Delete(subnet); err != nil {
-			return err
-		}
-		log.Info("Subnet deleted from store", "Subnet", subnet)
-	} else {
-		if err := subnetStore.Add(subnet); err != nil {
-			return err
-		}
-		log.Info("Subnet added to store", "Subnet", subnet)
-	}
-	return nil
-}
-
-func (subnetStore *SubnetStore) GetByIndex(key string, value string) []*model.VpcSubnet {
-	subnets := make([]*model.VpcSubnet, 0)
-	objects := subnetStore.ResourceStore.GetByIndex(key, value)
-	for _, subnet := range objects {
-		subnets = append(subnets, subnet.(*model.VpcSubnet))
-	}
-	return subnets
-}
-
-func (subnetStore *SubnetStore) GetByKey(key string) *model.VpcSubnet {
-	obj := subnetStore.ResourceStore.GetByKey(key)
-	if obj == nil {
-		return nil
-	}
-	subnet := obj.(*model.VpcSubnet)
-	return subnet
-}
-
-func (subnetStore *SubnetStore) DeleteMultipleObjects(subnets []*model.VpcSubnet) {
-	for _, subnet := range subnets {
-		_ = subnetStore.Delete(subnet)
-	}
+	common.TypedStore[model.VpcSubnet]
 }
 
 func buildSubnetStore() *SubnetStore {
 	return &SubnetStore{
-		ResourceStore: common.ResourceStore{
+		TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 			Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 				common.TagScopeSubnetCRUID:    subnetIndexFunc,
 				common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -140,6 +97,6 @@ func buildSubnetStore() *SubnetStore {
 				nsxSubnetNameIndexKey:         subnetIndexNameFunc,
 			}),
 			BindingType: model.VpcSubnetBindingType(),
-		},
+		}},
 	}
 }
@@ -89,10 +89,10 @@ func Test_InitializeSubnetStore(t *testing.T) {
 				},
 			},
 		},
-		SubnetStore: &SubnetStore{ResourceStore: common.ResourceStore{
+		SubnetStore: &SubnetStore{TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 			Indexer:     subnetCacheIndexer,
 			BindingType: model.VpcSubnetBindingType(),
-		}},
+		}}},
 	}
 
 	wg := sync.WaitGroup{}
@@ -123,7 +123,7 @@ func TestSubnetStore_Apply(t *testing.T) {
 		Indexer:     subnetCacheIndexer,
 		BindingType: model.SecurityPolicyBindingType(),
 	}
-	subnetStore := &SubnetStore{ResourceStore: resourceStore}
+	subnetStore := &SubnetStore{TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: resourceStore}}
 	type args struct {
 		subnetVPC interface{}
 	}
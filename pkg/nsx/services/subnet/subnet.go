@@ -95,6 +95,13 @@ func InitializeSubnetService(service common.Service) (*SubnetService, error) {
 	return subnetService, nil
 }
 
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (service *SubnetService) ResourceStores() map[string]*common.ResourceStore {
+	return map[string]*common.ResourceStore{
+		"subnets": &service.SubnetStore.ResourceStore,
+	}
+}
+
 func (service *SubnetService) RestoreSubnetSet(obj *v1alpha1.SubnetSet, vpcInfo common.VPCResourceInfo, tags []model.Tag) error {
 	nsxSubnets := service.SubnetStore.GetByIndex(common.TagScopeSubnetSetCRUID, string(obj.UID))
 	var errList []error
@@ -121,7 +128,7 @@ func (service *SubnetService) RestoreSubnetSet(obj *v1alpha1.SubnetSet, vpcInfo
 			}
 		}
 		if changed {
-			_, err = service.createOrUpdateSubnet(obj, nsxSubnet, &vpcInfo, true)
+			_, err = service.createOrUpdateSubnet(context.TODO(), obj, nsxSubnet, &vpcInfo, true)
 			if err != nil {
 				errList = append(errList, err)
 			}
@@ -142,7 +149,7 @@ func isSubnetReady(subnet *v1alpha1.Subnet) bool {
 	return false
 }
 
-func (service *SubnetService) CreateOrUpdateSubnet(obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (subnet *model.VpcSubnet, err error) {
+func (service *SubnetService) CreateOrUpdateSubnet(ctx context.Context, obj client.Object, vpcInfo common.VPCResourceInfo, tags []model.Tag) (subnet *model.VpcSubnet, err error) {
 	uid := string(obj.GetUID())
 	nsxSubnet, err := service.buildSubnet(obj, tags, []string{})
 
@@ -190,7 +197,7 @@ func (service *SubnetService) CreateOrUpdateSubnet(obj client.Object, vpcInfo co
 			// unrealized Subnet will be saved to the store after full sync
 			// Recheck the realizedstate if the Subnet CR is not ready.
 			if !isSubnetReady(subnet) {
-				if err = service.checkSubnetRealizeState(nsxSubnet); err != nil {
+				if err = service.checkSubnetRealizeState(ctx, nsxSubnet); err != nil {
 					return nil, err
 				}
 			}
@@ -198,18 +205,18 @@ func (service *SubnetService) CreateOrUpdateSubnet(obj client.Object, vpcInfo co
 			return existingSubnet, nil
 		}
 	}
-	return service.createOrUpdateSubnet(obj, nsxSubnet, &vpcInfo, false)
+	return service.createOrUpdateSubnet(ctx, obj, nsxSubnet, &vpcInfo, false)
 }
 
-func (service *SubnetService) checkSubnetRealizeState(nsxSubnet *model.VpcSubnet) error {
+func (service *SubnetService) checkSubnetRealizeState(ctx context.Context, nsxSubnet *model.VpcSubnet) error {
 	realizeService := realizestate.InitializeRealizeState(service.Service)
 	// Failure of CheckRealizeState may result in the creation of an existing Subnet.
 	// For Subnets, it's important to reuse the already created NSXSubnet.
 	// For SubnetSets, since the ID includes a random value, the created NSX Subnet needs to be deleted and recreated.
-	if err := realizeService.CheckRealizeState(util.NSXTRealizeRetry, *nsxSubnet.Path, []string{}); err != nil {
+	if err := realizeService.CheckRealizeState(ctx, util.NSXTRealizeRetry, *nsxSubnet.Path, []string{}); err != nil {
 		log.Error(err, "Failed to check Subnet realization state", "ID", *nsxSubnet.Id)
 		// Delete the subnet if the realization check fails, avoiding creating duplicate subnets continuously.
-		deleteErr := service.DeleteSubnet(*nsxSubnet)
+		deleteErr := service.DeleteSubnet(ctx, *nsxSubnet)
 		if deleteErr != nil {
 			log.Error(deleteErr, "Failed to delete Subnet after realization check failure", "ID", *nsxSubnet.Id)
 			return fmt.Errorf("realization check failed: %v; deletion failed: %v", err, deleteErr)
@@ -219,8 +226,16 @@ func (service *SubnetService) checkSubnetRealizeState(nsxSubnet *model.VpcSubnet
 	return nil
 }
 
-func (service *SubnetService) createOrUpdateSubnet(obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo, restoreMode bool) (*model.VpcSubnet, error) {
-	err := service.NSXClient.SubnetsClient.Patch(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSubnet.Id, *nsxSubnet)
+func (service *SubnetService) createOrUpdateSubnet(ctx context.Context, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo, restoreMode bool) (*model.VpcSubnet, error) {
+	if service.PlanModeEnabled(ctx, obj) {
+		plan := &common.Plan{}
+		if err := plan.Add(&service.SubnetStore.ResourceStore, nsxSubnet); err != nil {
+			return nil, err
+		}
+		return nil, plan.Err()
+	}
+	nsxClient := service.NSXClient.WithContext(ctx)
+	err := nsxClient.SubnetsClient.Patch(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSubnet.Id, *nsxSubnet)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "Failed to create or update nsxSubnet", "ID", *nsxSubnet.Id)
@@ -228,11 +243,11 @@ func (service *SubnetService) createOrUpdateSubnet(obj client.Object, nsxSubnet
 	}
 
 	// Get Subnet from NSX after patch operation as NSX renders several fields like `path`/`parent_path`.
-	if *nsxSubnet, err = service.NSXClient.SubnetsClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSubnet.Id); err != nil {
+	if *nsxSubnet, err = nsxClient.SubnetsClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSubnet.Id); err != nil {
 		err = nsxutil.TransNSXApiError(err)
 		return nil, err
 	}
-	err = service.checkSubnetRealizeState(nsxSubnet)
+	err = service.checkSubnetRealizeState(ctx, nsxSubnet)
 	if err != nil {
 		return nil, err
 	}
@@ -253,10 +268,17 @@ func (service *SubnetService) createOrUpdateSubnet(obj client.Object, nsxSubnet
 	return nsxSubnet, nil
 }
 
-func (service *SubnetService) DeleteSubnet(nsxSubnet model.VpcSubnet) error {
+func (service *SubnetService) DeleteSubnet(ctx context.Context, nsxSubnet model.VpcSubnet) error {
 	subnetInfo, _ := common.ParseVPCResourcePath(*nsxSubnet.Path)
 	nsxSubnet.MarkedForDelete = &MarkedForDelete
-	err := service.NSXClient.SubnetsClient.Delete(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID)
+	if service.PlanModeEnabled(ctx, nil) {
+		plan := &common.Plan{}
+		if err := plan.Add(&service.SubnetStore.ResourceStore, &nsxSubnet); err != nil {
+			return err
+		}
+		return plan.Err()
+	}
+	err := service.NSXClient.WithContext(ctx).SubnetsClient.Delete(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		// GC will finally delete subnets that are not deleted successfully.
@@ -488,7 +510,7 @@ func (service *SubnetService) GenerateSubnetNSTags(obj client.Object) []model.Ta
 	return tags
 }
 
-func (service *SubnetService) UpdateSubnetSet(ns string, vpcSubnets []*model.VpcSubnet, tags []model.Tag, dhcpMode string) error {
+func (service *SubnetService) UpdateSubnetSet(ctx context.Context, ns string, vpcSubnets []*model.VpcSubnet, tags []model.Tag, dhcpMode string) error {
 	if dhcpMode == "" {
 		dhcpMode = v1alpha1.DHCPConfigModeDeactivated
 	}
@@ -545,7 +567,7 @@ func (service *SubnetService) UpdateSubnetSet(ns string, vpcSubnets []*model.Vpc
 			err := fmt.Errorf("failed to parse NSX VPC path for Subnet %s: %s", *vpcSubnets[i].Path, err)
 			return err
 		}
-		if _, err := service.createOrUpdateSubnet(subnetSet, &updatedSubnet, &vpcInfo, false); err != nil {
+		if _, err := service.createOrUpdateSubnet(ctx, subnetSet, &updatedSubnet, &vpcInfo, false); err != nil {
 			return fmt.Errorf("failed to update Subnet %s in SubnetSet %s: %w", *vpcSubnet.Id, subnetSet.Name, err)
 		}
 		log.Info("Successfully updated SubnetSet", "subnetSet", subnetSet, "Subnet", *vpcSubnet.Id)
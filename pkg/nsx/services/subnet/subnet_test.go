@@ -341,7 +341,7 @@ func TestInitializeSubnetService(t *testing.T) {
 			res := service.ListAllSubnet()
 			assert.Equal(t, tc.expectAllSubnetNum, len(res))
 
-			createdNSXSubnet, err := service.CreateOrUpdateSubnet(tc.existingSubnetCR, *tc.existingVPCInfo, tc.subnetCRTags)
+			createdNSXSubnet, err := service.CreateOrUpdateSubnet(context.TODO(), tc.existingSubnetCR, *tc.existingVPCInfo, tc.subnetCRTags)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expectCreateSubnetUID, *createdNSXSubnet.Id)
 
@@ -517,7 +517,7 @@ func TestSubnetService_UpdateSubnetSet(t *testing.T) {
 			},
 		},
 		SubnetStore: &SubnetStore{
-			ResourceStore: common.ResourceStore{
+			TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 					common.TagScopeSubnetCRUID:    subnetIndexFunc,
 					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -525,7 +525,7 @@ func TestSubnetService_UpdateSubnetSet(t *testing.T) {
 					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 				}),
 				BindingType: model.VpcSubnetBindingType(),
-			},
+			}},
 		},
 	}
 	tags := []model.Tag{
@@ -566,7 +566,7 @@ func TestSubnetService_UpdateSubnetSet(t *testing.T) {
 	})
 
 	patchesCreateOrUpdateSubnet := gomonkey.ApplyFunc((*SubnetService).createOrUpdateSubnet,
-		func(r *SubnetService, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo) (*model.VpcSubnet, error) {
+		func(r *SubnetService, ctx context.Context, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo, restoreMode bool) (*model.VpcSubnet, error) {
 			return &model.VpcSubnet{Path: &fakeSubnetPath}, nil
 		})
 	defer patchesCreateOrUpdateSubnet.Reset()
@@ -574,7 +574,7 @@ func TestSubnetService_UpdateSubnetSet(t *testing.T) {
 		func(_ client.Client, _ string) (bool, error) {
 			return false, nil
 		})
-	err := service.UpdateSubnetSet("ns-1", vpcSubnets, tags, "")
+	err := service.UpdateSubnetSet(context.TODO(), "ns-1", vpcSubnets, tags, "")
 	assert.Nil(t, err)
 }
 
@@ -628,7 +628,7 @@ func TestSubnetService_createOrUpdateSubnet(t *testing.T) {
 					})
 				// Patch the realization check
 				p.ApplyPrivateMethod(reflect.TypeOf(service), "checkSubnetRealizeState",
-					func(_ *SubnetService, _ *model.VpcSubnet) error {
+					func(_ *SubnetService, _ context.Context, _ *model.VpcSubnet) error {
 						return nil
 					})
 				p.ApplyMethod(reflect.TypeOf(service), "UpdateSubnetSetStatus",
@@ -656,7 +656,7 @@ func TestSubnetService_createOrUpdateSubnet(t *testing.T) {
 					})
 				// Patch the realization check
 				p.ApplyPrivateMethod(reflect.TypeOf(service), "checkSubnetRealizeState",
-					func(_ *SubnetService, _ *model.VpcSubnet) error {
+					func(_ *SubnetService, _ context.Context, _ *model.VpcSubnet) error {
 						return nil
 					})
 			},
@@ -690,7 +690,7 @@ func TestSubnetService_createOrUpdateSubnet(t *testing.T) {
 			// We pass a SubnetSet as the obj to test the status update branch
 			obj := &v1alpha1.SubnetSet{}
 
-			res, err := service.createOrUpdateSubnet(obj, tt.nsxSubnet, tt.vpcInfo, tt.restoreMode)
+			res, err := service.createOrUpdateSubnet(context.TODO(), obj, tt.nsxSubnet, tt.vpcInfo, tt.restoreMode)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -715,7 +715,7 @@ func TestSubnetService_DeleteSubnet(t *testing.T) {
 			},
 		},
 		SubnetStore: &SubnetStore{
-			ResourceStore: common.ResourceStore{
+			TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 					common.TagScopeSubnetCRUID:    subnetIndexFunc,
 					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -723,7 +723,7 @@ func TestSubnetService_DeleteSubnet(t *testing.T) {
 					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 				}),
 				BindingType: model.VpcSubnetBindingType(),
-			},
+			}},
 		},
 	}
 
@@ -799,7 +799,7 @@ func TestSubnetService_DeleteSubnet(t *testing.T) {
 				patches := tt.prepareFunc()
 				defer patches.Reset()
 			}
-			err := service.DeleteSubnet(fakeSubnet)
+			err := service.DeleteSubnet(context.TODO(), fakeSubnet)
 			if tt.expectedErr != "" {
 				assert.NotNil(t, err, "Expected an error but got nil")
 				if err != nil {
@@ -846,14 +846,14 @@ func TestSubnetService_RestoreSubnetSet(t *testing.T) {
 				},
 			},
 			prepareFunc: func() *gomonkey.Patches {
-				patches := gomonkey.ApplyFunc((*SubnetStore).GetByIndex, func(s *SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(s *common.ResourceStore, key string, value string) []interface{} {
 					switch key {
 					case common.TagScopeSubnetSetCRUID:
 						assert.Equal(t, "pod-default-ns-1", value)
 					}
-					return []*model.VpcSubnet{}
+					return []interface{}{}
 				})
-				patches.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo) (*model.VpcSubnet, error) {
+				patches.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, ctx context.Context, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo, restoreMode bool) (*model.VpcSubnet, error) {
 					return nil, nil
 				})
 				return patches
@@ -874,17 +874,17 @@ func TestSubnetService_RestoreSubnetSet(t *testing.T) {
 				},
 			},
 			prepareFunc: func() *gomonkey.Patches {
-				patches := gomonkey.ApplyFunc((*SubnetStore).GetByIndex, func(s *SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(s *common.ResourceStore, key string, value string) []interface{} {
 					switch key {
 					case common.TagScopeSubnetSetCRUID:
 						assert.Equal(t, "pod-default-ns-1", value)
-						return []*model.VpcSubnet{
-							{IpAddresses: []string{"10.0.0.0/28"}},
+						return []interface{}{
+							&model.VpcSubnet{IpAddresses: []string{"10.0.0.0/28"}},
 						}
 					}
-					return []*model.VpcSubnet{}
+					return []interface{}{}
 				})
-				patches.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo) (*model.VpcSubnet, error) {
+				patches.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, ctx context.Context, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo, restoreMode bool) (*model.VpcSubnet, error) {
 					return nil, nil
 				})
 				return patches
@@ -905,14 +905,14 @@ func TestSubnetService_RestoreSubnetSet(t *testing.T) {
 				},
 			},
 			prepareFunc: func() *gomonkey.Patches {
-				patches := gomonkey.ApplyFunc((*SubnetStore).GetByIndex, func(s *SubnetStore, key string, value string) []*model.VpcSubnet {
+				patches := gomonkey.ApplyMethod(reflect.TypeOf(&common.ResourceStore{}), "GetByIndex", func(s *common.ResourceStore, key string, value string) []interface{} {
 					switch key {
 					case common.TagScopeSubnetSetCRUID:
 						assert.Equal(t, "pod-default-ns-1", value)
 					}
-					return []*model.VpcSubnet{}
+					return []interface{}{}
 				})
-				patches.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo) (*model.VpcSubnet, error) {
+				patches.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, ctx context.Context, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo, restoreMode bool) (*model.VpcSubnet, error) {
 					return nil, fmt.Errorf("mocked error")
 				})
 				return patches
@@ -2048,7 +2048,7 @@ func TestSubnetService_CreateOrUpdateSubnet_ConnectivityState(t *testing.T) {
 			},
 		},
 		SubnetStore: &SubnetStore{
-			ResourceStore: common.ResourceStore{
+			TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 					common.TagScopeSubnetCRUID:    subnetIndexFunc,
 					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -2056,7 +2056,7 @@ func TestSubnetService_CreateOrUpdateSubnet_ConnectivityState(t *testing.T) {
 					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 				}),
 				BindingType: model.VpcSubnetBindingType(),
-			},
+			}},
 		},
 	}
 
@@ -2146,7 +2146,7 @@ func TestSubnetService_CreateOrUpdateSubnet_ConnectivityState(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Reset store for each test case
 			service.SubnetStore = &SubnetStore{
-				ResourceStore: common.ResourceStore{
+				TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 					Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 						common.TagScopeSubnetCRUID:    subnetIndexFunc,
 						common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -2154,7 +2154,7 @@ func TestSubnetService_CreateOrUpdateSubnet_ConnectivityState(t *testing.T) {
 						common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 					}),
 					BindingType: model.VpcSubnetBindingType(),
-				},
+				}},
 			}
 
 			subnetCR := &v1alpha1.Subnet{
@@ -2171,7 +2171,7 @@ func TestSubnetService_CreateOrUpdateSubnet_ConnectivityState(t *testing.T) {
 			}
 
 			updateCalled := false
-			patches := gomonkey.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo) (*model.VpcSubnet, error) {
+			patches := gomonkey.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, ctx context.Context, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo, restoreMode bool) (*model.VpcSubnet, error) {
 				updateCalled = true
 				// Verify the connectivity state is correctly set
 				if tc.expectedConnectivityState != nil {
@@ -2187,7 +2187,7 @@ func TestSubnetService_CreateOrUpdateSubnet_ConnectivityState(t *testing.T) {
 			})
 			defer patches.Reset()
 
-			_, err := service.CreateOrUpdateSubnet(subnetCR, vpcResourceInfo, basicTags)
+			_, err := service.CreateOrUpdateSubnet(context.TODO(), subnetCR, vpcResourceInfo, basicTags)
 			require.NoError(t, err)
 
 			if tc.expectUpdate {
@@ -2291,7 +2291,7 @@ func TestSubnetService_CreateOrUpdateSubnet_Consistency(t *testing.T) {
 					},
 				},
 				SubnetStore: &SubnetStore{
-					ResourceStore: common.ResourceStore{
+					TypedStore: common.TypedStore[model.VpcSubnet]{ResourceStore: common.ResourceStore{
 						Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 							common.TagScopeSubnetCRUID:    subnetIndexFunc,
 							common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
@@ -2299,7 +2299,7 @@ func TestSubnetService_CreateOrUpdateSubnet_Consistency(t *testing.T) {
 							common.TagScopeNamespace:      subnetIndexNamespaceFunc,
 						}),
 						BindingType: model.VpcSubnetBindingType(),
-					},
+					}},
 				},
 			}
 
@@ -2307,7 +2307,7 @@ func TestSubnetService_CreateOrUpdateSubnet_Consistency(t *testing.T) {
 				require.NoError(t, service.SubnetStore.Apply(tc.existingSubnet))
 			}
 
-			patches := gomonkey.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo) (*model.VpcSubnet, error) {
+			patches := gomonkey.ApplyFunc((*SubnetService).createOrUpdateSubnet, func(service *SubnetService, ctx context.Context, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo, restoreMode bool) (*model.VpcSubnet, error) {
 				for _, tags := range nsxSubnet.Tags {
 					fmt.Printf("tags scope %s tag %s\n", *tags.Scope, *tags.Tag)
 				}
@@ -2316,12 +2316,12 @@ func TestSubnetService_CreateOrUpdateSubnet_Consistency(t *testing.T) {
 				}
 				return nil, nil
 			})
-			patches.ApplyFunc((*SubnetService).checkSubnetRealizeState, func(service *SubnetService, nsxSubnet *model.VpcSubnet) error {
+			patches.ApplyFunc((*SubnetService).checkSubnetRealizeState, func(service *SubnetService, ctx context.Context, nsxSubnet *model.VpcSubnet) error {
 				return nil
 			})
 			defer patches.Reset()
 
-			_, err := service.CreateOrUpdateSubnet(subnetCR, vpcResourceInfo, basicTags)
+			_, err := service.CreateOrUpdateSubnet(context.TODO(), subnetCR, vpcResourceInfo, basicTags)
 			require.NoError(t, err)
 		})
 	}
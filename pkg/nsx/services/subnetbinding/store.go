@@ -11,7 +11,7 @@ import (
 )
 
 type BindingStore struct {
-	common.ResourceStore
+	common.TypedStore[model.SubnetConnectionBindingMap]
 }
 
 const (
@@ -21,47 +21,6 @@ const (
 	bindingMapCRNameIndexKey = "bindingMapCRName"
 )
 
-func (s *BindingStore) GetByIndex(key string, value string) []*model.SubnetConnectionBindingMap {
-	bindings := make([]*model.SubnetConnectionBindingMap, 0)
-	objs := s.ResourceStore.GetByIndex(key, value)
-	for _, binding := range objs {
-		bindings = append(bindings, binding.(*model.SubnetConnectionBindingMap))
-	}
-	return bindings
-}
-
-func (s *BindingStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	binding := i.(*model.SubnetConnectionBindingMap)
-	if binding.MarkedForDelete != nil && *binding.MarkedForDelete {
-		err := s.Delete(binding)
-		if err != nil {
-			log.Error(err, "Failed to delete SubnetConnectionBindingMap", "subnetConnectionBindingMap", binding)
-			return err
-		}
-		log.Info("Deleted SubnetConnectionBindingMap from store", "subnetConnectionBindingMap", binding)
-	} else {
-		err := s.Add(binding)
-		if err != nil {
-			log.Error(err, "Failed to add SubnetConnectionBindingMap", "subnetConnectionBindingMap", binding)
-			return err
-		}
-		log.Info("Added SubnetConnectionBindingMap to store", "subnetConnectionBindingMap", binding)
-	}
-	return nil
-}
-
-func (s *BindingStore) GetByKey(key string) *model.SubnetConnectionBindingMap {
-	obj := s.ResourceStore.GetByKey(key)
-	if obj == nil {
-		return nil
-	}
-	binding := obj.(*model.SubnetConnectionBindingMap)
-	return binding
-}
-
 func (s *BindingStore) getBindingsByParentSubnet(subnetPath string) []*model.SubnetConnectionBindingMap {
 	return s.GetByIndex(parentSubnetIndexKey, subnetPath)
 }
@@ -79,12 +38,6 @@ func (s *BindingStore) getBindingsByBindingMapCRName(bindingName string, binding
 	return s.GetByIndex(bindingMapCRNameIndexKey, nn.String())
 }
 
-func (s *BindingStore) DeleteMultipleObjects(bindingMaps []*model.SubnetConnectionBindingMap) {
-	for _, bindingMap := range bindingMaps {
-		s.Delete(bindingMap)
-	}
-}
-
 func keyFunc(obj interface{}) (string, error) {
 	switch v := obj.(type) {
 	case *model.SubnetConnectionBindingMap:
@@ -154,7 +107,7 @@ func parentSubnetIndexFunc(obj interface{}) ([]string, error) {
 }
 
 func SetupStore() *BindingStore {
-	return &BindingStore{ResourceStore: common.ResourceStore{
+	return &BindingStore{TypedStore: common.TypedStore[model.SubnetConnectionBindingMap]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(
 			keyFunc, cache.Indexers{
 				bindingMapCRUIDIndexKey:      bindingMapCRUIDIndexFunc,
@@ -164,5 +117,5 @@ func SetupStore() *BindingStore {
 				common.IndexByVPCPathFuncKey: common.IndexByVPCFunc,
 			}),
 		BindingType: model.SubnetConnectionBindingMapBindingType(),
-	}}
+	}}}
 }
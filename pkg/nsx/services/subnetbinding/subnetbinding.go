@@ -50,6 +50,13 @@ func InitializeService(service servicecommon.Service) (*BindingService, error) {
 	return bindingService, nil
 }
 
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (s *BindingService) ResourceStores() map[string]*servicecommon.ResourceStore {
+	return map[string]*servicecommon.ResourceStore{
+		"subnetbindings": &s.BindingStore.ResourceStore,
+	}
+}
+
 // CreateOrUpdateSubnetConnectionBindingMap creates or updates the SubnetConnectionBindingMaps with the given
 // SubnetConnectionBindingMap CR and attaches it to the parentSubnets.
 func (s *BindingService) CreateOrUpdateSubnetConnectionBindingMap(
@@ -51,6 +51,13 @@ func InitializeService(service common.Service) (*IPReservationService, error) {
 	return ipReservationService, nil
 }
 
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (s *IPReservationService) ResourceStores() map[string]*common.ResourceStore {
+	return map[string]*common.ResourceStore{
+		"subnetipreservations": &s.IPReservationStore.ResourceStore,
+	}
+}
+
 func (s *IPReservationService) GetOrCreateSubnetIPReservation(ipReservation *v1alpha1.SubnetIPReservation, subnetPath string) (*model.DynamicIpAddressReservation, error) {
 	log.Info("Getting or creating Subnet IPReservation", "SubnetIPReservation", ipReservation.UID, "nsxSubnetPath", subnetPath)
 	nsxIPReservation := s.buildIPReservation(ipReservation, subnetPath)
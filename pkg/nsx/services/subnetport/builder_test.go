@@ -40,7 +40,7 @@ func TestBuildSubnetPort(t *testing.T) {
 				},
 			},
 		},
-		SubnetPortStore: setupStore(),
+		SubnetPortStore: SetupStore(),
 	}
 	ctx := context.Background()
 	namespace := &corev1.Namespace{}
@@ -9,19 +9,16 @@ import (
 )
 
 func (service *SubnetPortService) CleanupBeforeVPCDeletion(ctx context.Context) error {
-	objs := service.SubnetPortStore.List()
-	log.Info("Cleaning up VpcSubnetPorts", "Count", len(objs), "status", "attempting")
-	if len(objs) == 0 {
+	ports := service.SubnetPortStore.Snapshot()
+	log.Info("Cleaning up VpcSubnetPorts", "Count", len(ports), "status", "attempting")
+	if len(ports) == 0 {
 		log.Info("No VpcSubnetPorts found to clean up", "count", 0)
 		return nil
 	}
 
 	// Mark the resources for delete.
-	ports := make([]*model.VpcSubnetPort, len(objs))
-	for i, obj := range objs {
-		port := obj.(*model.VpcSubnetPort)
+	for _, port := range ports {
 		port.MarkedForDelete = &MarkedForDelete
-		ports[i] = port
 	}
 	log.Info("Starting deletion of VpcSubnetPorts", "count", len(ports))
 	err := service.builder.PagingUpdateResources(ctx, ports, common.DefaultHAPIChildrenCount, service.NSXClient, func(delObjs []*model.VpcSubnetPort) {
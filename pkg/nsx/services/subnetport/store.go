@@ -100,7 +100,7 @@ func subnetPortIndexPodNamespace(obj interface{}) ([]string, error) {
 
 // SubnetPortStore is a store for SubnetPorts
 type SubnetPortStore struct {
-	common.ResourceStore
+	common.TypedStore[model.VpcSubnetPort]
 	// PortCountInfo stores the Subnet and the information
 	// regarding SubnetPort count on that Subnet
 	PortCountInfo sync.Map
@@ -115,51 +115,6 @@ type CountInfo struct {
 	exhaustedCheckTime time.Time
 }
 
-func (vs *SubnetPortStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	subnetPort := i.(*model.VpcSubnetPort)
-	if subnetPort.MarkedForDelete != nil && *subnetPort.MarkedForDelete {
-		err := vs.Delete(subnetPort)
-		log.Debug("delete SubnetPort from store", "subnetport", subnetPort)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := vs.Add(subnetPort)
-		log.Debug("add SubnetPort to store", "subnetport", subnetPort)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (subnetPortStore *SubnetPortStore) GetByKey(key string) *model.VpcSubnetPort {
-	var subnetPort *model.VpcSubnetPort
-	obj := subnetPortStore.ResourceStore.GetByKey(key)
-	if obj != nil {
-		subnetPort = obj.(*model.VpcSubnetPort)
-	}
-	return subnetPort
-}
-
-func (subnetPortStore *SubnetPortStore) GetByIndex(key string, value string) []*model.VpcSubnetPort {
-	subnetPorts := make([]*model.VpcSubnetPort, 0)
-	objs := subnetPortStore.ResourceStore.GetByIndex(key, value)
-	for _, subnetPort := range objs {
-		subnetPorts = append(subnetPorts, subnetPort.(*model.VpcSubnetPort))
-	}
-	return subnetPorts
-}
-
-func (subnetPortStore *SubnetPortStore) DeleteMultipleObjects(ports []*model.VpcSubnetPort) {
-	for _, port := range ports {
-		subnetPortStore.Delete(port)
-	}
-}
-
 func (subnetPortStore *SubnetPortStore) GetVpcSubnetPortByUID(uid types.UID) (*model.VpcSubnetPort, error) {
 	subnetPort := &model.VpcSubnetPort{}
 	var indexResults []interface{}
@@ -183,7 +138,7 @@ func (subnetPortStore *SubnetPortStore) GetVpcSubnetPortByUID(uid types.UID) (*m
 }
 
 type VifStore struct {
-	common.ResourceStore
+	common.TypedStore[mpmodel.VirtualNetworkInterface]
 }
 
 func vifIndexByAttachmentID(obj interface{}) ([]string, error) {
@@ -199,7 +154,7 @@ func vifIndexByAttachmentID(obj interface{}) ([]string, error) {
 }
 
 func NewVifStore() VifStore {
-	return VifStore{
+	return VifStore{TypedStore: common.TypedStore[mpmodel.VirtualNetworkInterface]{
 		ResourceStore: common.ResourceStore{
 			Indexer: cache.NewIndexer(
 				keyFunc,
@@ -208,22 +163,21 @@ func NewVifStore() VifStore {
 				}),
 			BindingType: model.VirtualNetworkInterfaceBindingType(),
 		},
-	}
+	}}
 }
 
 func (vifStore *VifStore) GetMACByAttachmentID(attachmentID string) (string, error) {
 	macAddresses := sets.New[string]()
-	objects := vifStore.ResourceStore.GetByIndex(common.IndexKeyAttachmentID, attachmentID)
-	if len(objects) == 0 {
+	vifs := vifStore.GetByIndex(common.IndexKeyAttachmentID, attachmentID)
+	if len(vifs) == 0 {
 		return "", fmt.Errorf("VIF not found for attachment ID: %s", attachmentID)
 	}
 	// We observed in some cases, multiple VIFs are returned for the same attachment ID and their MAC addresses are the same.
 	// Not sure whether it's a NSX API bug or expected behavior. Whatever, we log a warning here, then continue because this may not break our logic, i.e. multiple vifs may have the same MAC address.
-	if len(objects) > 1 {
-		log.Warn("Multiple VIFs found for attachment ID", "attachmentID", attachmentID, "objects", objects)
+	if len(vifs) > 1 {
+		log.Warn("Multiple VIFs found for attachment ID", "attachmentID", attachmentID, "objects", vifs)
 	}
-	for _, obj := range objects {
-		vif := obj.(*mpmodel.VirtualNetworkInterface)
+	for _, vif := range vifs {
 		if vif.MacAddress != nil && *vif.MacAddress != "" {
 			macAddresses.Insert(*vif.MacAddress)
 		}
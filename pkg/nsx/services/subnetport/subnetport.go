@@ -58,7 +58,7 @@ func InitializeSubnetPort(service servicecommon.Service, vpcService servicecommo
 		builder:                    builder,
 	}
 
-	subnetPortService.SubnetPortStore = setupStore()
+	subnetPortService.SubnetPortStore = SetupStore()
 
 	go subnetPortService.InitializeResourceStore(&wg, fatalErrors, ResourceTypeSubnetPort, nil, subnetPortService.SubnetPortStore)
 	go func() {
@@ -76,9 +76,9 @@ func InitializeSubnetPort(service servicecommon.Service, vpcService servicecommo
 	return subnetPortService, nil
 }
 
-func setupStore() *SubnetPortStore {
+func SetupStore() *SubnetPortStore {
 	return &SubnetPortStore{
-		ResourceStore: servicecommon.ResourceStore{
+		TypedStore: servicecommon.TypedStore[model.VpcSubnetPort]{ResourceStore: servicecommon.ResourceStore{
 			Indexer: cache.NewIndexer(
 				keyFunc,
 				cache.Indexers{
@@ -90,7 +90,14 @@ func setupStore() *SubnetPortStore {
 					servicecommon.IndexKeySubnetPath: subnetPortIndexBySubnetPath,
 				}),
 			BindingType: model.VpcSubnetPortBindingType(),
-		}}
+		}}}
+}
+
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (service *SubnetPortService) ResourceStores() map[string]*servicecommon.ResourceStore {
+	return map[string]*servicecommon.ResourceStore{
+		"subnetports": &service.SubnetPortStore.ResourceStore,
+	}
 }
 
 func (service *SubnetPortService) portAlreadyRealized(obj interface{}, nsxSubnetPort *model.VpcSubnetPort) bool {
@@ -123,7 +130,7 @@ func (service *SubnetPortService) portAlreadyRealized(obj interface{}, nsxSubnet
 	return false
 }
 
-func (service *SubnetPortService) CreateOrUpdateSubnetPort(obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
+func (service *SubnetPortService) CreateOrUpdateSubnetPort(ctx context.Context, obj interface{}, nsxSubnet *model.VpcSubnet, contextID string, tags *map[string]string, isVmSubnetPort bool, restoreMode bool) (*model.SegmentPortState, bool, error) {
 	var uid string
 	switch o := obj.(type) {
 	case *v1alpha1.SubnetPort:
@@ -160,6 +167,13 @@ func (service *SubnetPortService) CreateOrUpdateSubnetPort(obj interface{}, nsxS
 		}
 	} else {
 		log.Info("Updating the NSX subnet port", "existingSubnetPort", existingSubnetPort, "desiredSubnetPort", nsxSubnetPort)
+		if metaObj, _ := obj.(metav1.Object); service.PlanModeEnabled(ctx, metaObj) {
+			plan := &servicecommon.Plan{}
+			if err := plan.Add(&service.SubnetPortStore.ResourceStore, nsxSubnetPort); err != nil {
+				return nil, false, err
+			}
+			return nil, false, plan.Err()
+		}
 		err = service.NSXClient.PortClient.Patch(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID, *nsxSubnetPort.Id, *nsxSubnetPort)
 		err = nsxutil.TransNSXApiError(err)
 		if err != nil {
@@ -243,7 +257,11 @@ func (service *SubnetPortService) CheckSubnetPortState(obj interface{}, nsxSubne
 	portID := *nsxSubnetPort.Id
 	realizeService := realizestate.InitializeRealizeState(service.Service)
 
-	if err := realizeService.CheckRealizeState(util.NSXTRealizeRetry, *nsxSubnetPort.Path, []string{}); err != nil {
+	if err := realizeService.PollRealizeState(context.TODO(), util.NSXTRealizeRetry, *nsxSubnetPort.Path, []string{}); err != nil {
+		if nsxutil.IsRealizePendingError(err) {
+			log.Info("The SubnetPort is not realized yet", "nsxSubnetPortPath", *nsxSubnetPort.Path)
+			return nil, err
+		}
 		log.Error(err, "Failed to get realized status", "nsxSubnetPortPath", *nsxSubnetPort.Path)
 		if nsxutil.IsRealizeStateError(err) {
 			realizedStateErr := err.(*nsxutil.RealizeStateError)
@@ -252,7 +270,7 @@ func (service *SubnetPortService) CheckSubnetPortState(obj interface{}, nsxSubne
 			}
 			log.Error(err, "The created SubnetPort is in error realization state, cleaning the resource", "SubnetPort", portID)
 			// only recreate subnet port on RealizationErrorStateError.
-			if err := service.DeleteSubnetPortById(portID); err != nil {
+			if err := service.DeleteSubnetPortById(context.TODO(), portID); err != nil {
 				log.Error(err, "Cleanup error SubnetPort failed", "SubnetPort", portID)
 				return nil, err
 			}
@@ -279,9 +297,16 @@ func (service *SubnetPortService) GetSubnetPortState(nsxSubnetPortID string, nsx
 	return &nsxSubnetPortState, nil
 }
 
-func (service *SubnetPortService) DeleteSubnetPort(nsxSubnetPort *model.VpcSubnetPort) error {
+func (service *SubnetPortService) DeleteSubnetPort(ctx context.Context, nsxSubnetPort *model.VpcSubnetPort) error {
+	if service.PlanModeEnabled(ctx, nil) {
+		plan := &servicecommon.Plan{}
+		if err := plan.Delete(&service.SubnetPortStore.ResourceStore, nsxSubnetPort); err != nil {
+			return err
+		}
+		return plan.Err()
+	}
 	subnetPortInfo, _ := servicecommon.ParseVPCResourcePath(*nsxSubnetPort.Path)
-	err := service.NSXClient.PortClient.Delete(subnetPortInfo.OrgID, subnetPortInfo.ProjectID, subnetPortInfo.VPCID, subnetPortInfo.ParentID, *nsxSubnetPort.Id)
+	err := service.NSXClientWithContext(ctx).PortClient.Delete(subnetPortInfo.OrgID, subnetPortInfo.ProjectID, subnetPortInfo.VPCID, subnetPortInfo.ParentID, *nsxSubnetPort.Id)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "failed to delete nsxSubnetPort", "nsxSubnetPort.Path", *nsxSubnetPort.Path)
@@ -294,13 +319,13 @@ func (service *SubnetPortService) DeleteSubnetPort(nsxSubnetPort *model.VpcSubne
 	return nil
 }
 
-func (service *SubnetPortService) DeleteSubnetPortById(portID string) error {
+func (service *SubnetPortService) DeleteSubnetPortById(ctx context.Context, portID string) error {
 	nsxSubnetPort := service.SubnetPortStore.GetByKey(portID)
 	if nsxSubnetPort == nil || nsxSubnetPort.Id == nil {
 		log.Info("NSX subnet port is not found in store, skip deleting it", "id", portID)
 		return nil
 	}
-	return service.DeleteSubnetPort(nsxSubnetPort)
+	return service.DeleteSubnetPort(ctx, nsxSubnetPort)
 }
 
 func (service *SubnetPortService) ListNSXSubnetPortIDForCR() sets.Set[string] {
@@ -201,7 +201,7 @@ func TestSubnetPortService_CreateOrUpdateSubnetPort(t *testing.T) {
 	builder, _ := common.PolicyPathVpcSubnetPort.NewPolicyTreeBuilder()
 	service := &SubnetPortService{
 		Service: commonService,
-		SubnetPortStore: &SubnetPortStore{ResourceStore: common.ResourceStore{
+		SubnetPortStore: &SubnetPortStore{TypedStore: common.TypedStore[model.VpcSubnetPort]{ResourceStore: common.ResourceStore{
 			Indexer: cache.NewIndexer(
 				keyFunc,
 				cache.Indexers{
@@ -209,7 +209,7 @@ func TestSubnetPortService_CreateOrUpdateSubnetPort(t *testing.T) {
 					common.TagScopePodUID:          subnetPortIndexByPodUID,
 				}),
 			BindingType: model.VpcSubnetPortBindingType(),
-		}},
+		}}},
 		builder: builder,
 	}
 
@@ -414,7 +414,7 @@ func TestSubnetPortService_CreateOrUpdateSubnetPort(t *testing.T) {
 			if patches != nil {
 				defer patches.Reset()
 			}
-			_, enableDHCP, err := service.CreateOrUpdateSubnetPort(subnetPortCR, tt.nsxSubnet, "", nil, false, false)
+			_, enableDHCP, err := service.CreateOrUpdateSubnetPort(context.TODO(), subnetPortCR, tt.nsxSubnet, "", nil, false, false)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateSubnetPort() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -477,7 +477,7 @@ func TestSubnetPortService_DeleteSubnetPort(t *testing.T) {
 			}
 			service := &SubnetPortService{
 				Service: commonService,
-				SubnetPortStore: &SubnetPortStore{ResourceStore: common.ResourceStore{
+				SubnetPortStore: &SubnetPortStore{TypedStore: common.TypedStore[model.VpcSubnetPort]{ResourceStore: common.ResourceStore{
 					Indexer: cache.NewIndexer(
 						keyFunc,
 						cache.Indexers{
@@ -485,7 +485,7 @@ func TestSubnetPortService_DeleteSubnetPort(t *testing.T) {
 							common.TagScopePodUID:          subnetPortIndexByPodUID,
 						}),
 					BindingType: model.VpcSubnetPortBindingType(),
-				}},
+				}}},
 			}
 
 			patches := tt.prepareFunc(service)
@@ -493,7 +493,7 @@ func TestSubnetPortService_DeleteSubnetPort(t *testing.T) {
 				defer patches.Reset()
 			}
 
-			if err := service.DeleteSubnetPortById(subnetPortId1); (err != nil) != tt.wantErr {
+			if err := service.DeleteSubnetPortById(context.TODO(), subnetPortId1); (err != nil) != tt.wantErr {
 				t.Errorf("DeleteSubnetPort() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -557,7 +557,7 @@ func TestSubnetPortService_GetSubnetPathForSubnetPortFromStore(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			service := &SubnetPortService{
-				SubnetPortStore: &SubnetPortStore{ResourceStore: common.ResourceStore{
+				SubnetPortStore: &SubnetPortStore{TypedStore: common.TypedStore[model.VpcSubnetPort]{ResourceStore: common.ResourceStore{
 					Indexer: cache.NewIndexer(
 						keyFunc,
 						cache.Indexers{
@@ -565,7 +565,7 @@ func TestSubnetPortService_GetSubnetPathForSubnetPortFromStore(t *testing.T) {
 							common.TagScopePodUID:          subnetPortIndexByPodUID,
 						}),
 					BindingType: model.VpcSubnetPortBindingType(),
-				}},
+				}}},
 			}
 
 			if tt.args.obj != nil {
@@ -586,14 +586,14 @@ func TestSubnetPortService_GetPortsOfSubnet(t *testing.T) {
 		ParentPath: &subnetPath,
 	}
 	service := &SubnetPortService{
-		SubnetPortStore: &SubnetPortStore{ResourceStore: common.ResourceStore{
+		SubnetPortStore: &SubnetPortStore{TypedStore: common.TypedStore[model.VpcSubnetPort]{ResourceStore: common.ResourceStore{
 			Indexer: cache.NewIndexer(
 				keyFunc,
 				cache.Indexers{
 					common.IndexKeySubnetPath: subnetPortIndexBySubnetPath,
 				}),
 			BindingType: model.VpcSubnetPortBindingType(),
-		}},
+		}}},
 	}
 	service.SubnetPortStore.Add(&port)
 	ports := service.GetPortsOfSubnet(subnetPath)
@@ -620,14 +620,14 @@ func TestSubnetPortService_Cleanup(t *testing.T) {
 				OrgRootClient: mockOrgRootClient,
 			},
 		},
-		SubnetPortStore: &SubnetPortStore{ResourceStore: common.ResourceStore{
+		SubnetPortStore: &SubnetPortStore{TypedStore: common.TypedStore[model.VpcSubnetPort]{ResourceStore: common.ResourceStore{
 			Indexer: cache.NewIndexer(
 				keyFunc,
 				cache.Indexers{
 					common.IndexKeySubnetPath: subnetPortIndexBySubnetPath,
 				}),
 			BindingType: model.VpcSubnetPortBindingType(),
-		}},
+		}}},
 		builder: builder,
 	}
 
@@ -1167,7 +1167,7 @@ func createSubnetPortService(t *testing.T) *SubnetPortService {
 	builder, _ := common.PolicyPathVpcSubnetPort.NewPolicyTreeBuilder()
 	return &SubnetPortService{
 		Service: commonService,
-		SubnetPortStore: &SubnetPortStore{ResourceStore: common.ResourceStore{
+		SubnetPortStore: &SubnetPortStore{TypedStore: common.TypedStore[model.VpcSubnetPort]{ResourceStore: common.ResourceStore{
 			Indexer: cache.NewIndexer(
 				keyFunc,
 				cache.Indexers{
@@ -1178,7 +1178,7 @@ func createSubnetPortService(t *testing.T) *SubnetPortService {
 					common.IndexKeySubnetPath:      subnetPortIndexBySubnetPath,
 				}),
 			BindingType: model.VpcSubnetPortBindingType(),
-		}},
+		}}},
 		builder: builder,
 	}
 }
@@ -35,13 +35,13 @@ var (
 
 func TestListAutoCreatedVPCPaths(t *testing.T) {
 	vpcService := &VPCService{
-		VpcStore: &VPCStore{ResourceStore: common.ResourceStore{
+		VpcStore: &VPCStore{TypedStore: common.TypedStore[model.Vpc]{ResourceStore: common.ResourceStore{
 			Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 				common.TagScopeNamespaceUID: vpcIndexNamespaceIDFunc,
 				common.TagScopeNamespace:    vpcIndexNamespaceNameFunc,
 			}),
 			BindingType: model.VpcBindingType(),
-		}},
+		}}},
 	}
 
 	vpc1 := &model.Vpc{
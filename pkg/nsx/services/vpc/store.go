@@ -75,37 +75,7 @@ func filterTagBy(v []model.Tag, tagScope string) []string {
 
 // VPCStore is a store for VPCs
 type VPCStore struct {
-	common.ResourceStore
-}
-
-func (s *VPCStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	vpc := i.(*model.Vpc)
-	if vpc.MarkedForDelete != nil && *vpc.MarkedForDelete {
-		err := s.Delete(vpc)
-		log.Debug("delete VPC from store", "VPC", vpc)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := s.Add(vpc)
-		log.Debug("add VPC to store", "VPC", vpc)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (s *VPCStore) GetByIndex(key string, value string) []*model.Vpc {
-	vpcs := make([]*model.Vpc, 0)
-	objs := s.ResourceStore.GetByIndex(key, value)
-	for _, vpc := range objs {
-		vpcs = append(vpcs, vpc.(*model.Vpc))
-	}
-	return vpcs
+	common.TypedStore[model.Vpc]
 }
 
 func (s *VPCStore) GetVPCsByNamespaceFromStore(ns string) []*model.Vpc {
@@ -116,16 +86,8 @@ func (s *VPCStore) GetVPCsByNamespaceIDFromStore(namespaceID string) []*model.Vp
 	return s.GetByIndex(common.TagScopeNamespaceUID, namespaceID)
 }
 
-func (s *VPCStore) GetByKey(key string) *model.Vpc {
-	obj := s.ResourceStore.GetByKey(key)
-	if obj != nil {
-		vpc := obj.(*model.Vpc)
-		return vpc
-	}
-	return nil
-}
-
-// ResourceStore is a store to query nsx resource
+// ResourceStore holds the results of a one-off query whose resource type is chosen at runtime, e.g. the SLB
+// resources found in the cleanup, so it isn't a TypedStore. The results are only listed, Apply is a no-op.
 type ResourceStore struct {
 	common.ResourceStore
 }
@@ -136,45 +98,9 @@ func (r *ResourceStore) Apply(i interface{}) error {
 
 // LBSStore is a store for LBS
 type LBSStore struct {
-	common.ResourceStore
-}
-
-func (ls *LBSStore) Apply(i interface{}) error {
-	if i == nil {
-		return nil
-	}
-	lbs := i.(*model.LBService)
-	if lbs.MarkedForDelete != nil && *lbs.MarkedForDelete {
-		err := ls.Delete(lbs)
-		log.Debug("delete LBS from store", "LBS", lbs)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := ls.Add(lbs)
-		log.Debug("add LBS to store", "LBS", lbs)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	common.TypedStore[model.LBService]
 }
 
 func (ls *LBSStore) GetByKey(vpcID string) *model.LBService {
-	key := combineVPCIDAndLBSID(vpcID, defaultLBSName)
-	obj := ls.ResourceStore.GetByKey(key)
-	if obj != nil {
-		lbs := obj.(*model.LBService)
-		return lbs
-	}
-	return nil
-}
-
-func (ls *LBSStore) GetByIndex(key string, value string) []*model.LBService {
-	lbss := make([]*model.LBService, 0)
-	objs := ls.ResourceStore.GetByIndex(key, value)
-	for _, lbs := range objs {
-		lbss = append(lbss, lbs.(*model.LBService))
-	}
-	return lbss
+	return ls.TypedStore.GetByKey(combineVPCIDAndLBSID(vpcID, defaultLBSName))
 }
@@ -72,10 +72,10 @@ func Test_InitializeVPCStore(t *testing.T) {
 	cluster, _ := nsx.NewCluster(config2)
 	rc := cluster.NewRestConnector()
 	vpcCacheIndexer := cache.NewIndexer(keyFunc, cache.Indexers{})
-	vpcStore := &VPCStore{ResourceStore: common.ResourceStore{
+	vpcStore := &VPCStore{TypedStore: common.TypedStore[model.Vpc]{ResourceStore: common.ResourceStore{
 		Indexer:     vpcCacheIndexer,
 		BindingType: model.VpcBindingType(),
-	}}
+	}}}
 
 	service := VPCService{
 		Service: common.Service{
@@ -125,7 +125,7 @@ func TestVPCStore_CRUDResource(t *testing.T) {
 		Indexer:     vpcCacheIndexer,
 		BindingType: model.VpcBindingType(),
 	}
-	vpcStore := &VPCStore{ResourceStore: resourceStore}
+	vpcStore := &VPCStore{TypedStore: common.TypedStore[model.Vpc]{ResourceStore: resourceStore}}
 	type args struct {
 		i interface{}
 	}
@@ -152,7 +152,7 @@ func TestVPCStore_CRUDResource_List(t *testing.T) {
 		Indexer:     vpcCacheIndexer,
 		BindingType: model.VpcBindingType(),
 	}
-	vpcStore := &VPCStore{ResourceStore: resourceStore}
+	vpcStore := &VPCStore{TypedStore: common.TypedStore[model.Vpc]{ResourceStore: resourceStore}}
 	ns1 := "test-ns-1"
 	ns1UID := "fakeNamespace1UID"
 	tag1 := []model.Tag{
@@ -315,7 +315,7 @@ func TestLBSStore_CRUD(t *testing.T) {
 		BindingType: model.LBServiceBindingType(),
 	}
 	ls := &LBSStore{
-		ResourceStore: resourceStore,
+		TypedStore: common.TypedStore[model.LBService]{ResourceStore: resourceStore},
 	}
 	lbs1 := &model.LBService{Id: &defaultLBSName, ConnectivityPath: &vpcID1}
 	lbs2 := &model.LBService{Id: &defaultLBSName, ConnectivityPath: &vpcID2}
@@ -328,8 +328,5 @@ func TestLBSStore_CRUD(t *testing.T) {
 	require.NoError(t, ls.Apply(lbs2))
 	require.Equal(t, 1, len(ls.List()))
 	require.Nil(t, ls.GetByKey(vpcID2))
-	defer func() {
-		require.NotNil(t, recover())
-	}()
-	ls.Apply(&model.AntreaTraceflowConfig{Id: common.String("invalid")})
+	require.Error(t, ls.Apply(&model.AntreaTraceflowConfig{Id: common.String("invalid")}))
 }
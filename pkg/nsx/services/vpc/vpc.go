@@ -58,6 +58,14 @@ type VPCService struct {
 	LbsStore *LBSStore
 }
 
+// ResourceStores returns the stores of the service by name, they are dumped by the debug server.
+func (s *VPCService) ResourceStores() map[string]*common.ResourceStore {
+	return map[string]*common.ResourceStore{
+		"vpcs":          &s.VpcStore.ResourceStore,
+		"loadbalancers": &s.LbsStore.ResourceStore,
+	}
+}
+
 func (s *VPCService) GetDefaultNetworkConfig() (*v1alpha1.VPCNetworkConfiguration, error) {
 	vpcNetworkConfigList := &v1alpha1.VPCNetworkConfigurationList{}
 	err := s.Client.List(context.Background(), vpcNetworkConfigList)
@@ -162,19 +170,19 @@ func InitializeVPC(service common.Service) (*VPCService, error) {
 	fatalErrors := make(chan error, 2)
 
 	VPCService := &VPCService{Service: service}
-	VPCService.VpcStore = &VPCStore{ResourceStore: common.ResourceStore{
+	VPCService.VpcStore = &VPCStore{TypedStore: common.TypedStore[model.Vpc]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 			common.TagScopeNamespaceUID: vpcIndexNamespaceIDFunc,
 			common.TagScopeNamespace:    vpcIndexNamespaceNameFunc,
 			nsxVpcNameIndexKey:          vpcIndexVpcNameFunc,
 		}),
 		BindingType: model.VpcBindingType(),
-	}}
+	}}}
 
-	VPCService.LbsStore = &LBSStore{ResourceStore: common.ResourceStore{
+	VPCService.LbsStore = &LBSStore{TypedStore: common.TypedStore[model.LBService]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.LBServiceBindingType(),
-	}}
+	}}}
 	// Note: waitgroup.Add must be called before its consumptions.
 	wg.Add(2)
 	// initialize vpc store, lbs store
@@ -222,12 +230,21 @@ func (s *VPCService) ListVPC() []model.Vpc {
 }
 
 // DeleteVPC will try to delete VPC resource from NSX.
-func (s *VPCService) DeleteVPC(path string) error {
+func (s *VPCService) DeleteVPC(ctx context.Context, path string) error {
 	pathInfo, err := common.ParseVPCResourcePath(path)
 	if err != nil {
 		return err
 	}
-	vpcClient := s.NSXClient.VPCClient
+	if s.PlanModeEnabled(ctx, nil) {
+		plan := &common.Plan{}
+		if vpc := s.VpcStore.GetByKey(pathInfo.VPCID); vpc != nil {
+			if err := plan.Delete(&s.VpcStore.ResourceStore, vpc); err != nil {
+				return err
+			}
+		}
+		return plan.Err()
+	}
+	vpcClient := s.NSXClientWithContext(ctx).VPCClient
 
 	if err := vpcClient.Delete(pathInfo.OrgID, pathInfo.ProjectID, pathInfo.VPCID, common.Bool(true)); err != nil {
 		err = nsxutil.TransNSXApiError(err)
@@ -595,6 +612,19 @@ func (s *VPCService) CreateOrUpdateVPC(ctx context.Context, obj *v1alpha1.Networ
 		return existingVPC[0], nil
 	}
 
+	if s.PlanModeEnabled(ctx, obj) {
+		plan := &common.Plan{}
+		if err := plan.Add(&s.VpcStore.ResourceStore, createdVpc); err != nil {
+			return nil, err
+		}
+		if createdLBS != nil {
+			if err := plan.Add(&s.LbsStore.ResourceStore, createdLBS); err != nil {
+				return nil, err
+			}
+		}
+		return nil, plan.Err()
+	}
+
 	orgRoot, err := s.WrapHierarchyVPC(org, project, createdVpc, createdLBS, createdAttachment)
 	if err != nil {
 		log.Error(err, "Failed to build HAPI request")
@@ -666,12 +696,12 @@ func (s *VPCService) createNSXVPC(createdVpc *model.Vpc, nc *v1alpha1.VPCNetwork
 func (s *VPCService) checkVPCRealizationState(createdVpc *model.Vpc, newVpcPath string) error {
 	log.Trace("Check VPC realization state", "VPC", *createdVpc.Id)
 	realizeService := realizestate.InitializeRealizeState(s.Service)
-	if err := realizeService.CheckRealizeState(util.NSXTRealizeRetry, newVpcPath, []string{common.GatewayInterfaceId}); err != nil {
+	if err := realizeService.CheckRealizeState(context.TODO(), util.NSXTRealizeRetry, newVpcPath, []string{common.GatewayInterfaceId}); err != nil {
 		log.Error(err, "Failed to check VPC realization state", "VPC", *createdVpc.Id)
 		if nsxutil.IsRealizeStateError(err) {
 			log.Error(err, "The created VPC is in error realization state, cleaning the resource", "VPC", *createdVpc.Id)
 			// delete the nsx vpc object and re-create it in the next loop
-			if err := s.DeleteVPC(newVpcPath); err != nil {
+			if err := s.DeleteVPC(context.TODO(), newVpcPath); err != nil {
 				log.Error(err, "Cleanup VPC failed", "VPC", *createdVpc.Id)
 				return err
 			}
@@ -702,12 +732,12 @@ func (s *VPCService) checkLBSRealization(createdLBS *model.LBService, createdVpc
 
 	log.Trace("Check LBS realization state", "LBS", *createdLBS.Id)
 	realizeService := realizestate.InitializeRealizeState(s.Service)
-	if err = realizeService.CheckRealizeState(util.NSXTRealizeRetry, *newLBS.Path, []string{}); err != nil {
+	if err = realizeService.CheckRealizeState(context.TODO(), util.NSXTRealizeRetry, *newLBS.Path, []string{}); err != nil {
 		log.Error(err, "Failed to check LBS realization state", "LBS", *createdLBS.Id)
 		if nsxutil.IsRealizeStateError(err) {
 			log.Error(err, "The created LBS is in error realization state, cleaning the resource", "LBS", *createdLBS.Id)
 			// delete the nsx vpc object and re-create it in the next loop
-			if err := s.DeleteVPC(newVpcPath); err != nil {
+			if err := s.DeleteVPC(context.TODO(), newVpcPath); err != nil {
 				log.Error(err, "Cleanup VPC failed", "VPC", *createdVpc.Id)
 				return err
 			}
@@ -736,12 +766,12 @@ func (s *VPCService) checkVpcAttachmentRealization(createdAttachment *model.VpcA
 	}
 	log.Trace("Check VPC attachment realization state", "VpcAttachment", *createdAttachment.Id)
 	realizeService := realizestate.InitializeRealizeState(s.Service)
-	if err = realizeService.CheckRealizeState(util.NSXTRealizeRetry, *newAttachment.Path, []string{}); err != nil {
+	if err = realizeService.CheckRealizeState(context.TODO(), util.NSXTRealizeRetry, *newAttachment.Path, []string{}); err != nil {
 		log.Error(err, "Failed to check VPC attachment realization state", "VpcAttachment", *createdAttachment.Id)
 		if nsxutil.IsRealizeStateError(err) {
 			log.Error(err, "The created VPC attachment is in error realization state, cleaning the resource", "VpcAttachment", *createdAttachment.Id)
 			// delete the nsx vpc object and re-create it in the next loop
-			if err := s.DeleteVPC(newVpcPath); err != nil {
+			if err := s.DeleteVPC(context.TODO(), newVpcPath); err != nil {
 				log.Error(err, "Cleanup VPC failed", "VPC", *createdVpc.Id)
 				return err
 			}
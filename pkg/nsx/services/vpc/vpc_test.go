@@ -68,19 +68,19 @@ func createService(t *testing.T) (*VPCService, *gomock.Controller, *mocks.MockVp
 	mockTransitStateClient := mock_stateclient.NewMockStateClient(mockCtrl)
 	k8sClient := mock_client.NewMockClient(mockCtrl)
 
-	vpcStore := &VPCStore{ResourceStore: common.ResourceStore{
+	vpcStore := &VPCStore{TypedStore: common.TypedStore[model.Vpc]{ResourceStore: common.ResourceStore{
 		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
 			common.TagScopeNamespaceUID: vpcIndexNamespaceIDFunc,
 			common.TagScopeNamespace:    vpcIndexNamespaceNameFunc,
 			nsxVpcNameIndexKey:          vpcIndexVpcNameFunc,
 		}),
 		BindingType: model.VpcBindingType(),
-	}}
+	}}}
 
-	lbsStore := &LBSStore{ResourceStore: common.ResourceStore{
+	lbsStore := &LBSStore{TypedStore: common.TypedStore[model.LBService]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.LBServiceBindingType(),
-	}}
+	}}}
 
 	service := &VPCService{
 		Service: common.Service{
@@ -330,7 +330,7 @@ func TestGetVPCsByNamespace(t *testing.T) {
 		Indexer:     vpcCacheIndexer,
 		BindingType: model.VpcBindingType(),
 	}
-	vpcStore := &VPCStore{ResourceStore: resourceStore}
+	vpcStore := &VPCStore{TypedStore: common.TypedStore[model.Vpc]{ResourceStore: resourceStore}}
 	service := &VPCService{
 		Service: common.Service{NSXClient: nil},
 	}
@@ -551,10 +551,10 @@ func TestGetLBProvider(t *testing.T) {
 				Cluster: &nsx.Cluster{},
 			},
 		},
-		LbsStore: &LBSStore{ResourceStore: common.ResourceStore{
+		LbsStore: &LBSStore{TypedStore: common.TypedStore[model.LBService]{ResourceStore: common.ResourceStore{
 			Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 			BindingType: model.LBServiceBindingType(),
-		}},
+		}}},
 	}
 
 	testCases := []struct {
@@ -657,10 +657,10 @@ func TestGetLbProvider(t *testing.T) {
 				Cluster: &nsx.Cluster{},
 			},
 		},
-		LbsStore: &LBSStore{ResourceStore: common.ResourceStore{
+		LbsStore: &LBSStore{TypedStore: common.TypedStore[model.LBService]{ResourceStore: common.ResourceStore{
 			Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 			BindingType: model.LBServiceBindingType(),
-		}},
+		}}},
 	}
 	// Test when UseAVILoadBalancer is false
 	patch := gomonkey.ApplyPrivateMethod(reflect.TypeOf(vpcService.Service.NSXClient.Cluster), "HttpGet", func(_ *nsx.Cluster, path string) (map[string]interface{}, error) {
@@ -1651,6 +1651,16 @@ func TestVPCService_GetNamespacesByNetworkconfigName(t *testing.T) {
 	}
 }
 
+// deleteErrorIndexer fails to delete the objects from the store.
+type deleteErrorIndexer struct {
+	cache.Indexer
+	err error
+}
+
+func (i *deleteErrorIndexer) Delete(_ interface{}) error {
+	return i.err
+}
+
 func TestVPCService_DeleteVPC(t *testing.T) {
 	mockVpc := "mockVpc"
 	mockLb := "mockLb"
@@ -1723,19 +1733,16 @@ func TestVPCService_DeleteVPC(t *testing.T) {
 		},
 		{
 			name: "delete vpc store fail",
-			prepareFunc: func(_ *testing.T, service *VPCService) (patches *gomonkey.Patches) {
+			prepareFunc: func(t *testing.T, service *VPCService) (patches *gomonkey.Patches) {
 				patches = gomonkey.ApplyMethodSeq(reflect.TypeOf(service.NSXClient.VPCClient), "Delete", []gomonkey.OutputCell{{
 					Values: gomonkey.Params{
 						nil,
 					},
 					Times: 1,
 				}})
-				patches.ApplyMethodSeq(reflect.TypeOf(service.VpcStore), "Apply", []gomonkey.OutputCell{{
-					Values: gomonkey.Params{
-						fakeErr,
-					},
-					Times: 1,
-				}})
+				indexer := service.VpcStore.Indexer
+				service.VpcStore.Indexer = &deleteErrorIndexer{Indexer: indexer, err: fakeErr}
+				t.Cleanup(func() { service.VpcStore.Indexer = indexer })
 				return patches
 			},
 			Lb: &model.LBService{
@@ -1761,12 +1768,6 @@ func TestVPCService_DeleteVPC(t *testing.T) {
 					},
 					Times: 1,
 				}})
-				patches.ApplyMethodSeq(reflect.TypeOf(service.VpcStore), "Apply", []gomonkey.OutputCell{{
-					Values: gomonkey.Params{
-						nil,
-					},
-					Times: 1,
-				}})
 				return patches
 			},
 			Lb: &model.LBService{
@@ -1801,7 +1802,7 @@ func TestVPCService_DeleteVPC(t *testing.T) {
 				service.VpcStore.Add(tt.Vpc)
 			}
 
-			err := service.DeleteVPC(tt.path)
+			err := service.DeleteVPC(context.TODO(), tt.path)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("VPCService.DeleteVPC() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -1899,15 +1900,15 @@ func createFakeVPCService(t *testing.T, objs []client.Object) *VPCService {
 	mockCtrl := gomock.NewController(t)
 	mockVpcClient := mocks.NewMockVpcsClient(mockCtrl)
 
-	vpcStore := &VPCStore{ResourceStore: common.ResourceStore{
+	vpcStore := &VPCStore{TypedStore: common.TypedStore[model.Vpc]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.VpcBindingType(),
-	}}
+	}}}
 
-	lbsStore := &LBSStore{ResourceStore: common.ResourceStore{
+	lbsStore := &LBSStore{TypedStore: common.TypedStore[model.LBService]{ResourceStore: common.ResourceStore{
 		Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 		BindingType: model.VpcBindingType(),
-	}}
+	}}}
 
 	service := &VPCService{
 		Service: common.Service{
@@ -2380,7 +2381,7 @@ func TestVPCService_checkVPCRealizationState(t *testing.T) {
 							},
 						}, nil
 					})
-				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, path string) error {
+				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, _ context.Context, path string) error {
 					return nil
 				})
 				return patches
@@ -2401,7 +2402,7 @@ func TestVPCService_checkVPCRealizationState(t *testing.T) {
 							},
 						}, nil
 					})
-				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, path string) error {
+				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, _ context.Context, path string) error {
 					return fmt.Errorf("mocked deletion error")
 				})
 				return patches
@@ -2489,7 +2490,7 @@ func TestVPCService_checkLBSRealization(t *testing.T) {
 							},
 						}, nil
 					})
-				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, path string) error {
+				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, _ context.Context, path string) error {
 					return nil
 				})
 				return patches
@@ -2514,7 +2515,7 @@ func TestVPCService_checkLBSRealization(t *testing.T) {
 							},
 						}, nil
 					})
-				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, path string) error {
+				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, _ context.Context, path string) error {
 					return fmt.Errorf("mocked deletion error")
 				})
 				return patches
@@ -2608,7 +2609,7 @@ func TestVPCService_checkVpcAttachmentRealization(t *testing.T) {
 							},
 						}, nil
 					})
-				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, path string) error {
+				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, _ context.Context, path string) error {
 					return nil
 				})
 				return patches
@@ -2633,7 +2634,7 @@ func TestVPCService_checkVpcAttachmentRealization(t *testing.T) {
 							},
 						}, nil
 					})
-				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, path string) error {
+				patches.ApplyMethod(service, "DeleteVPC", func(s *VPCService, _ context.Context, path string) error {
 					return fmt.Errorf("mocked deletion error")
 				})
 				return patches
@@ -2766,10 +2767,10 @@ func TestGetNSXLBSNATIP(t *testing.T) {
 				IPAddressAllocationClient: &fakeIPAddressAllocationClientInstance,
 			},
 		},
-		LbsStore: &LBSStore{ResourceStore: common.ResourceStore{
+		LbsStore: &LBSStore{TypedStore: common.TypedStore[model.LBService]{ResourceStore: common.ResourceStore{
 			Indexer:     cache.NewIndexer(keyFunc, cache.Indexers{}),
 			BindingType: model.LBServiceBindingType(),
-		}},
+		}}},
 	}
 
 	vpc1 := model.Vpc{
@@ -0,0 +1,80 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/core"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
+	"github.com/vmware-tanzu/nsx-operator/pkg/tracing"
+)
+
+// contextConnector sets ctx on the execution context of every SDK call, the SDK builds the HTTP request
+// with it so that the span created in Transport.RoundTrip is a child of the span in ctx.
+type contextConnector struct {
+	client.Connector
+	ctx context.Context
+}
+
+func (c *contextConnector) NewExecutionContext() *core.ExecutionContext {
+	executionContext := c.Connector.NewExecutionContext()
+	executionContext.WithContext(c.ctx)
+	return executionContext
+}
+
+// WithContext returns a copy of the Client whose SDK clients send the requests with ctx, the SDK clients are created
+// again with the connectors carrying ctx since the SDK doesn't pass a context per call. The Cluster, the version
+// checker and the reload state are shared with the copy.
+// The Client itself is returned if ctx carries neither a span nor a request priority, so the SDK clients replaced in tests are kept.
+func (client *Client) WithContext(ctx context.Context) *Client {
+	if client == nil || client.RestConnector == nil {
+		return client
+	}
+	if !tracing.IsTraced(ctx) && ratelimiter.PriorityFromContext(ctx) == ratelimiter.PriorityInteractive {
+		return client
+	}
+	connector := &contextConnector{Connector: client.RestConnector, ctx: ctx}
+	connectorAllowOverwrite := connector
+	if client.restConnectorAllowOverwrite != nil {
+		connectorAllowOverwrite = &contextConnector{Connector: client.restConnectorAllowOverwrite, ctx: ctx}
+	}
+	c := *client
+	c.RestConnector = connector
+	c.restConnectorAllowOverwrite = connectorAllowOverwrite
+	c.setSDKClients(connector, connectorAllowOverwrite)
+	return &c
+}
+
+// WithPriority returns a copy of the Client whose SDK clients send the requests with the priority in the NSX API rate
+// limiter.
+func (client *Client) WithPriority(priority ratelimiter.Priority) *Client {
+	return client.WithContext(ratelimiter.WithPriority(context.Background(), priority))
+}
+
+// startRoundTripSpan starts the client span of an NSX API request. The trace context and the X-Request-ID header
+// are set on the request so the NSX API log could be correlated with the trace.
+func startRoundTripSpan(r *http.Request) trace.Span {
+	ctx, span := tracing.StartClientSpan(r.Context(), fmt.Sprintf("NSX %s %s", r.Method, normalizeAPIPath(r.URL.Path)),
+		attribute.String("http.request.method", r.Method),
+		attribute.String("url.path", r.URL.Path),
+	)
+	spanContext := span.SpanContext()
+	if !spanContext.IsValid() {
+		return span
+	}
+	requestID := fmt.Sprintf("%s-%s", spanContext.TraceID(), spanContext.SpanID())
+	r.Header.Set(tracing.RequestIDHeader, requestID)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+	span.SetAttributes(attribute.String("nsx.request_id", requestID))
+	return span
+}
@@ -0,0 +1,113 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
+	"github.com/vmware-tanzu/nsx-operator/pkg/tracing"
+)
+
+func TestRoundTripTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	origin := otel.GetTracerProvider()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(origin)
+
+	var requestIDs, traceParents []string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get(tracing.RequestIDHeader))
+		traceParents = append(traceParents, r.Header.Get("traceparent"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	cluster := &Cluster{config: &Config{}}
+	tr := cluster.createTransport(idleConnTimeout)
+	client := cluster.createHTTPClient(tr, timeout)
+	noBClient := cluster.createNoBalancerClient(timeout, idleConnTimeout)
+	eps, _ := cluster.createEndpoints([]string{ts.URL[strings.Index(ts.URL, "//")+2:]}, client, noBClient, ratelimiter.NewFixRateLimiter(0), nil)
+	eps[0].setStatus(UP)
+	tr.endpoints = eps
+	tr.config = cluster.config
+
+	// request without a span in the context starts a new trace
+	req, _ := http.NewRequest("GET", ts.URL+"/policy/api/v1/orgs/default/projects/p1/vpcs/vpc1", nil)
+	_, err := tr.RoundTrip(req)
+	assert.Nil(t, err)
+	spans := exporter.GetSpans()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, "NSX GET /policy/api/v1/orgs/{id}/projects/{id}/vpcs/{id}", spans[0].Name)
+	assert.False(t, spans[0].Parent.IsValid())
+	assert.Equal(t, spans[0].SpanContext.TraceID().String()+"-"+spans[0].SpanContext.SpanID().String(), requestIDs[0])
+	assert.Contains(t, traceParents[0], spans[0].SpanContext.TraceID().String())
+
+	// request with the reconcile span in the context is traced as its child
+	exporter.Reset()
+	ctx, parent := tracing.StartSpan(context.TODO(), "Reconcile SecurityPolicy")
+	req, _ = http.NewRequestWithContext(ctx, "PATCH", ts.URL+"/policy/api/v1/orgs/default", nil)
+	_, err = tr.RoundTrip(req)
+	assert.Nil(t, err)
+	parent.End()
+	spans = exporter.GetSpans()
+	assert.Equal(t, 2, len(spans))
+	assert.Equal(t, "NSX PATCH /policy/api/v1/orgs/{id}", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID().String()+"-"+spans[0].SpanContext.SpanID().String(), requestIDs[1])
+}
+
+func TestClientWithContext(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	origin := otel.GetTracerProvider()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(origin)
+
+	var nilClient *Client
+	assert.Nil(t, nilClient.WithContext(context.TODO()))
+
+	cluster := &Cluster{endpoints: createSelectorEndpoints(), client: http.DefaultClient, config: &Config{}}
+	nsxClient := &Client{RestConnector: cluster.NewRestConnector(), NSXVerChecker: &NSXVersionChecker{cluster: cluster}}
+	// the client is not copied without a span in the context
+	assert.Same(t, nsxClient, nsxClient.WithContext(context.TODO()))
+
+	ctx, span := tracing.StartSpan(context.TODO(), "Reconcile Subnet")
+	defer span.End()
+	tracedClient := nsxClient.WithContext(ctx)
+	assert.NotSame(t, nsxClient, tracedClient)
+	assert.NotNil(t, tracedClient.OrgRootClient)
+	assert.NotNil(t, tracedClient.RealizedEntitiesClient)
+	assert.Nil(t, nsxClient.OrgRootClient)
+	assert.Equal(t, ctx, tracedClient.RestConnector.NewExecutionContext().Context())
+	// the supported features are shared with the copy
+	assert.Same(t, nsxClient.NSXVerChecker, tracedClient.NSXVerChecker)
+	tracedClient.NSXVerChecker.featureSupported[VPC] = true
+	assert.True(t, nsxClient.FeatureEnabled(VPC))
+}
+
+func TestClientWithPriority(t *testing.T) {
+	cluster := &Cluster{endpoints: createSelectorEndpoints(), client: http.DefaultClient, config: &Config{}}
+	nsxClient := &Client{RestConnector: cluster.NewRestConnector()}
+	assert.Same(t, nsxClient, nsxClient.WithPriority(ratelimiter.PriorityInteractive))
+
+	backgroundClient := nsxClient.WithPriority(ratelimiter.PriorityBackground)
+	assert.NotSame(t, nsxClient, backgroundClient)
+	assert.NotNil(t, backgroundClient.QueryClient)
+	// all the SDK clients are created with the priority, not only the ones used by the garbage collectors
+	assert.NotNil(t, backgroundClient.VPCClient)
+	assert.NotNil(t, backgroundClient.IPBlockClient)
+	assert.NotNil(t, backgroundClient.VPCConnectivityProfilesClient)
+	assert.NotNil(t, backgroundClient.PortClient)
+	ctx := backgroundClient.RestConnector.NewExecutionContext().Context()
+	assert.Equal(t, ratelimiter.PriorityBackground, ratelimiter.PriorityFromContext(ctx))
+}
@@ -8,11 +8,18 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 	"github.com/vmware-tanzu/nsx-operator/pkg/third_party/retry"
+	"github.com/vmware-tanzu/nsx-operator/pkg/tracing"
 )
 
 // Transport is used in http.Client to replace default implement.
@@ -21,6 +28,9 @@ type Transport struct {
 	Base      http.RoundTripper
 	endpoints []*Endpoint
 	config    *Config
+	selector  EndpointSelector
+	// mu protects endpoints, config and selector which are replaced when the cluster is reloaded.
+	mu sync.RWMutex
 }
 
 // RoundTrip is the core of the transport. It accepts a request,
@@ -32,28 +42,57 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 	var resp *http.Response
 	var resul error
 
-	retry.Do(
+	span := startRoundTripSpan(r)
+	defer span.End()
+
+	attempt := 0
+	err := retry.Do(
 		func() error {
 			ep, err := t.selectEndpoint()
 			if err != nil {
 				log.Error(err, "Endpoint is unavailable")
+				resp, resul = nil, err
+				return err
+			}
+			attempt++
+			if attempt > 1 {
+				observeAPIRetry(ep)
+			}
+			span.AddEvent("attempt", trace.WithAttributes(attribute.String("nsx.endpoint", ep.Host()), attribute.Int("attempt", attempt)))
+			// the connection slot isn't held while waiting for the throttle window, so the other endpoints could
+			// be selected by the requests in the meantime
+			if err := ep.waitThrottle(r.Context()); err != nil {
+				// the request isn't sent, the half-open trial slot reserved by selectEndpoint is freed
+				ep.breaker.release()
+				log.Error(err, "Request is canceled while the endpoint is throttled", "endpoint", ep.Host())
+				resp, resul = nil, err
 				return err
 			}
 			ep.increaseConnNumber()
 			defer ep.decreaseConnNumber()
 
-			util.UpdateRequestURL(r.URL, ep.Host(), ep.Thumbprint)
+			util.UpdateRequestURL(r.URL, ep.Host(), ep.thumbprint())
 			ep.UpdateHttpRequestAuth(r)
 			ep.UpdateCAforEnvoy(r)
 			start := time.Now()
-			ep.wait()
+			ep.wait(ratelimiter.PriorityFromContext(r.Context()))
 			util.DumpHttpRequest(r)
 			waitTime := time.Since(start)
-			if resp, resul = t.base().RoundTrip(r); resul != nil {
+			resp, resul = t.base().RoundTrip(r)
+			transTime := time.Since(start) - waitTime
+			observeAPIRequest(ep, r, resp, transTime)
+			if resul != nil {
+				ep.breaker.record(false)
 				ep.setStatus(DOWN)
 				return handleRoundTripError(resul, ep)
 			}
-			transTime := time.Since(start) - waitTime
+			ep.breaker.record(resp.StatusCode < http.StatusInternalServerError)
+			if ratelimiter.IsReduceRateCode(resp.StatusCode) {
+				if retryAfter := ratelimiter.ParseRetryAfter(resp.Header.Get(ratelimiter.RetryAfterHeader), time.Now()); retryAfter > 0 {
+					ep.throttleFor(retryAfter)
+				}
+			}
+			t.endpointSelector().Observe(ep, transTime)
 			ep.adjustRate(waitTime, resp.StatusCode)
 			if resp == nil {
 				return nil
@@ -72,10 +111,13 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 				return nil
 			}
 			if util.ShouldRegenerate(err) {
-				if t.config.TokenProvider != nil {
-					t.config.TokenProvider.GetToken(true)
+				config := t.getConfig()
+				if config.TokenProvider != nil {
+					observeAPIReauth(ep, reauthTypeJWT)
+					config.TokenProvider.GetToken(true)
 				} else {
-					ep.createAuthSession(t.config.ClientCertProvider, t.config.TokenProvider, t.config.Username, t.config.Password, jarCache)
+					observeAPIReauth(ep, reauthTypeXSRF)
+					ep.createAuthSession(config.ClientCertProvider, config.TokenProvider, config.Username, config.Password, jarCache)
 				}
 			}
 			return err
@@ -90,6 +132,11 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 			}
 		}), retry.LastErrorOnly(true),
 	)
+	span.SetAttributes(attribute.Int("nsx.attempts", attempt))
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	}
+	tracing.RecordError(span, err)
 
 	return resp, resul
 }
@@ -98,11 +145,14 @@ func handleRoundTripError(err error, ep *Endpoint) error {
 	log.Error(err, "Failed to request")
 	errString := err.Error()
 	if strings.HasSuffix(errString, "connection refused") {
+		observeRoundTripError(ep, roundTripErrorConnectionRefused)
 		ep.setStatus(DOWN)
 		return util.CreateConnectionError(ep.Host())
 	} else if strings.HasSuffix(errString, "i/o timeout") {
+		observeRoundTripError(ep, roundTripErrorTimeout)
 		return util.CreateTimeout(ep.Host())
 	} else {
+		observeRoundTripError(ep, roundTripErrorGeneral)
 		return util.CreateGeneralManagerError(ep.Host(), "RoundTrip", err.Error())
 	}
 }
@@ -114,27 +164,108 @@ func (t *Transport) base() http.RoundTripper {
 	return http.DefaultTransport
 }
 
+func (t *Transport) endpointSelector() EndpointSelector {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.selector != nil {
+		return t.selector
+	}
+	return &leastConnectionsSelector{}
+}
+
+func (t *Transport) getEndpoints() []*Endpoint {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.endpoints
+}
+
+func (t *Transport) getConfig() *Config {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.config
+}
+
+// setEndpoints replaces the endpoints and config, the in-flight requests keep using the endpoint they selected.
+// The selector is recreated if the selection strategy is changed, otherwise the state it keeps for the removed
+// endpoints is dropped.
+func (t *Transport) setEndpoints(eps []*Endpoint, config *Config) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.config == nil || t.config.EndpointSelector != config.EndpointSelector {
+		t.selector = NewEndpointSelector(config.EndpointSelector)
+	} else if pruner, ok := t.selector.(endpointPruner); ok {
+		pruner.prune(eps)
+	}
+	t.endpoints = eps
+	t.config = config
+}
+
+// CloseIdleConnections closes the idle connections of the base RoundTripper, http.Client.CloseIdleConnections
+// calls it.
+func (t *Transport) CloseIdleConnections() {
+	type closeIdler interface {
+		CloseIdleConnections()
+	}
+	if tr, ok := t.base().(closeIdler); ok {
+		tr.CloseIdleConnections()
+	}
+}
+
 func (t *Transport) selectEndpoint() (*Endpoint, error) {
-	small := 100
-	index := -1
-	for i, ep := range t.endpoints {
-		if ep.Status() == DOWN {
+	endpoints := t.getEndpoints()
+	var candidates []*Endpoint
+	for _, ep := range endpoints {
+		if ep.Status() == DOWN || !ep.breaker.ready() {
 			continue
 		}
-		conn := ep.ConnNumber()
-		if conn < small {
-			small = conn
-			index = i
+		candidates = append(candidates, ep)
+	}
+	// Avoid the endpoints throttled by NSX, the request waits for the throttle window if all of them are throttled.
+	if available := slices.DeleteFunc(slices.Clone(candidates), (*Endpoint).throttled); len(available) > 0 {
+		candidates = available
+	}
+	var selected *Endpoint
+	for len(candidates) > 0 {
+		selected = t.endpointSelector().Select(candidates)
+		// The half-open trial slot may be taken by a concurrent request, try another endpoint.
+		if selected == nil || selected.breaker.allow() {
+			break
 		}
+		candidates = slices.DeleteFunc(candidates, func(ep *Endpoint) bool { return ep == selected })
+		selected = nil
 	}
-	if index == -1 {
+	if selected == nil {
 		var eps []string
-		for _, i := range t.endpoints {
+		for _, i := range endpoints {
 			eps = append(eps, i.Host())
 		}
 		log.Error(errors.New("all endpoints down for cluster"), "select endpoint failed")
 		id := strings.Join(eps, ",")
 		return nil, util.CreateServiceClusterUnavailable(id)
 	}
-	return t.endpoints[index], nil
+	return selected, nil
+}
+
+// priorityTransport sends the requests with the priority in the NSX API rate limiter.
+type priorityTransport struct {
+	base     http.RoundTripper
+	priority ratelimiter.Priority
+}
+
+func (t *priorityTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(r.WithContext(ratelimiter.WithPriority(r.Context(), t.priority)))
+}
+
+// withPriority returns a copy of client which sends the requests with the priority.
+func withPriority(client *http.Client, priority ratelimiter.Priority) *http.Client {
+	if client == nil {
+		return nil
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c := *client
+	c.Transport = &priorityTransport{base: base, priority: priority}
+	return &c
 }
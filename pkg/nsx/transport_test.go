@@ -4,6 +4,7 @@
 package nsx
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -13,8 +14,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
 )
 
@@ -223,3 +226,125 @@ func TestTransport_selectEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestRoundTripRetryAfter(t *testing.T) {
+	assert := assert.New(t)
+	healthresult := `{
+		"healthy" : true,
+		"components_health" : "POLICY:UP, SEARCH:UP, MANAGER:UP, NODE_MGMT:UP, UI:UP"
+	}`
+	result := `{"error_code":102,"error_message":"Client 'admin' exceeded request rate of 100 per second"}`
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "reverse-proxy/node/health") || strings.Contains(r.URL.Path, "api/session/create") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(healthresult))
+			return
+		}
+		requests++
+		if requests == 1 {
+			w.Header().Set(ratelimiter.RetryAfterHeader, "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(result))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(healthresult))
+	}))
+	defer ts.Close()
+	index := strings.Index(ts.URL, "//")
+	a := ts.URL[index+2:]
+	config := NewConfig(a, "admin", "passw0rd", []string{}, 10, 3, 20, 20, true, true, true, ratelimiter.AIMD, nil, nil, []string{})
+	cluster, err := NewCluster(config)
+	assert.Nil(err, fmt.Sprintf("Create cluster error %v", err))
+	cluster.endpoints[0].keepAlive()
+	tr := cluster.transport
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	resp, err := tr.RoundTrip(req)
+	assert.Nil(err)
+	assert.Equal(http.StatusTooManyRequests, resp.StatusCode)
+	assert.True(cluster.endpoints[0].throttled())
+	assert.Equal(1.0, testutil.ToFloat64(metrics.NSXAPIThrottledTotal.WithLabelValues(cluster.endpoints[0].Host())))
+
+	// the next request waits for the throttle window
+	start := time.Now()
+	resp, err = tr.RoundTrip(req)
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(2, requests)
+	assert.GreaterOrEqual(time.Since(start), 900*time.Millisecond)
+	assert.False(cluster.endpoints[0].throttled())
+
+	// the request waiting for the throttle window is canceled with its ctx, and it doesn't hold the connection
+	cluster.endpoints[0].throttleFor(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := tr.RoundTrip(req.WithContext(ctx))
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(0, cluster.endpoints[0].ConnNumber())
+	cancel()
+	select {
+	case err = <-done:
+		assert.ErrorIs(err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request isn't canceled while the endpoint is throttled")
+	}
+	assert.Equal(2, requests)
+}
+
+func TestSelectEndpointThrottled(t *testing.T) {
+	assert := assert.New(t)
+	a := "127.0.0.1, 127.0.0.2"
+	config := NewConfig(a, "admin", "passw0rd", []string{}, 10, 3, 20, 20, true, true, true, ratelimiter.AIMD, nil, nil, []string{})
+	cluster := &Cluster{config: &Config{}}
+	tr := cluster.createTransport(idleConnTimeout)
+	client := cluster.createHTTPClient(tr, timeout)
+	noBClient := cluster.createNoBalancerClient(timeout, idleConnTimeout)
+	r := ratelimiter.NewRateLimiter(config.APIRateMode)
+	eps, _ := cluster.createEndpoints(config.APIManagers, client, noBClient, r, nil)
+	eps[0].status = UP
+	eps[1].status = UP
+	eps[1].connnumber = 1
+	tr.endpoints = eps
+
+	// the throttled endpoint is skipped
+	eps[0].throttleFor(time.Minute)
+	ep, err := tr.selectEndpoint()
+	assert.Nil(err)
+	assert.Equal(eps[1].Host(), ep.Host())
+
+	// the throttled endpoint is still selected if all endpoints are throttled
+	eps[1].throttleFor(time.Minute)
+	ep, err = tr.selectEndpoint()
+	assert.Nil(err)
+	assert.Equal(eps[0].Host(), ep.Host())
+}
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithPriority(t *testing.T) {
+	assert.Nil(t, withPriority(nil, ratelimiter.PriorityBackground))
+
+	var priority ratelimiter.Priority
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		priority = ratelimiter.PriorityFromContext(r.Context())
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})}
+	backgroundClient := withPriority(client, ratelimiter.PriorityBackground)
+	req, _ := http.NewRequest("GET", "https://127.0.0.1/api/v1/fabric/container-clusters", nil)
+	_, err := backgroundClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, ratelimiter.PriorityBackground, priority)
+
+	// the original client is kept
+	_, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, ratelimiter.PriorityInteractive, priority)
+}
@@ -15,6 +15,9 @@ const (
 	IPAllocationErrorCode                     = 8212
 	ReservedIPRangesOverlappedErrorCode       = 508134
 	ReservedIPRangesOutOfSubnetRangeErrorCode = 508135
+	OverlapVlanErrorCode                      = 8327
+	ResourceInUseErrorCode                    = 500030
+	OverlapAddressesErrorCode                 = 500105
 )
 
 type NsxError interface {
@@ -208,6 +211,10 @@ func CreateRealizationErrorStateError(resourceType string, resourceID string, er
 	return nsxErr
 }
 
+func (e *RealizationErrorStateError) Error() string {
+	return e.msg
+}
+
 type RealizationTimeoutError struct {
 	msg string `parent:"RealizationError"`
 }
@@ -218,6 +225,10 @@ func CreateRealizationTimeoutError(resourceType string, resourceID string, attem
 	return nsxErr
 }
 
+func (e *RealizationTimeoutError) Error() string {
+	return e.msg
+}
+
 type DetailedRealizationTimeoutError struct {
 	msg string `parent:"RealizationError"`
 }
@@ -228,6 +239,10 @@ func CreateDetailedRealizationTimeoutError(resourceType string, resourceID strin
 	return nsxErr
 }
 
+func (e *DetailedRealizationTimeoutError) Error() string {
+	return e.msg
+}
+
 type StaleRevision struct {
 	managerErrorImpl
 }
@@ -619,6 +634,8 @@ var (
 type RealizeStateError struct {
 	message string
 	code    int
+	// errorCodes are the error codes in the alarms of the realized entities.
+	errorCodes []int
 }
 
 func (e *RealizeStateError) Error() string {
@@ -629,8 +646,13 @@ func (e *RealizeStateError) GetCode() int {
 	return e.code
 }
 
-func NewRealizeStateError(msg string, code int) *RealizeStateError {
-	return &RealizeStateError{message: msg, code: code}
+// GetErrorCodes returns the error codes in the alarms of the realized entities.
+func (e *RealizeStateError) GetErrorCodes() []int {
+	return e.errorCodes
+}
+
+func NewRealizeStateError(msg string, code int, errorCodes ...int) *RealizeStateError {
+	return &RealizeStateError{message: msg, code: code, errorCodes: errorCodes}
 }
 
 func IsRealizeStateError(err error) bool {
@@ -650,6 +672,25 @@ func NewRetryRealizeError(msg string) *RetryRealizeError {
 	return &RetryRealizeError{message: msg}
 }
 
+// RealizePendingError is returned by the realization tracker if the intent path isn't realized yet, the caller
+// requeues the CR instead of waiting.
+type RealizePendingError struct {
+	message string
+}
+
+func (e *RealizePendingError) Error() string {
+	return e.message
+}
+
+func NewRealizePendingError(msg string) *RealizePendingError {
+	return &RealizePendingError{message: msg}
+}
+
+func IsRealizePendingError(err error) bool {
+	_, ok := err.(*RealizePendingError)
+	return ok
+}
+
 func IsRetryRealizeError(alarm model.PolicyAlarmResource) bool {
 	// The ProviderNotReady error indicates NSX get timeout when waiting for the dependencies
 	// and may become Realized after retry.
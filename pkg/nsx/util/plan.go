@@ -0,0 +1,97 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package util
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	apierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
+)
+
+// PlanAction is what the operator would do to an NSX resource in plan mode.
+type PlanAction string
+
+const (
+	PlanActionCreate PlanAction = "create"
+	PlanActionUpdate PlanAction = "update"
+	PlanActionDelete PlanAction = "delete"
+)
+
+// PlannedChange is a change of an NSX resource rendered in plan mode.
+type PlannedChange struct {
+	Action       PlanAction
+	ResourceType string
+	Path         string
+	// Diff is the changed fields of the resource, one per line in the format of "+ field: value",
+	// "- field: value" or "~ field: old -> new". It's empty for the deleted resources.
+	Diff []string
+}
+
+func (c PlannedChange) String() string {
+	s := fmt.Sprintf("%s %s %s", c.Action, c.ResourceType, c.Path)
+	for _, line := range c.Diff {
+		s += "\n  " + line
+	}
+	return s
+}
+
+// PlanError is returned instead of applying the changes to NSX in plan mode, the controllers record the changes
+// on the CR rather than failing the reconciliation.
+type PlanError struct {
+	Changes []PlannedChange
+}
+
+func (e *PlanError) Error() string {
+	if len(e.Changes) == 0 {
+		return "plan mode: no changes"
+	}
+	changes := make([]string, 0, len(e.Changes))
+	for _, change := range e.Changes {
+		changes = append(changes, change.String())
+	}
+	return "plan mode: changes are not applied to NSX:\n" + strings.Join(changes, "\n")
+}
+
+// planRequestPattern matches the message of PlanRequestError, the vAPI SDK only keeps the message of the errors
+// returned by the HTTP transport.
+var planRequestPattern = regexp.MustCompile(`plan mode: ([A-Z]+) (\S+) is not sent to NSX`)
+
+// PlanRequestError is returned by the HTTP transport for the NSX API request changing NSX in plan mode, which
+// isn't planned by the service sending it. It wraps the PlanError of the request.
+type PlanRequestError struct {
+	Method string
+	Path   string
+}
+
+func (e *PlanRequestError) Error() string {
+	return fmt.Sprintf("plan mode: %s %s is not sent to NSX", e.Method, e.Path)
+}
+
+func (e *PlanRequestError) Unwrap() error {
+	action := PlanActionUpdate
+	switch e.Method {
+	case http.MethodDelete:
+		action = PlanActionDelete
+	case http.MethodPost:
+		action = PlanActionCreate
+	}
+	return &PlanError{Changes: []PlannedChange{{Action: action, Path: e.Path}}}
+}
+
+// planRequestErrorFromSDK restores the PlanRequestError from the ServiceUnavailable error of the vAPI SDK.
+func planRequestErrorFromSDK(err error) *PlanRequestError {
+	unavailable, ok := err.(apierrors.ServiceUnavailable)
+	if !ok {
+		return nil
+	}
+	for _, msg := range unavailable.Messages {
+		if matches := planRequestPattern.FindStringSubmatch(msg.DefaultMessage); matches != nil {
+			return &PlanRequestError{Method: matches[1], Path: matches[2]}
+		}
+	}
+	return nil
+}
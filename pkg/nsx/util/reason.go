@@ -0,0 +1,128 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package util
+
+import (
+	"errors"
+
+	apierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// The reasons of the conditions of the CRs failed by the NSX errors, automation can rely on them.
+const (
+	// ReasonIPBlockExhausted means no Subnet can be allocated from the IP blocks.
+	ReasonIPBlockExhausted = "IPBlockExhausted"
+	// ReasonSubnetIPExhausted means no IP can be allocated from the Subnet.
+	ReasonSubnetIPExhausted = "SubnetIPExhausted"
+	// ReasonScaleLimitExceeded means NSX can't allocate more resources of the kind, e.g. on the edges.
+	ReasonScaleLimitExceeded = "ScaleLimitExceeded"
+	// ReasonInvalidConfiguration means the CR or the NSX configuration it refers to, e.g. the VPC connectivity
+	// profile, is rejected by NSX.
+	ReasonInvalidConfiguration = "InvalidConfiguration"
+	ReasonInvalidLicense       = "InvalidLicense"
+	ReasonResourceInUse        = "ResourceInUse"
+	// ReasonDependencyNotReady means the resources the NSX resource depends on aren't realized yet.
+	ReasonDependencyNotReady = "DependencyNotReady"
+	ReasonRealizationTimeout = "RealizationTimeout"
+	// ReasonRealizationFailed means the NSX resource is realized with errors not known by the operator.
+	ReasonRealizationFailed = "RealizationFailed"
+	ReasonNSXUnavailable    = "NSXUnavailable"
+)
+
+// RealizationReason is the machine-readable reason of a failed realization. The retryable ones are expected to go
+// away by retrying, the others need the users to fix the configuration or free the capacity.
+type RealizationReason struct {
+	Reason    string
+	Retryable bool
+}
+
+// errorCodeReasons maps the error codes of the NSX API errors and the alarms of the realized entities to the reasons.
+var errorCodeReasons = map[int]RealizationReason{
+	IPAllocationErrorCode:                     {Reason: ReasonSubnetIPExhausted},
+	ProviderNotReadyErrorCode:                 {Reason: ReasonDependencyNotReady, Retryable: true},
+	InvalidLicenseErrorCode:                   {Reason: ReasonInvalidLicense},
+	ReservedIPRangesOverlappedErrorCode:       {Reason: ReasonInvalidConfiguration},
+	ReservedIPRangesOutOfSubnetRangeErrorCode: {Reason: ReasonInvalidConfiguration},
+	OverlapVlanErrorCode:                      {Reason: ReasonInvalidConfiguration},
+	OverlapAddressesErrorCode:                 {Reason: ReasonInvalidConfiguration},
+	ResourceInUseErrorCode:                    {Reason: ReasonResourceInUse, Retryable: true},
+}
+
+// errorTypeReasons maps the types of the NSX API errors without known error codes to the reasons.
+var errorTypeReasons = map[apierrors.ErrorTypeEnum]RealizationReason{
+	apierrors.ErrorType_UNABLE_TO_ALLOCATE_RESOURCE: {Reason: ReasonScaleLimitExceeded},
+	apierrors.ErrorType_INVALID_REQUEST:             {Reason: ReasonInvalidConfiguration},
+	apierrors.ErrorType_INVALID_ARGUMENT:            {Reason: ReasonInvalidConfiguration},
+	apierrors.ErrorType_RESOURCE_IN_USE:             {Reason: ReasonResourceInUse, Retryable: true},
+	apierrors.ErrorType_RESOURCE_BUSY:               {Reason: ReasonNSXUnavailable, Retryable: true},
+	apierrors.ErrorType_SERVICE_UNAVAILABLE:         {Reason: ReasonNSXUnavailable, Retryable: true},
+	apierrors.ErrorType_TIMED_OUT:                   {Reason: ReasonNSXUnavailable, Retryable: true},
+}
+
+// GetRealizationReason returns the reason of err, nil if it's unknown. The error codes are checked before the
+// error types, e.g. a RealizeStateError with the IP allocation error code is SubnetIPExhausted.
+func GetRealizationReason(err error) *RealizationReason {
+	if err == nil {
+		return nil
+	}
+	var codes []int
+	var realizeStateErr *RealizeStateError
+	var apiErr *NSXApiError
+	if errors.As(err, &realizeStateErr) {
+		codes = append([]int{realizeStateErr.GetCode()}, realizeStateErr.GetErrorCodes()...)
+	} else if errors.As(err, &apiErr) && apiErr.ApiError != nil {
+		codes = apiErrorCodes(apiErr.ApiError)
+	}
+	for _, code := range codes {
+		if reason, ok := errorCodeReasons[code]; ok {
+			return &reason
+		}
+	}
+
+	var serverBusy ServerBusy
+	switch {
+	case realizeStateErr != nil:
+		// The realization might succeed after NSX recovers from the unknown errors.
+		return &RealizationReason{Reason: ReasonRealizationFailed, Retryable: true}
+	case apiErr != nil:
+		if reason, ok := errorTypeReasons[apiErr.ErrorTypeEnum]; ok {
+			return &reason
+		}
+	// The errors with value receivers are returned both as values and pointers.
+	case errors.As(err, &IPBlockAllExhaustedError{}), errors.As(err, new(*IPBlockAllExhaustedError)):
+		return &RealizationReason{Reason: ReasonIPBlockExhausted}
+	case errors.As(err, &ExceedTagsError{}), errors.As(err, new(*ExceedTagsError)),
+		errors.As(err, &ValidationError{}), errors.As(err, new(*ValidationError)),
+		errors.As(err, &RestrictionError{}), errors.As(err, new(*RestrictionError)):
+		return &RealizationReason{Reason: ReasonInvalidConfiguration}
+	case errors.As(err, new(*NSGroupIsFull)), errors.As(err, new(*SecurityGroupMaximumCapacityReached)):
+		return &RealizationReason{Reason: ReasonScaleLimitExceeded}
+	case errors.As(err, new(*InvalidLicense)):
+		return &RealizationReason{Reason: ReasonInvalidLicense}
+	case errors.As(err, new(*RetryRealizeError)):
+		return &RealizationReason{Reason: ReasonDependencyNotReady, Retryable: true}
+	case errors.As(err, new(*RealizationTimeoutError)), errors.As(err, new(*DetailedRealizationTimeoutError)):
+		return &RealizationReason{Reason: ReasonRealizationTimeout, Retryable: true}
+	case errors.As(err, new(*RealizationErrorStateError)):
+		return &RealizationReason{Reason: ReasonRealizationFailed, Retryable: true}
+	case errors.As(err, &serverBusy), errors.As(err, new(*CannotConnectToServer)), errors.As(err, new(*APITransactionAborted)),
+		errors.As(err, new(*ConnectionError)), errors.As(err, new(*Timeout)):
+		return &RealizationReason{Reason: ReasonNSXUnavailable, Retryable: true}
+	}
+	return nil
+}
+
+func apiErrorCodes(apiError *model.ApiError) []int {
+	var codes []int
+	if apiError.ErrorCode != nil {
+		codes = append(codes, int(*apiError.ErrorCode))
+	}
+	for _, relatedErr := range apiError.RelatedErrors {
+		if relatedErr.ErrorCode != nil {
+			codes = append(codes, int(*relatedErr.ErrorCode))
+		}
+	}
+	return codes
+}
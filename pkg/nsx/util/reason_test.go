@@ -0,0 +1,56 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func TestGetRealizationReason(t *testing.T) {
+	code := func(c int64) *int64 { return &c }
+	for _, tc := range []struct {
+		name   string
+		err    error
+		reason *RealizationReason
+	}{
+		{"nil", nil, nil},
+		{"unknown", errors.New("unknown"), nil},
+		{"IP allocation alarm", NewRealizeStateError("no IP", IPAllocationErrorCode), &RealizationReason{Reason: ReasonSubnetIPExhausted}},
+		{"related error code in alarms", NewRealizeStateError("overlapped", 0, 1, ReservedIPRangesOverlappedErrorCode),
+			&RealizationReason{Reason: ReasonInvalidConfiguration}},
+		{"unknown alarm", NewRealizeStateError("failed", 0, 1), &RealizationReason{Reason: ReasonRealizationFailed, Retryable: true}},
+		{"provider not ready", NewRetryRealizeError("not ready"), &RealizationReason{Reason: ReasonDependencyNotReady, Retryable: true}},
+		{"API error code", NewNSXApiError(&model.ApiError{ErrorCode: code(ReservedIPRangesOutOfSubnetRangeErrorCode)}, apierrors.ErrorType_INVALID_REQUEST),
+			&RealizationReason{Reason: ReasonInvalidConfiguration}},
+		{"API related error code", NewNSXApiError(&model.ApiError{ErrorCode: code(1), RelatedErrors: []model.RelatedApiError{{ErrorCode: code(ResourceInUseErrorCode)}}},
+			apierrors.ErrorType_INVALID_REQUEST), &RealizationReason{Reason: ReasonResourceInUse, Retryable: true}},
+		{"API error type", NewNSXApiError(&model.ApiError{ErrorCode: code(1)}, apierrors.ErrorType_UNABLE_TO_ALLOCATE_RESOURCE),
+			&RealizationReason{Reason: ReasonScaleLimitExceeded}},
+		{"API error unknown type", NewNSXApiError(&model.ApiError{ErrorCode: code(1)}, apierrors.ErrorType_ERROR), nil},
+		{"wrapped IP block exhausted", fmt.Errorf("failed to allocate Subnet: %w", IPBlockAllExhaustedError{Desc: "exhausted"}),
+			&RealizationReason{Reason: ReasonIPBlockExhausted}},
+		{"tags exceeded", ExceedTagsError{Desc: "too many tags"}, &RealizationReason{Reason: ReasonInvalidConfiguration}},
+		{"tags exceeded pointer", &ExceedTagsError{Desc: "too many tags"}, &RealizationReason{Reason: ReasonInvalidConfiguration}},
+		{"validation", ValidationError{Desc: "invalid"}, &RealizationReason{Reason: ReasonInvalidConfiguration}},
+		{"wrapped validation pointer", fmt.Errorf("failed to build rule: %w", &ValidationError{Desc: "invalid"}),
+			&RealizationReason{Reason: ReasonInvalidConfiguration}},
+		{"restriction pointer", &RestrictionError{Desc: "restricted"}, &RealizationReason{Reason: ReasonInvalidConfiguration}},
+		{"IP block exhausted pointer", &IPBlockAllExhaustedError{Desc: "exhausted"}, &RealizationReason{Reason: ReasonIPBlockExhausted}},
+		{"group full", CreateNSGroupIsFull("group-1"), &RealizationReason{Reason: ReasonScaleLimitExceeded}},
+		{"timeout", CreateDetailedRealizationTimeoutError("Subnet", "subnet-1", "", "", "", "3", "1"),
+			&RealizationReason{Reason: ReasonRealizationTimeout, Retryable: true}},
+		{"server busy", &ServiceUnavailable{}, &RealizationReason{Reason: ReasonNSXUnavailable, Retryable: true}},
+		{"connection error", CreateConnectionError("nsx-1"), &RealizationReason{Reason: ReasonNSXUnavailable, Retryable: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.reason, GetRealizationReason(tc.err))
+		})
+	}
+}
@@ -402,6 +402,9 @@ func TransNSXApiError(err error) error {
 	if err == nil {
 		return err
 	}
+	if planErr := planRequestErrorFromSDK(err); planErr != nil {
+		return planErr
+	}
 	apierror, errorType := DumpAPIError(err)
 	if apierror == nil {
 		return err
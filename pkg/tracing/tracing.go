@@ -0,0 +1,126 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+)
+
+const (
+	TracerName  = "github.com/vmware-tanzu/nsx-operator"
+	ServiceName = "nsx-operator"
+
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+
+	// RequestIDHeader carries the trace ID to NSX, NSX logs it with the API request.
+	RequestIDHeader = "X-Request-ID"
+)
+
+var log = logger.Log
+
+// IsTracingEnabled returns true if the spans are exported to a collector.
+func IsTracingEnabled(cf *config.NSXOperatorConfig) bool {
+	return cf != nil && cf.TracingConfig != nil && cf.EnableTracing
+}
+
+// InitTracing installs the global TracerProvider exporting the spans with OTLP.
+// Tracing is disabled by default, the no-op TracerProvider of otel is kept and the returned shutdown function does nothing.
+func InitTracing(ctx context.Context, cf *config.NSXOperatorConfig) (func(context.Context) error, error) {
+	if !IsTracingEnabled(cf) {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := newExporter(ctx, cf.TracingConfig)
+	if err != nil {
+		log.Error(err, "Failed to create tracing exporter", "exporter", cf.TracingExporter, "endpoint", cf.TracingEndpoint)
+		return nil, err
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName), attribute.String("cluster", cf.Cluster))
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cf.TracingSampleRatio))),
+	)
+	SetTracerProvider(tp)
+	log.Info("Tracing enabled", "exporter", cf.TracingExporter, "endpoint", cf.TracingEndpoint, "sampleRatio", cf.TracingSampleRatio)
+	return tp.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, tc *config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch tc.TracingExporter {
+	case ExporterOTLPGRPC:
+		opts := []otlptracegrpc.Option{}
+		if tc.TracingEndpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(tc.TracingEndpoint))
+		}
+		if tc.TracingInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{}
+		if tc.TracingEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(tc.TracingEndpoint))
+		}
+		if tc.TracingInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+	return nil, errors.New("unsupported tracing exporter " + tc.TracingExporter)
+}
+
+// SetTracerProvider registers tp as the global TracerProvider, the tests use it with an in-memory exporter.
+func SetTracerProvider(tp trace.TracerProvider) {
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// StartSpan starts a span from the global TracerProvider, the span is a child of the span in ctx if there is one.
+// ctx is returned as it is when tracing is disabled.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartClientSpan starts a span for an outgoing request.
+func StartClientSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return start(ctx, name, trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindClient))
+}
+
+func start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	spanCtx, span := otel.Tracer(TracerName).Start(ctx, name, opts...)
+	if !span.SpanContext().IsValid() {
+		return ctx, span
+	}
+	return spanCtx, span
+}
+
+// RecordError records err on the span and marks the span as failed.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// IsTraced returns true if ctx carries a valid span which the outgoing requests could be attached to.
+func IsTraced(ctx context.Context) bool {
+	return ctx != nil && trace.SpanContextFromContext(ctx).IsValid()
+}
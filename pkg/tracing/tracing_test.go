@@ -0,0 +1,81 @@
+/* Copyright © 2025 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+)
+
+func setupInMemoryTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	origin := otel.GetTracerProvider()
+	SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(origin) })
+	return exporter
+}
+
+func TestInitTracing(t *testing.T) {
+	// tracing is disabled by default
+	cf := config.NewNSXOpertorConfig()
+	assert.False(t, IsTracingEnabled(cf))
+	assert.False(t, IsTracingEnabled(nil))
+	assert.False(t, IsTracingEnabled(&config.NSXOperatorConfig{}))
+	shutdown, err := InitTracing(context.TODO(), cf)
+	assert.Nil(t, err)
+	assert.Nil(t, shutdown(context.TODO()))
+	ctx, span := StartSpan(context.TODO(), "test")
+	span.End()
+	assert.False(t, IsTraced(ctx))
+	assert.Equal(t, context.TODO(), ctx)
+
+	origin := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(origin)
+	cf.EnableTracing = true
+	cf.TracingExporter = ExporterOTLPHTTP
+	cf.TracingEndpoint = "127.0.0.1:4318"
+	cf.TracingInsecure = true
+	cf.CoeConfig.Cluster = "k8scl-one"
+	shutdown, err = InitTracing(context.TODO(), cf)
+	assert.Nil(t, err)
+	assert.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+	assert.Nil(t, shutdown(context.TODO()))
+
+	cf.TracingExporter = "zipkin"
+	_, err = InitTracing(context.TODO(), cf)
+	assert.NotNil(t, err)
+}
+
+func TestStartSpan(t *testing.T) {
+	exporter := setupInMemoryTracing(t)
+
+	ctx, parent := StartSpan(context.TODO(), "parent", attribute.String("k8s.resource.name", "sp-1"))
+	assert.True(t, IsTraced(ctx))
+	_, child := StartClientSpan(ctx, "child")
+	RecordError(child, errors.New("dummy error"))
+	RecordError(child, nil)
+	child.End()
+	parent.End()
+
+	spans := exporter.GetSpans()
+	assert.Equal(t, 2, len(spans))
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, spans[1].SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, 1, len(spans[0].Events))
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+	assert.Contains(t, spans[1].Attributes, attribute.String("k8s.resource.name", "sp-1"))
+}